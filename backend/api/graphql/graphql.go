@@ -0,0 +1,115 @@
+// Package graphql serves the league domain as a GraphQL schema. Resolvers read
+// through the app context's repositories and the services, and batch the loads
+// of a request with per-request loaders.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"log"
+
+	"league-sim/internal/apperrors"
+	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/contexts/services"
+	"league-sim/internal/models"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds how deeply a query may nest selections.
+const maxDepth = 10
+
+type Server struct {
+	schema *graphqlgo.Schema
+}
+
+// NewServer parses the schema against the resolvers. It panics if they do not
+// match, which the tests catch.
+func NewServer() *Server {
+	return &Server{
+		schema: graphqlgo.MustParseSchema(
+			schemaSDL, &resolver{}, graphqlgo.UseFieldResolvers(), graphqlgo.MaxDepth(maxDepth)),
+	}
+}
+
+// Execute runs req for the user on ctx. Errors raised by resolvers are reported
+// with the code of their apperrors kind in "extensions"; internal failures
+// never expose their cause.
+func (s *Server) Execute(
+	ctx context.Context, appCtx appContext.AppContext, services services.Service, req models.GraphQLRequest,
+) models.GraphQLResponse {
+	ctx = context.WithValue(ctx, stateKey{}, newState(appCtx, services))
+
+	result := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	response := models.GraphQLResponse{Data: result.Data}
+	for _, err := range result.Errors {
+		response.Errors = append(response.Errors, presentError(err))
+	}
+
+	return response
+}
+
+type stateKey struct{}
+
+// state is what the resolvers of one request share.
+type state struct {
+	appCtx      appContext.AppContext
+	services    services.Service
+	leagues     *Loader[string, models.League]
+	results     *Loader[string, []models.MatchResult]
+	predictions *Loader[string, []models.PredictedStanding]
+}
+
+func newState(appCtx appContext.AppContext, services services.Service) *state {
+	return &state{
+		appCtx:   appCtx,
+		services: services,
+		leagues: NewLoader(
+			appCtx.ActiveLeagueRepository().GetActiveLeagues,
+			func(id string) error { return apperrors.NotFound("league %s not found", id) }),
+		results: NewLoader(
+			appCtx.MatchResultRepository().GetMatchResultsByLeague,
+			func(string) error { return nil }),
+		predictions: NewLoader(
+			func(ctx context.Context, ids []string) (map[string][]models.PredictedStanding, error) {
+				return services.PredictService().PredictChampionships(ctx, ids)
+			},
+			func(id string) error { return apperrors.NotFound("league %s not found", id) }),
+	}
+}
+
+func stateFrom(ctx context.Context) *state {
+	return ctx.Value(stateKey{}).(*state)
+}
+
+func presentError(err *gqlerrors.QueryError) models.GraphQLError {
+	presented := models.GraphQLError{Message: err.Message, Path: err.Path}
+	for _, location := range err.Locations {
+		presented.Locations = append(presented.Locations, models.GraphQLLocation{Line: location.Line, Column: location.Column})
+	}
+
+	if err.ResolverError == nil {
+		return presented
+	}
+
+	var appErr *apperrors.Error
+	if errors.As(err.ResolverError, &appErr) && appErr.Kind != apperrors.KindInternal {
+		presented.Message = appErr.Message
+		presented.Extensions = map[string]any{"code": appErr.Kind}
+		if len(appErr.Fields) > 0 {
+			presented.Extensions["details"] = appErr.Fields
+		}
+		return presented
+	}
+
+	log.Printf("graphql %v: %v", err.Path, err.ResolverError)
+	presented.Message = "internal server error"
+	presented.Extensions = map[string]any{"code": apperrors.KindInternal}
+
+	return presented
+}
@@ -0,0 +1,354 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	appContext "league-sim/internal/contexts/appContexts"
+	leagueInterfaces "league-sim/internal/league/interfaces"
+	"league-sim/internal/models"
+	predictInterfaces "league-sim/internal/predict/interfaces"
+	"league-sim/internal/repositories/interfaces"
+	simulationInterfaces "league-sim/internal/simulation/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAppContext for testing
+type MockAppContext struct {
+	mock.Mock
+}
+
+func (m *MockAppContext) LeagueRepository() interfaces.LeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.LeagueRepository)
+}
+
+func (m *MockAppContext) ActiveLeagueRepository() interfaces.ActiveLeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.ActiveLeagueRepository)
+}
+
+func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.MatchResultRepository)
+}
+
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
+func (m *MockAppContext) DB() *appContext.DB {
+	args := m.Called()
+	return args.Get(0).(*appContext.DB)
+}
+
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
+// MockService for testing
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) LeagueService() leagueInterfaces.LeagueServiceInterface {
+	args := m.Called()
+	return args.Get(0).(leagueInterfaces.LeagueServiceInterface)
+}
+
+func (m *MockService) SimulationService() simulationInterfaces.SimulationServiceInterface {
+	args := m.Called()
+	return args.Get(0).(simulationInterfaces.SimulationServiceInterface)
+}
+
+func (m *MockService) PredictService() predictInterfaces.PredictServiceInterface {
+	args := m.Called()
+	return args.Get(0).(predictInterfaces.PredictServiceInterface)
+}
+
+func (m *MockService) UserService() userInterfaces.UserServiceInterface {
+	args := m.Called()
+	return args.Get(0).(userInterfaces.UserServiceInterface)
+}
+
+var testUser = models.User{UserId: "user-id", Username: "alice"}
+
+// testLeague is a two team league that has played its first week.
+func testLeague(id string, home string, away string) models.League {
+	homeTeam := &models.Team{Name: home, AttackPower: 80}
+	awayTeam := &models.Team{Name: away, AttackPower: 70}
+
+	return models.League{
+		LeagueID:    id,
+		Teams:       []models.Team{*homeTeam, *awayTeam},
+		CurrentWeek: 2,
+		Standings: []models.Standings{
+			{Team: *awayTeam, Played: 1, Losses: 1, Goals: 0, Against: 2},
+			{Team: *homeTeam, Played: 1, Wins: 1, Goals: 2, Against: 0, Points: 3},
+		},
+		PlayedFixtures:   []models.Week{{Number: 1, Matches: []models.Match{{Home: homeTeam, Away: awayTeam}}}},
+		UpcomingFixtures: []models.Week{{Number: 2, Matches: []models.Match{{Home: awayTeam, Away: homeTeam}}}},
+	}
+}
+
+type testMocks struct {
+	appCtx       *MockAppContext
+	services     *MockService
+	leagueRepo   *interfaces.MockLeagueRepository
+	activeRepo   *interfaces.MockActiveLeagueRepository
+	resultRepo   *interfaces.MockMatchResultRepository
+	simulation   *simulationInterfaces.MockSimulationServiceInterface
+	accessibleTo []models.GetLeaguesIdsWithNameResponse
+}
+
+func newTestMocks() *testMocks {
+	m := &testMocks{
+		appCtx:     &MockAppContext{},
+		services:   &MockService{},
+		leagueRepo: &interfaces.MockLeagueRepository{},
+		activeRepo: &interfaces.MockActiveLeagueRepository{},
+		resultRepo: &interfaces.MockMatchResultRepository{},
+		simulation: &simulationInterfaces.MockSimulationServiceInterface{},
+	}
+	m.appCtx.On("LeagueRepository").Return(m.leagueRepo)
+	m.appCtx.On("ActiveLeagueRepository").Return(m.activeRepo)
+	m.appCtx.On("MatchResultRepository").Return(m.resultRepo)
+	m.services.On("SimulationService").Return(m.simulation)
+	m.leagueRepo.On("GetLeague", mock.Anything, testUser.UserId).Return(
+		[]models.GetLeaguesIdsWithNameResponse{
+			{LeagueId: "league-1", LeagueName: "First"},
+			{LeagueId: "league-2", LeagueName: "Second"},
+		}, nil)
+
+	return m
+}
+
+func (m *testMocks) execute(t *testing.T, query string, variables map[string]any) (map[string]any, []models.GraphQLError) {
+	ctx := auth.WithUser(context.Background(), testUser)
+	response := NewServer().Execute(
+		ctx, m.appCtx, m.services, models.GraphQLRequest{Query: query, Variables: variables})
+
+	var data map[string]any
+	if response.Data != nil {
+		assert.NoError(t, json.Unmarshal(response.Data, &data))
+	}
+
+	return data, response.Errors
+}
+
+func TestExecute_BatchesLoadsAcrossLeagues(t *testing.T) {
+	// Setup
+	mocks := newTestMocks()
+	mocks.activeRepo.On("GetActiveLeagues", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		return assert.ElementsMatch(t, []string{"league-1", "league-2"}, ids)
+	})).Return(
+		map[string]models.League{
+			"league-1": testLeague("league-1", "Team A", "Team B"),
+			"league-2": testLeague("league-2", "Team C", "Team D"),
+		}, nil).Once()
+	mocks.resultRepo.On("GetMatchResultsByLeague", mock.Anything, mock.Anything).Return(
+		map[string][]models.MatchResult{
+			"league-1": {{MatchWeek: 1, Home: "Team A", HomeScore: 2, Away: "Team B", AwayScore: 0, Winner: "Team A"}},
+			"league-2": {{MatchWeek: 1, Home: "Team C", HomeScore: 2, Away: "Team D", AwayScore: 0, Winner: "Team C"}},
+		}, nil).Once()
+
+	query := `{
+		leagues {
+			id
+			name
+			totalWeeks
+			standings { position team { name attackPower } points draws }
+			weeks { number played matches { home { name } away { name } played homeScore awayScore winner } }
+		}
+	}`
+
+	// Execute
+	data, errs := mocks.execute(t, query, nil)
+
+	// Assert
+	assert.Empty(t, errs)
+	leagues := data["leagues"].([]any)
+	assert.Len(t, leagues, 2)
+
+	first := leagues[0].(map[string]any)
+	assert.Equal(t, "league-1", first["id"])
+	assert.Equal(t, float64(2), first["totalWeeks"])
+	assert.Equal(
+		t, []any{
+			map[string]any{
+				"position": float64(1), "team": map[string]any{"name": "Team A", "attackPower": float64(80)},
+				"points": float64(3), "draws": float64(0),
+			},
+			map[string]any{
+				"position": float64(2), "team": map[string]any{"name": "Team B", "attackPower": float64(70)},
+				"points": float64(0), "draws": float64(0),
+			},
+		}, first["standings"])
+	assert.Equal(
+		t, []any{
+			map[string]any{
+				"number": float64(1), "played": true, "matches": []any{
+					map[string]any{
+						"home": map[string]any{"name": "Team A"}, "away": map[string]any{"name": "Team B"},
+						"played": true, "homeScore": float64(2), "awayScore": float64(0), "winner": "Team A",
+					},
+				},
+			},
+			map[string]any{
+				"number": float64(2), "played": false, "matches": []any{
+					map[string]any{
+						"home": map[string]any{"name": "Team B"}, "away": map[string]any{"name": "Team A"},
+						"played": false, "homeScore": nil, "awayScore": nil, "winner": nil,
+					},
+				},
+			},
+		}, first["weeks"])
+
+	mocks.activeRepo.AssertNumberOfCalls(t, "GetActiveLeagues", 1)
+	mocks.resultRepo.AssertNumberOfCalls(t, "GetMatchResultsByLeague", 1)
+}
+
+func TestExecute_BatchesPredictionsAcrossLeagues(t *testing.T) {
+	// Setup
+	mocks := newTestMocks()
+	predict := &predictInterfaces.MockPredictServiceInterface{}
+	mocks.services.On("PredictService").Return(predict)
+	mocks.activeRepo.On("GetActiveLeagues", mock.Anything, mock.Anything).Return(
+		map[string]models.League{
+			"league-1": testLeague("league-1", "Team A", "Team B"),
+			"league-2": testLeague("league-2", "Team C", "Team D"),
+		}, nil).Once()
+	predict.On("PredictChampionships", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		return assert.ElementsMatch(t, []string{"league-1", "league-2"}, ids)
+	})).Return(
+		map[string][]models.PredictedStanding{
+			"league-1": {{TeamName: "Team A", Points: 3, Odds: 75}, {TeamName: "Team B", Odds: 25}},
+			"league-2": {{TeamName: "Team C", Points: 3, Odds: 60}, {TeamName: "Team D", Odds: 40}},
+		}, nil).Once()
+
+	// Execute
+	data, errs := mocks.execute(t, `{ leagues { id predictions { team { name } probability } } }`, nil)
+
+	// Assert
+	assert.Empty(t, errs)
+	leagues := data["leagues"].([]any)
+	assert.Len(t, leagues, 2)
+	assert.Equal(
+		t, []any{
+			map[string]any{"team": map[string]any{"name": "Team C"}, "probability": 0.6},
+			map[string]any{"team": map[string]any{"name": "Team D"}, "probability": 0.4},
+		}, leagues[1].(map[string]any)["predictions"])
+	predict.AssertNumberOfCalls(t, "PredictChampionships", 1)
+	predict.AssertNotCalled(t, "PredictChampionShipSession", mock.Anything, mock.Anything)
+}
+
+func TestExecute_LeagueNotAccessible(t *testing.T) {
+	// Setup
+	mocks := newTestMocks()
+
+	// Execute
+	data, errs := mocks.execute(t, `query($id: ID!) { league(id: $id) { name } }`, map[string]any{"id": "league-9"})
+
+	// Assert
+	assert.Nil(t, data)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "league league-9 not found", errs[0].Message)
+	assert.Equal(t, "not_found", string(errs[0].Extensions["code"].(apperrors.Kind)))
+	mocks.activeRepo.AssertNotCalled(t, "GetActiveLeagues", mock.Anything, mock.Anything)
+}
+
+func TestExecute_SimulateWeek(t *testing.T) {
+	// Setup
+	mocks := newTestMocks()
+	mocks.leagueRepo.On("GetMemberRole", mock.Anything, "league-1", testUser.UserId).Return(models.RoleEditor, nil)
+	mocks.simulation.On("Simulation", mock.Anything, "league-1", false).Return(
+		models.SimulationResponse{
+			Matches: []models.MatchResult{{MatchWeek: 2, Home: "Team B", HomeScore: 1, Away: "Team A", AwayScore: 1}},
+		}, nil)
+
+	simulated := testLeague("league-1", "Team A", "Team B")
+	simulated.CurrentWeek = 3
+	simulated.PlayedFixtures = append(simulated.PlayedFixtures, simulated.UpcomingFixtures...)
+	simulated.UpcomingFixtures = nil
+	mocks.activeRepo.On("GetActiveLeagues", mock.Anything, []string{"league-1"}).
+		Return(map[string]models.League{"league-1": simulated}, nil)
+
+	query := `mutation { simulateWeek(leagueId: "league-1") {
+		matches { week home { name } homeScore awayScore winner }
+		league { currentWeek finished }
+	} }`
+
+	// Execute
+	data, errs := mocks.execute(t, query, nil)
+
+	// Assert
+	assert.Empty(t, errs)
+	assert.Equal(
+		t, map[string]any{
+			"matches": []any{
+				map[string]any{
+					"week": float64(2), "home": map[string]any{"name": "Team B"},
+					"homeScore": float64(1), "awayScore": float64(1), "winner": nil,
+				},
+			},
+			"league": map[string]any{"currentWeek": float64(3), "finished": true},
+		}, data["simulateWeek"])
+	mocks.simulation.AssertExpectations(t)
+}
+
+func TestExecute_SimulateWeek_ViewerForbidden(t *testing.T) {
+	// Setup
+	mocks := newTestMocks()
+	mocks.leagueRepo.On("GetMemberRole", mock.Anything, "league-1", testUser.UserId).Return(models.RoleViewer, nil)
+
+	// Execute
+	_, errs := mocks.execute(t, `mutation { simulateWeek(leagueId: "league-1") { league { id } } }`, nil)
+
+	// Assert
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "forbidden", string(errs[0].Extensions["code"].(apperrors.Kind)))
+	mocks.simulation.AssertNotCalled(t, "Simulation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecute_InvalidQuery(t *testing.T) {
+	// Setup
+	mocks := newTestMocks()
+
+	// Execute
+	data, errs := mocks.execute(t, `{ leagues { unknownField } }`, nil)
+
+	// Assert
+	assert.Nil(t, data)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "unknownField")
+	assert.NotEmpty(t, errs[0].Locations)
+}
@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// batchWait is how long a loader collects keys before fetching them. Resolvers
+// of sibling list items run concurrently, so a short window is enough to catch
+// them all.
+const batchWait = 2 * time.Millisecond
+
+// BatchFunc fetches the values of many keys at once. Keys missing from the
+// returned map are reported through the loader's missing function.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader coalesces the loads issued within batchWait of each other into one
+// call to its batch function and caches the results for the life of a request.
+type Loader[K comparable, V any] struct {
+	batch   BatchFunc[K, V]
+	missing func(K) error
+
+	mu      sync.Mutex
+	cache   map[K]*loadResult[V]
+	pending map[K]*loadResult[V]
+}
+
+type loadResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func NewLoader[K comparable, V any](batch BatchFunc[K, V], missing func(K) error) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:   batch,
+		missing: missing,
+		cache:   make(map[K]*loadResult[V]),
+	}
+}
+
+// Load returns the value of key, fetching it together with the other keys
+// requested in the same window.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	result, ok := l.cache[key]
+	if !ok {
+		result = &loadResult[V]{done: make(chan struct{})}
+		l.cache[key] = result
+
+		if l.pending == nil {
+			l.pending = make(map[K]*loadResult[V])
+			time.AfterFunc(batchWait, func() { l.dispatch(ctx) })
+		}
+		l.pending[key] = result
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Clear drops the cached value of key so that the next load fetches it again,
+// e.g. after a mutation changed it.
+func (l *Loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if result, ok := l.cache[key]; ok && l.pending[key] != result {
+		delete(l.cache, key)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	keys := make([]K, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+
+	values, err := l.batch(ctx, keys)
+	for key, result := range pending {
+		switch value, ok := values[key]; {
+		case err != nil:
+			result.err = err
+		case !ok:
+			result.err = l.missing(key)
+		default:
+			result.value = value
+		}
+		close(result.done)
+	}
+}
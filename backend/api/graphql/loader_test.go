@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingBatch doubles its keys and records every batch it is called with.
+type countingBatch struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (b *countingBatch) load(_ context.Context, keys []int) (map[int]int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sorted := append([]int(nil), keys...)
+	sort.Ints(sorted)
+	b.batches = append(b.batches, sorted)
+
+	values := make(map[int]int, len(keys))
+	for _, key := range keys {
+		if key >= 0 {
+			values[key] = key * 2
+		}
+	}
+
+	return values, nil
+}
+
+func missingInt(key int) error {
+	return apperrors.NotFound("key %d not found", key)
+}
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	// Setup
+	batch := &countingBatch{}
+	loader := NewLoader(batch.load, missingInt)
+
+	// Execute
+	values := make([]int, 5)
+	var wg sync.WaitGroup
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = loader.Load(context.Background(), i%3)
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, []int{0, 2, 4, 0, 2}, values)
+	assert.Equal(t, [][]int{{0, 1, 2}}, batch.batches)
+}
+
+func TestLoader_CachesAndClears(t *testing.T) {
+	// Setup
+	batch := &countingBatch{}
+	loader := NewLoader(batch.load, missingInt)
+	ctx := context.Background()
+
+	// Execute
+	first, err := loader.Load(ctx, 7)
+	assert.NoError(t, err)
+	cached, err := loader.Load(ctx, 7)
+	assert.NoError(t, err)
+	loader.Clear(7)
+	reloaded, err := loader.Load(ctx, 7)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []int{14, 14, 14}, []int{first, cached, reloaded})
+	assert.Equal(t, [][]int{{7}, {7}}, batch.batches)
+}
+
+func TestLoader_MissingKey(t *testing.T) {
+	// Setup
+	batch := &countingBatch{}
+	loader := NewLoader(batch.load, missingInt)
+
+	// Execute
+	_, err := loader.Load(context.Background(), -1)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+}
+
+func TestLoader_BatchError(t *testing.T) {
+	// Setup
+	failure := errors.New("database down")
+	loader := NewLoader(
+		func(context.Context, []int) (map[int]int, error) { return nil, failure }, missingInt)
+
+	// Execute
+	_, err := loader.Load(context.Background(), 1)
+
+	// Assert
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestLoader_ContextCancelled(t *testing.T) {
+	// Setup
+	release := make(chan struct{})
+	loader := NewLoader(
+		func(context.Context, []int) (map[int]int, error) {
+			<-release
+			return map[int]int{}, nil
+		}, missingInt)
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	_, err := loader.Load(ctx, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,418 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// resolver is the root of the schema: its methods are the fields of Query and
+// Mutation.
+type resolver struct{}
+
+func (r *resolver) Leagues(ctx context.Context) ([]*leagueResolver, error) {
+	leagues, err := accessibleLeagues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*leagueResolver, 0, len(leagues))
+	for _, l := range leagues {
+		resolvers = append(resolvers, &leagueResolver{id: l.LeagueId, name: l.LeagueName})
+	}
+
+	return resolvers, nil
+}
+
+// League answers not found for leagues the caller has no role in, like the
+// REST API does.
+func (r *resolver) League(ctx context.Context, args struct{ Id graphqlgo.ID }) (*leagueResolver, error) {
+	leagues, err := accessibleLeagues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := string(args.Id)
+	for _, l := range leagues {
+		if l.LeagueId == id {
+			return &leagueResolver{id: l.LeagueId, name: l.LeagueName}, nil
+		}
+	}
+
+	return nil, apperrors.NotFound("league %s not found", id)
+}
+
+func (r *resolver) SimulateWeek(ctx context.Context, args struct{ LeagueId graphqlgo.ID }) (*simulationResolver, error) {
+	target, err := r.League(ctx, struct{ Id graphqlgo.ID }{args.LeagueId})
+	if err != nil {
+		return nil, err
+	}
+
+	user, _ := auth.UserFrom(ctx)
+	st := stateFrom(ctx)
+	role, err := st.appCtx.LeagueRepository().GetMemberRole(ctx, target.id, user.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains([]string{models.RoleOwner, models.RoleEditor}, role) {
+		return nil, apperrors.Forbidden("the %s role may not perform this operation", role)
+	}
+
+	result, err := st.services.SimulationService().Simulation(ctx, target.id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Matches) == 0 {
+		return nil, apperrors.Conflict("no matches left to simulate in league %s", target.id)
+	}
+
+	st.leagues.Clear(target.id)
+	st.results.Clear(target.id)
+	st.predictions.Clear(target.id)
+
+	return &simulationResolver{league: target, matches: result.Matches}, nil
+}
+
+func accessibleLeagues(ctx context.Context) ([]models.GetLeaguesIdsWithNameResponse, error) {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return nil, apperrors.Unauthorized("authentication required")
+	}
+
+	return stateFrom(ctx).appCtx.LeagueRepository().GetLeague(ctx, user.UserId)
+}
+
+type leagueResolver struct {
+	id   string
+	name string
+}
+
+func (l *leagueResolver) load(ctx context.Context) (models.League, error) {
+	return stateFrom(ctx).leagues.Load(ctx, l.id)
+}
+
+func (l *leagueResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(l.id)
+}
+
+func (l *leagueResolver) Name() string {
+	return l.name
+}
+
+func (l *leagueResolver) CurrentWeek(ctx context.Context) (int32, error) {
+	current, err := l.load(ctx)
+	return int32(current.CurrentWeek), err
+}
+
+func (l *leagueResolver) TotalWeeks(ctx context.Context) (int32, error) {
+	current, err := l.load(ctx)
+	return int32(len(current.PlayedFixtures) + len(current.UpcomingFixtures)), err
+}
+
+func (l *leagueResolver) Finished(ctx context.Context) (bool, error) {
+	current, err := l.load(ctx)
+	return err == nil && len(current.UpcomingFixtures) == 0, err
+}
+
+func (l *leagueResolver) Teams(ctx context.Context) ([]*models.Team, error) {
+	current, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*models.Team, 0, len(current.Teams))
+	for i := range current.Teams {
+		teams = append(teams, &current.Teams[i])
+	}
+
+	return teams, nil
+}
+
+func (l *leagueResolver) Standings(ctx context.Context) ([]*standingResolver, error) {
+	current, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := league.RankStandings(current.Standings)
+	standings := make([]*standingResolver, 0, len(ranked))
+	for i, s := range ranked {
+		standings = append(
+			standings, &standingResolver{position: i + 1, standing: s, team: teamNamed(current, s.Team.Name)})
+	}
+
+	return standings, nil
+}
+
+func (l *leagueResolver) Weeks(ctx context.Context, args struct{ Played *bool }) ([]*weekResolver, error) {
+	current, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var weeks []*weekResolver
+	if args.Played == nil || *args.Played {
+		for _, week := range current.PlayedFixtures {
+			weeks = append(weeks, &weekResolver{league: current, week: week, played: true})
+		}
+	}
+	if args.Played == nil || !*args.Played {
+		for _, week := range current.UpcomingFixtures {
+			weeks = append(weeks, &weekResolver{league: current, week: week})
+		}
+	}
+
+	return weeks, nil
+}
+
+func (l *leagueResolver) Week(ctx context.Context, args struct{ Number int32 }) (*weekResolver, error) {
+	weeks, err := l.Weeks(ctx, struct{ Played *bool }{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, week := range weeks {
+		if week.week.Number == int(args.Number) {
+			return week, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (l *leagueResolver) Predictions(ctx context.Context) ([]*predictionResolver, error) {
+	current, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	predicted, err := stateFrom(ctx).predictions.Load(ctx, l.id)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([]*predictionResolver, 0, len(predicted))
+	for _, p := range predicted {
+		predictions = append(predictions, &predictionResolver{prediction: p, team: teamNamed(current, p.TeamName)})
+	}
+
+	return predictions, nil
+}
+
+// teamNamed returns the league's current version of a team. Fixtures, results
+// and standings only know teams by name.
+func teamNamed(league models.League, name string) *models.Team {
+	for i := range league.Teams {
+		if league.Teams[i].Name == name {
+			return &league.Teams[i]
+		}
+	}
+
+	return &models.Team{Name: name}
+}
+
+type standingResolver struct {
+	position int
+	standing models.Standings
+	team     *models.Team
+}
+
+func (s *standingResolver) Position() int32 {
+	return int32(s.position)
+}
+
+func (s *standingResolver) Team() *models.Team {
+	return s.team
+}
+
+func (s *standingResolver) Played() int32 {
+	return int32(s.standing.Played)
+}
+
+func (s *standingResolver) Wins() int32 {
+	return int32(s.standing.Wins)
+}
+
+func (s *standingResolver) Draws() int32 {
+	return int32(s.standing.Played - s.standing.Wins - s.standing.Losses)
+}
+
+func (s *standingResolver) Losses() int32 {
+	return int32(s.standing.Losses)
+}
+
+func (s *standingResolver) GoalsFor() int32 {
+	return int32(s.standing.Goals)
+}
+
+func (s *standingResolver) GoalsAgainst() int32 {
+	return int32(s.standing.Against)
+}
+
+func (s *standingResolver) GoalDifference() int32 {
+	return int32(s.standing.Goals - s.standing.Against)
+}
+
+func (s *standingResolver) Points() int32 {
+	return int32(s.standing.Points)
+}
+
+type weekResolver struct {
+	league models.League
+	week   models.Week
+	played bool
+}
+
+func (w *weekResolver) Number() int32 {
+	return int32(w.week.Number)
+}
+
+func (w *weekResolver) Played() bool {
+	return w.played
+}
+
+// Matches attaches the results of a played week, loaded together with those of
+// the other leagues in the query.
+func (w *weekResolver) Matches(ctx context.Context) ([]*matchResolver, error) {
+	var results []models.MatchResult
+	if w.played {
+		var err error
+		results, err = stateFrom(ctx).results.Load(ctx, w.league.LeagueID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches := make([]*matchResolver, 0, len(w.week.Matches))
+	for _, match := range w.week.Matches {
+		if match.Home == nil || match.Away == nil {
+			continue
+		}
+
+		resolved := &matchResolver{
+			week: w.week.Number,
+			home: teamNamed(w.league, match.Home.Name),
+			away: teamNamed(w.league, match.Away.Name),
+		}
+		for i, result := range results {
+			if result.MatchWeek == w.week.Number && result.Home == match.Home.Name && result.Away == match.Away.Name {
+				resolved.result = &results[i]
+				break
+			}
+		}
+		matches = append(matches, resolved)
+	}
+
+	return matches, nil
+}
+
+type matchResolver struct {
+	week   int
+	home   *models.Team
+	away   *models.Team
+	result *models.MatchResult
+}
+
+func (m *matchResolver) Week() int32 {
+	return int32(m.week)
+}
+
+func (m *matchResolver) Home() *models.Team {
+	return m.home
+}
+
+func (m *matchResolver) Away() *models.Team {
+	return m.away
+}
+
+func (m *matchResolver) Played() bool {
+	return m.result != nil
+}
+
+func (m *matchResolver) HomeScore() *int32 {
+	if m.result == nil {
+		return nil
+	}
+
+	score := int32(m.result.HomeScore)
+	return &score
+}
+
+func (m *matchResolver) AwayScore() *int32 {
+	if m.result == nil {
+		return nil
+	}
+
+	score := int32(m.result.AwayScore)
+	return &score
+}
+
+func (m *matchResolver) Winner() *string {
+	if m.result == nil || m.result.Winner == "" {
+		return nil
+	}
+
+	return &m.result.Winner
+}
+
+type predictionResolver struct {
+	prediction models.PredictedStanding
+	team       *models.Team
+}
+
+func (p *predictionResolver) Team() *models.Team {
+	return p.team
+}
+
+func (p *predictionResolver) Points() int32 {
+	return int32(p.prediction.Points)
+}
+
+func (p *predictionResolver) Strength() float64 {
+	return p.prediction.Strength
+}
+
+func (p *predictionResolver) Probability() float64 {
+	return p.prediction.Odds / 100
+}
+
+func (p *predictionResolver) Eliminated() bool {
+	return p.prediction.Eliminated
+}
+
+type simulationResolver struct {
+	league  *leagueResolver
+	matches []models.MatchResult
+}
+
+func (s *simulationResolver) Matches(ctx context.Context) ([]*matchResolver, error) {
+	current, err := s.league.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*matchResolver, 0, len(s.matches))
+	for i, result := range s.matches {
+		matches = append(
+			matches, &matchResolver{
+				week:   result.MatchWeek,
+				home:   teamNamed(current, result.Home),
+				away:   teamNamed(current, result.Away),
+				result: &s.matches[i],
+			})
+	}
+
+	return matches, nil
+}
+
+func (s *simulationResolver) League() *leagueResolver {
+	return s.league
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+func GetAuditLog(c echo.Context) error {
+	var query models.GetAuditLogRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	entries, err := appCtx.AuditRepository().GetAuditEntries(c.Request().Context(), leagueId, query.Action)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, entries)
+}
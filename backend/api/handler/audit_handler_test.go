@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAuditLog_FiltersByAction(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/audit?action=edit", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	entries := []models.AuditEntry{
+		{
+			Id:        7,
+			LeagueId:  "test-league",
+			Action:    models.AuditActionEdit,
+			ActorId:   testUser.UserId,
+			RequestId: "req-1",
+			Before:    json.RawMessage(`{"homeScore":1}`),
+			After:     json.RawMessage(`{"homeScore":3}`),
+			CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	mockAuditRepo.On("GetAuditEntries", mock.Anything, "test-league", models.AuditActionEdit).Return(entries, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetAuditLog(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []models.AuditEntry
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, entries, response)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestGetAuditLog_UnknownAction(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/audit?action=rename", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := GetAuditLog(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Len(t, body.Error.Details, 1)
+	assert.Equal(t, "action", body.Error.Details[0].Field)
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
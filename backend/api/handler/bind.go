@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// bindAndValidate decodes the request into body and checks it with the echo
+// validator registered by the router.
+func bindAndValidate(c echo.Context, body any) error {
+	if err := c.Bind(body); err != nil {
+		return bindError(err)
+	}
+
+	return c.Validate(body)
+}
+
+// bindError reports a body that could not be decoded, naming the field when
+// the JSON decoder knows which one had the wrong type.
+func bindError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperrors.InvalidFields(
+			apperrors.FieldError{Field: typeErr.Field, Message: "must be a " + jsonTypeName(typeErr.Type.Kind().String())})
+	}
+
+	return apperrors.Validation("invalid request body")
+}
+
+func jsonTypeName(kind string) string {
+	switch kind {
+	case "bool":
+		return "boolean"
+	case "string":
+		return "string"
+	case "struct", "map":
+		return "object"
+	case "slice", "array":
+		return "list"
+	}
+
+	return "number"
+}
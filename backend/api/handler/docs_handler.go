@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/api/openapi"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OpenAPISpec serves doc, which is built once when the server starts.
+func OpenAPISpec(doc *openapi.Document) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, doc)
+	}
+}
+
+// APIDocs serves the embedded page that renders the OpenAPI document.
+func APIDocs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, openapi.DocsPage)
+}
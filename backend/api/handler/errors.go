@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ErrorBody struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details []apperrors.FieldError `json:"details,omitempty"`
+}
+
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+var kindStatus = map[apperrors.Kind]int{
+	apperrors.KindNotFound:     http.StatusNotFound,
+	apperrors.KindValidation:   http.StatusBadRequest,
+	apperrors.KindConflict:     http.StatusConflict,
+	apperrors.KindUnauthorized: http.StatusUnauthorized,
+	apperrors.KindForbidden:    http.StatusForbidden,
+	apperrors.KindRateLimited:  http.StatusTooManyRequests,
+	apperrors.KindQuota:        http.StatusTooManyRequests,
+	apperrors.KindInternal:     http.StatusInternalServerError,
+}
+
+// ErrorStatus maps an error returned by a handler to its HTTP status and the
+// body sent to the client. Internal failures never expose their cause.
+func ErrorStatus(err error) (int, ErrorResponse) {
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		status, ok := kindStatus[appErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+
+		return status, ErrorResponse{
+			Error: ErrorBody{Code: string(appErr.Kind), Message: appErr.Message, Details: appErr.Fields},
+		}
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message := http.StatusText(httpErr.Code)
+		if httpErr.Message != nil {
+			message = fmt.Sprint(httpErr.Message)
+		}
+
+		return httpErr.Code, ErrorResponse{Error: ErrorBody{Code: codeForStatus(httpErr.Code), Message: message}}
+	}
+
+	return http.StatusInternalServerError, ErrorResponse{
+		Error: ErrorBody{Code: string(apperrors.KindInternal), Message: "internal server error"},
+	}
+}
+
+// HTTPErrorHandler is the echo error handler for the whole API. It writes every
+// error as an ErrorResponse and logs the ones that are the server's fault.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status, body := ErrorStatus(err)
+	if status >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, body)
+	}
+	if err != nil {
+		log.Printf("writing error response: %v", err)
+	}
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return string(apperrors.KindNotFound)
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return string(apperrors.KindValidation)
+	case http.StatusConflict:
+		return string(apperrors.KindConflict)
+	case http.StatusUnauthorized:
+		return string(apperrors.KindUnauthorized)
+	case http.StatusForbidden:
+		return string(apperrors.KindForbidden)
+	case http.StatusTooManyRequests:
+		return string(apperrors.KindRateLimited)
+	}
+
+	if status >= http.StatusInternalServerError {
+		return string(apperrors.KindInternal)
+	}
+
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
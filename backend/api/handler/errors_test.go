@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{
+			name: "Not found", err: apperrors.NotFound("league abc not found"),
+			status: http.StatusNotFound, code: "not_found", message: "league abc not found",
+		},
+		{
+			name: "Validation", err: apperrors.Validation("team count must be even"),
+			status: http.StatusBadRequest, code: "validation", message: "team count must be even",
+		},
+		{
+			name: "Conflict", err: apperrors.Conflict("league is finished"),
+			status: http.StatusConflict, code: "conflict", message: "league is finished",
+		},
+		{
+			name: "Rate limited", err: apperrors.RateLimited("rate limit exceeded"),
+			status: http.StatusTooManyRequests, code: "rate_limited", message: "rate limit exceeded",
+		},
+		{
+			name: "Quota", err: apperrors.QuotaExceeded("league quota of 5 reached"),
+			status: http.StatusTooManyRequests, code: "quota_exceeded", message: "league quota of 5 reached",
+		},
+		{
+			name: "Internal hides cause", err: apperrors.Internal(errors.New("dial tcp"), "failed to read league"),
+			status: http.StatusInternalServerError, code: "internal", message: "failed to read league",
+		},
+		{
+			name: "Echo error", err: echo.NewHTTPError(http.StatusMethodNotAllowed, "method not allowed"),
+			status: http.StatusMethodNotAllowed, code: "method_not_allowed", message: "method not allowed",
+		},
+		{
+			name: "Echo not found", err: echo.ErrNotFound,
+			status: http.StatusNotFound, code: "not_found", message: "Not Found",
+		},
+		{
+			name: "Plain error", err: errors.New("sql: connection refused"),
+			status: http.StatusInternalServerError, code: "internal", message: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				status, body := ErrorStatus(tt.err)
+
+				assert.Equal(t, tt.status, status)
+				assert.Equal(t, tt.code, body.Error.Code)
+				assert.Equal(t, tt.message, body.Error.Message)
+			})
+	}
+}
+
+func TestHTTPErrorHandler_WritesJSONBody(t *testing.T) {
+	// Setup
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/abc/standing", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Execute
+	HTTPErrorHandler(apperrors.NotFound("league abc not found"), c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var body ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "not_found", body.Error.Code)
+	assert.Equal(t, "league abc not found", body.Error.Message)
+}
+
+func TestHTTPErrorHandler_CommittedResponse(t *testing.T) {
+	// Setup
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	assert.NoError(t, c.String(http.StatusOK, "done"))
+
+	// Execute
+	HTTPErrorHandler(apperrors.Conflict("too late"), c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "done", rec.Body.String())
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"runtime"
+
+	"league-sim/internal/experiment"
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RunExperiment plays a batch of synthetic leagues in memory and returns the
+// aggregated outcomes as JSON, or one CSV row per league with ?format=csv.
+// The leagues are spread over one worker per CPU.
+func RunExperiment(c echo.Context) error {
+	var query models.ExperimentFormatRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &query); err != nil {
+		return bindError(err)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	var body models.ExperimentRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	result, err := experiment.Run(c.Request().Context(), body, runtime.GOMAXPROCS(0))
+	if err != nil {
+		return err
+	}
+
+	if query.Format == "csv" {
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+		c.Response().WriteHeader(http.StatusOK)
+		return experiment.WriteCSV(c.Response(), result)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExperimentContext(e *echo.Echo, target string, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	return e.NewContext(req, rec), rec
+}
+
+func TestRunExperiment_JSON(t *testing.T) {
+	// Setup
+	c, rec := newExperimentContext(newTestEcho(), "/api/v1/experiments", `{"leagues": 3, "teamCount": 4}`)
+
+	// Execute
+	err := RunExperiment(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result models.ExperimentResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	assert.Equal(t, 3, result.Leagues)
+	assert.Equal(t, 4, result.TeamCount)
+	assert.Len(t, result.Runs, 3)
+}
+
+func TestRunExperiment_CSV(t *testing.T) {
+	// Setup
+	c, rec := newExperimentContext(newTestEcho(), "/api/v1/experiments?format=csv", `{"leagues": 2, "teamCount": 4}`)
+
+	// Execute
+	err := RunExperiment(c)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "league,champion,"))
+}
+
+func TestRunExperiment_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		field  string
+	}{
+		{
+			name: "Unknown format", target: "/api/v1/experiments?format=xml",
+			body: `{"leagues": 2, "teamCount": 4}`, field: "format",
+		},
+		{
+			name: "Too many leagues", target: "/api/v1/experiments",
+			body: `{"leagues": 5000, "teamCount": 4}`, field: "leagues",
+		},
+		{
+			name: "Missing team count", target: "/api/v1/experiments",
+			body: `{"leagues": 2}`, field: "teamCount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				c, _ := newExperimentContext(newTestEcho(), tt.target, tt.body)
+
+				// Execute
+				err := RunExperiment(c)
+
+				// Assert
+				assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+				var appErr *apperrors.Error
+				require.ErrorAs(t, err, &appErr)
+				require.Len(t, appErr.Fields, 1)
+				assert.Equal(t, tt.field, appErr.Fields[0].Field)
+			})
+	}
+}
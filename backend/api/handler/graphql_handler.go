@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/api/graphql"
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GraphQL runs GraphQL requests against server. Failures inside the query are
+// reported in the response's "errors" with a 200, as GraphQL clients expect.
+func GraphQL(server *graphql.Server) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var body models.GraphQLRequest
+		if err := bindAndValidate(c, &body); err != nil {
+			return err
+		}
+
+		appCtx, err := appContextFrom(c)
+		if err != nil {
+			return err
+		}
+
+		service, err := servicesFrom(c)
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, server.Execute(c.Request().Context(), appCtx, service, body))
+	}
+}
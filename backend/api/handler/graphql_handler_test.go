@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"league-sim/api/graphql"
+	"league-sim/internal/auth"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGraphQL_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", bytes.NewBufferString(`{"query":"{ leagues { id name } }"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Mock data
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(&interfaces.MockActiveLeagueRepository{})
+	mockAppCtx.On("MatchResultRepository").Return(&interfaces.MockMatchResultRepository{})
+	mockLeagueRepo.On("GetLeague", mock.Anything, testUser.UserId).
+		Return([]models.GetLeaguesIdsWithNameResponse{{LeagueId: "league1", LeagueName: "League 1"}}, nil)
+
+	// Set context
+	ctx := auth.WithUser(c.Request().Context(), testUser)
+	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
+	ctx = context.WithValue(ctx, "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GraphQL(graphql.NewServer())(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data":{"leagues":[{"id":"league1","name":"League 1"}]}}`, rec.Body.String())
+	mockLeagueRepo.AssertExpectations(t)
+}
+
+func TestGraphQL_MissingQuery(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", bytes.NewBufferString(`{"variables":{}}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Execute
+	err := GraphQL(graphql.NewServer())(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "validation", body.Error.Code)
+	assert.Equal(t, "query", body.Error.Details[0].Field)
+}
@@ -4,25 +4,28 @@ import (
 	"fmt"
 	"net/http"
 
-	appContext "league-sim/internal/contexts/appContexts"
-	"league-sim/internal/contexts/services"
+	"league-sim/internal/league"
 	"league-sim/internal/models"
+	"league-sim/internal/simulation"
 
 	"github.com/labstack/echo/v4"
 )
 
 func GetLeagueIds(c echo.Context) error {
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
-	if !ok || appCtx == nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	user, err := userFrom(c)
+	if err != nil {
+		return err
 	}
 
-	leagueIds, err := appCtx.LeagueRepository().GetLeague()
-
+	appCtx, err := appContextFrom(c)
 	if err != nil {
+		return err
+	}
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get league IDs")
+	leagueIds, err := appCtx.LeagueRepository().GetLeague(c.Request().Context(), user.UserId)
+
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, leagueIds)
@@ -36,63 +39,178 @@ func GetLeague(c echo.Context) error {
 
 func CreateLeague(c echo.Context) error {
 	var body models.CreateLeagueRequest
-	if err := c.Bind(&body); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err)
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := serviceInit.LeagueService().CreateLeague(c.Request().Context(), body.TeamCount, body.LeagueName, body.Rules)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ExportLeague returns everything stored for a league as a versioned JSON
+// document or, with ?format=csv, the ?table= standings, results or fixtures
+// as CSV.
+func ExportLeague(c echo.Context) error {
+	var query models.ExportLeagueRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
 	}
 
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
-	if !ok || appCtx == nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	leagueId := c.Param("leagueId")
+	export, err := serviceInit.LeagueService().ExportLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	if query.Format == "csv" {
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+		c.Response().Header().Set(
+			echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", query.Table+".csv"))
+		c.Response().WriteHeader(http.StatusOK)
+		return league.WriteExportCSV(c.Response(), export, query.Table)
 	}
 
-	serviceInit := c.Request().Context().Value("services").(services.Service)
-	result, err := serviceInit.LeagueService().CreateLeague(body.TeamCount, body.LeagueName)
+	return c.JSON(http.StatusOK, export)
+}
+
+// ImportLeague recreates a league from an export document, owned by the
+// caller, under its exported ID or, with ?newId=true, a new one.
+func ImportLeague(c echo.Context) error {
+	var query models.ImportLeagueRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &query); err != nil {
+		return bindError(err)
+	}
+
+	var body models.LeagueExport
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
 
+	serviceInit, err := servicesFrom(c)
 	if err != nil {
+		return err
+	}
+
+	result, err := serviceInit.LeagueService().ImportLeague(c.Request().Context(), body, query.NewID)
 
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, result)
 }
 
-func GetStanding(c echo.Context) error {
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
+// ForkLeague copies a league as it stands into a new league owned by the
+// caller, tied to the original as its parent.
+func ForkLeague(c echo.Context) error {
+	var body models.ForkLeagueRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
 
-	if !ok || appCtx == nil {
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	leagueId := c.Param("leagueId")
+	result, err := serviceInit.LeagueService().ForkLeague(c.Request().Context(), leagueId, body.LeagueName)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetStandingsDiff compares the standings of a league with those of the league
+// named by ?with=, or with its parent when it is a fork.
+func GetStandingsDiff(c echo.Context) error {
+	var query models.GetStandingsDiffRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
 	}
 
 	leagueId := c.Param("leagueId")
-	standings, err := appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(leagueId)
+	diff, err := serviceInit.LeagueService().DiffStandings(c.Request().Context(), leagueId, query.With)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
 
+func GetStanding(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
 	if err != nil {
-		fmt.Println(err)
+		return err
+	}
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get standings")
+	leagueId := c.Param("leagueId")
+	standings, err := appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, standings)
 }
 
+// GetFixtures returns the upcoming and played fixtures of a league; with
+// ?odds=true every upcoming match carries the match engine's odds.
 func GetFixtures(c echo.Context) error {
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
-
-	if !ok || appCtx == nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	var query models.GetFixturesRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
 	}
 
 	leagueId := c.Param("leagueId")
-	fixtures, err := appCtx.ActiveLeagueRepository().GetActiveLeaguesFixtures(leagueId)
+	if query.Odds {
+		service, err := servicesFrom(c)
+		if err != nil {
+			return err
+		}
 
+		fixtures, err := service.PredictService().PredictFixtures(c.Request().Context(), leagueId)
+
+		if err != nil {
+			return err
+		}
+
+		return c.JSON(http.StatusOK, fixtures)
+	}
+
+	appCtx, err := appContextFrom(c)
 	if err != nil {
+		return err
+	}
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get fixtures")
+	fixtures, err := appCtx.ActiveLeagueRepository().GetActiveLeaguesFixtures(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, fixtures)
@@ -101,18 +219,15 @@ func GetFixtures(c echo.Context) error {
 func DeleteLeague(c echo.Context) error {
 	leagueId := c.Param("leagueId")
 
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
-	if !ok || appCtx == nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
 	}
 
-	err := appCtx.LeagueRepository().DeleteLeague(leagueId)
+	err = serviceInit.LeagueService().DeleteLeague(c.Request().Context(), leagueId)
 
 	if err != nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete league")
+		return err
 	}
 
 	return c.JSON(http.StatusOK, leagueId)
@@ -120,29 +235,181 @@ func DeleteLeague(c echo.Context) error {
 
 func GetPredictTable(c echo.Context) error {
 	leagueId := c.Param("leagueId")
-	service := c.Request().Context().Value("services").(services.Service)
-	predictTable, err := service.PredictService().PredictChampionShipSession(leagueId)
-
+	service, err := servicesFrom(c)
 	if err != nil {
-		fmt.Println("Error predicting championship:", err)
+		return err
+	}
+
+	predictTable, err := service.PredictService().PredictChampionShipSession(c.Request().Context(), leagueId)
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to predict championship")
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, predictTable)
 }
 
-func ResetLeague(c echo.Context) error {
-	serviceInit := c.Request().Context().Value("services").(services.Service)
+// GetPredictionAccuracy scores the title odds given before every week of a
+// finished league against its champion.
+func GetPredictionAccuracy(c echo.Context) error {
+	leagueId := c.Param("leagueId")
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	accuracy, err := service.PredictService().PredictionAccuracy(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, accuracy)
+}
+
+// GetPositionProbabilities returns how often every team of a league finished
+// in every position over ?runs= playouts of the remaining fixtures, as JSON or,
+// with ?format=csv, one CSV row per team.
+func GetPositionProbabilities(c echo.Context) error {
+	var query models.GetPositionsRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	result, err := service.PredictService().PositionProbabilities(c.Request().Context(), leagueId, query.Runs)
+
+	if err != nil {
+		return err
+	}
+
+	if query.Format == "csv" {
+		c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=UTF-8")
+		c.Response().WriteHeader(http.StatusOK)
+		return simulation.WritePositionsCSV(c.Response(), result)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// WhatIf recomputes the standings and title odds of a league as they would be
+// after the hypothetical results in the body, without storing anything.
+func WhatIf(c echo.Context) error {
+	var body models.WhatIfRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
 	leagueId := c.Param("leagueId")
+	response, err := service.PredictService().WhatIf(c.Request().Context(), leagueId, body)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
 
-	err := serviceInit.LeagueService().ResetLeague(leagueId)
+// GetClinch returns, for every team of a league, whether it has clinched or
+// is out of the title, the ?top= places and safety from ?relegation=, with
+// the magic numbers that decide them.
+func GetClinch(c echo.Context) error {
+	var query models.GetClinchRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
 
+	service, err := servicesFrom(c)
 	if err != nil {
-		fmt.Println("Error resetting league:", err)
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	analysis, err := service.PredictService().Clinch(c.Request().Context(), leagueId, query)
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to reset league")
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, analysis)
+}
+
+func ResetLeague(c echo.Context) error {
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	err = serviceInit.LeagueService().ResetLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, "League reset successfully")
 }
+
+func GetLeagueMembers(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	members, err := appCtx.LeagueRepository().GetLeagueMembers(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, members)
+}
+
+func AddLeagueMember(c echo.Context) error {
+	var body models.AddLeagueMemberRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	err = serviceInit.LeagueService().AddMember(c.Request().Context(), leagueId, body.UserId, body.Role)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, body)
+}
+
+func RemoveLeagueMember(c echo.Context) error {
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	userId := c.Param("userId")
+	err = serviceInit.LeagueService().RemoveMember(c.Request().Context(), leagueId, userId)
+
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -10,12 +10,17 @@ import (
 	"strings"
 	"testing"
 
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
 	appContext "league-sim/internal/contexts/appContexts"
 	leagueInterfaces "league-sim/internal/league/interfaces"
 	"league-sim/internal/models"
 	predictInterfaces "league-sim/internal/predict/interfaces"
 	"league-sim/internal/repositories/interfaces"
 	simulationInterfaces "league-sim/internal/simulation/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
+	"league-sim/internal/validation"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -42,11 +47,54 @@ func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepositor
 	return args.Get(0).(interfaces.MatchResultRepository)
 }
 
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
 func (m *MockAppContext) DB() *appContext.DB {
 	args := m.Called()
 	return args.Get(0).(*appContext.DB)
 }
 
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
+// testUser is the authenticated caller for handlers that need one.
+var testUser = models.User{UserId: "user-id", Username: "alice"}
+
+// newTestEcho returns an echo instance wired like the router: with the request
+// validator and the central error handler.
+func newTestEcho() *echo.Echo {
+	e := echo.New()
+	e.Validator = validation.New(config.Default().Limits)
+	e.HTTPErrorHandler = HTTPErrorHandler
+
+	return e
+}
+
 // MockService for testing
 type MockService struct {
 	mock.Mock
@@ -67,9 +115,14 @@ func (m *MockService) PredictService() predictInterfaces.PredictServiceInterface
 	return args.Get(0).(predictInterfaces.PredictServiceInterface)
 }
 
+func (m *MockService) UserService() userInterfaces.UserServiceInterface {
+	args := m.Called()
+	return args.Get(0).(userInterfaces.UserServiceInterface)
+}
+
 func TestGetLeagueIds_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -84,10 +137,10 @@ func TestGetLeagueIds_Success(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
-	mockLeagueRepo.On("GetLeague").Return(expectedLeagueIds, nil)
+	mockLeagueRepo.On("GetLeague", mock.Anything, testUser.UserId).Return(expectedLeagueIds, nil)
 
 	// Set context
-	ctx := c.Request().Context()
+	ctx := auth.WithUser(c.Request().Context(), testUser)
 	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
 	c.SetRequest(c.Request().WithContext(ctx))
 
@@ -109,25 +162,43 @@ func TestGetLeagueIds_Success(t *testing.T) {
 
 func TestGetLeagueIds_MissingAppContext(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
 	// Set context with nil value to trigger the error
-	ctx := c.Request().Context()
+	ctx := auth.WithUser(c.Request().Context(), testUser)
 	ctx = context.WithValue(ctx, "appContext", nil)
 	c.SetRequest(c.Request().WithContext(ctx))
 
-	// Execute and expect panic due to type assertion
-	assert.Panics(t, func() {
-		GetLeagueIds(c)
-	}, "Should panic when app context is nil")
+	// Execute
+	err := GetLeagueIds(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestGetLeagueIds_Unauthenticated(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Execute
+	err := GetLeagueIds(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "unauthorized", body.Error.Code)
 }
 
 func TestGetLeagueIds_RepositoryError(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -139,10 +210,10 @@ func TestGetLeagueIds_RepositoryError(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
-	mockLeagueRepo.On("GetLeague").Return([]models.GetLeaguesIdsWithNameResponse{}, expectedError)
+	mockLeagueRepo.On("GetLeague", mock.Anything, testUser.UserId).Return([]models.GetLeaguesIdsWithNameResponse{}, expectedError)
 
 	// Set context
-	ctx := c.Request().Context()
+	ctx := auth.WithUser(c.Request().Context(), testUser)
 	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
 	c.SetRequest(c.Request().WithContext(ctx))
 
@@ -151,9 +222,9 @@ func TestGetLeagueIds_RepositoryError(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusInternalServerError, httpError.Code)
-	assert.Equal(t, "Failed to get league IDs", httpError.Message)
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.Equal(t, "internal server error", body.Error.Message, "internal causes are not exposed")
 
 	// Verify mocks
 	mockAppCtx.AssertExpectations(t)
@@ -162,7 +233,7 @@ func TestGetLeagueIds_RepositoryError(t *testing.T) {
 
 func TestGetLeague_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league-id", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -180,7 +251,7 @@ func TestGetLeague_Success(t *testing.T) {
 
 func TestCreateLeague_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.CreateLeagueRequest{
 		TeamCount:  "8",
 		LeagueName: "Test League",
@@ -202,7 +273,7 @@ func TestCreateLeague_Success(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("LeagueService").Return(mockLeagueService)
-	mockLeagueService.On("CreateLeague", "8", "Test League").Return(expectedResponse, nil)
+	mockLeagueService.On("CreateLeague", mock.Anything, "8", "Test League", (*models.LeagueRules)(nil)).Return(expectedResponse, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -228,7 +299,7 @@ func TestCreateLeague_Success(t *testing.T) {
 
 func TestCreateLeague_InvalidRequestBody(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league", strings.NewReader("invalid json"))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
@@ -239,13 +310,44 @@ func TestCreateLeague_InvalidRequestBody(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusBadRequest, httpError.Code)
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestCreateLeague_ValidationErrors(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/v1/league", strings.NewReader(`{"leagueName": "", "teamCount": "-3"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := &MockService{}
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := CreateLeague(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "validation", body.Error.Code)
+	assert.ElementsMatch(
+		t, []apperrors.FieldError{
+			{Field: "leagueName", Message: "is required"},
+			{Field: "teamCount", Message: "must be a whole number between 2 and 26"},
+		}, body.Error.Details)
+	mockService.AssertNotCalled(t, "LeagueService")
 }
 
 func TestCreateLeague_MissingAppContext(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.CreateLeagueRequest{TeamCount: "8", LeagueName: "Test League"}
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league", bytes.NewBuffer(jsonBody))
@@ -258,15 +360,17 @@ func TestCreateLeague_MissingAppContext(t *testing.T) {
 	ctx = context.WithValue(ctx, "appContext", nil)
 	c.SetRequest(c.Request().WithContext(ctx))
 
-	// Execute and expect panic due to type assertion
-	assert.Panics(t, func() {
-		CreateLeague(c)
-	}, "Should panic when app context is nil")
+	// Execute
+	err := CreateLeague(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 }
 
 func TestCreateLeague_ServiceError(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.CreateLeagueRequest{TeamCount: "8", LeagueName: "Test League"}
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league", bytes.NewBuffer(jsonBody))
@@ -282,7 +386,7 @@ func TestCreateLeague_ServiceError(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("LeagueService").Return(mockLeagueService)
-	mockLeagueService.On("CreateLeague", "8", "Test League").Return(models.GetLeaguesIdsWithNameResponse{}, expectedError)
+	mockLeagueService.On("CreateLeague", mock.Anything, "8", "Test League", (*models.LeagueRules)(nil)).Return(models.GetLeaguesIdsWithNameResponse{}, expectedError)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -295,8 +399,8 @@ func TestCreateLeague_ServiceError(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusInternalServerError, httpError.Code)
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 
 	// Verify mocks
 	mockService.AssertExpectations(t)
@@ -305,7 +409,7 @@ func TestCreateLeague_ServiceError(t *testing.T) {
 
 func TestGetStanding_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/standing", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -322,7 +426,7 @@ func TestGetStanding_Success(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", "test-league").Return(expectedStandings, nil)
+	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", mock.Anything, "test-league").Return(expectedStandings, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -347,7 +451,7 @@ func TestGetStanding_Success(t *testing.T) {
 
 func TestGetStanding_RepositoryError(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/standing", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -361,7 +465,7 @@ func TestGetStanding_RepositoryError(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", "test-league").Return([]models.Standings{}, expectedError)
+	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", mock.Anything, "test-league").Return([]models.Standings{}, expectedError)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -373,18 +477,53 @@ func TestGetStanding_RepositoryError(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusInternalServerError, httpError.Code)
-	assert.Equal(t, "Failed to get standings", httpError.Message)
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 
 	// Verify mocks
 	mockAppCtx.AssertExpectations(t)
 	mockActiveLeagueRepo.AssertExpectations(t)
 }
 
+func TestGetStanding_LeagueNotFound(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/unknown/standing", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("unknown")
+
+	// Mock data
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	// Configure mocks
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", mock.Anything, "unknown").
+		Return([]models.Standings(nil), apperrors.NotFound("league unknown not found"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetStanding(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var body ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "not_found", body.Error.Code)
+	assert.Equal(t, "league unknown not found", body.Error.Message)
+}
+
 func TestGetFixtures_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/fixtures", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -403,7 +542,7 @@ func TestGetFixtures_Success(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesFixtures", "test-league").Return(expectedFixtures, nil)
+	mockActiveLeagueRepo.On("GetActiveLeaguesFixtures", mock.Anything, "test-league").Return(expectedFixtures, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -426,9 +565,73 @@ func TestGetFixtures_Success(t *testing.T) {
 	mockActiveLeagueRepo.AssertExpectations(t)
 }
 
+func TestGetFixtures_WithOdds(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/fixtures?odds=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	odds := &models.MatchOdds{
+		HomeWin: 0.45, Draw: 0.2, AwayWin: 0.35, ExpectedHomeGoals: 1.6, ExpectedAwayGoals: 1.3,
+		LikeliestScore: models.Scoreline{Home: 1, Away: 0, Probability: 0.09},
+	}
+	expectedFixtures := models.GetActiveLeagueFixturesResponse{
+		UpcomingFixtures: []models.Week{
+			{
+				Number:  1,
+				Matches: []models.Match{{Home: &models.Team{Name: "Team A"}, Away: &models.Team{Name: "Team B"}, Odds: odds}},
+			},
+		},
+		PlayedFixtures: []models.Week{},
+	}
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("PredictFixtures", mock.Anything, "test-league").Return(expectedFixtures, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetFixtures(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.GetActiveLeagueFixturesResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, expectedFixtures, response)
+	mockPredictService.AssertExpectations(t)
+}
+
+func TestGetFixtures_InvalidOdds(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/fixtures?odds=maybe", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := GetFixtures(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
 func TestDeleteLeague_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/league/test-league", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -436,16 +639,16 @@ func TestDeleteLeague_Success(t *testing.T) {
 	c.SetParamValues("test-league")
 
 	// Mock data
-	mockLeagueRepo := &interfaces.MockLeagueRepository{}
-	mockAppCtx := &MockAppContext{}
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
 
 	// Configure mocks
-	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
-	mockLeagueRepo.On("DeleteLeague", "test-league").Return(nil)
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("DeleteLeague", mock.Anything, "test-league").Return(nil)
 
 	// Set context
 	ctx := c.Request().Context()
-	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
+	ctx = context.WithValue(ctx, "services", mockService)
 	c.SetRequest(c.Request().WithContext(ctx))
 
 	// Execute
@@ -457,13 +660,13 @@ func TestDeleteLeague_Success(t *testing.T) {
 	assert.Equal(t, `"test-league"`, strings.TrimSpace(rec.Body.String()))
 
 	// Verify mocks
-	mockAppCtx.AssertExpectations(t)
-	mockLeagueRepo.AssertExpectations(t)
+	mockService.AssertExpectations(t)
+	mockLeagueService.AssertExpectations(t)
 }
 
 func TestEditMatch_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.EditMatchResult{
 		Home:      "Team A",
 		Away:      "Team B",
@@ -488,7 +691,7 @@ func TestEditMatch_Success(t *testing.T) {
 	mockService.On("SimulationService").Return(mockSimulationService)
 	expectedEditData := requestBody
 	expectedEditData.LeagueId = "test-league"
-	mockSimulationService.On("EditMatch", expectedEditData).Return(nil)
+	mockSimulationService.On("EditMatch", mock.Anything, expectedEditData).Return(nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -510,7 +713,7 @@ func TestEditMatch_Success(t *testing.T) {
 
 func TestGetPredictTable_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/predict", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -527,7 +730,7 @@ func TestGetPredictTable_Success(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("PredictService").Return(mockPredictService)
-	mockPredictService.On("PredictChampionShipSession", "test-league").Return(expectedPredictTable, nil)
+	mockPredictService.On("PredictChampionShipSession", mock.Anything, "test-league").Return(expectedPredictTable, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -550,9 +753,79 @@ func TestGetPredictTable_Success(t *testing.T) {
 	mockPredictService.AssertExpectations(t)
 }
 
+func TestGetPredictionAccuracy_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/predictions/accuracy", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	expected := models.PredictionAccuracy{
+		Champion:    "Team A",
+		Snapshots:   2,
+		BrierScore:  0.25,
+		LogLoss:     0.35,
+		Calibration: []models.CalibrationBin{{From: 0.5, To: 0.6, Predictions: 2, MeanPredicted: 0.5, Observed: 0.5}},
+	}
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("PredictionAccuracy", mock.Anything, "test-league").Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetPredictionAccuracy(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.PredictionAccuracy
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+	mockPredictService.AssertExpectations(t)
+}
+
+func TestGetPredictionAccuracy_SeasonNotFinished(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/predictions/accuracy", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("PredictionAccuracy", mock.Anything, "test-league").
+		Return(models.PredictionAccuracy{}, apperrors.Conflict("season of league test-league is not finished"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetPredictionAccuracy(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
 func TestResetLeague_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/reset", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -565,7 +838,7 @@ func TestResetLeague_Success(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("LeagueService").Return(mockLeagueService)
-	mockLeagueService.On("ResetLeague", "test-league").Return(nil)
+	mockLeagueService.On("ResetLeague", mock.Anything, "test-league").Return(nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -584,3 +857,619 @@ func TestResetLeague_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 	mockLeagueService.AssertExpectations(t)
 }
+
+func TestAddLeagueMember_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	memberId := "0b6c2f3e-8d2a-4c7e-9f1a-5b3d7e9a1c24"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/members", strings.NewReader(`{"userId":"`+memberId+`","role":"viewer"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("AddMember", mock.Anything, "test-league", memberId, models.RoleViewer).Return(nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := AddLeagueMember(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	mockLeagueService.AssertExpectations(t)
+}
+
+func TestAddLeagueMember_InvalidBody(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/members", strings.NewReader(`{"userId":"bob","role":"owner"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := AddLeagueMember(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(
+		t, []apperrors.FieldError{
+			{Field: "userId", Message: "must be a UUID"},
+			{Field: "role", Message: "must be one of: editor, viewer"},
+		}, body.Error.Details)
+}
+
+func TestRemoveLeagueMember_NotAMember(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/league/test-league/members/stranger", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId", "userId")
+	c.SetParamValues("test-league", "stranger")
+
+	// Mock data
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("RemoveMember", mock.Anything, "test-league", "stranger").
+		Return(apperrors.NotFound("user stranger is not a member of league test-league"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := RemoveLeagueMember(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusNotFound, status)
+	mockLeagueService.AssertExpectations(t)
+}
+
+func TestGetClinch_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/clinch?method=maxflow&top=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	query := models.GetClinchRequest{Method: models.ClinchMethodMaxFlow, Top: 2}
+	expected := models.ClinchAnalysis{
+		Method:           models.ClinchMethodMaxFlow,
+		RemainingMatches: 2,
+		Teams: []models.TeamClinch{
+			{
+				Team: "Team A", Points: 9, MaxPoints: 15, BestPosition: 1, WorstPosition: 2,
+				Targets: []models.ClinchStatus{{Target: models.ClinchTargetTitle, Places: 1, MagicNumber: 4}},
+			},
+		},
+	}
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("Clinch", mock.Anything, "test-league", query).Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetClinch(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.ClinchAnalysis
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+	mockPredictService.AssertExpectations(t)
+}
+
+func TestGetClinch_InvalidMethod(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/clinch?method=guess", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := GetClinch(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetClinch_TooManyMatchesForExhaustive(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/clinch?method=exhaustive", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("Clinch", mock.Anything, "test-league", mock.Anything).
+		Return(models.ClinchAnalysis{}, apperrors.Validation("exhaustive search is limited to 12 remaining matches"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetClinch(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetPositionProbabilities_CSV(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/predictions/positions?runs=50&format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	result := models.PositionProbabilities{
+		Runs: 50,
+		Teams: []models.TeamPositions{
+			{Team: "Team A", Points: 3, Probabilities: []float64{80, 20}, ExpectedPosition: 1.2, ExpectedPoints: 5},
+			{Team: "Team B", Points: 0, Probabilities: []float64{20, 80}, ExpectedPosition: 1.8, ExpectedPoints: 2},
+		},
+	}
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("PositionProbabilities", mock.Anything, "test-league", 50).Return(result, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetPositionProbabilities(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[1], "Team A,3,1.2000"))
+	mockPredictService.AssertExpectations(t)
+}
+
+func TestGetPositionProbabilities_JSON(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/predictions/positions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	expected := models.PositionProbabilities{
+		Runs:  1000,
+		Teams: []models.TeamPositions{{Team: "Team A", Probabilities: []float64{100}, ExpectedPosition: 1}},
+	}
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("PositionProbabilities", mock.Anything, "test-league", 0).Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetPositionProbabilities(c)
+
+	// Assert
+	assert.NoError(t, err)
+	var response models.PositionProbabilities
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+}
+
+func TestGetPositionProbabilities_TooManyRuns(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/predictions/positions?runs=20000", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := GetPositionProbabilities(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWhatIf_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	body := models.WhatIfRequest{
+		Results: []models.HypotheticalResult{{Home: "Team A", Away: "Team B", HomeScore: 2, AwayScore: 1}},
+	}
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/what-if", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	expected := models.WhatIfResponse{
+		Results: []models.MatchResult{
+			{MatchWeek: 2, Home: "Team A", HomeScore: 2, Away: "Team B", AwayScore: 1, Winner: "Team A"},
+		},
+		Predictions: []models.PredictedStanding{{TeamName: "Team A", Points: 6, Odds: 75}},
+	}
+	mockPredictService := &predictInterfaces.MockPredictServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("PredictService").Return(mockPredictService)
+	mockPredictService.On("WhatIf", mock.Anything, "test-league", body).Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := WhatIf(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.WhatIfResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+	mockPredictService.AssertExpectations(t)
+}
+
+func TestWhatIf_InvalidBody(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/v1/league/test-league/what-if",
+		strings.NewReader(`{"results": [{"home": "Team A", "away": "Team A", "homeScore": 1, "awayScore": 0}]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := WhatIf(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWhatIf_NoResults(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/what-if", strings.NewReader(`{"results": []}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := WhatIf(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestForkLeague_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/v1/league/parent-id/fork", strings.NewReader(`{"leagueName": "What if"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("parent-id")
+
+	// Mock data
+	expected := models.GetLeaguesIdsWithNameResponse{LeagueId: "fork-id", LeagueName: "What if", ParentId: "parent-id"}
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("ForkLeague", mock.Anything, "parent-id", "What if").Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := ForkLeague(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.GetLeaguesIdsWithNameResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+	mockLeagueService.AssertExpectations(t)
+}
+
+func TestGetStandingsDiff_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/fork-id/diff?with=other-id", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("fork-id")
+
+	// Mock data
+	expected := models.StandingsDiff{
+		LeagueId:      "fork-id",
+		OtherLeagueId: "other-id",
+		Teams: []models.TeamStandingsDiff{
+			{Team: "Team A", Position: 1, OtherPosition: 2, Points: 6, OtherPoints: 3, PositionChange: 1, PointsDifference: 3},
+		},
+	}
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("DiffStandings", mock.Anything, "fork-id", "other-id").Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetStandingsDiff(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.StandingsDiff
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+}
+
+func TestGetStandingsDiff_DifferentTeams(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/fork-id/diff?with=other-id", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("fork-id")
+
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("DiffStandings", mock.Anything, "fork-id", "other-id").
+		Return(models.StandingsDiff{}, apperrors.Conflict("leagues fork-id and other-id do not have the same teams"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetStandingsDiff(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestExportLeague_JSON(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/export", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League:  models.League{LeagueID: "test-league", LeagueName: "Premier"},
+	}
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("ExportLeague", mock.Anything, "test-league").Return(export, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := ExportLeague(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.LeagueExport
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, export, response)
+	mockLeagueService.AssertExpectations(t)
+}
+
+func TestExportLeague_CSV(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/export?format=csv&table=results", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		Results: []models.MatchResult{
+			{MatchWeek: 1, Home: "Team A", Away: "Team B", HomeScore: 2, AwayScore: 0, Winner: "Team A"},
+		},
+	}
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("ExportLeague", mock.Anything, "test-league").Return(export, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := ExportLeague(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, `attachment; filename="results.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "week,home,home_score,away,away_score,winner\n1,Team A,2,Team B,0,Team A\n", rec.Body.String())
+}
+
+func TestExportLeague_CSVRequiresTable(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/export?format=csv", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := ExportLeague(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestImportLeague_NewID(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League:  models.League{LeagueID: "test-league", LeagueName: "Premier"},
+	}
+	body, _ := json.Marshal(export)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/import?newId=true", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Mock data
+	expected := models.GetLeaguesIdsWithNameResponse{LeagueId: "new-id", LeagueName: "Premier"}
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("ImportLeague", mock.Anything, export, true).Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := ImportLeague(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.GetLeaguesIdsWithNameResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, expected, response)
+	mockLeagueService.AssertExpectations(t)
+}
+
+func TestImportLeague_IDConflict(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/v1/league/import", strings.NewReader(`{"version": 1, "league": {"leagueName": "Premier"}}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
+	mockService := &MockService{}
+
+	// Configure mocks
+	mockService.On("LeagueService").Return(mockLeagueService)
+	mockLeagueService.On("ImportLeague", mock.Anything, mock.AnythingOfType("models.LeagueExport"), false).
+		Return(models.GetLeaguesIdsWithNameResponse{}, apperrors.Conflict("league test-league already exists"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := ImportLeague(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
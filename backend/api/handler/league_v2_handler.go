@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+func ListLeaguesV2(c echo.Context) error {
+	var query models.PageRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	user, err := userFrom(c)
+	if err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagues, err := appCtx.LeagueRepository().GetLeague(c.Request().Context(), user.UserId)
+
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]models.LeagueSummary, 0, len(leagues))
+	for _, league := range leagues {
+		summaries = append(summaries, models.LeagueSummary{Id: league.LeagueId, Name: league.LeagueName})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Id < summaries[j].Id })
+
+	page, info, err := paginate(summaries, func(l models.LeagueSummary) string { return l.Id }, query)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.ListResponse[models.LeagueSummary]{Data: page, Page: info})
+}
+
+func CreateLeagueV2(c echo.Context) error {
+	var body models.CreateLeagueV2Request
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := serviceInit.LeagueService().CreateLeague(
+		c.Request().Context(), strconv.Itoa(body.TeamCount), body.Name, body.Rules)
+
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/api/v2/leagues/"+result.LeagueId)
+	return c.JSON(
+		http.StatusCreated,
+		models.Response[models.LeagueSummary]{Data: models.LeagueSummary{Id: result.LeagueId, Name: result.LeagueName}})
+}
+
+func GetLeagueV2(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	rules, err := appCtx.LeagueRepository().GetLeagueRules(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	role, _ := c.Get(leagueRoleKey).(string)
+
+	return c.JSON(
+		http.StatusOK, models.Response[models.LeagueResource]{
+			Data: models.LeagueResource{
+				Id:        leagueId,
+				Name:      league.LeagueName,
+				Role:      role,
+				Rules:     rules,
+				TeamCount: len(league.Teams),
+				Season:    seasonResource(league),
+			},
+		})
+}
+
+func DeleteLeagueV2(c echo.Context) error {
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	err = serviceInit.LeagueService().DeleteLeague(c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func GetCurrentSeasonV2(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[models.SeasonResource]{Data: seasonResource(league)})
+}
+
+// StartSeasonV2 replaces the current season with a new one: fresh teams,
+// fixtures and standings.
+func StartSeasonV2(c echo.Context) error {
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	err = serviceInit.LeagueService().ResetLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, models.Response[models.SeasonResource]{Data: seasonResource(league)})
+}
+
+func ListStandingsV2(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	standings, err := appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(
+		c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[[]models.StandingResource]{Data: standingResources(standings)})
+}
+
+func ListPredictionsV2(c echo.Context) error {
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	predictions, err := service.PredictService().PredictChampionShipSession(c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(
+		http.StatusOK, models.Response[[]models.PredictionResource]{Data: predictionResources(predictions)})
+}
+
+func ListMembersV2(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	members, err := appCtx.LeagueRepository().GetLeagueMembers(c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[[]models.LeagueMember]{Data: members})
+}
+
+func AddMemberV2(c echo.Context) error {
+	var body models.AddLeagueMemberRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	err = serviceInit.LeagueService().AddMember(c.Request().Context(), c.Param("leagueId"), body.UserId, body.Role)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, models.Response[models.AddLeagueMemberRequest]{Data: body})
+}
+
+func ListAuditV2(c echo.Context) error {
+	var query models.ListAuditRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	entries, err := appCtx.AuditRepository().GetAuditEntries(c.Request().Context(), c.Param("leagueId"), query.Action)
+
+	if err != nil {
+		return err
+	}
+
+	page, info, err := paginate(
+		entries, func(e models.AuditEntry) string { return strconv.FormatInt(e.Id, 10) }, query.PageRequest)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.ListResponse[models.AuditEntry]{Data: page, Page: info})
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// leagueMatches loads every match of a league's current season.
+func leagueMatches(c echo.Context, leagueId string) ([]models.MatchResource, error) {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return nil, err
+	}
+
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), leagueId)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := appCtx.MatchResultRepository().GetMatchResults(c.Request().Context(), leagueId)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchResources(league, results), nil
+}
+
+func findMatch(matches []models.MatchResource, id string) (models.MatchResource, error) {
+	for _, match := range matches {
+		if match.Id == id {
+			return match, nil
+		}
+	}
+
+	return models.MatchResource{}, apperrors.NotFound("match %s not found", id)
+}
+
+func ListMatchesV2(c echo.Context) error {
+	var query models.ListMatchesRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	matches, err := leagueMatches(c, c.Param("leagueId"))
+	if err != nil {
+		return err
+	}
+
+	page, info, err := paginate(
+		filterMatches(matches, query), func(m models.MatchResource) string { return m.Id }, query.PageRequest)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.ListResponse[models.MatchResource]{Data: page, Page: info})
+}
+
+func GetMatchV2(c echo.Context) error {
+	matches, err := leagueMatches(c, c.Param("leagueId"))
+	if err != nil {
+		return err
+	}
+
+	match, err := findMatch(matches, c.Param("matchId"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[models.MatchResource]{Data: match})
+}
+
+// UpdateMatchScoreV2 corrects the score of a played match and recalculates the
+// standings.
+func UpdateMatchScoreV2(c echo.Context) error {
+	var body models.MatchScoreRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	matches, err := leagueMatches(c, leagueId)
+	if err != nil {
+		return err
+	}
+
+	match, err := findMatch(matches, c.Param("matchId"))
+	if err != nil {
+		return err
+	}
+
+	if match.Status != models.MatchPlayed {
+		return apperrors.Conflict("match %s has not been played yet", match.Id)
+	}
+
+	err = service.SimulationService().EditMatch(
+		c.Request().Context(), models.EditMatchResult{
+			LeagueId:  leagueId,
+			Home:      match.Home,
+			Away:      match.Away,
+			HomeScore: body.HomeScore,
+			AwayScore: body.AwayScore,
+			MatchWeek: match.Week,
+		})
+	if err != nil {
+		return err
+	}
+
+	matches, err = leagueMatches(c, leagueId)
+	if err != nil {
+		return err
+	}
+
+	match, err = findMatch(matches, match.Id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[models.MatchResource]{Data: match})
+}
+
+func CreateSimulationV2(c echo.Context) error {
+	var body models.CreateSimulationRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	result, err := service.SimulationService().Simulation(
+		c.Request().Context(), leagueId, body.Weeks == models.SimulateAllWeeks)
+
+	if err != nil {
+		return err
+	}
+
+	if len(result.Matches) == 0 {
+		return apperrors.Conflict("no matches left to simulate in league %s", leagueId)
+	}
+
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	matches := make([]models.MatchResource, 0, len(result.Matches))
+	for _, m := range result.Matches {
+		matches = append(matches, playedMatch(m))
+	}
+
+	return c.JSON(
+		http.StatusCreated, models.Response[models.SimulationResource]{
+			Data: models.SimulationResource{Matches: matches, Season: seasonResource(league)},
+		})
+}
@@ -2,11 +2,23 @@ package handler
 
 import (
 	"context"
+	"log"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"time"
 
+	"league-sim/internal/apperrors"
+	"league-sim/internal/audit"
+	"league-sim/internal/auth"
 	appContext "league-sim/internal/contexts/appContexts"
 	"league-sim/internal/contexts/services"
+	"league-sim/internal/models"
+	"league-sim/internal/ratelimit"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 )
 
 func ContextMiddleware(appContext appContext.AppContext) echo.MiddlewareFunc {
@@ -30,3 +42,272 @@ func ServiceMiddleware(services services.Service) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// AuthMiddleware resolves the "Authorization: Bearer <api key>" header to a user
+// and stores it on the request context. Requests without a valid key are
+// rejected.
+func AuthMiddleware(services services.Service) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			apiKey, ok := auth.BearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+			if !ok {
+				return apperrors.Unauthorized("missing bearer token")
+			}
+
+			user, err := services.UserService().Authenticate(c.Request().Context(), apiKey)
+			if err != nil {
+				return err
+			}
+
+			c.SetRequest(c.Request().WithContext(auth.WithUser(c.Request().Context(), user)))
+			return next(c)
+		}
+	}
+}
+
+// leagueRoleKey is where RequireLeagueRole leaves the caller's role for the
+// handler.
+const leagueRoleKey = "leagueRole"
+
+// RequireLeagueRole only lets through callers holding one of roles in the
+// league named by the :leagueId parameter. Callers with no role at all get a
+// 404 so that other users' leagues stay invisible.
+func RequireLeagueRole(roles ...string) echo.MiddlewareFunc {
+	return requireLeagueRole(roles, nil)
+}
+
+// RequireAuditReader is RequireLeagueRole for the audit log, which outlives
+// its league. Members are gone with a deleted league, so its log stays
+// readable by whoever the log says created it.
+func RequireAuditReader(roles ...string) echo.MiddlewareFunc {
+	return requireLeagueRole(roles, recordedOwner)
+}
+
+// deletedLeagueAccess decides whether userId may still reach a league that no
+// longer exists.
+type deletedLeagueAccess func(ctx context.Context, appCtx appContext.AppContext, leagueId string, userId string) (bool, error)
+
+// requireLeagueRole checks the caller's role in the league. When the league
+// does not exist, deleted decides instead whether the caller may go on.
+func requireLeagueRole(roles []string, deleted deletedLeagueAccess) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, err := userFrom(c)
+			if err != nil {
+				return err
+			}
+
+			appCtx, err := appContextFrom(c)
+			if err != nil {
+				return err
+			}
+
+			ctx := c.Request().Context()
+			leagueId := c.Param("leagueId")
+			role, err := appCtx.LeagueRepository().GetMemberRole(ctx, leagueId, user.UserId)
+			if apperrors.Is(err, apperrors.KindNotFound) && deleted != nil {
+				allowed, err := deleted(ctx, appCtx, leagueId, user.UserId)
+				if err != nil {
+					return err
+				}
+				if !allowed {
+					return apperrors.NotFound("league %s not found", leagueId)
+				}
+
+				return next(c)
+			}
+			if err != nil {
+				return err
+			}
+
+			if role == "" {
+				return apperrors.NotFound("league %s not found", leagueId)
+			}
+
+			if !slices.Contains(roles, role) {
+				return apperrors.Forbidden("the %s role may not perform this operation", role)
+			}
+
+			c.Set(leagueRoleKey, role)
+			return next(c)
+		}
+	}
+}
+
+// recordedOwner reports whether the audit log names userId as the user who
+// first created the league, by creating, importing or forking it. Only the
+// first such entry counts, so reusing the ID of a deleted league cannot open
+// up the log of the league that had it before.
+func recordedOwner(ctx context.Context, appCtx appContext.AppContext, leagueId string, userId string) (bool, error) {
+	entries, err := appCtx.AuditRepository().GetAuditEntries(ctx, leagueId, "")
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		switch entry.Action {
+		case models.AuditActionCreate, models.AuditActionImport, models.AuditActionFork:
+			return entry.ActorId == userId, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RequestIDMiddleware gives every request an ID, taken from the X-Request-ID
+// header when the client sends one. The ID is echoed in the response and put on
+// the request context for the audit log.
+func RequestIDMiddleware() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(
+		middleware.RequestIDConfig{
+			RequestIDHandler: func(c echo.Context, requestId string) {
+				c.SetRequest(c.Request().WithContext(audit.WithRequestID(c.Request().Context(), requestId)))
+			},
+		})
+}
+
+// Rate limit headers sent with every throttled response.
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimitMiddleware spends a token from the bucket that key picks for each
+// request and refuses the request with a 429 once the bucket is empty. A
+// failing store lets requests through rather than taking the API down with it.
+func RateLimitMiddleware(store ratelimit.Store, rate ratelimit.Rate, key func(c echo.Context) string) echo.MiddlewareFunc {
+	return rateLimitMiddleware(store, rate, key, true)
+}
+
+// RegistrationLimitMiddleware throttles user registration per client IP with
+// its own bucket. Every new user comes with fresh quotas, so unlike
+// RateLimitMiddleware a failing store refuses the request.
+func RegistrationLimitMiddleware(store ratelimit.Store, rate ratelimit.Rate) echo.MiddlewareFunc {
+	return rateLimitMiddleware(store, rate, RegistrationKey, false)
+}
+
+func rateLimitMiddleware(
+	store ratelimit.Store, rate ratelimit.Rate, key func(c echo.Context) string, failOpen bool,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !rate.Enabled() {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			result, err := store.Take(c.Request().Context(), key(c), rate)
+			if err != nil && !failOpen {
+				return apperrors.Internal(err, "checking the rate limit")
+			}
+			if err != nil {
+				log.Printf("rate limit: %v", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				retryAfter := ceilSeconds(result.RetryAfter)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return apperrors.RateLimited("rate limit exceeded, retry in %d seconds", retryAfter)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ClientIPKey buckets requests by the client's address, as found by the
+// server's ClientIPExtractor.
+func ClientIPKey(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// RegistrationKey buckets registrations by the client's address, apart from
+// the client's other requests.
+func RegistrationKey(c echo.Context) string {
+	return "register:" + c.RealIP()
+}
+
+// ClientIPExtractor finds the client's address. X-Forwarded-For is only
+// believed for requests from the trustedProxies CIDR ranges; anything else is
+// bucketed by its peer address, so a client cannot pick its own bucket by
+// sending forwarding headers.
+func ClientIPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		// Ranges were checked when the config was loaded.
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// APIKeyKey buckets requests by the API key, identified by the user it belongs
+// to. It must run after AuthMiddleware.
+func APIKeyKey(c echo.Context) string {
+	user, _ := auth.UserFrom(c.Request().Context())
+	return "key:" + user.UserId
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// appContextFrom returns the AppContext installed by ContextMiddleware.
+func appContextFrom(c echo.Context) (appContext.AppContext, error) {
+	appCtx, ok := c.Request().Context().Value("appContext").(appContext.AppContext)
+	if !ok || appCtx == nil {
+		return nil, apperrors.Internal(nil, "app context missing")
+	}
+
+	return appCtx, nil
+}
+
+// servicesFrom returns the services installed by ServiceMiddleware.
+func servicesFrom(c echo.Context) (services.Service, error) {
+	service, ok := c.Request().Context().Value("services").(services.Service)
+	if !ok || service == nil {
+		return nil, apperrors.Internal(nil, "service context missing")
+	}
+
+	return service, nil
+}
+
+// userFrom returns the user installed by AuthMiddleware.
+func userFrom(c echo.Context) (models.User, error) {
+	user, ok := auth.UserFrom(c.Request().Context())
+	if !ok {
+		return models.User{}, apperrors.Unauthorized("authentication required")
+	}
+
+	return user, nil
+}
+
+// TimeoutMiddleware puts a deadline on the request context so that service and
+// repository calls give up once the request has run for too long. A zero
+// timeout disables the deadline.
+func TimeoutMiddleware(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if timeout <= 0 {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,488 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/audit"
+	"league-sim/internal/auth"
+	"league-sim/internal/models"
+	"league-sim/internal/ratelimit"
+	"league-sim/internal/repositories/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTimeoutMiddleware_SetsDeadline(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var deadline time.Time
+	var hasDeadline bool
+	next := func(c echo.Context) error {
+		deadline, hasDeadline = c.Request().Context().Deadline()
+		return nil
+	}
+
+	// Execute
+	err := TimeoutMiddleware(time.Minute)(next)(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestTimeoutMiddleware_ZeroDisablesDeadline(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var hasDeadline bool
+	next := func(c echo.Context) error {
+		_, hasDeadline = c.Request().Context().Deadline()
+		return nil
+	}
+
+	// Execute
+	err := TimeoutMiddleware(0)(next)(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, hasDeadline)
+}
+
+func TestAuthMiddleware_MissingToken(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockService := &MockService{}
+	next := func(c echo.Context) error {
+		t.Fatal("next must not run without a token")
+		return nil
+	}
+
+	// Execute
+	err := AuthMiddleware(mockService)(next)(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	mockService.AssertNotCalled(t, "UserService")
+}
+
+func TestAuthMiddleware_ResolvesUser(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer lsk_key")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUserService := &userInterfaces.MockUserServiceInterface{}
+	mockService := &MockService{}
+	mockService.On("UserService").Return(mockUserService)
+	mockUserService.On("Authenticate", mock.Anything, "lsk_key").Return(testUser, nil)
+
+	var seen models.User
+	next := func(c echo.Context) error {
+		seen, _ = auth.UserFrom(c.Request().Context())
+		return nil
+	}
+
+	// Execute
+	err := AuthMiddleware(mockService)(next)(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, testUser, seen)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestRequireLeagueRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		roleErr        error
+		expectedStatus int
+	}{
+		{name: "allowed role", role: models.RoleOwner, expectedStatus: http.StatusOK},
+		{name: "role not allowed", role: models.RoleViewer, expectedStatus: http.StatusForbidden},
+		{name: "no access hides the league", role: "", expectedStatus: http.StatusNotFound},
+		{name: "missing league", roleErr: apperrors.NotFound("league test-league not found"), expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				e := echo.New()
+				req := httptest.NewRequest(http.MethodDelete, "/api/v1/league/test-league", nil)
+				rec := httptest.NewRecorder()
+				c := e.NewContext(req, rec)
+				c.SetParamNames("leagueId")
+				c.SetParamValues("test-league")
+
+				mockLeagueRepo := &interfaces.MockLeagueRepository{}
+				mockAppCtx := &MockAppContext{}
+				mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+				mockLeagueRepo.On("GetMemberRole", mock.Anything, "test-league", testUser.UserId).Return(tt.role, tt.roleErr)
+
+				ctx := auth.WithUser(c.Request().Context(), testUser)
+				ctx = context.WithValue(ctx, "appContext", mockAppCtx)
+				c.SetRequest(c.Request().WithContext(ctx))
+
+				next := func(c echo.Context) error {
+					return c.NoContent(http.StatusOK)
+				}
+
+				// Execute
+				err := RequireLeagueRole(models.RoleOwner)(next)(c)
+
+				// Assert
+				status := rec.Code
+				if err != nil {
+					status, _ = ErrorStatus(err)
+				}
+				assert.Equal(t, tt.expectedStatus, status)
+				mockLeagueRepo.AssertExpectations(t)
+			})
+	}
+}
+
+func TestRequireAuditReader_DeletedLeague(t *testing.T) {
+	// The history of a league testUser created and then deleted
+	history := []models.AuditEntry{
+		{Id: 1, LeagueId: "test-league", Action: models.AuditActionCreate, ActorId: testUser.UserId},
+		{Id: 2, LeagueId: "test-league", Action: models.AuditActionSimulate, ActorId: "editor-id"},
+		{Id: 3, LeagueId: "test-league", Action: models.AuditActionDelete, ActorId: testUser.UserId},
+	}
+
+	tests := []struct {
+		name           string
+		user           models.User
+		expectedStatus int
+	}{
+		{name: "creator reads the log", user: testUser, expectedStatus: http.StatusOK},
+		{name: "former editor cannot", user: models.User{UserId: "editor-id"}, expectedStatus: http.StatusNotFound},
+		{name: "stranger cannot", user: models.User{UserId: "stranger-id"}, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) { assertAuditReader(t, history, tt.user, tt.expectedStatus) })
+	}
+}
+
+func TestRequireAuditReader_ReusedLeagueId(t *testing.T) {
+	// testUser created and deleted the league, then another user imported a
+	// league under its ID and deleted that one too.
+	history := []models.AuditEntry{
+		{Id: 1, LeagueId: "test-league", Action: models.AuditActionCreate, ActorId: testUser.UserId},
+		{Id: 2, LeagueId: "test-league", Action: models.AuditActionDelete, ActorId: testUser.UserId},
+		{Id: 3, LeagueId: "test-league", Action: models.AuditActionImport, ActorId: "importer-id"},
+		{Id: 4, LeagueId: "test-league", Action: models.AuditActionDelete, ActorId: "importer-id"},
+	}
+
+	assertAuditReader(t, history, testUser, http.StatusOK)
+	assertAuditReader(t, history, models.User{UserId: "importer-id"}, http.StatusNotFound)
+}
+
+// assertAuditReader reads the audit log of the deleted league test-league,
+// whose log is history, as user and checks the status it gets.
+func assertAuditReader(t *testing.T, history []models.AuditEntry, user models.User, expectedStatus int) {
+	t.Helper()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/audit", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "test-league", user.UserId).
+		Return("", apperrors.NotFound("league test-league not found"))
+	mockAuditRepo.On("GetAuditEntries", mock.Anything, "test-league", "").Return(history, nil)
+
+	ctx := auth.WithUser(c.Request().Context(), user)
+	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := RequireAuditReader(models.RoleOwner, models.RoleEditor, models.RoleViewer)(GetAuditLog)(c)
+
+	// Assert
+	status := rec.Code
+	if err != nil {
+		status, _ = ErrorStatus(err)
+	}
+	assert.Equal(t, expectedStatus, status)
+	if expectedStatus == http.StatusOK {
+		var response []models.AuditEntry
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Len(t, response, len(history))
+	}
+}
+
+func TestRequireAuditReader_ExistingLeagueUsesRoles(t *testing.T) {
+	// Setup
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/audit", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "test-league", testUser.UserId).Return("", nil)
+
+	ctx := auth.WithUser(c.Request().Context(), testUser)
+	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := RequireAuditReader(models.RoleOwner)(func(c echo.Context) error { return nil })(c)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	mockAppCtx.AssertNotCalled(t, "AuditRepository")
+}
+
+func TestRequestIDMiddleware_PropagatesClientID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/reset", nil)
+	req.Header.Set(echo.HeaderXRequestID, "client-request-id")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var seen string
+	next := func(c echo.Context) error {
+		seen = audit.RequestIDFrom(c.Request().Context())
+		return nil
+	}
+
+	// Execute
+	err := RequestIDMiddleware()(next)(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "client-request-id", seen)
+	assert.Equal(t, "client-request-id", rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestRequestIDMiddleware_GeneratesID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/reset", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var seen string
+	next := func(c echo.Context) error {
+		seen = audit.RequestIDFrom(c.Request().Context())
+		return nil
+	}
+
+	// Execute
+	err := RequestIDMiddleware()(next)(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, seen)
+	assert.Equal(t, seen, rec.Header().Get(echo.HeaderXRequestID))
+}
+
+// failingStore is a rate limit store whose backend is down.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Rate) (ratelimit.Result, error) {
+	return ratelimit.Result{}, apperrors.Internal(nil, "store unavailable")
+}
+
+func TestRateLimitMiddleware_RefusesOverLimit(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	limiter := RateLimitMiddleware(ratelimit.NewMemoryStore(), ratelimit.Rate{PerMinute: 6, Burst: 2}, ClientIPKey)
+	e.POST("/api/v1/league", func(c echo.Context) error { return c.NoContent(http.StatusCreated) }, limiter)
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/league", nil)
+		req.RemoteAddr = "203.0.113.7:4000"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Execute
+	first := send()
+	send()
+	refused := send()
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, "2", first.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", first.Header().Get(HeaderRateLimitRemaining))
+	assert.Empty(t, first.Header().Get(echo.HeaderRetryAfter))
+
+	assert.Equal(t, http.StatusTooManyRequests, refused.Code)
+	assert.Equal(t, "0", refused.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "10", refused.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "20", refused.Header().Get(HeaderRateLimitReset))
+	assert.Contains(t, refused.Body.String(), `"code":"rate_limited"`)
+}
+
+func TestRateLimitMiddleware_BucketsPerAPIKey(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	limiter := RateLimitMiddleware(store, ratelimit.Rate{PerMinute: 1, Burst: 1}, APIKeyKey)
+	next := func(c echo.Context) error { return nil }
+
+	send := func(user models.User) error {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/league", nil)
+		req = req.WithContext(auth.WithUser(req.Context(), user))
+		return limiter(next)(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	// Execute & Assert
+	assert.NoError(t, send(models.User{UserId: "alice"}))
+	assert.NoError(t, send(models.User{UserId: "bob"}))
+	assert.True(t, apperrors.Is(send(models.User{UserId: "alice"}), apperrors.KindRateLimited))
+}
+
+func TestRateLimitMiddleware_DisabledAndFailingStore(t *testing.T) {
+	tests := []struct {
+		name  string
+		store ratelimit.Store
+		rate  ratelimit.Rate
+	}{
+		{name: "Zero rate disables the limit", store: failingStore{}, rate: ratelimit.Rate{}},
+		{name: "Store failure lets the request through", store: failingStore{}, rate: ratelimit.Rate{PerMinute: 1, Burst: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				e := echo.New()
+				rec := httptest.NewRecorder()
+				c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+				called := false
+				next := func(c echo.Context) error {
+					called = true
+					return nil
+				}
+
+				// Execute
+				err := RateLimitMiddleware(tt.store, tt.rate, ClientIPKey)(next)(c)
+
+				// Assert
+				assert.NoError(t, err)
+				assert.True(t, called)
+				assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+			})
+	}
+}
+
+func TestRegistrationLimitMiddleware_OwnBucket(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	rate := ratelimit.Rate{PerMinute: 1, Burst: 1}
+	registrationLimit := RegistrationLimitMiddleware(store, rate)
+	ipLimit := RateLimitMiddleware(store, rate, ClientIPKey)
+	next := func(c echo.Context) error { return nil }
+
+	send := func(limiter echo.MiddlewareFunc, remoteAddr string) error {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users", nil)
+		req.RemoteAddr = remoteAddr
+		return limiter(next)(e.NewContext(req, httptest.NewRecorder()))
+	}
+
+	// Execute & Assert
+	assert.NoError(t, send(registrationLimit, "203.0.113.7:4000"))
+	assert.True(t, apperrors.Is(send(registrationLimit, "203.0.113.7:4001"), apperrors.KindRateLimited))
+	assert.NoError(t, send(registrationLimit, "203.0.113.8:4000"))
+	assert.NoError(t, send(ipLimit, "203.0.113.7:4000"), "registering does not spend the client's other bucket")
+}
+
+func TestRegistrationLimitMiddleware_FailingStoreRefuses(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/users", nil), httptest.NewRecorder())
+
+	called := false
+	next := func(c echo.Context) error {
+		called = true
+		return nil
+	}
+
+	// Execute
+	err := RegistrationLimitMiddleware(failingStore{}, ratelimit.Rate{PerMinute: 1, Burst: 1})(next)(c)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.False(t, called)
+}
+
+func TestClientIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		headers        map[string]string
+		want           string
+	}{
+		{
+			name: "Forwarding headers ignored without trusted proxies", remoteAddr: "203.0.113.7:4000",
+			headers: map[string]string{echo.HeaderXForwardedFor: "198.51.100.1", echo.HeaderXRealIP: "198.51.100.2"},
+			want:    "203.0.113.7",
+		},
+		{
+			name: "Untrusted peer cannot forward", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "203.0.113.7:4000",
+			headers: map[string]string{echo.HeaderXForwardedFor: "198.51.100.1"},
+			want:    "203.0.113.7",
+		},
+		{
+			name: "Trusted proxy forwards the client", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "10.1.2.3:4000",
+			headers: map[string]string{echo.HeaderXForwardedFor: "198.51.100.1"},
+			want:    "198.51.100.1",
+		},
+		{
+			name: "Private peers are not trusted by default", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "192.168.1.1:4000",
+			headers: map[string]string{echo.HeaderXForwardedFor: "198.51.100.1"},
+			want:    "192.168.1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				e := echo.New()
+				e.IPExtractor = ClientIPExtractor(tt.trustedProxies)
+				req := httptest.NewRequest(http.MethodPost, "/api/v1/users", nil)
+				req.RemoteAddr = tt.remoteAddr
+				for name, value := range tt.headers {
+					req.Header.Set(name, value)
+				}
+
+				// Execute
+				key := ClientIPKey(e.NewContext(req, httptest.NewRecorder()))
+
+				// Assert
+				assert.Equal(t, "ip:"+tt.want, key)
+			})
+	}
+}
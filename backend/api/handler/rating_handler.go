@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/internal/models"
+	"league-sim/internal/rating"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetRatingHistory returns the Elo rating of every team of a league, or of
+// the team named by ?team=, after every match it played.
+func GetRatingHistory(c echo.Context) error {
+	var query models.GetRatingHistoryRequest
+	if err := bindAndValidate(c, &query); err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	changes, err := appCtx.RatingRepository().GetRatingChanges(c.Request().Context(), leagueId, query.Team)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rating.History(changes))
+}
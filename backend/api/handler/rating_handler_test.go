@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetRatingHistory_OneTeam(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/ratings?team=Team+A", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	changes := []models.RatingChange{
+		{MatchWeek: 1, Team: "Team A", Opponent: "Team B", Before: 1500, After: 1512},
+		{MatchWeek: 2, Team: "Team A", Opponent: "Team C", Before: 1512, After: 1505},
+	}
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo)
+	mockRatingRepo.On("GetRatingChanges", mock.Anything, "test-league", "Team A").Return(changes, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetRatingHistory(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response []models.TeamRatingHistory
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(
+		t, []models.TeamRatingHistory{
+			{
+				Team:   "Team A",
+				Rating: 1505,
+				History: []models.RatingPoint{
+					{Week: 0, Rating: 1500},
+					{Week: 1, Rating: 1512, Change: 12, Opponent: "Team B"},
+					{Week: 2, Rating: 1505, Change: -7, Opponent: "Team C"},
+				},
+			},
+		}, response)
+	mockRatingRepo.AssertExpectations(t)
+}
+
+func TestGetRatingHistory_TeamNameTooLong(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/ratings?team="+strings.Repeat("a", 37), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := GetRatingHistory(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Len(t, body.Error.Details, 1)
+	assert.Equal(t, "team", body.Error.Details[0].Field)
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
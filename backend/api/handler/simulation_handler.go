@@ -1,12 +1,10 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"reflect"
 
-	appContext "league-sim/internal/contexts/appContexts"
-	"league-sim/internal/contexts/services"
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 
 	"github.com/labstack/echo/v4"
@@ -16,55 +14,39 @@ func StartSimulation(c echo.Context) error {
 	leagueId := c.Param("leagueId")
 	var body models.SimulateLeagueRequest
 
-	if err := c.Bind(&body); err != nil {
-
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
-	}
-
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
-
-	if !ok || appCtx == nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
 	}
 
-	service := c.Request().Context().Value("services").(services.Service)
-	if service == nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "Service context missing")
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
 	}
 
-	result, err := service.SimulationService().Simulation(leagueId, body.PlayAllFixture)
+	result, err := service.SimulationService().Simulation(c.Request().Context(), leagueId, body.PlayAllFixture)
 
 	if err != nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to start simulation: "+err.Error())
+		return err
 	}
 
 	if reflect.DeepEqual(result, models.SimulationResponse{}) {
-
-		return echo.NewHTTPError(http.StatusNotFound, "No matches to simulate")
+		return apperrors.Conflict("no matches left to simulate in league %s", leagueId)
 	}
 
 	return c.JSON(http.StatusOK, result)
 }
 
 func GetMatchResults(c echo.Context) error {
-	appCtx := c.Request().Context().Value("appContext").(appContext.AppContext)
-	appCtx, ok := appCtx.(appContext.AppContext)
-
-	if !ok || appCtx == nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "App context missing")
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
 	}
 
 	leagueId := c.Param("leagueId")
-	matchResults, err := appCtx.MatchResultRepository().GetMatchResults(leagueId)
+	matchResults, err := appCtx.MatchResultRepository().GetMatchResults(c.Request().Context(), leagueId)
 
 	if err != nil {
-
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get match results: "+err.Error())
+		return err
 	}
 
 	return c.JSON(http.StatusOK, matchResults)
@@ -73,21 +55,42 @@ func GetMatchResults(c echo.Context) error {
 func EditMatch(c echo.Context) error {
 	var body models.EditMatchResult
 
-	if err := c.Bind(&body); err != nil {
-
-		return echo.NewHTTPError(http.StatusBadRequest, err)
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
 	}
 
 	leagueId := c.Param("leagueId")
 	body.LeagueId = leagueId
-	service := c.Request().Context().Value("services").(services.Service)
-
-	err := service.SimulationService().EditMatch(body)
+	service, err := servicesFrom(c)
 	if err != nil {
-		fmt.Println("Error editing match:", err)
+		return err
+	}
 
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to edit match")
+	err = service.SimulationService().EditMatch(c.Request().Context(), body)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(http.StatusOK, "Standings updated successfully")
 }
+
+func SetTactics(c echo.Context) error {
+	var body models.SetTacticsRequest
+
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	err = service.SimulationService().SetTactics(c.Request().Context(), leagueId, body.Team, body.Tactics)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, "Tactics updated successfully")
+}
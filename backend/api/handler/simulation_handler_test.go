@@ -10,12 +10,15 @@ import (
 	"strings"
 	"testing"
 
+	"league-sim/config"
+	"league-sim/internal/apperrors"
 	appContext "league-sim/internal/contexts/appContexts"
 	leagueInterfaces "league-sim/internal/league/interfaces"
 	"league-sim/internal/models"
 	predictInterfaces "league-sim/internal/predict/interfaces"
 	"league-sim/internal/repositories/interfaces"
 	simulationInterfaces "league-sim/internal/simulation/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -42,11 +45,41 @@ func (m *MockAppContextSim) MatchResultRepository() interfaces.MatchResultReposi
 	return args.Get(0).(interfaces.MatchResultRepository)
 }
 
+func (m *MockAppContextSim) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContextSim) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContextSim) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContextSim) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
+func (m *MockAppContextSim) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
 func (m *MockAppContextSim) DB() *appContext.DB {
 	args := m.Called()
 	return args.Get(0).(*appContext.DB)
 }
 
+func (m *MockAppContextSim) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
 // MockService for testing
 type MockServiceSim struct {
 	mock.Mock
@@ -67,9 +100,14 @@ func (m *MockServiceSim) PredictService() predictInterfaces.PredictServiceInterf
 	return args.Get(0).(predictInterfaces.PredictServiceInterface)
 }
 
+func (m *MockServiceSim) UserService() userInterfaces.UserServiceInterface {
+	args := m.Called()
+	return args.Get(0).(userInterfaces.UserServiceInterface)
+}
+
 func TestStartSimulation_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.SimulateLeagueRequest{
 		PlayAllFixture: false,
 	}
@@ -104,7 +142,7 @@ func TestStartSimulation_Success(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("SimulationService").Return(mockSimulationService)
-	mockSimulationService.On("Simulation", "test-league", false).Return(expectedResponse, nil)
+	mockSimulationService.On("Simulation", mock.Anything, "test-league", false).Return(expectedResponse, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -130,7 +168,7 @@ func TestStartSimulation_Success(t *testing.T) {
 
 func TestStartSimulation_PlayAllFixtures(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.SimulateLeagueRequest{
 		PlayAllFixture: true,
 	}
@@ -160,7 +198,7 @@ func TestStartSimulation_PlayAllFixtures(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("SimulationService").Return(mockSimulationService)
-	mockSimulationService.On("Simulation", "test-league", true).Return(expectedResponse, nil)
+	mockSimulationService.On("Simulation", mock.Anything, "test-league", true).Return(expectedResponse, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -184,9 +222,29 @@ func TestStartSimulation_PlayAllFixtures(t *testing.T) {
 	mockSimulationService.AssertExpectations(t)
 }
 
+func TestStartSimulation_WrongFieldType(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/v1/league/test-league/simulation", strings.NewReader(`{"playAllFixture": "yes"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := StartSimulation(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []apperrors.FieldError{{Field: "playAllFixture", Message: "must be a boolean"}}, body.Error.Details)
+}
+
 func TestStartSimulation_InvalidRequestBody(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/simulation", strings.NewReader("invalid json"))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
@@ -199,14 +257,14 @@ func TestStartSimulation_InvalidRequestBody(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusBadRequest, httpError.Code)
-	assert.Equal(t, "Invalid request body", httpError.Message)
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body.Error.Message, "invalid request body")
 }
 
 func TestStartSimulation_MissingServiceContext(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.SimulateLeagueRequest{PlayAllFixture: false}
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/simulation", bytes.NewBuffer(jsonBody))
@@ -219,21 +277,23 @@ func TestStartSimulation_MissingServiceContext(t *testing.T) {
 	// Mock data
 	mockAppCtx := &MockAppContextSim{}
 
-	// Set context - only app context, no services (nil services will cause panic)
+	// Set context - only app context, no services
 	ctx := c.Request().Context()
 	ctx = context.WithValue(ctx, "appContext", mockAppCtx)
 	ctx = context.WithValue(ctx, "services", nil)
 	c.SetRequest(c.Request().WithContext(ctx))
 
-	// Execute and expect panic due to type assertion
-	assert.Panics(t, func() {
-		StartSimulation(c)
-	}, "Should panic when service context is nil")
+	// Execute
+	err := StartSimulation(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 }
 
 func TestStartSimulation_SimulationServiceError(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.SimulateLeagueRequest{PlayAllFixture: false}
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/simulation", bytes.NewBuffer(jsonBody))
@@ -251,7 +311,7 @@ func TestStartSimulation_SimulationServiceError(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("SimulationService").Return(mockSimulationService)
-	mockSimulationService.On("Simulation", "test-league", false).Return(models.SimulationResponse{}, expectedError)
+	mockSimulationService.On("Simulation", mock.Anything, "test-league", false).Return(models.SimulationResponse{}, expectedError)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -264,9 +324,9 @@ func TestStartSimulation_SimulationServiceError(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusInternalServerError, httpError.Code)
-	assert.Contains(t, httpError.Message.(string), "Failed to start simulation")
+	assert.ErrorIs(t, err, expectedError)
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 
 	// Verify mocks
 	mockService.AssertExpectations(t)
@@ -275,7 +335,7 @@ func TestStartSimulation_SimulationServiceError(t *testing.T) {
 
 func TestStartSimulation_EmptyResponse(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	requestBody := models.SimulateLeagueRequest{PlayAllFixture: false}
 	jsonBody, _ := json.Marshal(requestBody)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/league/test-league/simulation", bytes.NewBuffer(jsonBody))
@@ -297,7 +357,7 @@ func TestStartSimulation_EmptyResponse(t *testing.T) {
 
 	// Configure mocks
 	mockService.On("SimulationService").Return(mockSimulationService)
-	mockSimulationService.On("Simulation", "test-league", false).Return(emptyResponse, nil)
+	mockSimulationService.On("Simulation", mock.Anything, "test-league", false).Return(emptyResponse, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -324,7 +384,7 @@ func TestStartSimulation_EmptyResponse(t *testing.T) {
 
 func TestGetMatchResults_Success(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/match-results", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -355,7 +415,7 @@ func TestGetMatchResults_Success(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockMatchResultRepo.On("GetMatchResults", "test-league").Return(expectedResults, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "test-league").Return(expectedResults, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -381,7 +441,7 @@ func TestGetMatchResults_Success(t *testing.T) {
 
 func TestGetMatchResults_MissingAppContext(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/match-results", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -393,15 +453,17 @@ func TestGetMatchResults_MissingAppContext(t *testing.T) {
 	ctx = context.WithValue(ctx, "appContext", nil)
 	c.SetRequest(c.Request().WithContext(ctx))
 
-	// Execute and expect panic due to type assertion
-	assert.Panics(t, func() {
-		GetMatchResults(c)
-	}, "Should panic when app context is nil")
+	// Execute
+	err := GetMatchResults(c)
+
+	// Assert
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 }
 
 func TestGetMatchResults_RepositoryError(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/match-results", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -415,7 +477,7 @@ func TestGetMatchResults_RepositoryError(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockMatchResultRepo.On("GetMatchResults", "test-league").Return([]models.MatchResult{}, expectedError)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "test-league").Return([]models.MatchResult{}, expectedError)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -427,8 +489,8 @@ func TestGetMatchResults_RepositoryError(t *testing.T) {
 
 	// Assert
 	assert.Error(t, err)
-	httpError := err.(*echo.HTTPError)
-	assert.Equal(t, http.StatusInternalServerError, httpError.Code)
+	status, _ := ErrorStatus(err)
+	assert.Equal(t, http.StatusInternalServerError, status)
 
 	// Verify mocks
 	mockAppCtx.AssertExpectations(t)
@@ -437,7 +499,7 @@ func TestGetMatchResults_RepositoryError(t *testing.T) {
 
 func TestGetMatchResults_EmptyResults(t *testing.T) {
 	// Setup
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/league/test-league/match-results", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
@@ -451,7 +513,7 @@ func TestGetMatchResults_EmptyResults(t *testing.T) {
 
 	// Configure mocks
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockMatchResultRepo.On("GetMatchResults", "test-league").Return(emptyResults, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "test-league").Return(emptyResults, nil)
 
 	// Set context
 	ctx := c.Request().Context()
@@ -474,3 +536,99 @@ func TestGetMatchResults_EmptyResults(t *testing.T) {
 	mockAppCtx.AssertExpectations(t)
 	mockMatchResultRepo.AssertExpectations(t)
 }
+
+func TestSetTactics_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	requestBody := models.SetTacticsRequest{
+		Team:    "Team B",
+		Tactics: models.Tactics{Formation: "5-3-2", Pressing: "low", DefensiveLine: "deep"},
+	}
+	jsonBody, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/league/test-league/tactics", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockSimulationService := &simulationInterfaces.MockSimulationServiceInterface{}
+	mockService := &MockServiceSim{}
+
+	// Configure mocks
+	mockService.On("SimulationService").Return(mockSimulationService)
+	mockSimulationService.On("SetTactics", mock.Anything, "test-league", "Team B", requestBody.Tactics).Return(nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := SetTactics(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+	mockSimulationService.AssertExpectations(t)
+}
+
+func TestSetTactics_ServiceError(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	jsonBody, _ := json.Marshal(models.SetTacticsRequest{Team: "Team Z", Tactics: models.Tactics{Formation: "4-3-3"}})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/league/test-league/tactics", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockSimulationService := &simulationInterfaces.MockSimulationServiceInterface{}
+	mockService := &MockServiceSim{}
+
+	// Configure mocks
+	mockService.On("SimulationService").Return(mockSimulationService)
+	mockSimulationService.On("SetTactics", mock.Anything, "test-league", "Team Z", models.Tactics{Formation: "4-3-3"}).
+		Return(apperrors.NotFound("team Team Z not found in league test-league"))
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := SetTactics(c)
+
+	// Assert
+	assert.Error(t, err)
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, body.Error.Message, "Team Z")
+}
+
+func TestSetTactics_InvalidTactics(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	jsonBody, _ := json.Marshal(models.SetTacticsRequest{Team: "Team B", Tactics: models.Tactics{Formation: "1-1-8"}})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/league/test-league/tactics", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	mockService := &MockServiceSim{}
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := SetTactics(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Len(t, body.Error.Details, 1)
+	assert.Equal(t, "tactics", body.Error.Details[0].Field)
+	assert.Contains(t, body.Error.Details[0].Message, "unknown formation")
+	mockService.AssertNotCalled(t, "SimulationService")
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+func ListTeamsV2(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	teams, err := appCtx.ActiveLeagueRepository().GetActiveLeagueTeams(c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	resources := make([]models.TeamResource, 0, len(teams))
+	for _, team := range teams {
+		resources = append(resources, teamResource(team))
+	}
+
+	return c.JSON(http.StatusOK, models.Response[[]models.TeamResource]{Data: resources})
+}
+
+func GetTeamV2(c echo.Context) error {
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), c.Param("leagueId"))
+
+	if err != nil {
+		return err
+	}
+
+	team, err := findTeam(league, c.Param("teamId"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[models.TeamResource]{Data: teamResource(team)})
+}
+
+// SetTeamTacticsV2 replaces a team's tactics. The body is the tactics object
+// itself; the team comes from the path.
+func SetTeamTacticsV2(c echo.Context) error {
+	var body models.SetTacticsRequest
+	if err := c.Bind(&body.Tactics); err != nil {
+		return bindError(err)
+	}
+
+	service, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	appCtx, err := appContextFrom(c)
+	if err != nil {
+		return err
+	}
+
+	leagueId := c.Param("leagueId")
+	league, err := appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	team, err := findTeam(league, c.Param("teamId"))
+	if err != nil {
+		return err
+	}
+
+	body.Team = team.Name
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+
+	err = service.SimulationService().SetTactics(c.Request().Context(), leagueId, body.Team, body.Tactics)
+	if err != nil {
+		return err
+	}
+
+	league, err = appCtx.ActiveLeagueRepository().GetActiveLeague(c.Request().Context(), leagueId)
+
+	if err != nil {
+		return err
+	}
+
+	team, err = findTeam(league, c.Param("teamId"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, models.Response[models.TeamResource]{Data: teamResource(team)})
+}
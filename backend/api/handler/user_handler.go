@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"league-sim/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+func RegisterUser(c echo.Context) error {
+	var body models.RegisterUserRequest
+	if err := bindAndValidate(c, &body); err != nil {
+		return err
+	}
+
+	serviceInit, err := servicesFrom(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := serviceInit.UserService().Register(c.Request().Context(), body.Username)
+
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+func GetCurrentUser(c echo.Context) error {
+	user, err := userFrom(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	"league-sim/internal/models"
+	userInterfaces "league-sim/internal/user/interfaces"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterUser_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(`{"username":"alice"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Mock data
+	expected := models.RegisterUserResponse{User: testUser, APIKey: "lsk_key"}
+	mockUserService := &userInterfaces.MockUserServiceInterface{}
+	mockService := &MockService{}
+	mockService.On("UserService").Return(mockUserService)
+	mockUserService.On("Register", mock.Anything, "alice").Return(expected, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := RegisterUser(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response models.RegisterUserResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, expected, response)
+	mockUserService.AssertExpectations(t)
+}
+
+func TestRegisterUser_InvalidUsername(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users", strings.NewReader(`{"username":"a b"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Execute
+	err := RegisterUser(c)
+
+	// Assert
+	status, body := ErrorStatus(err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []apperrors.FieldError{{Field: "username", Message: "must contain only letters and digits"}}, body.Error.Details)
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetRequest(c.Request().WithContext(auth.WithUser(c.Request().Context(), testUser)))
+
+	// Execute
+	err := GetCurrentUser(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.User
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, testUser, response)
+}
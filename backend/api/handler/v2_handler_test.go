@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+	simulationInterfaces "league-sim/internal/simulation/interfaces"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// v2League is a four team league one week into its season.
+func v2League() models.League {
+	teamA := &models.Team{Name: "Team A"}
+	teamB := &models.Team{Name: "Team B"}
+	teamC := &models.Team{Name: "Team C"}
+	teamD := &models.Team{Name: "Team D"}
+
+	return models.League{
+		LeagueID:    "test-league",
+		LeagueName:  "Test League",
+		Teams:       []models.Team{*teamA, *teamB, *teamC, *teamD},
+		CurrentWeek: 2,
+		PlayedFixtures: []models.Week{
+			{Number: 1, Matches: []models.Match{{Home: teamA, Away: teamB}, {Home: teamC, Away: teamD}}},
+		},
+		UpcomingFixtures: []models.Week{
+			{Number: 2, Matches: []models.Match{{Home: teamA, Away: teamC}, {Home: teamB, Away: teamD}}},
+		},
+	}
+}
+
+var v2Results = []models.MatchResult{
+	{MatchWeek: 1, Home: "Team A", HomeScore: 2, Away: "Team B", AwayScore: 1, Winner: "Team A"},
+	{MatchWeek: 1, Home: "Team C", HomeScore: 0, Away: "Team D", AwayScore: 0},
+}
+
+func TestPaginate(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	key := func(s string) string { return s }
+
+	// Execute
+	first, info, err := paginate(items, key, models.PageRequest{Limit: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, first)
+	assert.Equal(t, 2, info.Limit)
+	assert.NotEmpty(t, info.NextCursor)
+
+	second, info, err := paginate(items, key, models.PageRequest{Limit: 2, Cursor: info.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, second)
+
+	last, info, err := paginate(items, key, models.PageRequest{Limit: 2, Cursor: info.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e"}, last)
+	assert.Empty(t, info.NextCursor)
+
+	all, info, err := paginate(items, key, models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, items, all)
+	assert.Equal(t, defaultPageLimit, info.Limit)
+}
+
+func TestPaginate_InvalidCursor(t *testing.T) {
+	items := []string{"a", "b"}
+	key := func(s string) string { return s }
+
+	for _, cursor := range []string{"not base64!", "eg"} {
+		_, _, err := paginate(items, key, models.PageRequest{Cursor: cursor})
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), cursor)
+	}
+}
+
+func TestStandingResources_RanksByPointsThenGoalDifference(t *testing.T) {
+	// Setup
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Team A"}, Goals: 3, Against: 3, Played: 2, Wins: 1, Losses: 1, Points: 3},
+		{Team: models.Team{Name: "Team B"}, Goals: 4, Against: 1, Played: 2, Wins: 1, Losses: 0, Points: 4},
+		{Team: models.Team{Name: "Team C"}, Goals: 5, Against: 2, Played: 2, Wins: 1, Losses: 1, Points: 3},
+	}
+
+	// Execute
+	table := standingResources(standings)
+
+	// Assert
+	assert.Equal(t, []string{"team-b", "team-c", "team-a"}, []string{table[0].TeamId, table[1].TeamId, table[2].TeamId})
+	assert.Equal(t, []int{1, 2, 3}, []int{table[0].Position, table[1].Position, table[2].Position})
+	assert.Equal(t, 1, table[0].Draws)
+	assert.Equal(t, 3, table[0].GoalDifference)
+}
+
+func TestGetLeagueV2_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/leagues/test-league", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+	c.Set(leagueRoleKey, models.RoleEditor)
+
+	// Mock data
+	rules := models.LeagueRules{PointsForWin: 3, PointsForDraw: 1}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	// Configure mocks
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(v2League(), nil)
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, "test-league").Return(rules, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := GetLeagueV2(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response models.Response[models.LeagueResource]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(
+		t, models.LeagueResource{
+			Id:        "test-league",
+			Name:      "Test League",
+			Role:      models.RoleEditor,
+			Rules:     rules,
+			TeamCount: 4,
+			Season:    models.SeasonResource{TotalWeeks: 2, CurrentWeek: 2},
+		}, response.Data)
+
+	mockAppCtx.AssertExpectations(t)
+	mockActiveLeagueRepo.AssertExpectations(t)
+	mockLeagueRepo.AssertExpectations(t)
+}
+
+func TestListMatchesV2_Filters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		ids   []string
+	}{
+		{"all", "", []string{"1-team-a-team-b", "1-team-c-team-d", "2-team-a-team-c", "2-team-b-team-d"}},
+		{"week", "?week=2", []string{"2-team-a-team-c", "2-team-b-team-d"}},
+		{"team", "?team=team-d", []string{"1-team-c-team-d", "2-team-b-team-d"}},
+		{"status", "?status=played", []string{"1-team-a-team-b", "1-team-c-team-d"}},
+		{"combined", "?team=team-a&status=upcoming", []string{"2-team-a-team-c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				e := newTestEcho()
+				req := httptest.NewRequest(http.MethodGet, "/api/v2/leagues/test-league/matches"+tt.query, nil)
+				rec := httptest.NewRecorder()
+				c := e.NewContext(req, rec)
+				c.SetParamNames("leagueId")
+				c.SetParamValues("test-league")
+
+				mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+				mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+				mockAppCtx := &MockAppContext{}
+				mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+				mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+				mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(v2League(), nil)
+				mockMatchResultRepo.On("GetMatchResults", mock.Anything, "test-league").Return(v2Results, nil)
+
+				ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+				c.SetRequest(c.Request().WithContext(ctx))
+
+				// Execute
+				err := ListMatchesV2(c)
+
+				// Assert
+				assert.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+
+				var response models.ListResponse[models.MatchResource]
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				ids := make([]string, 0, len(response.Data))
+				for _, match := range response.Data {
+					ids = append(ids, match.Id)
+				}
+				assert.Equal(t, tt.ids, ids)
+				assert.Empty(t, response.Page.NextCursor)
+			})
+	}
+}
+
+func TestListMatchesV2_InvalidStatus(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/leagues/test-league/matches?status=live", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Execute
+	err := ListMatchesV2(c)
+	e.HTTPErrorHandler(err, c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var body ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "validation", body.Error.Code)
+	assert.Equal(t, "status", body.Error.Details[0].Field)
+}
+
+func TestUpdateMatchScoreV2_UpcomingMatch(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	jsonBody, _ := json.Marshal(models.MatchScoreRequest{HomeScore: 1, AwayScore: 1})
+	req := httptest.NewRequest(
+		http.MethodPatch, "/api/v2/leagues/test-league/matches/2-team-a-team-c", bytes.NewBuffer(jsonBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId", "matchId")
+	c.SetParamValues("test-league", "2-team-a-team-c")
+
+	// Mock data
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockService := &MockService{}
+	mockAppCtx := &MockAppContext{}
+
+	// Configure mocks
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(v2League(), nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "test-league").Return(v2Results, nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+	ctx = context.WithValue(ctx, "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := UpdateMatchScoreV2(c)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	mockService.AssertNotCalled(t, "SimulationService")
+}
+
+func TestCreateSimulationV2_Success(t *testing.T) {
+	// Setup
+	e := newTestEcho()
+	req := httptest.NewRequest(
+		http.MethodPost, "/api/v2/leagues/test-league/simulations", bytes.NewBufferString(`{"weeks":"next"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("leagueId")
+	c.SetParamValues("test-league")
+
+	// Mock data
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockSimulationService := &simulationInterfaces.MockSimulationServiceInterface{}
+	mockService := &MockService{}
+	mockAppCtx := &MockAppContext{}
+
+	// Configure mocks
+	mockService.On("SimulationService").Return(mockSimulationService)
+	mockSimulationService.On("Simulation", mock.Anything, "test-league", false).
+		Return(models.SimulationResponse{Matches: v2Results}, nil)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(v2League(), nil)
+
+	// Set context
+	ctx := context.WithValue(c.Request().Context(), "appContext", mockAppCtx)
+	ctx = context.WithValue(ctx, "services", mockService)
+	c.SetRequest(c.Request().WithContext(ctx))
+
+	// Execute
+	err := CreateSimulationV2(c)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response models.Response[models.SimulationResource]
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Len(t, response.Data.Matches, 2)
+	assert.Equal(t, "1-team-a-team-b", response.Data.Matches[0].Id)
+	assert.Equal(t, models.MatchPlayed, response.Data.Matches[0].Status)
+	assert.Equal(t, 2, *response.Data.Matches[0].HomeScore)
+
+	mockSimulationService.AssertExpectations(t)
+	mockActiveLeagueRepo.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+)
+
+// Page sizes of the v2 list endpoints.
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// paginate returns the page of items following the one named by the cursor.
+// Cursors are opaque to clients: the base64 encoded key of the last item they
+// have seen, so a page stays put when earlier items are added or removed.
+func paginate[T any](items []T, key func(T) string, page models.PageRequest) ([]T, models.PageInfo, error) {
+	limit := page.Limit
+	if limit <= 0 {
+		limit = defaultPageLimit
+	}
+	limit = min(limit, maxPageLimit)
+
+	start := 0
+	if page.Cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+		if err != nil {
+			return nil, models.PageInfo{}, apperrors.Validation("invalid cursor")
+		}
+
+		start = -1
+		for i, item := range items {
+			if key(item) == string(after) {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return nil, models.PageInfo{}, apperrors.Validation("invalid cursor")
+		}
+	}
+
+	end := min(start+limit, len(items))
+	info := models.PageInfo{Limit: limit}
+	if end < len(items) {
+		info.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(key(items[end-1])))
+	}
+
+	return items[start:end], info, nil
+}
+
+// teamId turns a team name into the identifier used in v2 paths, e.g.
+// "Team A" becomes "team-a".
+func teamId(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
+
+func matchId(week int, home string, away string) string {
+	return fmt.Sprintf("%d-%s-%s", week, teamId(home), teamId(away))
+}
+
+func seasonResource(league models.League) models.SeasonResource {
+	totalWeeks := len(league.PlayedFixtures) + len(league.UpcomingFixtures)
+
+	return models.SeasonResource{
+		TotalWeeks:  totalWeeks,
+		CurrentWeek: league.CurrentWeek,
+		Finished:    len(league.UpcomingFixtures) == 0,
+	}
+}
+
+func teamResource(team models.Team) models.TeamResource {
+	return models.TeamResource{
+		Id:           teamId(team.Name),
+		Name:         team.Name,
+		AttackPower:  team.AttackPower,
+		DefensePower: team.DefensePower,
+		Morale:       team.Morale,
+		Stamina:      team.Stamina,
+		Tactics:      team.Tactics,
+	}
+}
+
+func findTeam(league models.League, id string) (models.Team, error) {
+	for _, team := range league.Teams {
+		if teamId(team.Name) == id {
+			return team, nil
+		}
+	}
+
+	return models.Team{}, apperrors.NotFound("team %s not found", id)
+}
+
+// matchResources lists every match of the season in week order: played
+// matches with their results, then the upcoming fixtures.
+func matchResources(league models.League, results []models.MatchResult) []models.MatchResource {
+	played := make([]models.MatchResult, len(results))
+	copy(played, results)
+	sort.SliceStable(played, func(i, j int) bool { return played[i].MatchWeek < played[j].MatchWeek })
+
+	matches := make([]models.MatchResource, 0, len(played))
+	for _, result := range played {
+		matches = append(matches, playedMatch(result))
+	}
+
+	for _, week := range league.UpcomingFixtures {
+		for _, match := range week.Matches {
+			if match.Home == nil || match.Away == nil {
+				continue
+			}
+			matches = append(
+				matches, models.MatchResource{
+					Id:     matchId(week.Number, match.Home.Name, match.Away.Name),
+					Week:   week.Number,
+					Home:   match.Home.Name,
+					Away:   match.Away.Name,
+					Status: models.MatchUpcoming,
+				})
+		}
+	}
+
+	return matches
+}
+
+func playedMatch(result models.MatchResult) models.MatchResource {
+	homeScore, awayScore := result.HomeScore, result.AwayScore
+
+	return models.MatchResource{
+		Id:        matchId(result.MatchWeek, result.Home, result.Away),
+		Week:      result.MatchWeek,
+		Home:      result.Home,
+		Away:      result.Away,
+		Status:    models.MatchPlayed,
+		HomeScore: &homeScore,
+		AwayScore: &awayScore,
+		Winner:    result.Winner,
+	}
+}
+
+// filterMatches keeps the matches of the given week, team and status; zero
+// values match everything.
+func filterMatches(matches []models.MatchResource, query models.ListMatchesRequest) []models.MatchResource {
+	filtered := make([]models.MatchResource, 0, len(matches))
+	for _, match := range matches {
+		if query.Week != 0 && match.Week != query.Week {
+			continue
+		}
+		if query.Team != "" && teamId(match.Home) != query.Team && teamId(match.Away) != query.Team {
+			continue
+		}
+		if query.Status != "" && match.Status != query.Status {
+			continue
+		}
+		filtered = append(filtered, match)
+	}
+
+	return filtered
+}
+
+// standingResources ranks the table by points, then goal difference, then
+// goals scored.
+func standingResources(standings []models.Standings) []models.StandingResource {
+	table := make([]models.StandingResource, 0, len(standings))
+	for i, s := range league.RankStandings(standings) {
+		table = append(
+			table, models.StandingResource{
+				Position:       i + 1,
+				TeamId:         teamId(s.Team.Name),
+				Team:           s.Team.Name,
+				Played:         s.Played,
+				Wins:           s.Wins,
+				Draws:          s.Played - s.Wins - s.Losses,
+				Losses:         s.Losses,
+				GoalsFor:       s.Goals,
+				GoalsAgainst:   s.Against,
+				GoalDifference: s.Goals - s.Against,
+				Points:         s.Points,
+			})
+	}
+
+	return table
+}
+
+func predictionResources(predictions []models.PredictedStanding) []models.PredictionResource {
+	resources := make([]models.PredictionResource, 0, len(predictions))
+	for _, p := range predictions {
+		resources = append(
+			resources, models.PredictionResource{
+				TeamId:      teamId(p.TeamName),
+				Team:        p.TeamName,
+				Points:      p.Points,
+				Strength:    p.Strength,
+				Probability: p.Odds / 100,
+				Eliminated:  p.Eliminated,
+			})
+	}
+
+	return resources
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"net/http"
+
+	"league-sim/api/handler"
+	"league-sim/api/openapi"
+	"league-sim/internal/models"
+)
+
+// apiRoutes documents every endpoint registered under /api/v1 and /api/v2, and
+// /api/graphql. TestOpenAPI fails when a route is registered in NewServer
+// without an entry here.
+var apiRoutes = []openapi.Route{
+	{
+		Method: http.MethodPost, Path: "/api/v1/users", Tag: "users", Public: true,
+		Summary:     "Register a user",
+		Description: "Creates a user and returns its API key, shown only once. Rate limited per client IP.",
+		Body:        models.RegisterUserRequest{}, Status: http.StatusCreated, Response: models.RegisterUserResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/me", Tag: "users",
+		Summary:  "Get the authenticated user",
+		Response: models.User{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league", Tag: "leagues",
+		Summary:  "List the leagues the user owns or is a member of",
+		Response: []models.GetLeaguesIdsWithNameResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league", Tag: "leagues",
+		Summary:  "Create a league owned by the user",
+		Body:     models.CreateLeagueRequest{},
+		Response: models.GetLeaguesIdsWithNameResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league/import", Tag: "leagues",
+		Summary: "Recreate a league from an export document",
+		Description: "The league is owned by the caller and keeps its exported ID, or gets a new one with " +
+			"?newId=true. 409 when a league with the kept ID exists or existed; counts against the league quota.",
+		Query: models.ImportLeagueRequest{}, Body: models.LeagueExport{},
+		Response: models.GetLeaguesIdsWithNameResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/experiments", Tag: "experiments",
+		Summary: "Play a batch of synthetic leagues and aggregate the outcomes",
+		Description: "Generates the leagues, plays every season in memory and stores nothing. " +
+			"With format=csv the response is text/csv with one row per league instead.",
+		Query: models.ExperimentFormatRequest{}, Body: models.ExperimentRequest{},
+		Response: models.ExperimentResult{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/v1/league/:leagueId", Tag: "leagues",
+		Summary: "Delete a league", Description: "Owner only.",
+		Response: "",
+	},
+	{
+		Method: http.MethodPut, Path: "/api/v1/league/:leagueId", Tag: "matches",
+		Summary: "Edit the score of a played match", Description: "Owner or editor.",
+		Body: models.EditMatchResult{}, Response: "",
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/standing", Tag: "leagues",
+		Summary:  "Get the standings",
+		Response: []models.Standings{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/fixtures", Tag: "leagues",
+		Summary:     "Get the upcoming and played fixtures",
+		Description: "With ?odds=true every upcoming match carries its win, draw and loss chances and expected score.",
+		Query:       models.GetFixturesRequest{},
+		Response:    models.GetActiveLeagueFixturesResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/predict", Tag: "leagues",
+		Summary:  "Get each team's chance of winning the league",
+		Response: []models.PredictedStanding{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/matchResults", Tag: "matches",
+		Summary:  "Get the results of played matches",
+		Response: []models.MatchResult{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/members", Tag: "members",
+		Summary:  "List the members of a league and their roles",
+		Response: []models.LeagueMember{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/audit", Tag: "leagues",
+		Summary:     "Get the audit log of a league",
+		Description: "Still readable after the league is deleted, by the user who created it.",
+		Query:       models.GetAuditLogRequest{},
+		Response:    []models.AuditEntry{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/ratings", Tag: "leagues",
+		Summary:     "Get the Elo rating history of every team",
+		Description: "One point per match, strongest team first; ?team= narrows it to one team.",
+		Query:       models.GetRatingHistoryRequest{},
+		Response:    []models.TeamRatingHistory{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/predictions/accuracy", Tag: "leagues",
+		Summary: "Score the title predictions made before every week",
+		Description: "Brier score, log loss and calibration of the recorded predictions against the champion. " +
+			"409 until the season is finished.",
+		Response: models.PredictionAccuracy{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/predictions/positions", Tag: "leagues",
+		Summary: "Probability of every team finishing in every position",
+		Description: "Plays the remaining fixtures ?runs= times (1000 by default) and returns the team by position " +
+			"matrix in percent, with expected position and 5th and 95th percentile points. ?format=csv returns " +
+			"one row per team.",
+		Query:    models.GetPositionsRequest{},
+		Response: models.PositionProbabilities{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/clinch", Tag: "leagues",
+		Summary: "Clinch and elimination analysis with magic numbers",
+		Description: "Best and worst finishing position of every team from the remaining fixtures, and whether it " +
+			"has clinched or is out of the title, the top places and safety. Exhaustive search is limited to " +
+			"12 remaining matches.",
+		Query:    models.GetClinchRequest{},
+		Response: models.ClinchAnalysis{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league/:leagueId/fork", Tag: "leagues",
+		Summary: "Fork a league into a new league owned by the caller",
+		Description: "Copies the teams, standings, fixtures, results, rating history and recorded predictions. " +
+			"The fork remembers its parent and counts against the caller's league quota.",
+		Body: models.ForkLeagueRequest{}, Response: models.GetLeaguesIdsWithNameResponse{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/export", Tag: "leagues",
+		Summary: "Export a league",
+		Description: "Everything stored for the league as a versioned JSON document, or with ?format=csv the " +
+			"?table= standings, results or fixtures as CSV.",
+		Query:    models.ExportLeagueRequest{},
+		Response: models.LeagueExport{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v1/league/:leagueId/diff", Tag: "leagues",
+		Summary: "Compare standings with another league",
+		Description: "Team by team comparison with the league named by ?with=, or with the parent of a fork. " +
+			"The caller must be able to read both, and both must have the same teams.",
+		Query:    models.GetStandingsDiffRequest{},
+		Response: models.StandingsDiff{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league/:leagueId/what-if", Tag: "leagues",
+		Summary: "Standings and title odds after hypothetical results",
+		Description: "Applies the given scores to upcoming matches of a copy of the league and returns its " +
+			"standings, remaining fixtures and title odds. Nothing is stored; viewers may call it.",
+		Body: models.WhatIfRequest{}, Response: models.WhatIfResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league/:leagueId/simulation", Tag: "matches",
+		Summary: "Simulate the next week, or every remaining week", Description: "Owner or editor.",
+		Body: models.SimulateLeagueRequest{}, Response: models.SimulationResponse{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league/:leagueId/reset", Tag: "leagues",
+		Summary: "Regenerate the teams and fixtures of a league", Description: "Owner or editor.",
+		Response: "",
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v1/league/:leagueId/members", Tag: "members",
+		Summary: "Invite a user as editor or viewer", Description: "Owner only.",
+		Body: models.AddLeagueMemberRequest{}, Status: http.StatusCreated, Response: models.AddLeagueMemberRequest{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/v1/league/:leagueId/members/:userId", Tag: "members",
+		Summary: "Remove a member", Description: "Owner only.",
+		Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPut, Path: "/api/v1/league/:leagueId/tactics", Tag: "matches",
+		Summary: "Set a team's tactics for the coming weeks", Description: "Owner or editor.",
+		Body: models.SetTacticsRequest{}, Response: "",
+	},
+
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues", Tag: "v2 leagues",
+		Summary: "List the leagues the user owns or is a member of",
+		Query:   models.PageRequest{}, Response: models.ListResponse[models.LeagueSummary]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v2/leagues", Tag: "v2 leagues",
+		Summary: "Create a league owned by the user",
+		Body:    models.CreateLeagueV2Request{}, Status: http.StatusCreated,
+		Response: models.Response[models.LeagueSummary]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId", Tag: "v2 leagues",
+		Summary:  "Get a league, the caller's role in it and its current season",
+		Response: models.Response[models.LeagueResource]{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/v2/leagues/:leagueId", Tag: "v2 leagues",
+		Summary: "Delete a league", Description: "Owner only.",
+		Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/seasons/current", Tag: "v2 seasons",
+		Summary:  "Get the progress of the current season",
+		Response: models.Response[models.SeasonResource]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v2/leagues/:leagueId/seasons", Tag: "v2 seasons",
+		Summary:     "Start a new season",
+		Description: "Replaces the current season with new teams, fixtures and standings. Owner or editor.",
+		Status:      http.StatusCreated, Response: models.Response[models.SeasonResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/teams", Tag: "v2 teams",
+		Summary:  "List the teams of a league",
+		Response: models.Response[[]models.TeamResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/teams/:teamId", Tag: "v2 teams",
+		Summary:  "Get a team",
+		Response: models.Response[models.TeamResource]{},
+	},
+	{
+		Method: http.MethodPut, Path: "/api/v2/leagues/:leagueId/teams/:teamId/tactics", Tag: "v2 teams",
+		Summary: "Replace a team's tactics for the coming weeks", Description: "Owner or editor.",
+		Body: models.Tactics{}, Response: models.Response[models.TeamResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/matches", Tag: "v2 matches",
+		Summary:     "List the matches of the current season",
+		Description: "Played matches come first, in week order, followed by the upcoming fixtures.",
+		Query:       models.ListMatchesRequest{}, Response: models.ListResponse[models.MatchResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/matches/:matchId", Tag: "v2 matches",
+		Summary:  "Get a match",
+		Response: models.Response[models.MatchResource]{},
+	},
+	{
+		Method: http.MethodPut, Path: "/api/v2/leagues/:leagueId/matches/:matchId/score", Tag: "v2 matches",
+		Summary: "Correct the score of a played match", Description: "Owner or editor.",
+		Body: models.MatchScoreRequest{}, Response: models.Response[models.MatchResource]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v2/leagues/:leagueId/simulations", Tag: "v2 matches",
+		Summary: "Simulate the next week, or every remaining week", Description: "Owner or editor.",
+		Body: models.CreateSimulationRequest{}, Status: http.StatusCreated,
+		Response: models.Response[models.SimulationResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/standings", Tag: "v2 standings",
+		Summary:  "Get the ranked league table",
+		Response: models.Response[[]models.StandingResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/predictions", Tag: "v2 standings",
+		Summary:  "Get each team's probability of winning the league",
+		Response: models.Response[[]models.PredictionResource]{},
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/members", Tag: "v2 members",
+		Summary:  "List the members of a league and their roles",
+		Response: models.Response[[]models.LeagueMember]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/v2/leagues/:leagueId/members", Tag: "v2 members",
+		Summary: "Invite a user as editor or viewer", Description: "Owner only.",
+		Body: models.AddLeagueMemberRequest{}, Status: http.StatusCreated,
+		Response: models.Response[models.AddLeagueMemberRequest]{},
+	},
+	{
+		Method: http.MethodDelete, Path: "/api/v2/leagues/:leagueId/members/:userId", Tag: "v2 members",
+		Summary: "Remove a member", Description: "Owner only.",
+		Status: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/api/v2/leagues/:leagueId/audit", Tag: "v2 leagues",
+		Summary: "Get the audit log of a league",
+		Query:   models.ListAuditRequest{}, Response: models.ListResponse[models.AuditEntry]{},
+	},
+	{
+		Method: http.MethodPost, Path: "/api/graphql", Tag: "graphql",
+		Summary: "Run a GraphQL query or mutation",
+		Description: "Leagues, teams, standings, weeks, matches and predictions in one request, plus the " +
+			"simulateWeek mutation. The schema is in backend/api/graphql/schema.graphql and can be introspected.",
+		Body: models.GraphQLRequest{}, Response: models.GraphQLResponse{},
+	},
+}
+
+// OpenAPISpec describes the API served by NewServer.
+func OpenAPISpec() *openapi.Document {
+	return openapi.Build(
+		openapi.Info{
+			Title:   "League Simulator API",
+			Version: "v2",
+			Description: "Every endpoint except user registration expects Authorization: Bearer <api key>. " +
+				"Requests are rate limited per IP and per API key. /api/v2 wraps every response in " +
+				"{\"data\": ...} and paginates long lists with opaque cursors; /api/v1 keeps its original shapes.",
+		},
+		apiRoutes, handler.ErrorResponse{})
+}
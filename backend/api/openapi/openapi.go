@@ -0,0 +1,241 @@
+// Package openapi builds an OpenAPI 3 document from a table of routes whose
+// request and response bodies are described by Go types. Schemas are derived
+// from the types' json and validate tags, so the document follows the DTOs in
+// models without being edited by hand.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Tags       []Tag               `json:"tags,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem maps lower-case HTTP methods to the operation served there.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationId string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// BearerAuth is the name of the API key security scheme.
+const BearerAuth = "bearerAuth"
+
+// Route documents one endpoint. Query, Body and Response are zero values of the
+// types bound from the query string, bound from the body and sent back; nil
+// means there is none.
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Tag         string
+	Public      bool
+	Query       any
+	Body        any
+	Status      int
+	Response    any
+}
+
+// Build describes routes as an OpenAPI document. Errors are documented once as
+// the default response using errorBody.
+func Build(info Info, routes []Route, errorBody any) *Document {
+	gen := NewGenerator()
+	errorSchema := gen.Schema(errorBody)
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+	}
+
+	tags := map[string]bool{}
+	for _, route := range routes {
+		path := Path(route.Path)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+
+		item[strings.ToLower(route.Method)] = gen.operation(route, errorSchema)
+
+		if route.Tag != "" && !tags[route.Tag] {
+			tags[route.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: route.Tag})
+		}
+	}
+
+	doc.Components = Components{
+		Schemas: gen.Schemas(),
+		SecuritySchemes: map[string]SecurityScheme{
+			BearerAuth: {
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "The API key returned by POST /api/v1/users.",
+			},
+		},
+	}
+
+	return doc
+}
+
+func (g *Generator) operation(route Route, errorSchema *Schema) *Operation {
+	op := &Operation{
+		OperationId: operationId(route.Method, route.Path),
+		Summary:     route.Summary,
+		Description: route.Description,
+		Parameters:  pathParameters(route.Path),
+		Responses: map[string]Response{
+			"default": {
+				Description: "Error",
+				Content:     jsonContent(errorSchema),
+			},
+		},
+		Security: []map[string][]string{{BearerAuth: {}}},
+	}
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+	if route.Public {
+		op.Security = []map[string][]string{}
+	}
+
+	if route.Query != nil {
+		op.Parameters = append(op.Parameters, g.queryParameters(route.Query)...)
+	}
+
+	if route.Body != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.Schema(route.Body))}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	response := Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		response.Content = jsonContent(g.Schema(route.Response))
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	return op
+}
+
+var paramPattern = regexp.MustCompile(`:(\w+)`)
+
+// Path turns an echo route path such as /league/:leagueId into the OpenAPI
+// form /league/{leagueId}.
+func Path(echoPath string) string {
+	return paramPattern.ReplaceAllString(echoPath, "{$1}")
+}
+
+func pathParameters(echoPath string) []Parameter {
+	var params []Parameter
+	for _, match := range paramPattern.FindAllStringSubmatch(echoPath, -1) {
+		params = append(
+			params, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	return params
+}
+
+// operationId names an operation after its method and path, e.g.
+// GET /api/v1/league/:leagueId/standing becomes getLeagueStanding.
+func operationId(method string, echoPath string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(echoPath, "/") {
+		if segment == "" || segment == "api" || strings.HasPrefix(segment, ":") || isVersion(segment) {
+			continue
+		}
+		b.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+
+	return b.String()
+}
+
+func isVersion(segment string) bool {
+	_, err := strconv.Atoi(strings.TrimPrefix(segment, "v"))
+	return strings.HasPrefix(segment, "v") && err == nil
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// Operations lists the "METHOD path" pairs documented in doc, sorted.
+func (d *Document) Operations() []string {
+	var ops []string
+	for path, item := range d.Paths {
+		for method := range item {
+			ops = append(ops, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(ops)
+
+	return ops
+}
@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMember struct {
+	UserId string `json:"userId" validate:"required,uuid"`
+	Role   string `json:"role" validate:"required,oneof=editor viewer"`
+}
+
+type testQuery struct {
+	Action string `query:"action" validate:"omitempty"`
+}
+
+type testEntry struct {
+	testMember
+	Name     string          `json:"name" validate:"required,min=3,max=32"`
+	Week     int             `json:"week" validate:"gte=1"`
+	Note     *string         `json:"note,omitempty"`
+	Parent   *testMember     `json:"parent"`
+	Tags     []string        `json:"tags"`
+	Extra    json.RawMessage `json:"extra"`
+	At       time.Time       `json:"at"`
+	internal string
+	Skipped  string `json:"-"`
+}
+
+type testPage struct {
+	Cursor string `query:"cursor"`
+}
+
+type testListQuery struct {
+	testPage
+	Week int `query:"week" validate:"omitempty,min=1"`
+}
+
+type testResponse[T any] struct {
+	Data T `json:"data"`
+}
+
+type testError struct {
+	Message string `json:"message"`
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, "/api/v1/league/{leagueId}/members/{userId}", Path("/api/v1/league/:leagueId/members/:userId"))
+	assert.Equal(t, "/api/v1/league", Path("/api/v1/league"))
+}
+
+func TestOperationId(t *testing.T) {
+	assert.Equal(t, "getLeagueStanding", operationId(http.MethodGet, "/api/v1/league/:leagueId/standing"))
+	assert.Equal(t, "deleteLeagueMembers", operationId(http.MethodDelete, "/api/v1/league/:leagueId/members/:userId"))
+	assert.Equal(t, "postUsers", operationId(http.MethodPost, "/api/v1/users"))
+}
+
+func TestGenerator_StructSchema(t *testing.T) {
+	// Setup
+	gen := NewGenerator()
+
+	// Execute
+	ref := gen.Schema(testEntry{})
+
+	// Assert
+	assert.Equal(t, "#/components/schemas/testEntry", ref.Ref)
+	schema := gen.Schemas()["testEntry"]
+	assert.ElementsMatch(t, []string{"userId", "role", "name"}, schema.Required)
+	assert.Contains(t, schema.Properties, "userId", "embedded fields are flattened")
+	assert.NotContains(t, schema.Properties, "internal")
+	assert.NotContains(t, schema.Properties, "Skipped")
+
+	assert.Equal(t, "uuid", schema.Properties["userId"].Format)
+	assert.Equal(t, []string{"editor", "viewer"}, schema.Properties["role"].Enum)
+	assert.Equal(t, 3, *schema.Properties["name"].MinLength)
+	assert.Equal(t, 32, *schema.Properties["name"].MaxLength)
+	assert.Equal(t, 1.0, *schema.Properties["week"].Minimum)
+	assert.True(t, schema.Properties["note"].Nullable)
+	assert.Equal(t, "#/components/schemas/testMember", schema.Properties["parent"].Ref)
+	assert.Equal(t, "array", schema.Properties["tags"].Type)
+	assert.Equal(t, "string", schema.Properties["tags"].Items.Type)
+	assert.True(t, schema.Properties["extra"].Nullable)
+	assert.Equal(t, "date-time", schema.Properties["at"].Format)
+	assert.Contains(t, gen.Schemas(), "testMember")
+}
+
+func TestBuild(t *testing.T) {
+	// Setup
+	routes := []Route{
+		{Method: http.MethodPost, Path: "/api/v1/users", Tag: "users", Public: true, Body: testMember{}, Status: http.StatusCreated, Response: testMember{}},
+		{Method: http.MethodGet, Path: "/api/v1/league/:leagueId/audit", Tag: "leagues", Query: testQuery{}, Response: []testEntry{}},
+		{Method: http.MethodDelete, Path: "/api/v1/league/:leagueId", Tag: "leagues", Status: http.StatusNoContent},
+	}
+
+	// Execute
+	doc := Build(Info{Title: "Test", Version: "v1"}, routes, testError{})
+
+	// Assert
+	assert.Equal(t, []string{
+		"DELETE /api/v1/league/{leagueId}",
+		"GET /api/v1/league/{leagueId}/audit",
+		"POST /api/v1/users",
+	}, doc.Operations())
+	assert.Equal(t, []Tag{{Name: "users"}, {Name: "leagues"}}, doc.Tags)
+
+	register := doc.Paths["/api/v1/users"]["post"]
+	assert.Empty(t, register.Security)
+	assert.NotNil(t, register.RequestBody)
+	assert.Contains(t, register.Responses, "201")
+	assert.Contains(t, register.Responses, "default")
+
+	audit := doc.Paths["/api/v1/league/{leagueId}/audit"]["get"]
+	assert.Equal(t, []map[string][]string{{BearerAuth: {}}}, audit.Security)
+	assert.Len(t, audit.Parameters, 2)
+	assert.Equal(t, "path", audit.Parameters[0].In)
+	assert.Equal(t, "action", audit.Parameters[1].Name)
+	assert.False(t, audit.Parameters[1].Required)
+	assert.Equal(t, "array", audit.Responses["200"].Content["application/json"].Schema.Type)
+
+	deleted := doc.Paths["/api/v1/league/{leagueId}"]["delete"]
+	assert.Nil(t, deleted.Responses["204"].Content)
+	assert.Contains(t, doc.Components.Schemas, "testError")
+
+	_, err := json.Marshal(doc)
+	assert.NoError(t, err)
+}
+
+func TestGenerator_GenericNames(t *testing.T) {
+	gen := NewGenerator()
+
+	assert.Equal(t, "#/components/schemas/testResponsetestMember", gen.Schema(testResponse[testMember]{}).Ref)
+	assert.Equal(t, "#/components/schemas/testResponsetestMemberList", gen.Schema(testResponse[[]testMember]{}).Ref)
+	assert.Equal(t, "array", gen.Schemas()["testResponsetestMemberList"].Properties["data"].Type)
+}
+
+func TestGenerator_EmbeddedQueryParameters(t *testing.T) {
+	params := NewGenerator().queryParameters(testListQuery{})
+
+	assert.Len(t, params, 2)
+	assert.Equal(t, "cursor", params[0].Name)
+	assert.Equal(t, "week", params[1].Name)
+	assert.Equal(t, 1.0, *params[1].Schema.Minimum)
+}
@@ -0,0 +1,236 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of the OpenAPI schema object the generator produces.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Generator turns Go types into schemas. Named structs become components and
+// are referenced by name, so each is described once however often it is used.
+type Generator struct {
+	schemas map[string]*Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{schemas: map[string]*Schema{}}
+}
+
+// Schema describes the type of v.
+func (g *Generator) Schema(v any) *Schema {
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+// Schemas returns the components collected so far, by type name.
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{Description: "Any JSON value", Nullable: true}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := g.schemaOf(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			g.schemas[name] = &Schema{} // placeholder for recursive types
+			g.schemas[name] = g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	return &Schema{}
+}
+
+// schemaName names the component of a struct type. Instances of generic types
+// are named after their type arguments, so Response[models.Team] becomes
+// ResponseTeam and Response[[]models.Team] becomes ResponseTeamList.
+func schemaName(t reflect.Type) string {
+	name, args, ok := strings.Cut(t.Name(), "[")
+	if !ok {
+		return name
+	}
+
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		list := strings.HasPrefix(arg, "[]")
+		arg = strings.TrimPrefix(arg, "[]")
+		name += arg[strings.LastIndex(arg, ".")+1:]
+		if list {
+			name += "List"
+		}
+	}
+
+	return name
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(schema, t)
+
+	return schema
+}
+
+// addFields adds the JSON fields of t to schema, flattening embedded structs
+// the way encoding/json does.
+func (g *Generator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			g.addFields(schema, field.Type)
+			continue
+		}
+
+		name, omitempty, skip := jsonName(field)
+		if skip || !field.IsExported() {
+			continue
+		}
+
+		property := g.schemaOf(field.Type)
+		rules := applyValidation(property, field.Tag.Get("validate"))
+		schema.Properties[name] = property
+
+		if rules["required"] && !omitempty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// queryParameters describes the fields of v bound from the query string.
+func (g *Generator) queryParameters(v any) []Parameter {
+	return g.queryFields(reflect.TypeOf(v))
+}
+
+func (g *Generator) queryFields(t reflect.Type) []Parameter {
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = append(params, g.queryFields(field.Type)...)
+			continue
+		}
+
+		name := field.Tag.Get("query")
+		if name == "" {
+			continue
+		}
+
+		schema := g.schemaOf(field.Type)
+		rules := applyValidation(schema, field.Tag.Get("validate"))
+		params = append(params, Parameter{Name: name, In: "query", Required: rules["required"], Schema: schema})
+	}
+
+	return params
+}
+
+func jsonName(field reflect.StructField) (name string, omitempty bool, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty, false
+}
+
+// applyValidation copies the constraints of a validate tag that OpenAPI can
+// express onto schema and reports which rules the tag contains.
+func applyValidation(schema *Schema, tag string) map[string]bool {
+	rules := map[string]bool{}
+	if tag == "" {
+		return rules
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		rules[name] = true
+
+		switch name {
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "uuid":
+			schema.Format = "uuid"
+		case "min", "max", "gte":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			switch {
+			case schema.Type == "string" && name == "max":
+				schema.MaxLength = &n
+			case schema.Type == "string":
+				schema.MinLength = &n
+			case schema.Type == "integer" || schema.Type == "number":
+				if name != "max" {
+					f := float64(n)
+					schema.Minimum = &f
+				}
+			}
+		}
+	}
+
+	return rules
+}
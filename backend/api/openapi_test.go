@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"league-sim/api/openapi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// registeredOperations lists the "METHOD path" pairs NewServer serves under
+// /api/v1 and /api/v2, and /api/graphql, in OpenAPI path form.
+func registeredOperations(t *testing.T) []string {
+	mockAppCtx := &MockAppContext{}
+	mockService := &MockService{}
+	setupMocks(mockAppCtx, mockService, "0")
+
+	e := NewServer(mockAppCtx, mockService)
+
+	var ops []string
+	for _, route := range e.Routes() {
+		versioned := strings.HasPrefix(route.Path, "/api/v1/") || strings.HasPrefix(route.Path, "/api/v2/")
+		if (!versioned && route.Path != "/api/graphql") || strings.HasSuffix(route.Path, "*") {
+			continue
+		}
+		switch route.Method {
+		case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
+			ops = append(ops, route.Method+" "+openapi.Path(route.Path))
+		}
+	}
+
+	return ops
+}
+
+func TestOpenAPI_DocumentsEveryRegisteredRoute(t *testing.T) {
+	// Setup
+	documented := OpenAPISpec().Operations()
+
+	// Execute
+	registered := registeredOperations(t)
+
+	// Assert
+	assert.NotEmpty(t, registered)
+	for _, op := range registered {
+		assert.Contains(t, documented, op, "route is registered but missing from the OpenAPI spec")
+	}
+	for _, op := range documented {
+		assert.Contains(t, registered, op, "route is in the OpenAPI spec but not registered")
+	}
+}
+
+func TestOpenAPI_ServedWithDocs(t *testing.T) {
+	// Setup
+	mockAppCtx := &MockAppContext{}
+	mockService := &MockService{}
+	setupMocks(mockAppCtx, mockService, "0")
+	e := NewServer(mockAppCtx, mockService)
+
+	// Execute
+	specRec := httptest.NewRecorder()
+	e.ServeHTTP(specRec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	docsRec := httptest.NewRecorder()
+	e.ServeHTTP(docsRec, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, specRec.Code)
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(specRec.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/api/v1/league/{leagueId}/standing")
+	assert.Contains(t, doc.Components.Schemas, "CreateLeagueRequest")
+	assert.Contains(t, doc.Components.Schemas, "ErrorResponse")
+
+	assert.Equal(t, http.StatusOK, docsRec.Code)
+	assert.Contains(t, docsRec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, docsRec.Body.String(), "openapi.json")
+}
@@ -6,17 +6,44 @@ import (
 	"os"
 	"path/filepath"
 
+	"league-sim/api/graphql"
 	"league-sim/api/handler"
+	"league-sim/api/rpc"
 	"league-sim/config"
 	appContext "league-sim/internal/contexts/appContexts"
 	"league-sim/internal/contexts/services"
+	"league-sim/internal/models"
+	"league-sim/internal/ratelimit"
+	"league-sim/internal/validation"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// StartServer serves the HTTP API and, on its own port, the gRPC API. Both
+// throttle clients with the same buckets. It returns when either server fails.
 func StartServer(appCtx appContext.AppContext, services services.Service) error {
+	limits := rateLimitStore(appCtx)
+	e := newServer(appCtx, services, limits)
+	grpcServer := rpc.NewServer(appCtx, services, limits)
+
+	errs := make(chan error, 2)
+	go func() { errs <- rpc.Serve(grpcServer, appCtx.Config().GRPC.Port) }()
+	go func() { errs <- e.Start(fmt.Sprintf(":%s", appCtx.Config().HTTP.Port)) }()
+
+	return <-errs
+}
+
+// NewServer builds the echo instance serving the frontend and the API.
+func NewServer(appCtx appContext.AppContext, services services.Service) *echo.Echo {
+	return newServer(appCtx, services, rateLimitStore(appCtx))
+}
+
+func newServer(appCtx appContext.AppContext, services services.Service, limits ratelimit.Store) *echo.Echo {
 	e := echo.New()
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.IPExtractor = handler.ClientIPExtractor(appCtx.Config().RateLimit.TrustedProxies)
+	e.Validator = validation.New(appCtx.Config().Limits)
 	e.Use(
 		middleware.CORSWithConfig(
 			middleware.CORSConfig{
@@ -24,7 +51,11 @@ func StartServer(appCtx appContext.AppContext, services services.Service) error
 				AllowMethods: []string{
 					http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions,
 				},
-				AllowHeaders:     []string{"Content-Type", "Authorization"},
+				AllowHeaders: []string{"Content-Type", "Authorization"},
+				ExposeHeaders: []string{
+					handler.HeaderRateLimitLimit, handler.HeaderRateLimitRemaining, handler.HeaderRateLimitReset,
+					echo.HeaderRetryAfter,
+				},
 				AllowCredentials: true,
 			}))
 	buildDir := "public"
@@ -42,23 +73,95 @@ func StartServer(appCtx appContext.AppContext, services services.Service) error
 			return c.File(filepath.Join(buildDir, "index.html"))
 		})
 
+	e.GET("/api/openapi.json", handler.OpenAPISpec(OpenAPISpec())) // OpenAPI description of the API
+	e.GET("/api/docs", handler.APIDocs)                            // Browsable API documentation
+
+	e.Use(handler.RequestIDMiddleware())
+	e.Use(handler.TimeoutMiddleware(appCtx.Config().Timeouts.Request))
 	e.Use(handler.ContextMiddleware(appCtx))
 	e.Use(handler.ServiceMiddleware(services))
-	v1 := e.Group("/api/v1")
+	rateLimit := appCtx.Config().RateLimit
+	ipLimit := handler.RateLimitMiddleware(limits, ratelimit.Rate(rateLimit.PerIP), handler.ClientIPKey)
+	keyLimit := handler.RateLimitMiddleware(limits, ratelimit.Rate(rateLimit.PerKey), handler.APIKeyKey)
+	registrationLimit := handler.RegistrationLimitMiddleware(limits, ratelimit.Rate(rateLimit.Registration))
+	v1 := e.Group("/api/v1", ipLimit)
+
+	v1.POST("/users", handler.RegisterUser, registrationLimit) // Register a user and issue its API key
 
-	v1.GET("/league", handler.GetLeagueIds)                           // Get all league IDs
-	v1.GET("/league/:leagueId/standing", handler.GetStanding)         // Get league standing by ID
-	v1.GET("/league/:leagueId/fixtures", handler.GetFixtures)         // Get fixtures for a league by ID
-	v1.GET("/league/:leagueId/predict", handler.GetPredictTable)      // Get simulation results for a league by ID
-	v1.GET("/league/:leagueId/matchResults", handler.GetMatchResults) // Get match results for a league by ID
+	authed := v1.Group("", handler.AuthMiddleware(services), keyLimit)
+	authed.GET("/me", handler.GetCurrentUser)           // Get the authenticated user
+	authed.GET("/league", handler.GetLeagueIds)         // Get the IDs of leagues the user can access
+	authed.POST("/league", handler.CreateLeague)        // Create a new league owned by the user
+	authed.POST("/league/import", handler.ImportLeague) // Recreate a league from an export document
+	authed.POST("/experiments", handler.RunExperiment)  // Play synthetic leagues in memory and aggregate them
 
-	v1.POST("/league", handler.CreateLeague)                         // Create a new league
-	v1.POST("/league/:leagueId/simulation", handler.StartSimulation) // Start a league simulation
-	v1.POST("/league/:leagueId/reset", handler.ResetLeague)          // Create fixtures for a league by ID
+	canRead := handler.RequireLeagueRole(models.RoleOwner, models.RoleEditor, models.RoleViewer)
+	canEdit := handler.RequireLeagueRole(models.RoleOwner, models.RoleEditor)
+	ownerOnly := handler.RequireLeagueRole(models.RoleOwner)
+	canReadAudit := handler.RequireAuditReader(models.RoleOwner, models.RoleEditor, models.RoleViewer)
 
-	v1.DELETE("/league/:leagueId", handler.DeleteLeague) // Delete a league by ID
+	league := authed.Group("/league/:leagueId")
+	league.GET("/standing", handler.GetStanding, canRead)                           // Get league standing by ID
+	league.GET("/fixtures", handler.GetFixtures, canRead)                           // Get fixtures for a league by ID
+	league.GET("/predict", handler.GetPredictTable, canRead)                        // Get simulation results for a league by ID
+	league.GET("/matchResults", handler.GetMatchResults, canRead)                   // Get match results for a league by ID
+	league.GET("/members", handler.GetLeagueMembers, canRead)                       // List the members of a league and their roles
+	league.GET("/audit", handler.GetAuditLog, canReadAudit)                         // Audit log of a league, filterable by ?action=
+	league.GET("/ratings", handler.GetRatingHistory, canRead)                       // Elo rating history, filterable by ?team=
+	league.GET("/predictions/accuracy", handler.GetPredictionAccuracy, canRead)     // Scores of past title predictions
+	league.GET("/predictions/positions", handler.GetPositionProbabilities, canRead) // Finishing-position distribution
+	league.GET("/export", handler.ExportLeague, canRead)                            // Versioned JSON export, or ?format=csv&table=
+	league.GET("/diff", handler.GetStandingsDiff, canRead)                          // Standings compared with ?with= or the parent league
+	league.GET("/clinch", handler.GetClinch, canRead)                               // Clinch and elimination with magic numbers
+
+	league.POST("/fork", handler.ForkLeague, canRead)            // Copy the league into a new one owned by the caller
+	league.POST("/what-if", handler.WhatIf, canRead)             // Standings and odds after hypothetical results
+	league.POST("/simulation", handler.StartSimulation, canEdit) // Start a league simulation
+	league.POST("/reset", handler.ResetLeague, canEdit)          // Create fixtures for a league by ID
+	league.POST("/members", handler.AddLeagueMember, ownerOnly)  // Invite a user as editor or viewer
+
+	league.DELETE("", handler.DeleteLeague, ownerOnly)                       // Delete a league by ID
+	league.DELETE("/members/:userId", handler.RemoveLeagueMember, ownerOnly) // Remove a member
+
+	league.PUT("", handler.EditMatch, canEdit)          // Update league details by ID
+	league.PUT("/tactics", handler.SetTactics, canEdit) // Set a team's tactics for the coming weeks
+
+	// v2 serves the same data as resources wrapped in {"data": ...} envelopes.
+	v2 := e.Group("/api/v2", ipLimit, handler.AuthMiddleware(services), keyLimit)
+	v2.GET("/leagues", handler.ListLeaguesV2)   // Leagues the user can access, paginated
+	v2.POST("/leagues", handler.CreateLeagueV2) // Create a league owned by the user
+
+	leagueV2 := v2.Group("/leagues/:leagueId")
+	leagueV2.GET("", handler.GetLeagueV2, canRead)                               // League details and current season
+	leagueV2.DELETE("", handler.DeleteLeagueV2, ownerOnly)                       // Delete a league
+	leagueV2.GET("/seasons/current", handler.GetCurrentSeasonV2, canRead)        // Progress of the current season
+	leagueV2.POST("/seasons", handler.StartSeasonV2, canEdit)                    // Replace the season with a new one
+	leagueV2.GET("/teams", handler.ListTeamsV2, canRead)                         // Teams of the league
+	leagueV2.GET("/teams/:teamId", handler.GetTeamV2, canRead)                   // One team
+	leagueV2.PUT("/teams/:teamId/tactics", handler.SetTeamTacticsV2, canEdit)    // Replace a team's tactics
+	leagueV2.GET("/matches", handler.ListMatchesV2, canRead)                     // Matches by week, team and status
+	leagueV2.GET("/matches/:matchId", handler.GetMatchV2, canRead)               // One match
+	leagueV2.PUT("/matches/:matchId/score", handler.UpdateMatchScoreV2, canEdit) // Correct a played match
+	leagueV2.POST("/simulations", handler.CreateSimulationV2, canEdit)           // Play the next or all weeks
+	leagueV2.GET("/standings", handler.ListStandingsV2, canRead)                 // Ranked table
+	leagueV2.GET("/predictions", handler.ListPredictionsV2, canRead)             // Title probabilities
+	leagueV2.GET("/members", handler.ListMembersV2, canRead)                     // Members and their roles
+	leagueV2.POST("/members", handler.AddMemberV2, ownerOnly)                    // Invite an editor or viewer
+	leagueV2.DELETE("/members/:userId", handler.RemoveLeagueMember, ownerOnly)   // Remove a member
+	leagueV2.GET("/audit", handler.ListAuditV2, canReadAudit)                    // Audit log, filterable by ?action=
+
+	// GraphQL resolves league access itself, per league in the query.
+	e.POST("/api/graphql", handler.GraphQL(graphql.NewServer()), ipLimit, handler.AuthMiddleware(services), keyLimit)
+
+	return e
+}
 
-	v1.PUT("/league/:leagueId", handler.EditMatch) // Update league details by ID
+// rateLimitStore picks where the rate limiter keeps its buckets.
+func rateLimitStore(appCtx appContext.AppContext) ratelimit.Store {
+	cfg := appCtx.Config()
+	if cfg.RateLimit.Store == config.RateLimitStoreMySQL {
+		return ratelimit.NewSQLStore(appCtx.DB().Sql, cfg.Timeouts.Query)
+	}
 
-	return e.Start(fmt.Sprintf(":%s", config.HTTPPort))
+	return ratelimit.NewMemoryStore()
 }
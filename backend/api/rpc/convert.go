@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"league-sim/api/rpc/leaguesimv1"
+	"league-sim/internal/models"
+)
+
+// Conversions between the models and their protobuf messages.
+
+func rulesFromProto(rules *leaguesimv1.LeagueRules) *models.LeagueRules {
+	if rules == nil {
+		return nil
+	}
+
+	converted := &models.LeagueRules{
+		PointsForWin:  int(rules.GetPointsForWin()),
+		PointsForDraw: int(rules.GetPointsForDraw()),
+		PointsForLoss: int(rules.GetPointsForLoss()),
+		HomeAdvantage: rules.GetHomeAdvantage(),
+		SquadCap:      int(rules.GetSquadCap()),
+		StrengthWeights: models.StrengthWeights{
+			Attack:  rules.GetStrengthWeights().GetAttack(),
+			Defense: rules.GetStrengthWeights().GetDefense(),
+			Morale:  rules.GetStrengthWeights().GetMorale(),
+			Stamina: rules.GetStrengthWeights().GetStamina(),
+		},
+		Draw: models.DrawModel{
+			Chance:   rules.GetDraw().GetChance(),
+			MaxGoals: int(rules.GetDraw().GetMaxGoals()),
+		},
+		BonusPoints: models.BonusPoints{
+			GoalThreshold: int(rules.GetBonusPoints().GetGoalThreshold()),
+			Points:        int(rules.GetBonusPoints().GetPoints()),
+		},
+	}
+	if rates := rules.GetGoalRates(); rates != nil {
+		converted.GoalRates = &models.GoalRates{
+			Winner: rates.GetWinner(),
+			Loser:  rates.GetLoser(),
+			Draw:   rates.GetDraw(),
+		}
+	}
+	if elo := rules.GetElo(); elo != nil {
+		converted.Elo = &models.EloRules{
+			KFactor:       elo.GetKFactor(),
+			HomeAdvantage: elo.GetHomeAdvantage(),
+			InEngine:      elo.GetInEngine(),
+			InPredictor:   elo.GetInPredictor(),
+		}
+	}
+
+	return converted
+}
+
+func tacticsFromProto(tactics *leaguesimv1.Tactics) models.Tactics {
+	return models.Tactics{
+		Formation:     tactics.GetFormation(),
+		Pressing:      tactics.GetPressing(),
+		DefensiveLine: tactics.GetDefensiveLine(),
+	}
+}
+
+func teamToProto(team models.Team) *leaguesimv1.Team {
+	return &leaguesimv1.Team{
+		Name:         team.Name,
+		AttackPower:  team.AttackPower,
+		DefensePower: team.DefensePower,
+		Morale:       team.Morale,
+		Stamina:      team.Stamina,
+		Rating:       team.Rating,
+		Tactics: &leaguesimv1.Tactics{
+			Formation:     team.Tactics.Formation,
+			Pressing:      team.Tactics.Pressing,
+			DefensiveLine: team.Tactics.DefensiveLine,
+		},
+	}
+}
+
+func standingsToProto(standings []models.Standings) []*leaguesimv1.Standing {
+	converted := make([]*leaguesimv1.Standing, 0, len(standings))
+	for _, s := range standings {
+		converted = append(
+			converted, &leaguesimv1.Standing{
+				Team:    teamToProto(s.Team),
+				Goals:   int32(s.Goals),
+				Against: int32(s.Against),
+				Played:  int32(s.Played),
+				Wins:    int32(s.Wins),
+				Losses:  int32(s.Losses),
+				Points:  int32(s.Points),
+			})
+	}
+
+	return converted
+}
+
+func weeksToProto(weeks []models.Week) []*leaguesimv1.Week {
+	converted := make([]*leaguesimv1.Week, 0, len(weeks))
+	for _, week := range weeks {
+		matches := make([]*leaguesimv1.Match, 0, len(week.Matches))
+		for _, match := range week.Matches {
+			converted := &leaguesimv1.Match{}
+			if match.Home != nil {
+				converted.Home = teamToProto(*match.Home)
+			}
+			if match.Away != nil {
+				converted.Away = teamToProto(*match.Away)
+			}
+			matches = append(matches, converted)
+		}
+		converted = append(converted, &leaguesimv1.Week{Number: int32(week.Number), Matches: matches})
+	}
+
+	return converted
+}
+
+func matchResultsToProto(results []models.MatchResult) []*leaguesimv1.MatchResult {
+	converted := make([]*leaguesimv1.MatchResult, 0, len(results))
+	for _, result := range results {
+		converted = append(
+			converted, &leaguesimv1.MatchResult{
+				MatchWeek: int32(result.MatchWeek),
+				Home:      result.Home,
+				HomeScore: int32(result.HomeScore),
+				Away:      result.Away,
+				AwayScore: int32(result.AwayScore),
+				Winner:    result.Winner,
+			})
+	}
+
+	return converted
+}
+
+func predictionsToProto(predictions []models.PredictedStanding) []*leaguesimv1.PredictedStanding {
+	converted := make([]*leaguesimv1.PredictedStanding, 0, len(predictions))
+	for _, p := range predictions {
+		converted = append(
+			converted, &leaguesimv1.PredictedStanding{
+				TeamName:   p.TeamName,
+				Points:     int32(p.Points),
+				Strength:   p.Strength,
+				Odds:       p.Odds,
+				Eliminated: p.Eliminated,
+			})
+	}
+
+	return converted
+}
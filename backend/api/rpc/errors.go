@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"errors"
+	"log"
+
+	"league-sim/internal/apperrors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the ErrorInfo domain of every error the API reports.
+const errorDomain = "leaguesim"
+
+var kindCode = map[apperrors.Kind]codes.Code{
+	apperrors.KindNotFound:     codes.NotFound,
+	apperrors.KindValidation:   codes.InvalidArgument,
+	apperrors.KindConflict:     codes.FailedPrecondition,
+	apperrors.KindUnauthorized: codes.Unauthenticated,
+	apperrors.KindForbidden:    codes.PermissionDenied,
+	apperrors.KindRateLimited:  codes.ResourceExhausted,
+	apperrors.KindQuota:        codes.ResourceExhausted,
+	apperrors.KindInternal:     codes.Internal,
+}
+
+// statusOf maps an error returned by a service to the status sent to the
+// client. The apperrors kind travels as the ErrorInfo reason, so clients can
+// tell a rate limit from a quota, and invalid fields as BadRequest violations.
+// Internal failures never expose their cause.
+func statusOf(err error) *status.Status {
+	if _, ok := status.FromError(err); ok {
+		return status.Convert(err)
+	}
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Kind == apperrors.KindInternal {
+		return withDetails(
+			status.New(codes.Internal, "internal server error"),
+			&errdetails.ErrorInfo{Reason: string(apperrors.KindInternal), Domain: errorDomain})
+	}
+
+	code, ok := kindCode[appErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: string(appErr.Kind), Domain: errorDomain}}
+	if len(appErr.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(appErr.Fields))
+		for _, field := range appErr.Fields {
+			violations = append(
+				violations, &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	return withDetails(status.New(code, appErr.Message), details...)
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		log.Printf("grpc: attaching error details: %v", err)
+		return st
+	}
+
+	return detailed
+}
@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+		reason  apperrors.Kind
+	}{
+		{
+			name: "Not found", err: apperrors.NotFound("league %s not found", "abc"),
+			code: codes.NotFound, message: "league abc not found", reason: apperrors.KindNotFound,
+		},
+		{
+			name: "Conflict", err: apperrors.Conflict("league is finished"),
+			code: codes.FailedPrecondition, message: "league is finished", reason: apperrors.KindConflict,
+		},
+		{
+			name: "Forbidden", err: apperrors.Forbidden("no"),
+			code: codes.PermissionDenied, message: "no", reason: apperrors.KindForbidden,
+		},
+		{
+			name: "Quota", err: apperrors.QuotaExceeded("league quota of %d reached", 5),
+			code: codes.ResourceExhausted, message: "league quota of 5 reached", reason: apperrors.KindQuota,
+		},
+		{
+			name: "Internal", err: apperrors.Internal(errors.New("connection refused"), "failed to read league"),
+			code: codes.Internal, message: "internal server error", reason: apperrors.KindInternal,
+		},
+		{
+			name: "Unknown error", err: errors.New("boom"),
+			code: codes.Internal, message: "internal server error", reason: apperrors.KindInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Execute
+				st := statusOf(tt.err)
+
+				// Assert
+				assert.Equal(t, tt.code, st.Code())
+				assert.Equal(t, tt.message, st.Message())
+				require.NotEmpty(t, st.Details())
+				info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+				require.True(t, ok)
+				assert.Equal(t, string(tt.reason), info.GetReason())
+			})
+	}
+}
+
+func TestStatusOf_FieldViolations(t *testing.T) {
+	// Setup
+	err := apperrors.InvalidFields(apperrors.FieldError{Field: "teamCount", Message: "must be between 2 and 20"})
+
+	// Execute
+	st := statusOf(err)
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 2)
+	badRequest, ok := st.Details()[1].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "teamCount", badRequest.GetFieldViolations()[0].GetField())
+	assert.Equal(t, "must be between 2 and 20", badRequest.GetFieldViolations()[0].GetDescription())
+}
+
+func TestStatusOf_KeepsStatusErrors(t *testing.T) {
+	// Setup
+	err := status.Error(codes.Canceled, "context canceled")
+
+	// Execute
+	st := statusOf(err)
+
+	// Assert
+	assert.Equal(t, codes.Canceled, st.Code())
+	assert.Equal(t, "context canceled", st.Message())
+}
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+
+	"league-sim/api/rpc/leaguesimv1"
+	"league-sim/internal/models"
+)
+
+var memberRoles = map[leaguesimv1.MemberRole]string{
+	leaguesimv1.MemberRole_MEMBER_ROLE_EDITOR: models.RoleEditor,
+	leaguesimv1.MemberRole_MEMBER_ROLE_VIEWER: models.RoleViewer,
+}
+
+type leagueServer struct {
+	leaguesimv1.UnimplementedLeagueServiceServer
+	base
+}
+
+func (s *leagueServer) CreateLeague(
+	ctx context.Context, req *leaguesimv1.CreateLeagueRequest,
+) (*leaguesimv1.CreateLeagueResponse, error) {
+	body := models.CreateLeagueRequest{
+		LeagueName: req.GetLeagueName(),
+		TeamCount:  strconv.Itoa(int(req.GetTeamCount())),
+		Rules:      rulesFromProto(req.GetRules()),
+	}
+	if err := s.validate.Validate(&body); err != nil {
+		return nil, err
+	}
+
+	result, err := s.services.LeagueService().CreateLeague(ctx, body.TeamCount, body.LeagueName, body.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.CreateLeagueResponse{LeagueId: result.LeagueId, LeagueName: result.LeagueName}, nil
+}
+
+func (s *leagueServer) ResetLeague(
+	ctx context.Context, req *leaguesimv1.ResetLeagueRequest,
+) (*leaguesimv1.ResetLeagueResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), canEdit); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.LeagueService().ResetLeague(ctx, req.GetLeagueId()); err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.ResetLeagueResponse{}, nil
+}
+
+func (s *leagueServer) DeleteLeague(
+	ctx context.Context, req *leaguesimv1.DeleteLeagueRequest,
+) (*leaguesimv1.DeleteLeagueResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), ownerOnly); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.LeagueService().DeleteLeague(ctx, req.GetLeagueId()); err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.DeleteLeagueResponse{}, nil
+}
+
+func (s *leagueServer) AddMember(
+	ctx context.Context, req *leaguesimv1.AddMemberRequest,
+) (*leaguesimv1.AddMemberResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), ownerOnly); err != nil {
+		return nil, err
+	}
+
+	body := models.AddLeagueMemberRequest{UserId: req.GetUserId(), Role: memberRoles[req.GetRole()]}
+	if err := s.validate.Validate(&body); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.LeagueService().AddMember(ctx, req.GetLeagueId(), body.UserId, body.Role); err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.AddMemberResponse{}, nil
+}
+
+func (s *leagueServer) RemoveMember(
+	ctx context.Context, req *leaguesimv1.RemoveMemberRequest,
+) (*leaguesimv1.RemoveMemberResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), ownerOnly); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.LeagueService().RemoveMember(ctx, req.GetLeagueId(), req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.RemoveMemberResponse{}, nil
+}
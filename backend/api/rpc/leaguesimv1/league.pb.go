@@ -0,0 +1,644 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: leaguesim/v1/league.proto
+
+package leaguesimv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MemberRole int32
+
+const (
+	MemberRole_MEMBER_ROLE_UNSPECIFIED MemberRole = 0
+	MemberRole_MEMBER_ROLE_EDITOR      MemberRole = 1
+	MemberRole_MEMBER_ROLE_VIEWER      MemberRole = 2
+)
+
+// Enum value maps for MemberRole.
+var (
+	MemberRole_name = map[int32]string{
+		0: "MEMBER_ROLE_UNSPECIFIED",
+		1: "MEMBER_ROLE_EDITOR",
+		2: "MEMBER_ROLE_VIEWER",
+	}
+	MemberRole_value = map[string]int32{
+		"MEMBER_ROLE_UNSPECIFIED": 0,
+		"MEMBER_ROLE_EDITOR":      1,
+		"MEMBER_ROLE_VIEWER":      2,
+	}
+)
+
+func (x MemberRole) Enum() *MemberRole {
+	p := new(MemberRole)
+	*p = x
+	return p
+}
+
+func (x MemberRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemberRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_leaguesim_v1_league_proto_enumTypes[0].Descriptor()
+}
+
+func (MemberRole) Type() protoreflect.EnumType {
+	return &file_leaguesim_v1_league_proto_enumTypes[0]
+}
+
+func (x MemberRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemberRole.Descriptor instead.
+func (MemberRole) EnumDescriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{0}
+}
+
+type CreateLeagueRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TeamCount  int32                  `protobuf:"varint,1,opt,name=team_count,json=teamCount,proto3" json:"team_count,omitempty"`
+	LeagueName string                 `protobuf:"bytes,2,opt,name=league_name,json=leagueName,proto3" json:"league_name,omitempty"`
+	// The default rules apply when unset.
+	Rules         *LeagueRules `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLeagueRequest) Reset() {
+	*x = CreateLeagueRequest{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLeagueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLeagueRequest) ProtoMessage() {}
+
+func (x *CreateLeagueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLeagueRequest.ProtoReflect.Descriptor instead.
+func (*CreateLeagueRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateLeagueRequest) GetTeamCount() int32 {
+	if x != nil {
+		return x.TeamCount
+	}
+	return 0
+}
+
+func (x *CreateLeagueRequest) GetLeagueName() string {
+	if x != nil {
+		return x.LeagueName
+	}
+	return ""
+}
+
+func (x *CreateLeagueRequest) GetRules() *LeagueRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type CreateLeagueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	LeagueName    string                 `protobuf:"bytes,2,opt,name=league_name,json=leagueName,proto3" json:"league_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLeagueResponse) Reset() {
+	*x = CreateLeagueResponse{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLeagueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLeagueResponse) ProtoMessage() {}
+
+func (x *CreateLeagueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLeagueResponse.ProtoReflect.Descriptor instead.
+func (*CreateLeagueResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateLeagueResponse) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *CreateLeagueResponse) GetLeagueName() string {
+	if x != nil {
+		return x.LeagueName
+	}
+	return ""
+}
+
+type ResetLeagueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetLeagueRequest) Reset() {
+	*x = ResetLeagueRequest{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetLeagueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetLeagueRequest) ProtoMessage() {}
+
+func (x *ResetLeagueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetLeagueRequest.ProtoReflect.Descriptor instead.
+func (*ResetLeagueRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResetLeagueRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+type ResetLeagueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetLeagueResponse) Reset() {
+	*x = ResetLeagueResponse{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetLeagueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetLeagueResponse) ProtoMessage() {}
+
+func (x *ResetLeagueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetLeagueResponse.ProtoReflect.Descriptor instead.
+func (*ResetLeagueResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{3}
+}
+
+type DeleteLeagueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLeagueRequest) Reset() {
+	*x = DeleteLeagueRequest{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLeagueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLeagueRequest) ProtoMessage() {}
+
+func (x *DeleteLeagueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLeagueRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLeagueRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteLeagueRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+type DeleteLeagueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLeagueResponse) Reset() {
+	*x = DeleteLeagueResponse{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLeagueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLeagueResponse) ProtoMessage() {}
+
+func (x *DeleteLeagueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLeagueResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLeagueResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{5}
+}
+
+type AddMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          MemberRole             `protobuf:"varint,3,opt,name=role,proto3,enum=leaguesim.v1.MemberRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberRequest) Reset() {
+	*x = AddMemberRequest{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberRequest) ProtoMessage() {}
+
+func (x *AddMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddMemberRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddMemberRequest) GetRole() MemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return MemberRole_MEMBER_ROLE_UNSPECIFIED
+}
+
+type AddMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddMemberResponse) Reset() {
+	*x = AddMemberResponse{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddMemberResponse) ProtoMessage() {}
+
+func (x *AddMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddMemberResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{7}
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveMemberRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_leaguesim_v1_league_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_league_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_league_proto_rawDescGZIP(), []int{9}
+}
+
+var File_leaguesim_v1_league_proto protoreflect.FileDescriptor
+
+const file_leaguesim_v1_league_proto_rawDesc = "" +
+	"\n" +
+	"\x19leaguesim/v1/league.proto\x12\fleaguesim.v1\x1a\x18leaguesim/v1/types.proto\"\x86\x01\n" +
+	"\x13CreateLeagueRequest\x12\x1d\n" +
+	"\n" +
+	"team_count\x18\x01 \x01(\x05R\tteamCount\x12\x1f\n" +
+	"\vleague_name\x18\x02 \x01(\tR\n" +
+	"leagueName\x12/\n" +
+	"\x05rules\x18\x03 \x01(\v2\x19.leaguesim.v1.LeagueRulesR\x05rules\"T\n" +
+	"\x14CreateLeagueResponse\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12\x1f\n" +
+	"\vleague_name\x18\x02 \x01(\tR\n" +
+	"leagueName\"1\n" +
+	"\x12ResetLeagueRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\"\x15\n" +
+	"\x13ResetLeagueResponse\"2\n" +
+	"\x13DeleteLeagueRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\"\x16\n" +
+	"\x14DeleteLeagueResponse\"v\n" +
+	"\x10AddMemberRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12,\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x18.leaguesim.v1.MemberRoleR\x04role\"\x13\n" +
+	"\x11AddMemberResponse\"K\n" +
+	"\x13RemoveMemberRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x16\n" +
+	"\x14RemoveMemberResponse*Y\n" +
+	"\n" +
+	"MemberRole\x12\x1b\n" +
+	"\x17MEMBER_ROLE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12MEMBER_ROLE_EDITOR\x10\x01\x12\x16\n" +
+	"\x12MEMBER_ROLE_VIEWER\x10\x022\xb6\x03\n" +
+	"\rLeagueService\x12U\n" +
+	"\fCreateLeague\x12!.leaguesim.v1.CreateLeagueRequest\x1a\".leaguesim.v1.CreateLeagueResponse\x12R\n" +
+	"\vResetLeague\x12 .leaguesim.v1.ResetLeagueRequest\x1a!.leaguesim.v1.ResetLeagueResponse\x12U\n" +
+	"\fDeleteLeague\x12!.leaguesim.v1.DeleteLeagueRequest\x1a\".leaguesim.v1.DeleteLeagueResponse\x12L\n" +
+	"\tAddMember\x12\x1e.leaguesim.v1.AddMemberRequest\x1a\x1f.leaguesim.v1.AddMemberResponse\x12U\n" +
+	"\fRemoveMember\x12!.leaguesim.v1.RemoveMemberRequest\x1a\".leaguesim.v1.RemoveMemberResponseB,Z*league-sim/api/rpc/leaguesimv1;leaguesimv1b\x06proto3"
+
+var (
+	file_leaguesim_v1_league_proto_rawDescOnce sync.Once
+	file_leaguesim_v1_league_proto_rawDescData []byte
+)
+
+func file_leaguesim_v1_league_proto_rawDescGZIP() []byte {
+	file_leaguesim_v1_league_proto_rawDescOnce.Do(func() {
+		file_leaguesim_v1_league_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaguesim_v1_league_proto_rawDesc), len(file_leaguesim_v1_league_proto_rawDesc)))
+	})
+	return file_leaguesim_v1_league_proto_rawDescData
+}
+
+var file_leaguesim_v1_league_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_leaguesim_v1_league_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_leaguesim_v1_league_proto_goTypes = []any{
+	(MemberRole)(0),              // 0: leaguesim.v1.MemberRole
+	(*CreateLeagueRequest)(nil),  // 1: leaguesim.v1.CreateLeagueRequest
+	(*CreateLeagueResponse)(nil), // 2: leaguesim.v1.CreateLeagueResponse
+	(*ResetLeagueRequest)(nil),   // 3: leaguesim.v1.ResetLeagueRequest
+	(*ResetLeagueResponse)(nil),  // 4: leaguesim.v1.ResetLeagueResponse
+	(*DeleteLeagueRequest)(nil),  // 5: leaguesim.v1.DeleteLeagueRequest
+	(*DeleteLeagueResponse)(nil), // 6: leaguesim.v1.DeleteLeagueResponse
+	(*AddMemberRequest)(nil),     // 7: leaguesim.v1.AddMemberRequest
+	(*AddMemberResponse)(nil),    // 8: leaguesim.v1.AddMemberResponse
+	(*RemoveMemberRequest)(nil),  // 9: leaguesim.v1.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil), // 10: leaguesim.v1.RemoveMemberResponse
+	(*LeagueRules)(nil),          // 11: leaguesim.v1.LeagueRules
+}
+var file_leaguesim_v1_league_proto_depIdxs = []int32{
+	11, // 0: leaguesim.v1.CreateLeagueRequest.rules:type_name -> leaguesim.v1.LeagueRules
+	0,  // 1: leaguesim.v1.AddMemberRequest.role:type_name -> leaguesim.v1.MemberRole
+	1,  // 2: leaguesim.v1.LeagueService.CreateLeague:input_type -> leaguesim.v1.CreateLeagueRequest
+	3,  // 3: leaguesim.v1.LeagueService.ResetLeague:input_type -> leaguesim.v1.ResetLeagueRequest
+	5,  // 4: leaguesim.v1.LeagueService.DeleteLeague:input_type -> leaguesim.v1.DeleteLeagueRequest
+	7,  // 5: leaguesim.v1.LeagueService.AddMember:input_type -> leaguesim.v1.AddMemberRequest
+	9,  // 6: leaguesim.v1.LeagueService.RemoveMember:input_type -> leaguesim.v1.RemoveMemberRequest
+	2,  // 7: leaguesim.v1.LeagueService.CreateLeague:output_type -> leaguesim.v1.CreateLeagueResponse
+	4,  // 8: leaguesim.v1.LeagueService.ResetLeague:output_type -> leaguesim.v1.ResetLeagueResponse
+	6,  // 9: leaguesim.v1.LeagueService.DeleteLeague:output_type -> leaguesim.v1.DeleteLeagueResponse
+	8,  // 10: leaguesim.v1.LeagueService.AddMember:output_type -> leaguesim.v1.AddMemberResponse
+	10, // 11: leaguesim.v1.LeagueService.RemoveMember:output_type -> leaguesim.v1.RemoveMemberResponse
+	7,  // [7:12] is the sub-list for method output_type
+	2,  // [2:7] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_leaguesim_v1_league_proto_init() }
+func file_leaguesim_v1_league_proto_init() {
+	if File_leaguesim_v1_league_proto != nil {
+		return
+	}
+	file_leaguesim_v1_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaguesim_v1_league_proto_rawDesc), len(file_leaguesim_v1_league_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaguesim_v1_league_proto_goTypes,
+		DependencyIndexes: file_leaguesim_v1_league_proto_depIdxs,
+		EnumInfos:         file_leaguesim_v1_league_proto_enumTypes,
+		MessageInfos:      file_leaguesim_v1_league_proto_msgTypes,
+	}.Build()
+	File_leaguesim_v1_league_proto = out.File
+	file_leaguesim_v1_league_proto_goTypes = nil
+	file_leaguesim_v1_league_proto_depIdxs = nil
+}
@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: leaguesim/v1/league.proto
+
+package leaguesimv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LeagueService_CreateLeague_FullMethodName = "/leaguesim.v1.LeagueService/CreateLeague"
+	LeagueService_ResetLeague_FullMethodName  = "/leaguesim.v1.LeagueService/ResetLeague"
+	LeagueService_DeleteLeague_FullMethodName = "/leaguesim.v1.LeagueService/DeleteLeague"
+	LeagueService_AddMember_FullMethodName    = "/leaguesim.v1.LeagueService/AddMember"
+	LeagueService_RemoveMember_FullMethodName = "/leaguesim.v1.LeagueService/RemoveMember"
+)
+
+// LeagueServiceClient is the client API for LeagueService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// LeagueService mirrors LeagueServiceInterface: creating, resetting and deleting
+// leagues and managing who may access them.
+type LeagueServiceClient interface {
+	// Creates a league owned by the caller.
+	CreateLeague(ctx context.Context, in *CreateLeagueRequest, opts ...grpc.CallOption) (*CreateLeagueResponse, error)
+	// Replaces the league's season with a fresh one. Owner or editor.
+	ResetLeague(ctx context.Context, in *ResetLeagueRequest, opts ...grpc.CallOption) (*ResetLeagueResponse, error)
+	// Deletes the league. Owner only.
+	DeleteLeague(ctx context.Context, in *DeleteLeagueRequest, opts ...grpc.CallOption) (*DeleteLeagueResponse, error)
+	// Invites a user as editor or viewer. Owner only.
+	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	// Removes a member. Owner only.
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+}
+
+type leagueServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeagueServiceClient(cc grpc.ClientConnInterface) LeagueServiceClient {
+	return &leagueServiceClient{cc}
+}
+
+func (c *leagueServiceClient) CreateLeague(ctx context.Context, in *CreateLeagueRequest, opts ...grpc.CallOption) (*CreateLeagueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateLeagueResponse)
+	err := c.cc.Invoke(ctx, LeagueService_CreateLeague_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leagueServiceClient) ResetLeague(ctx context.Context, in *ResetLeagueRequest, opts ...grpc.CallOption) (*ResetLeagueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetLeagueResponse)
+	err := c.cc.Invoke(ctx, LeagueService_ResetLeague_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leagueServiceClient) DeleteLeague(ctx context.Context, in *DeleteLeagueRequest, opts ...grpc.CallOption) (*DeleteLeagueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteLeagueResponse)
+	err := c.cc.Invoke(ctx, LeagueService_DeleteLeague_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leagueServiceClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddMemberResponse)
+	err := c.cc.Invoke(ctx, LeagueService_AddMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leagueServiceClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, LeagueService_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeagueServiceServer is the server API for LeagueService service.
+// All implementations must embed UnimplementedLeagueServiceServer
+// for forward compatibility.
+//
+// LeagueService mirrors LeagueServiceInterface: creating, resetting and deleting
+// leagues and managing who may access them.
+type LeagueServiceServer interface {
+	// Creates a league owned by the caller.
+	CreateLeague(context.Context, *CreateLeagueRequest) (*CreateLeagueResponse, error)
+	// Replaces the league's season with a fresh one. Owner or editor.
+	ResetLeague(context.Context, *ResetLeagueRequest) (*ResetLeagueResponse, error)
+	// Deletes the league. Owner only.
+	DeleteLeague(context.Context, *DeleteLeagueRequest) (*DeleteLeagueResponse, error)
+	// Invites a user as editor or viewer. Owner only.
+	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	// Removes a member. Owner only.
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	mustEmbedUnimplementedLeagueServiceServer()
+}
+
+// UnimplementedLeagueServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeagueServiceServer struct{}
+
+func (UnimplementedLeagueServiceServer) CreateLeague(context.Context, *CreateLeagueRequest) (*CreateLeagueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLeague not implemented")
+}
+func (UnimplementedLeagueServiceServer) ResetLeague(context.Context, *ResetLeagueRequest) (*ResetLeagueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetLeague not implemented")
+}
+func (UnimplementedLeagueServiceServer) DeleteLeague(context.Context, *DeleteLeagueRequest) (*DeleteLeagueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLeague not implemented")
+}
+func (UnimplementedLeagueServiceServer) AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedLeagueServiceServer) RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedLeagueServiceServer) mustEmbedUnimplementedLeagueServiceServer() {}
+func (UnimplementedLeagueServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeLeagueServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeagueServiceServer will
+// result in compilation errors.
+type UnsafeLeagueServiceServer interface {
+	mustEmbedUnimplementedLeagueServiceServer()
+}
+
+func RegisterLeagueServiceServer(s grpc.ServiceRegistrar, srv LeagueServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLeagueServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LeagueService_ServiceDesc, srv)
+}
+
+func _LeagueService_CreateLeague_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLeagueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeagueServiceServer).CreateLeague(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeagueService_CreateLeague_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeagueServiceServer).CreateLeague(ctx, req.(*CreateLeagueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeagueService_ResetLeague_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetLeagueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeagueServiceServer).ResetLeague(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeagueService_ResetLeague_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeagueServiceServer).ResetLeague(ctx, req.(*ResetLeagueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeagueService_DeleteLeague_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLeagueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeagueServiceServer).DeleteLeague(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeagueService_DeleteLeague_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeagueServiceServer).DeleteLeague(ctx, req.(*DeleteLeagueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeagueService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeagueServiceServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeagueService_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeagueServiceServer).AddMember(ctx, req.(*AddMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LeagueService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeagueServiceServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LeagueService_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeagueServiceServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LeagueService_ServiceDesc is the grpc.ServiceDesc for LeagueService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LeagueService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaguesim.v1.LeagueService",
+	HandlerType: (*LeagueServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLeague",
+			Handler:    _LeagueService_CreateLeague_Handler,
+		},
+		{
+			MethodName: "ResetLeague",
+			Handler:    _LeagueService_ResetLeague_Handler,
+		},
+		{
+			MethodName: "DeleteLeague",
+			Handler:    _LeagueService_DeleteLeague_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _LeagueService_AddMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _LeagueService_RemoveMember_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaguesim/v1/league.proto",
+}
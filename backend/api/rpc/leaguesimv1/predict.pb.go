@@ -0,0 +1,260 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: leaguesim/v1/predict.proto
+
+package leaguesimv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PredictChampionshipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictChampionshipRequest) Reset() {
+	*x = PredictChampionshipRequest{}
+	mi := &file_leaguesim_v1_predict_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictChampionshipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictChampionshipRequest) ProtoMessage() {}
+
+func (x *PredictChampionshipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_predict_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictChampionshipRequest.ProtoReflect.Descriptor instead.
+func (*PredictChampionshipRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_predict_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PredictChampionshipRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+type PredictChampionshipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Predictions   []*PredictedStanding   `protobuf:"bytes,1,rep,name=predictions,proto3" json:"predictions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictChampionshipResponse) Reset() {
+	*x = PredictChampionshipResponse{}
+	mi := &file_leaguesim_v1_predict_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictChampionshipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictChampionshipResponse) ProtoMessage() {}
+
+func (x *PredictChampionshipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_predict_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictChampionshipResponse.ProtoReflect.Descriptor instead.
+func (*PredictChampionshipResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_predict_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PredictChampionshipResponse) GetPredictions() []*PredictedStanding {
+	if x != nil {
+		return x.Predictions
+	}
+	return nil
+}
+
+type PredictedStanding struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Points   int32                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	Strength float64                `protobuf:"fixed64,3,opt,name=strength,proto3" json:"strength,omitempty"`
+	// Chance of winning the title in percent.
+	Odds          float64 `protobuf:"fixed64,4,opt,name=odds,proto3" json:"odds,omitempty"`
+	Eliminated    bool    `protobuf:"varint,5,opt,name=eliminated,proto3" json:"eliminated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictedStanding) Reset() {
+	*x = PredictedStanding{}
+	mi := &file_leaguesim_v1_predict_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictedStanding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictedStanding) ProtoMessage() {}
+
+func (x *PredictedStanding) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_predict_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictedStanding.ProtoReflect.Descriptor instead.
+func (*PredictedStanding) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_predict_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PredictedStanding) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *PredictedStanding) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *PredictedStanding) GetStrength() float64 {
+	if x != nil {
+		return x.Strength
+	}
+	return 0
+}
+
+func (x *PredictedStanding) GetOdds() float64 {
+	if x != nil {
+		return x.Odds
+	}
+	return 0
+}
+
+func (x *PredictedStanding) GetEliminated() bool {
+	if x != nil {
+		return x.Eliminated
+	}
+	return false
+}
+
+var File_leaguesim_v1_predict_proto protoreflect.FileDescriptor
+
+const file_leaguesim_v1_predict_proto_rawDesc = "" +
+	"\n" +
+	"\x1aleaguesim/v1/predict.proto\x12\fleaguesim.v1\"9\n" +
+	"\x1aPredictChampionshipRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\"`\n" +
+	"\x1bPredictChampionshipResponse\x12A\n" +
+	"\vpredictions\x18\x01 \x03(\v2\x1f.leaguesim.v1.PredictedStandingR\vpredictions\"\x98\x01\n" +
+	"\x11PredictedStanding\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x05R\x06points\x12\x1a\n" +
+	"\bstrength\x18\x03 \x01(\x01R\bstrength\x12\x12\n" +
+	"\x04odds\x18\x04 \x01(\x01R\x04odds\x12\x1e\n" +
+	"\n" +
+	"eliminated\x18\x05 \x01(\bR\n" +
+	"eliminated2|\n" +
+	"\x0ePredictService\x12j\n" +
+	"\x13PredictChampionship\x12(.leaguesim.v1.PredictChampionshipRequest\x1a).leaguesim.v1.PredictChampionshipResponseB,Z*league-sim/api/rpc/leaguesimv1;leaguesimv1b\x06proto3"
+
+var (
+	file_leaguesim_v1_predict_proto_rawDescOnce sync.Once
+	file_leaguesim_v1_predict_proto_rawDescData []byte
+)
+
+func file_leaguesim_v1_predict_proto_rawDescGZIP() []byte {
+	file_leaguesim_v1_predict_proto_rawDescOnce.Do(func() {
+		file_leaguesim_v1_predict_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaguesim_v1_predict_proto_rawDesc), len(file_leaguesim_v1_predict_proto_rawDesc)))
+	})
+	return file_leaguesim_v1_predict_proto_rawDescData
+}
+
+var file_leaguesim_v1_predict_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_leaguesim_v1_predict_proto_goTypes = []any{
+	(*PredictChampionshipRequest)(nil),  // 0: leaguesim.v1.PredictChampionshipRequest
+	(*PredictChampionshipResponse)(nil), // 1: leaguesim.v1.PredictChampionshipResponse
+	(*PredictedStanding)(nil),           // 2: leaguesim.v1.PredictedStanding
+}
+var file_leaguesim_v1_predict_proto_depIdxs = []int32{
+	2, // 0: leaguesim.v1.PredictChampionshipResponse.predictions:type_name -> leaguesim.v1.PredictedStanding
+	0, // 1: leaguesim.v1.PredictService.PredictChampionship:input_type -> leaguesim.v1.PredictChampionshipRequest
+	1, // 2: leaguesim.v1.PredictService.PredictChampionship:output_type -> leaguesim.v1.PredictChampionshipResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_leaguesim_v1_predict_proto_init() }
+func file_leaguesim_v1_predict_proto_init() {
+	if File_leaguesim_v1_predict_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaguesim_v1_predict_proto_rawDesc), len(file_leaguesim_v1_predict_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaguesim_v1_predict_proto_goTypes,
+		DependencyIndexes: file_leaguesim_v1_predict_proto_depIdxs,
+		MessageInfos:      file_leaguesim_v1_predict_proto_msgTypes,
+	}.Build()
+	File_leaguesim_v1_predict_proto = out.File
+	file_leaguesim_v1_predict_proto_goTypes = nil
+	file_leaguesim_v1_predict_proto_depIdxs = nil
+}
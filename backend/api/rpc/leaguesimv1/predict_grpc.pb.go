@@ -0,0 +1,129 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: leaguesim/v1/predict.proto
+
+package leaguesimv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PredictService_PredictChampionship_FullMethodName = "/leaguesim.v1.PredictService/PredictChampionship"
+)
+
+// PredictServiceClient is the client API for PredictService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PredictService mirrors PredictServiceInterface. Any member of the league may
+// call it.
+type PredictServiceClient interface {
+	// Each team's chance of winning the league.
+	PredictChampionship(ctx context.Context, in *PredictChampionshipRequest, opts ...grpc.CallOption) (*PredictChampionshipResponse, error)
+}
+
+type predictServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPredictServiceClient(cc grpc.ClientConnInterface) PredictServiceClient {
+	return &predictServiceClient{cc}
+}
+
+func (c *predictServiceClient) PredictChampionship(ctx context.Context, in *PredictChampionshipRequest, opts ...grpc.CallOption) (*PredictChampionshipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PredictChampionshipResponse)
+	err := c.cc.Invoke(ctx, PredictService_PredictChampionship_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PredictServiceServer is the server API for PredictService service.
+// All implementations must embed UnimplementedPredictServiceServer
+// for forward compatibility.
+//
+// PredictService mirrors PredictServiceInterface. Any member of the league may
+// call it.
+type PredictServiceServer interface {
+	// Each team's chance of winning the league.
+	PredictChampionship(context.Context, *PredictChampionshipRequest) (*PredictChampionshipResponse, error)
+	mustEmbedUnimplementedPredictServiceServer()
+}
+
+// UnimplementedPredictServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPredictServiceServer struct{}
+
+func (UnimplementedPredictServiceServer) PredictChampionship(context.Context, *PredictChampionshipRequest) (*PredictChampionshipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PredictChampionship not implemented")
+}
+func (UnimplementedPredictServiceServer) mustEmbedUnimplementedPredictServiceServer() {}
+func (UnimplementedPredictServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePredictServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PredictServiceServer will
+// result in compilation errors.
+type UnsafePredictServiceServer interface {
+	mustEmbedUnimplementedPredictServiceServer()
+}
+
+func RegisterPredictServiceServer(s grpc.ServiceRegistrar, srv PredictServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPredictServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PredictService_ServiceDesc, srv)
+}
+
+func _PredictService_PredictChampionship_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictChampionshipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PredictServiceServer).PredictChampionship(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PredictService_PredictChampionship_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PredictServiceServer).PredictChampionship(ctx, req.(*PredictChampionshipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PredictService_ServiceDesc is the grpc.ServiceDesc for PredictService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PredictService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaguesim.v1.PredictService",
+	HandlerType: (*PredictServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PredictChampionship",
+			Handler:    _PredictService_PredictChampionship_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "leaguesim/v1/predict.proto",
+}
@@ -0,0 +1,601 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: leaguesim/v1/simulation.proto
+
+package leaguesimv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SimulateRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId        string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	PlayAllFixtures bool                   `protobuf:"varint,2,opt,name=play_all_fixtures,json=playAllFixtures,proto3" json:"play_all_fixtures,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SimulateRequest) Reset() {
+	*x = SimulateRequest{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateRequest) ProtoMessage() {}
+
+func (x *SimulateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateRequest.ProtoReflect.Descriptor instead.
+func (*SimulateRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SimulateRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *SimulateRequest) GetPlayAllFixtures() bool {
+	if x != nil {
+		return x.PlayAllFixtures
+	}
+	return false
+}
+
+type SimulateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Matches          []*MatchResult         `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	UpcomingFixtures []*Week                `protobuf:"bytes,2,rep,name=upcoming_fixtures,json=upcomingFixtures,proto3" json:"upcoming_fixtures,omitempty"`
+	PlayedFixtures   []*Week                `protobuf:"bytes,3,rep,name=played_fixtures,json=playedFixtures,proto3" json:"played_fixtures,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SimulateResponse) Reset() {
+	*x = SimulateResponse{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateResponse) ProtoMessage() {}
+
+func (x *SimulateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateResponse.ProtoReflect.Descriptor instead.
+func (*SimulateResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SimulateResponse) GetMatches() []*MatchResult {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetUpcomingFixtures() []*Week {
+	if x != nil {
+		return x.UpcomingFixtures
+	}
+	return nil
+}
+
+func (x *SimulateResponse) GetPlayedFixtures() []*Week {
+	if x != nil {
+		return x.PlayedFixtures
+	}
+	return nil
+}
+
+type StreamSimulationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	// How many weeks to play; zero plays the rest of the season.
+	Weeks         int32 `protobuf:"varint,2,opt,name=weeks,proto3" json:"weeks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamSimulationRequest) Reset() {
+	*x = StreamSimulationRequest{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSimulationRequest) ProtoMessage() {}
+
+func (x *StreamSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSimulationRequest.ProtoReflect.Descriptor instead.
+func (*StreamSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamSimulationRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *StreamSimulationRequest) GetWeeks() int32 {
+	if x != nil {
+		return x.Weeks
+	}
+	return 0
+}
+
+// StreamSimulationResponse is the progress after one simulated week.
+type StreamSimulationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Week  int32                  `protobuf:"varint,1,opt,name=week,proto3" json:"week,omitempty"`
+	// Weeks played by this stream so far, and how many it will play in total.
+	WeeksPlayed int32          `protobuf:"varint,2,opt,name=weeks_played,json=weeksPlayed,proto3" json:"weeks_played,omitempty"`
+	WeeksTotal  int32          `protobuf:"varint,3,opt,name=weeks_total,json=weeksTotal,proto3" json:"weeks_total,omitempty"`
+	Matches     []*MatchResult `protobuf:"bytes,4,rep,name=matches,proto3" json:"matches,omitempty"`
+	// The table after the week, in finishing order.
+	Standings     []*Standing `protobuf:"bytes,5,rep,name=standings,proto3" json:"standings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamSimulationResponse) Reset() {
+	*x = StreamSimulationResponse{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSimulationResponse) ProtoMessage() {}
+
+func (x *StreamSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSimulationResponse.ProtoReflect.Descriptor instead.
+func (*StreamSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamSimulationResponse) GetWeek() int32 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *StreamSimulationResponse) GetWeeksPlayed() int32 {
+	if x != nil {
+		return x.WeeksPlayed
+	}
+	return 0
+}
+
+func (x *StreamSimulationResponse) GetWeeksTotal() int32 {
+	if x != nil {
+		return x.WeeksTotal
+	}
+	return 0
+}
+
+func (x *StreamSimulationResponse) GetMatches() []*MatchResult {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *StreamSimulationResponse) GetStandings() []*Standing {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+type EditMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	Home          string                 `protobuf:"bytes,2,opt,name=home,proto3" json:"home,omitempty"`
+	Away          string                 `protobuf:"bytes,3,opt,name=away,proto3" json:"away,omitempty"`
+	HomeScore     int32                  `protobuf:"varint,4,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
+	AwayScore     int32                  `protobuf:"varint,5,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
+	MatchWeek     int32                  `protobuf:"varint,6,opt,name=match_week,json=matchWeek,proto3" json:"match_week,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMatchRequest) Reset() {
+	*x = EditMatchRequest{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMatchRequest) ProtoMessage() {}
+
+func (x *EditMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMatchRequest.ProtoReflect.Descriptor instead.
+func (*EditMatchRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EditMatchRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *EditMatchRequest) GetHome() string {
+	if x != nil {
+		return x.Home
+	}
+	return ""
+}
+
+func (x *EditMatchRequest) GetAway() string {
+	if x != nil {
+		return x.Away
+	}
+	return ""
+}
+
+func (x *EditMatchRequest) GetHomeScore() int32 {
+	if x != nil {
+		return x.HomeScore
+	}
+	return 0
+}
+
+func (x *EditMatchRequest) GetAwayScore() int32 {
+	if x != nil {
+		return x.AwayScore
+	}
+	return 0
+}
+
+func (x *EditMatchRequest) GetMatchWeek() int32 {
+	if x != nil {
+		return x.MatchWeek
+	}
+	return 0
+}
+
+type EditMatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMatchResponse) Reset() {
+	*x = EditMatchResponse{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMatchResponse) ProtoMessage() {}
+
+func (x *EditMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMatchResponse.ProtoReflect.Descriptor instead.
+func (*EditMatchResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{5}
+}
+
+type SetTacticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeagueId      string                 `protobuf:"bytes,1,opt,name=league_id,json=leagueId,proto3" json:"league_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Tactics       *Tactics               `protobuf:"bytes,3,opt,name=tactics,proto3" json:"tactics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTacticsRequest) Reset() {
+	*x = SetTacticsRequest{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTacticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTacticsRequest) ProtoMessage() {}
+
+func (x *SetTacticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTacticsRequest.ProtoReflect.Descriptor instead.
+func (*SetTacticsRequest) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetTacticsRequest) GetLeagueId() string {
+	if x != nil {
+		return x.LeagueId
+	}
+	return ""
+}
+
+func (x *SetTacticsRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *SetTacticsRequest) GetTactics() *Tactics {
+	if x != nil {
+		return x.Tactics
+	}
+	return nil
+}
+
+type SetTacticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTacticsResponse) Reset() {
+	*x = SetTacticsResponse{}
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTacticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTacticsResponse) ProtoMessage() {}
+
+func (x *SetTacticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_simulation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTacticsResponse.ProtoReflect.Descriptor instead.
+func (*SetTacticsResponse) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_simulation_proto_rawDescGZIP(), []int{7}
+}
+
+var File_leaguesim_v1_simulation_proto protoreflect.FileDescriptor
+
+const file_leaguesim_v1_simulation_proto_rawDesc = "" +
+	"\n" +
+	"\x1dleaguesim/v1/simulation.proto\x12\fleaguesim.v1\x1a\x18leaguesim/v1/types.proto\"Z\n" +
+	"\x0fSimulateRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12*\n" +
+	"\x11play_all_fixtures\x18\x02 \x01(\bR\x0fplayAllFixtures\"\xc5\x01\n" +
+	"\x10SimulateResponse\x123\n" +
+	"\amatches\x18\x01 \x03(\v2\x19.leaguesim.v1.MatchResultR\amatches\x12?\n" +
+	"\x11upcoming_fixtures\x18\x02 \x03(\v2\x12.leaguesim.v1.WeekR\x10upcomingFixtures\x12;\n" +
+	"\x0fplayed_fixtures\x18\x03 \x03(\v2\x12.leaguesim.v1.WeekR\x0eplayedFixtures\"L\n" +
+	"\x17StreamSimulationRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12\x14\n" +
+	"\x05weeks\x18\x02 \x01(\x05R\x05weeks\"\xdd\x01\n" +
+	"\x18StreamSimulationResponse\x12\x12\n" +
+	"\x04week\x18\x01 \x01(\x05R\x04week\x12!\n" +
+	"\fweeks_played\x18\x02 \x01(\x05R\vweeksPlayed\x12\x1f\n" +
+	"\vweeks_total\x18\x03 \x01(\x05R\n" +
+	"weeksTotal\x123\n" +
+	"\amatches\x18\x04 \x03(\v2\x19.leaguesim.v1.MatchResultR\amatches\x124\n" +
+	"\tstandings\x18\x05 \x03(\v2\x16.leaguesim.v1.StandingR\tstandings\"\xb4\x01\n" +
+	"\x10EditMatchRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12\x12\n" +
+	"\x04home\x18\x02 \x01(\tR\x04home\x12\x12\n" +
+	"\x04away\x18\x03 \x01(\tR\x04away\x12\x1d\n" +
+	"\n" +
+	"home_score\x18\x04 \x01(\x05R\thomeScore\x12\x1d\n" +
+	"\n" +
+	"away_score\x18\x05 \x01(\x05R\tawayScore\x12\x1d\n" +
+	"\n" +
+	"match_week\x18\x06 \x01(\x05R\tmatchWeek\"\x13\n" +
+	"\x11EditMatchResponse\"~\n" +
+	"\x11SetTacticsRequest\x12\x1b\n" +
+	"\tleague_id\x18\x01 \x01(\tR\bleagueId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x12/\n" +
+	"\atactics\x18\x03 \x01(\v2\x15.leaguesim.v1.TacticsR\atactics\"\x14\n" +
+	"\x12SetTacticsResponse2\xe2\x02\n" +
+	"\x11SimulationService\x12I\n" +
+	"\bSimulate\x12\x1d.leaguesim.v1.SimulateRequest\x1a\x1e.leaguesim.v1.SimulateResponse\x12c\n" +
+	"\x10StreamSimulation\x12%.leaguesim.v1.StreamSimulationRequest\x1a&.leaguesim.v1.StreamSimulationResponse0\x01\x12L\n" +
+	"\tEditMatch\x12\x1e.leaguesim.v1.EditMatchRequest\x1a\x1f.leaguesim.v1.EditMatchResponse\x12O\n" +
+	"\n" +
+	"SetTactics\x12\x1f.leaguesim.v1.SetTacticsRequest\x1a .leaguesim.v1.SetTacticsResponseB,Z*league-sim/api/rpc/leaguesimv1;leaguesimv1b\x06proto3"
+
+var (
+	file_leaguesim_v1_simulation_proto_rawDescOnce sync.Once
+	file_leaguesim_v1_simulation_proto_rawDescData []byte
+)
+
+func file_leaguesim_v1_simulation_proto_rawDescGZIP() []byte {
+	file_leaguesim_v1_simulation_proto_rawDescOnce.Do(func() {
+		file_leaguesim_v1_simulation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaguesim_v1_simulation_proto_rawDesc), len(file_leaguesim_v1_simulation_proto_rawDesc)))
+	})
+	return file_leaguesim_v1_simulation_proto_rawDescData
+}
+
+var file_leaguesim_v1_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_leaguesim_v1_simulation_proto_goTypes = []any{
+	(*SimulateRequest)(nil),          // 0: leaguesim.v1.SimulateRequest
+	(*SimulateResponse)(nil),         // 1: leaguesim.v1.SimulateResponse
+	(*StreamSimulationRequest)(nil),  // 2: leaguesim.v1.StreamSimulationRequest
+	(*StreamSimulationResponse)(nil), // 3: leaguesim.v1.StreamSimulationResponse
+	(*EditMatchRequest)(nil),         // 4: leaguesim.v1.EditMatchRequest
+	(*EditMatchResponse)(nil),        // 5: leaguesim.v1.EditMatchResponse
+	(*SetTacticsRequest)(nil),        // 6: leaguesim.v1.SetTacticsRequest
+	(*SetTacticsResponse)(nil),       // 7: leaguesim.v1.SetTacticsResponse
+	(*MatchResult)(nil),              // 8: leaguesim.v1.MatchResult
+	(*Week)(nil),                     // 9: leaguesim.v1.Week
+	(*Standing)(nil),                 // 10: leaguesim.v1.Standing
+	(*Tactics)(nil),                  // 11: leaguesim.v1.Tactics
+}
+var file_leaguesim_v1_simulation_proto_depIdxs = []int32{
+	8,  // 0: leaguesim.v1.SimulateResponse.matches:type_name -> leaguesim.v1.MatchResult
+	9,  // 1: leaguesim.v1.SimulateResponse.upcoming_fixtures:type_name -> leaguesim.v1.Week
+	9,  // 2: leaguesim.v1.SimulateResponse.played_fixtures:type_name -> leaguesim.v1.Week
+	8,  // 3: leaguesim.v1.StreamSimulationResponse.matches:type_name -> leaguesim.v1.MatchResult
+	10, // 4: leaguesim.v1.StreamSimulationResponse.standings:type_name -> leaguesim.v1.Standing
+	11, // 5: leaguesim.v1.SetTacticsRequest.tactics:type_name -> leaguesim.v1.Tactics
+	0,  // 6: leaguesim.v1.SimulationService.Simulate:input_type -> leaguesim.v1.SimulateRequest
+	2,  // 7: leaguesim.v1.SimulationService.StreamSimulation:input_type -> leaguesim.v1.StreamSimulationRequest
+	4,  // 8: leaguesim.v1.SimulationService.EditMatch:input_type -> leaguesim.v1.EditMatchRequest
+	6,  // 9: leaguesim.v1.SimulationService.SetTactics:input_type -> leaguesim.v1.SetTacticsRequest
+	1,  // 10: leaguesim.v1.SimulationService.Simulate:output_type -> leaguesim.v1.SimulateResponse
+	3,  // 11: leaguesim.v1.SimulationService.StreamSimulation:output_type -> leaguesim.v1.StreamSimulationResponse
+	5,  // 12: leaguesim.v1.SimulationService.EditMatch:output_type -> leaguesim.v1.EditMatchResponse
+	7,  // 13: leaguesim.v1.SimulationService.SetTactics:output_type -> leaguesim.v1.SetTacticsResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_leaguesim_v1_simulation_proto_init() }
+func file_leaguesim_v1_simulation_proto_init() {
+	if File_leaguesim_v1_simulation_proto != nil {
+		return
+	}
+	file_leaguesim_v1_types_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaguesim_v1_simulation_proto_rawDesc), len(file_leaguesim_v1_simulation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaguesim_v1_simulation_proto_goTypes,
+		DependencyIndexes: file_leaguesim_v1_simulation_proto_depIdxs,
+		MessageInfos:      file_leaguesim_v1_simulation_proto_msgTypes,
+	}.Build()
+	File_leaguesim_v1_simulation_proto = out.File
+	file_leaguesim_v1_simulation_proto_goTypes = nil
+	file_leaguesim_v1_simulation_proto_depIdxs = nil
+}
@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: leaguesim/v1/simulation.proto
+
+package leaguesimv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SimulationService_Simulate_FullMethodName         = "/leaguesim.v1.SimulationService/Simulate"
+	SimulationService_StreamSimulation_FullMethodName = "/leaguesim.v1.SimulationService/StreamSimulation"
+	SimulationService_EditMatch_FullMethodName        = "/leaguesim.v1.SimulationService/EditMatch"
+	SimulationService_SetTactics_FullMethodName       = "/leaguesim.v1.SimulationService/SetTactics"
+)
+
+// SimulationServiceClient is the client API for SimulationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SimulationService mirrors SimulationServiceInterface. Every RPC requires the
+// owner or editor role in the league.
+type SimulationServiceClient interface {
+	// Plays the next week, or every remaining week when play_all_fixtures is set.
+	Simulate(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*SimulateResponse, error)
+	// Plays the remaining weeks one at a time and reports each as it finishes.
+	StreamSimulation(ctx context.Context, in *StreamSimulationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamSimulationResponse], error)
+	// Corrects the score of a played match and recalculates the standings.
+	EditMatch(ctx context.Context, in *EditMatchRequest, opts ...grpc.CallOption) (*EditMatchResponse, error)
+	// Sets a team's tactics for the coming weeks.
+	SetTactics(ctx context.Context, in *SetTacticsRequest, opts ...grpc.CallOption) (*SetTacticsResponse, error)
+}
+
+type simulationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulationServiceClient(cc grpc.ClientConnInterface) SimulationServiceClient {
+	return &simulationServiceClient{cc}
+}
+
+func (c *simulationServiceClient) Simulate(ctx context.Context, in *SimulateRequest, opts ...grpc.CallOption) (*SimulateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulateResponse)
+	err := c.cc.Invoke(ctx, SimulationService_Simulate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) StreamSimulation(ctx context.Context, in *StreamSimulationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamSimulationResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SimulationService_ServiceDesc.Streams[0], SimulationService_StreamSimulation_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamSimulationRequest, StreamSimulationResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamSimulationClient = grpc.ServerStreamingClient[StreamSimulationResponse]
+
+func (c *simulationServiceClient) EditMatch(ctx context.Context, in *EditMatchRequest, opts ...grpc.CallOption) (*EditMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditMatchResponse)
+	err := c.cc.Invoke(ctx, SimulationService_EditMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationServiceClient) SetTactics(ctx context.Context, in *SetTacticsRequest, opts ...grpc.CallOption) (*SetTacticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTacticsResponse)
+	err := c.cc.Invoke(ctx, SimulationService_SetTactics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulationServiceServer is the server API for SimulationService service.
+// All implementations must embed UnimplementedSimulationServiceServer
+// for forward compatibility.
+//
+// SimulationService mirrors SimulationServiceInterface. Every RPC requires the
+// owner or editor role in the league.
+type SimulationServiceServer interface {
+	// Plays the next week, or every remaining week when play_all_fixtures is set.
+	Simulate(context.Context, *SimulateRequest) (*SimulateResponse, error)
+	// Plays the remaining weeks one at a time and reports each as it finishes.
+	StreamSimulation(*StreamSimulationRequest, grpc.ServerStreamingServer[StreamSimulationResponse]) error
+	// Corrects the score of a played match and recalculates the standings.
+	EditMatch(context.Context, *EditMatchRequest) (*EditMatchResponse, error)
+	// Sets a team's tactics for the coming weeks.
+	SetTactics(context.Context, *SetTacticsRequest) (*SetTacticsResponse, error)
+	mustEmbedUnimplementedSimulationServiceServer()
+}
+
+// UnimplementedSimulationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSimulationServiceServer struct{}
+
+func (UnimplementedSimulationServiceServer) Simulate(context.Context, *SimulateRequest) (*SimulateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Simulate not implemented")
+}
+func (UnimplementedSimulationServiceServer) StreamSimulation(*StreamSimulationRequest, grpc.ServerStreamingServer[StreamSimulationResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSimulation not implemented")
+}
+func (UnimplementedSimulationServiceServer) EditMatch(context.Context, *EditMatchRequest) (*EditMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMatch not implemented")
+}
+func (UnimplementedSimulationServiceServer) SetTactics(context.Context, *SetTacticsRequest) (*SetTacticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTactics not implemented")
+}
+func (UnimplementedSimulationServiceServer) mustEmbedUnimplementedSimulationServiceServer() {}
+func (UnimplementedSimulationServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeSimulationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulationServiceServer will
+// result in compilation errors.
+type UnsafeSimulationServiceServer interface {
+	mustEmbedUnimplementedSimulationServiceServer()
+}
+
+func RegisterSimulationServiceServer(s grpc.ServiceRegistrar, srv SimulationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSimulationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SimulationService_ServiceDesc, srv)
+}
+
+func _SimulationService_Simulate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).Simulate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_Simulate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).Simulate(ctx, req.(*SimulateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_StreamSimulation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSimulationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SimulationServiceServer).StreamSimulation(m, &grpc.GenericServerStream[StreamSimulationRequest, StreamSimulationResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SimulationService_StreamSimulationServer = grpc.ServerStreamingServer[StreamSimulationResponse]
+
+func _SimulationService_EditMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).EditMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_EditMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).EditMatch(ctx, req.(*EditMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationService_SetTactics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTacticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationServiceServer).SetTactics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulationService_SetTactics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationServiceServer).SetTactics(ctx, req.(*SetTacticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimulationService_ServiceDesc is the grpc.ServiceDesc for SimulationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "leaguesim.v1.SimulationService",
+	HandlerType: (*SimulationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Simulate",
+			Handler:    _SimulationService_Simulate_Handler,
+		},
+		{
+			MethodName: "EditMatch",
+			Handler:    _SimulationService_EditMatch_Handler,
+		},
+		{
+			MethodName: "SetTactics",
+			Handler:    _SimulationService_SetTactics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSimulation",
+			Handler:       _SimulationService_StreamSimulation_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "leaguesim/v1/simulation.proto",
+}
@@ -0,0 +1,1018 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: leaguesim/v1/types.proto
+
+package leaguesimv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Tactics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Formation     string                 `protobuf:"bytes,1,opt,name=formation,proto3" json:"formation,omitempty"`
+	Pressing      string                 `protobuf:"bytes,2,opt,name=pressing,proto3" json:"pressing,omitempty"`
+	DefensiveLine string                 `protobuf:"bytes,3,opt,name=defensive_line,json=defensiveLine,proto3" json:"defensive_line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tactics) Reset() {
+	*x = Tactics{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tactics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tactics) ProtoMessage() {}
+
+func (x *Tactics) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tactics.ProtoReflect.Descriptor instead.
+func (*Tactics) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tactics) GetFormation() string {
+	if x != nil {
+		return x.Formation
+	}
+	return ""
+}
+
+func (x *Tactics) GetPressing() string {
+	if x != nil {
+		return x.Pressing
+	}
+	return ""
+}
+
+func (x *Tactics) GetDefensiveLine() string {
+	if x != nil {
+		return x.DefensiveLine
+	}
+	return ""
+}
+
+type Team struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AttackPower  float64                `protobuf:"fixed64,2,opt,name=attack_power,json=attackPower,proto3" json:"attack_power,omitempty"`
+	DefensePower float64                `protobuf:"fixed64,3,opt,name=defense_power,json=defensePower,proto3" json:"defense_power,omitempty"`
+	Morale       float64                `protobuf:"fixed64,4,opt,name=morale,proto3" json:"morale,omitempty"`
+	Stamina      float64                `protobuf:"fixed64,5,opt,name=stamina,proto3" json:"stamina,omitempty"`
+	Tactics      *Tactics               `protobuf:"bytes,6,opt,name=tactics,proto3" json:"tactics,omitempty"`
+	// Elo rating, zero until the team's league plays a match.
+	Rating        float64 `protobuf:"fixed64,7,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Team) GetAttackPower() float64 {
+	if x != nil {
+		return x.AttackPower
+	}
+	return 0
+}
+
+func (x *Team) GetDefensePower() float64 {
+	if x != nil {
+		return x.DefensePower
+	}
+	return 0
+}
+
+func (x *Team) GetMorale() float64 {
+	if x != nil {
+		return x.Morale
+	}
+	return 0
+}
+
+func (x *Team) GetStamina() float64 {
+	if x != nil {
+		return x.Stamina
+	}
+	return 0
+}
+
+func (x *Team) GetTactics() *Tactics {
+	if x != nil {
+		return x.Tactics
+	}
+	return nil
+}
+
+func (x *Team) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+type Standing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	Goals         int32                  `protobuf:"varint,2,opt,name=goals,proto3" json:"goals,omitempty"`
+	Against       int32                  `protobuf:"varint,3,opt,name=against,proto3" json:"against,omitempty"`
+	Played        int32                  `protobuf:"varint,4,opt,name=played,proto3" json:"played,omitempty"`
+	Wins          int32                  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,6,opt,name=losses,proto3" json:"losses,omitempty"`
+	Points        int32                  `protobuf:"varint,7,opt,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Standing) Reset() {
+	*x = Standing{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standing) ProtoMessage() {}
+
+func (x *Standing) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standing.ProtoReflect.Descriptor instead.
+func (*Standing) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Standing) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+func (x *Standing) GetGoals() int32 {
+	if x != nil {
+		return x.Goals
+	}
+	return 0
+}
+
+func (x *Standing) GetAgainst() int32 {
+	if x != nil {
+		return x.Against
+	}
+	return 0
+}
+
+func (x *Standing) GetPlayed() int32 {
+	if x != nil {
+		return x.Played
+	}
+	return 0
+}
+
+func (x *Standing) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *Standing) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *Standing) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+type Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Home          *Team                  `protobuf:"bytes,1,opt,name=home,proto3" json:"home,omitempty"`
+	Away          *Team                  `protobuf:"bytes,2,opt,name=away,proto3" json:"away,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Match) GetHome() *Team {
+	if x != nil {
+		return x.Home
+	}
+	return nil
+}
+
+func (x *Match) GetAway() *Team {
+	if x != nil {
+		return x.Away
+	}
+	return nil
+}
+
+type Week struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Matches       []*Match               `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Week) Reset() {
+	*x = Week{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Week) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Week) ProtoMessage() {}
+
+func (x *Week) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Week.ProtoReflect.Descriptor instead.
+func (*Week) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Week) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Week) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type MatchResult struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MatchWeek int32                  `protobuf:"varint,1,opt,name=match_week,json=matchWeek,proto3" json:"match_week,omitempty"`
+	Home      string                 `protobuf:"bytes,2,opt,name=home,proto3" json:"home,omitempty"`
+	HomeScore int32                  `protobuf:"varint,3,opt,name=home_score,json=homeScore,proto3" json:"home_score,omitempty"`
+	Away      string                 `protobuf:"bytes,4,opt,name=away,proto3" json:"away,omitempty"`
+	AwayScore int32                  `protobuf:"varint,5,opt,name=away_score,json=awayScore,proto3" json:"away_score,omitempty"`
+	// Empty for a draw.
+	Winner        string `protobuf:"bytes,6,opt,name=winner,proto3" json:"winner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResult) Reset() {
+	*x = MatchResult{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResult) ProtoMessage() {}
+
+func (x *MatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResult.ProtoReflect.Descriptor instead.
+func (*MatchResult) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MatchResult) GetMatchWeek() int32 {
+	if x != nil {
+		return x.MatchWeek
+	}
+	return 0
+}
+
+func (x *MatchResult) GetHome() string {
+	if x != nil {
+		return x.Home
+	}
+	return ""
+}
+
+func (x *MatchResult) GetHomeScore() int32 {
+	if x != nil {
+		return x.HomeScore
+	}
+	return 0
+}
+
+func (x *MatchResult) GetAway() string {
+	if x != nil {
+		return x.Away
+	}
+	return ""
+}
+
+func (x *MatchResult) GetAwayScore() int32 {
+	if x != nil {
+		return x.AwayScore
+	}
+	return 0
+}
+
+func (x *MatchResult) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+type LeagueRules struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PointsForWin    int32                  `protobuf:"varint,1,opt,name=points_for_win,json=pointsForWin,proto3" json:"points_for_win,omitempty"`
+	PointsForDraw   int32                  `protobuf:"varint,2,opt,name=points_for_draw,json=pointsForDraw,proto3" json:"points_for_draw,omitempty"`
+	PointsForLoss   int32                  `protobuf:"varint,3,opt,name=points_for_loss,json=pointsForLoss,proto3" json:"points_for_loss,omitempty"`
+	HomeAdvantage   float64                `protobuf:"fixed64,4,opt,name=home_advantage,json=homeAdvantage,proto3" json:"home_advantage,omitempty"`
+	SquadCap        int32                  `protobuf:"varint,5,opt,name=squad_cap,json=squadCap,proto3" json:"squad_cap,omitempty"`
+	StrengthWeights *StrengthWeights       `protobuf:"bytes,6,opt,name=strength_weights,json=strengthWeights,proto3" json:"strength_weights,omitempty"`
+	Draw            *DrawModel             `protobuf:"bytes,7,opt,name=draw,proto3" json:"draw,omitempty"`
+	BonusPoints     *BonusPoints           `protobuf:"bytes,8,opt,name=bonus_points,json=bonusPoints,proto3" json:"bonus_points,omitempty"`
+	// Unset keeps the engine's evenly spread scores.
+	GoalRates *GoalRates `protobuf:"bytes,9,opt,name=goal_rates,json=goalRates,proto3" json:"goal_rates,omitempty"`
+	// Unset keeps the default Elo settings, used only for rating history.
+	Elo           *EloRules `protobuf:"bytes,10,opt,name=elo,proto3" json:"elo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeagueRules) Reset() {
+	*x = LeagueRules{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeagueRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeagueRules) ProtoMessage() {}
+
+func (x *LeagueRules) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeagueRules.ProtoReflect.Descriptor instead.
+func (*LeagueRules) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LeagueRules) GetPointsForWin() int32 {
+	if x != nil {
+		return x.PointsForWin
+	}
+	return 0
+}
+
+func (x *LeagueRules) GetPointsForDraw() int32 {
+	if x != nil {
+		return x.PointsForDraw
+	}
+	return 0
+}
+
+func (x *LeagueRules) GetPointsForLoss() int32 {
+	if x != nil {
+		return x.PointsForLoss
+	}
+	return 0
+}
+
+func (x *LeagueRules) GetHomeAdvantage() float64 {
+	if x != nil {
+		return x.HomeAdvantage
+	}
+	return 0
+}
+
+func (x *LeagueRules) GetSquadCap() int32 {
+	if x != nil {
+		return x.SquadCap
+	}
+	return 0
+}
+
+func (x *LeagueRules) GetStrengthWeights() *StrengthWeights {
+	if x != nil {
+		return x.StrengthWeights
+	}
+	return nil
+}
+
+func (x *LeagueRules) GetDraw() *DrawModel {
+	if x != nil {
+		return x.Draw
+	}
+	return nil
+}
+
+func (x *LeagueRules) GetBonusPoints() *BonusPoints {
+	if x != nil {
+		return x.BonusPoints
+	}
+	return nil
+}
+
+func (x *LeagueRules) GetGoalRates() *GoalRates {
+	if x != nil {
+		return x.GoalRates
+	}
+	return nil
+}
+
+func (x *LeagueRules) GetElo() *EloRules {
+	if x != nil {
+		return x.Elo
+	}
+	return nil
+}
+
+type StrengthWeights struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attack        float64                `protobuf:"fixed64,1,opt,name=attack,proto3" json:"attack,omitempty"`
+	Defense       float64                `protobuf:"fixed64,2,opt,name=defense,proto3" json:"defense,omitempty"`
+	Morale        float64                `protobuf:"fixed64,3,opt,name=morale,proto3" json:"morale,omitempty"`
+	Stamina       float64                `protobuf:"fixed64,4,opt,name=stamina,proto3" json:"stamina,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrengthWeights) Reset() {
+	*x = StrengthWeights{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrengthWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrengthWeights) ProtoMessage() {}
+
+func (x *StrengthWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrengthWeights.ProtoReflect.Descriptor instead.
+func (*StrengthWeights) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StrengthWeights) GetAttack() float64 {
+	if x != nil {
+		return x.Attack
+	}
+	return 0
+}
+
+func (x *StrengthWeights) GetDefense() float64 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
+func (x *StrengthWeights) GetMorale() float64 {
+	if x != nil {
+		return x.Morale
+	}
+	return 0
+}
+
+func (x *StrengthWeights) GetStamina() float64 {
+	if x != nil {
+		return x.Stamina
+	}
+	return 0
+}
+
+type DrawModel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chance        float64                `protobuf:"fixed64,1,opt,name=chance,proto3" json:"chance,omitempty"`
+	MaxGoals      int32                  `protobuf:"varint,2,opt,name=max_goals,json=maxGoals,proto3" json:"max_goals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrawModel) Reset() {
+	*x = DrawModel{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrawModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrawModel) ProtoMessage() {}
+
+func (x *DrawModel) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrawModel.ProtoReflect.Descriptor instead.
+func (*DrawModel) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DrawModel) GetChance() float64 {
+	if x != nil {
+		return x.Chance
+	}
+	return 0
+}
+
+func (x *DrawModel) GetMaxGoals() int32 {
+	if x != nil {
+		return x.MaxGoals
+	}
+	return 0
+}
+
+type GoalRates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Winner        float64                `protobuf:"fixed64,1,opt,name=winner,proto3" json:"winner,omitempty"`
+	Loser         float64                `protobuf:"fixed64,2,opt,name=loser,proto3" json:"loser,omitempty"`
+	Draw          float64                `protobuf:"fixed64,3,opt,name=draw,proto3" json:"draw,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GoalRates) Reset() {
+	*x = GoalRates{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GoalRates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoalRates) ProtoMessage() {}
+
+func (x *GoalRates) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoalRates.ProtoReflect.Descriptor instead.
+func (*GoalRates) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GoalRates) GetWinner() float64 {
+	if x != nil {
+		return x.Winner
+	}
+	return 0
+}
+
+func (x *GoalRates) GetLoser() float64 {
+	if x != nil {
+		return x.Loser
+	}
+	return 0
+}
+
+func (x *GoalRates) GetDraw() float64 {
+	if x != nil {
+		return x.Draw
+	}
+	return 0
+}
+
+type EloRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KFactor       float64                `protobuf:"fixed64,1,opt,name=k_factor,json=kFactor,proto3" json:"k_factor,omitempty"`
+	HomeAdvantage float64                `protobuf:"fixed64,2,opt,name=home_advantage,json=homeAdvantage,proto3" json:"home_advantage,omitempty"`
+	InEngine      bool                   `protobuf:"varint,3,opt,name=in_engine,json=inEngine,proto3" json:"in_engine,omitempty"`
+	InPredictor   bool                   `protobuf:"varint,4,opt,name=in_predictor,json=inPredictor,proto3" json:"in_predictor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EloRules) Reset() {
+	*x = EloRules{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EloRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EloRules) ProtoMessage() {}
+
+func (x *EloRules) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EloRules.ProtoReflect.Descriptor instead.
+func (*EloRules) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EloRules) GetKFactor() float64 {
+	if x != nil {
+		return x.KFactor
+	}
+	return 0
+}
+
+func (x *EloRules) GetHomeAdvantage() float64 {
+	if x != nil {
+		return x.HomeAdvantage
+	}
+	return 0
+}
+
+func (x *EloRules) GetInEngine() bool {
+	if x != nil {
+		return x.InEngine
+	}
+	return false
+}
+
+func (x *EloRules) GetInPredictor() bool {
+	if x != nil {
+		return x.InPredictor
+	}
+	return false
+}
+
+type BonusPoints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GoalThreshold int32                  `protobuf:"varint,1,opt,name=goal_threshold,json=goalThreshold,proto3" json:"goal_threshold,omitempty"`
+	Points        int32                  `protobuf:"varint,2,opt,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BonusPoints) Reset() {
+	*x = BonusPoints{}
+	mi := &file_leaguesim_v1_types_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BonusPoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BonusPoints) ProtoMessage() {}
+
+func (x *BonusPoints) ProtoReflect() protoreflect.Message {
+	mi := &file_leaguesim_v1_types_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BonusPoints.ProtoReflect.Descriptor instead.
+func (*BonusPoints) Descriptor() ([]byte, []int) {
+	return file_leaguesim_v1_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BonusPoints) GetGoalThreshold() int32 {
+	if x != nil {
+		return x.GoalThreshold
+	}
+	return 0
+}
+
+func (x *BonusPoints) GetPoints() int32 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+var File_leaguesim_v1_types_proto protoreflect.FileDescriptor
+
+const file_leaguesim_v1_types_proto_rawDesc = "" +
+	"\n" +
+	"\x18leaguesim/v1/types.proto\x12\fleaguesim.v1\"j\n" +
+	"\aTactics\x12\x1c\n" +
+	"\tformation\x18\x01 \x01(\tR\tformation\x12\x1a\n" +
+	"\bpressing\x18\x02 \x01(\tR\bpressing\x12%\n" +
+	"\x0edefensive_line\x18\x03 \x01(\tR\rdefensiveLine\"\xdd\x01\n" +
+	"\x04Team\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fattack_power\x18\x02 \x01(\x01R\vattackPower\x12#\n" +
+	"\rdefense_power\x18\x03 \x01(\x01R\fdefensePower\x12\x16\n" +
+	"\x06morale\x18\x04 \x01(\x01R\x06morale\x12\x18\n" +
+	"\astamina\x18\x05 \x01(\x01R\astamina\x12/\n" +
+	"\atactics\x18\x06 \x01(\v2\x15.leaguesim.v1.TacticsR\atactics\x12\x16\n" +
+	"\x06rating\x18\a \x01(\x01R\x06rating\"\xbe\x01\n" +
+	"\bStanding\x12&\n" +
+	"\x04team\x18\x01 \x01(\v2\x12.leaguesim.v1.TeamR\x04team\x12\x14\n" +
+	"\x05goals\x18\x02 \x01(\x05R\x05goals\x12\x18\n" +
+	"\aagainst\x18\x03 \x01(\x05R\aagainst\x12\x16\n" +
+	"\x06played\x18\x04 \x01(\x05R\x06played\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x06 \x01(\x05R\x06losses\x12\x16\n" +
+	"\x06points\x18\a \x01(\x05R\x06points\"W\n" +
+	"\x05Match\x12&\n" +
+	"\x04home\x18\x01 \x01(\v2\x12.leaguesim.v1.TeamR\x04home\x12&\n" +
+	"\x04away\x18\x02 \x01(\v2\x12.leaguesim.v1.TeamR\x04away\"M\n" +
+	"\x04Week\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12-\n" +
+	"\amatches\x18\x02 \x03(\v2\x13.leaguesim.v1.MatchR\amatches\"\xaa\x01\n" +
+	"\vMatchResult\x12\x1d\n" +
+	"\n" +
+	"match_week\x18\x01 \x01(\x05R\tmatchWeek\x12\x12\n" +
+	"\x04home\x18\x02 \x01(\tR\x04home\x12\x1d\n" +
+	"\n" +
+	"home_score\x18\x03 \x01(\x05R\thomeScore\x12\x12\n" +
+	"\x04away\x18\x04 \x01(\tR\x04away\x12\x1d\n" +
+	"\n" +
+	"away_score\x18\x05 \x01(\x05R\tawayScore\x12\x16\n" +
+	"\x06winner\x18\x06 \x01(\tR\x06winner\"\xde\x03\n" +
+	"\vLeagueRules\x12$\n" +
+	"\x0epoints_for_win\x18\x01 \x01(\x05R\fpointsForWin\x12&\n" +
+	"\x0fpoints_for_draw\x18\x02 \x01(\x05R\rpointsForDraw\x12&\n" +
+	"\x0fpoints_for_loss\x18\x03 \x01(\x05R\rpointsForLoss\x12%\n" +
+	"\x0ehome_advantage\x18\x04 \x01(\x01R\rhomeAdvantage\x12\x1b\n" +
+	"\tsquad_cap\x18\x05 \x01(\x05R\bsquadCap\x12H\n" +
+	"\x10strength_weights\x18\x06 \x01(\v2\x1d.leaguesim.v1.StrengthWeightsR\x0fstrengthWeights\x12+\n" +
+	"\x04draw\x18\a \x01(\v2\x17.leaguesim.v1.DrawModelR\x04draw\x12<\n" +
+	"\fbonus_points\x18\b \x01(\v2\x19.leaguesim.v1.BonusPointsR\vbonusPoints\x126\n" +
+	"\n" +
+	"goal_rates\x18\t \x01(\v2\x17.leaguesim.v1.GoalRatesR\tgoalRates\x12(\n" +
+	"\x03elo\x18\n" +
+	" \x01(\v2\x16.leaguesim.v1.EloRulesR\x03elo\"u\n" +
+	"\x0fStrengthWeights\x12\x16\n" +
+	"\x06attack\x18\x01 \x01(\x01R\x06attack\x12\x18\n" +
+	"\adefense\x18\x02 \x01(\x01R\adefense\x12\x16\n" +
+	"\x06morale\x18\x03 \x01(\x01R\x06morale\x12\x18\n" +
+	"\astamina\x18\x04 \x01(\x01R\astamina\"@\n" +
+	"\tDrawModel\x12\x16\n" +
+	"\x06chance\x18\x01 \x01(\x01R\x06chance\x12\x1b\n" +
+	"\tmax_goals\x18\x02 \x01(\x05R\bmaxGoals\"M\n" +
+	"\tGoalRates\x12\x16\n" +
+	"\x06winner\x18\x01 \x01(\x01R\x06winner\x12\x14\n" +
+	"\x05loser\x18\x02 \x01(\x01R\x05loser\x12\x12\n" +
+	"\x04draw\x18\x03 \x01(\x01R\x04draw\"\x8c\x01\n" +
+	"\bEloRules\x12\x19\n" +
+	"\bk_factor\x18\x01 \x01(\x01R\akFactor\x12%\n" +
+	"\x0ehome_advantage\x18\x02 \x01(\x01R\rhomeAdvantage\x12\x1b\n" +
+	"\tin_engine\x18\x03 \x01(\bR\binEngine\x12!\n" +
+	"\fin_predictor\x18\x04 \x01(\bR\vinPredictor\"L\n" +
+	"\vBonusPoints\x12%\n" +
+	"\x0egoal_threshold\x18\x01 \x01(\x05R\rgoalThreshold\x12\x16\n" +
+	"\x06points\x18\x02 \x01(\x05R\x06pointsB,Z*league-sim/api/rpc/leaguesimv1;leaguesimv1b\x06proto3"
+
+var (
+	file_leaguesim_v1_types_proto_rawDescOnce sync.Once
+	file_leaguesim_v1_types_proto_rawDescData []byte
+)
+
+func file_leaguesim_v1_types_proto_rawDescGZIP() []byte {
+	file_leaguesim_v1_types_proto_rawDescOnce.Do(func() {
+		file_leaguesim_v1_types_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaguesim_v1_types_proto_rawDesc), len(file_leaguesim_v1_types_proto_rawDesc)))
+	})
+	return file_leaguesim_v1_types_proto_rawDescData
+}
+
+var file_leaguesim_v1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_leaguesim_v1_types_proto_goTypes = []any{
+	(*Tactics)(nil),         // 0: leaguesim.v1.Tactics
+	(*Team)(nil),            // 1: leaguesim.v1.Team
+	(*Standing)(nil),        // 2: leaguesim.v1.Standing
+	(*Match)(nil),           // 3: leaguesim.v1.Match
+	(*Week)(nil),            // 4: leaguesim.v1.Week
+	(*MatchResult)(nil),     // 5: leaguesim.v1.MatchResult
+	(*LeagueRules)(nil),     // 6: leaguesim.v1.LeagueRules
+	(*StrengthWeights)(nil), // 7: leaguesim.v1.StrengthWeights
+	(*DrawModel)(nil),       // 8: leaguesim.v1.DrawModel
+	(*GoalRates)(nil),       // 9: leaguesim.v1.GoalRates
+	(*EloRules)(nil),        // 10: leaguesim.v1.EloRules
+	(*BonusPoints)(nil),     // 11: leaguesim.v1.BonusPoints
+}
+var file_leaguesim_v1_types_proto_depIdxs = []int32{
+	0,  // 0: leaguesim.v1.Team.tactics:type_name -> leaguesim.v1.Tactics
+	1,  // 1: leaguesim.v1.Standing.team:type_name -> leaguesim.v1.Team
+	1,  // 2: leaguesim.v1.Match.home:type_name -> leaguesim.v1.Team
+	1,  // 3: leaguesim.v1.Match.away:type_name -> leaguesim.v1.Team
+	3,  // 4: leaguesim.v1.Week.matches:type_name -> leaguesim.v1.Match
+	7,  // 5: leaguesim.v1.LeagueRules.strength_weights:type_name -> leaguesim.v1.StrengthWeights
+	8,  // 6: leaguesim.v1.LeagueRules.draw:type_name -> leaguesim.v1.DrawModel
+	11, // 7: leaguesim.v1.LeagueRules.bonus_points:type_name -> leaguesim.v1.BonusPoints
+	9,  // 8: leaguesim.v1.LeagueRules.goal_rates:type_name -> leaguesim.v1.GoalRates
+	10, // 9: leaguesim.v1.LeagueRules.elo:type_name -> leaguesim.v1.EloRules
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_leaguesim_v1_types_proto_init() }
+func file_leaguesim_v1_types_proto_init() {
+	if File_leaguesim_v1_types_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaguesim_v1_types_proto_rawDesc), len(file_leaguesim_v1_types_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_leaguesim_v1_types_proto_goTypes,
+		DependencyIndexes: file_leaguesim_v1_types_proto_depIdxs,
+		MessageInfos:      file_leaguesim_v1_types_proto_msgTypes,
+	}.Build()
+	File_leaguesim_v1_types_proto = out.File
+	file_leaguesim_v1_types_proto_goTypes = nil
+	file_leaguesim_v1_types_proto_depIdxs = nil
+}
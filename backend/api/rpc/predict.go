@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"context"
+
+	"league-sim/api/rpc/leaguesimv1"
+)
+
+type predictServer struct {
+	leaguesimv1.UnimplementedPredictServiceServer
+	base
+}
+
+func (s *predictServer) PredictChampionship(
+	ctx context.Context, req *leaguesimv1.PredictChampionshipRequest,
+) (*leaguesimv1.PredictChampionshipResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), canRead); err != nil {
+		return nil, err
+	}
+
+	predictions, err := s.services.PredictService().PredictChampionShipSession(ctx, req.GetLeagueId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.PredictChampionshipResponse{Predictions: predictionsToProto(predictions)}, nil
+}
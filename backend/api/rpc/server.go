@@ -0,0 +1,269 @@
+// Package rpc serves the league services over gRPC for programmatic clients. It
+// runs on its own port next to the HTTP API and shares its API keys, roles and
+// rate limits. The protobuf definitions live in proto/leaguesim/v1 and the
+// leaguesimv1 package is generated from them with `buf generate`.
+package rpc
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"league-sim/api/rpc/leaguesimv1"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/audit"
+	"league-sim/internal/auth"
+	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/contexts/services"
+	"league-sim/internal/models"
+	"league-sim/internal/ratelimit"
+	"league-sim/internal/validation"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Metadata keys read from incoming calls.
+const (
+	metadataAuthorization = "authorization"
+	metadataRequestID     = "x-request-id"
+)
+
+var (
+	canRead   = []string{models.RoleOwner, models.RoleEditor, models.RoleViewer}
+	canEdit   = []string{models.RoleOwner, models.RoleEditor}
+	ownerOnly = []string{models.RoleOwner}
+)
+
+// NewServer registers the league, simulation and predict services and server
+// reflection on a new gRPC server. Calls spend tokens from limits, the store
+// the HTTP API throttles with.
+func NewServer(appCtx appContext.AppContext, services services.Service, limits ratelimit.Store) *grpc.Server {
+	cfg := appCtx.Config()
+	interceptors := &interceptors{
+		services: services,
+		limits:   limits,
+		perIP:    ratelimit.Rate(cfg.RateLimit.PerIP),
+		perKey:   ratelimit.Rate(cfg.RateLimit.PerKey),
+		timeout:  cfg.Timeouts.Request,
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptors.unary),
+		grpc.ChainStreamInterceptor(interceptors.stream))
+
+	base := base{
+		appCtx:   appCtx,
+		services: services,
+		validate: validation.New(cfg.Limits),
+		timeout:  cfg.Timeouts.Request,
+	}
+	leaguesimv1.RegisterLeagueServiceServer(server, &leagueServer{base: base})
+	leaguesimv1.RegisterSimulationServiceServer(server, &simulationServer{base: base})
+	leaguesimv1.RegisterPredictServiceServer(server, &predictServer{base: base})
+	reflection.Register(server)
+
+	return server
+}
+
+// Serve accepts calls on port until the server stops.
+func Serve(server *grpc.Server, port string) error {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	return server.Serve(listener)
+}
+
+// base is what the service implementations share.
+type base struct {
+	appCtx   appContext.AppContext
+	services services.Service
+	validate *validation.Validator
+	timeout  time.Duration
+}
+
+// requireRole only lets through callers holding one of roles in the league,
+// like the router's RequireLeagueRole. Callers with no role at all get not
+// found so that other users' leagues stay invisible.
+func (b *base) requireRole(ctx context.Context, leagueId string, roles []string) error {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return apperrors.Unauthorized("authentication required")
+	}
+
+	role, err := b.appCtx.LeagueRepository().GetMemberRole(ctx, leagueId, user.UserId)
+	if err != nil {
+		return err
+	}
+
+	if role == "" {
+		return apperrors.NotFound("league %s not found", leagueId)
+	}
+
+	if !slices.Contains(roles, role) {
+		return apperrors.Forbidden("the %s role may not perform this operation", role)
+	}
+
+	return nil
+}
+
+type interceptors struct {
+	services services.Service
+	limits   ratelimit.Store
+	perIP    ratelimit.Rate
+	perKey   ratelimit.Rate
+	timeout  time.Duration
+}
+
+func (i *interceptors) unary(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := i.prepare(ctx, info.FullMethod)
+	if err != nil {
+		return nil, failure(info.FullMethod, err)
+	}
+
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, failure(info.FullMethod, err)
+	}
+
+	return resp, nil
+}
+
+// stream prepares streaming calls like unary ones but leaves their deadline to
+// the handler, which may run for many request timeouts.
+func (i *interceptors) stream(
+	srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	ctx, err := i.prepare(ss.Context(), info.FullMethod)
+	if err != nil {
+		return failure(info.FullMethod, err)
+	}
+
+	if err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx}); err != nil {
+		return failure(info.FullMethod, err)
+	}
+
+	return nil
+}
+
+// prepare throttles the call by client address, resolves the
+// "authorization: Bearer <api key>" metadata to a user, throttles by API key
+// and tags the context with a request ID for the audit log. Reflection is
+// served to anyone so that tools can list the services.
+func (i *interceptors) prepare(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, "/grpc.reflection.") {
+		return ctx, nil
+	}
+
+	if err := i.take(ctx, "ip:"+clientIP(ctx), i.perIP); err != nil {
+		return nil, err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	apiKey, ok := auth.BearerToken(firstValue(md, metadataAuthorization))
+	if !ok {
+		return nil, apperrors.Unauthorized("missing bearer token")
+	}
+
+	user, err := i.services.UserService().Authenticate(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.take(ctx, "key:"+user.UserId, i.perKey); err != nil {
+		return nil, err
+	}
+
+	requestId := firstValue(md, metadataRequestID)
+	if requestId == "" {
+		requestId = uuid.NewString()
+	}
+
+	return audit.WithRequestID(auth.WithUser(ctx, user), requestId), nil
+}
+
+// take spends a token from the bucket of key. Like the HTTP middleware, a
+// failing store lets calls through rather than taking the API down with it.
+func (i *interceptors) take(ctx context.Context, key string, rate ratelimit.Rate) error {
+	if !rate.Enabled() {
+		return nil
+	}
+
+	result, err := i.limits.Take(ctx, key, rate)
+	if err != nil {
+		log.Printf("rate limit: %v", err)
+		return nil
+	}
+
+	if result.Allowed {
+		return nil
+	}
+
+	retryAfter := time.Duration(math.Ceil(result.RetryAfter.Seconds())) * time.Second
+	st := statusOf(apperrors.RateLimited("rate limit exceeded, retry in %d seconds", int(retryAfter.Seconds())))
+
+	return withDetails(st, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}).Err()
+}
+
+// failure converts err to the status returned to the client and logs the
+// failures that are the server's fault.
+func failure(method string, err error) error {
+	st := statusOf(err)
+	if st.Code() == codes.Internal {
+		log.Printf("grpc %s: %v", method, err)
+	}
+
+	return st.Err()
+}
+
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// serverStream replaces the context of a stream with the prepared one.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,432 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"league-sim/api/rpc/leaguesimv1"
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	appContext "league-sim/internal/contexts/appContexts"
+	leagueInterfaces "league-sim/internal/league/interfaces"
+	"league-sim/internal/models"
+	predictInterfaces "league-sim/internal/predict/interfaces"
+	"league-sim/internal/ratelimit"
+	"league-sim/internal/repositories/interfaces"
+	simulationInterfaces "league-sim/internal/simulation/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// MockAppContext for testing
+type MockAppContext struct {
+	mock.Mock
+}
+
+func (m *MockAppContext) LeagueRepository() interfaces.LeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.LeagueRepository)
+}
+
+func (m *MockAppContext) ActiveLeagueRepository() interfaces.ActiveLeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.ActiveLeagueRepository)
+}
+
+func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.MatchResultRepository)
+}
+
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
+func (m *MockAppContext) DB() *appContext.DB {
+	args := m.Called()
+	return args.Get(0).(*appContext.DB)
+}
+
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
+// MockService for testing
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) LeagueService() leagueInterfaces.LeagueServiceInterface {
+	args := m.Called()
+	return args.Get(0).(leagueInterfaces.LeagueServiceInterface)
+}
+
+func (m *MockService) SimulationService() simulationInterfaces.SimulationServiceInterface {
+	args := m.Called()
+	return args.Get(0).(simulationInterfaces.SimulationServiceInterface)
+}
+
+func (m *MockService) PredictService() predictInterfaces.PredictServiceInterface {
+	args := m.Called()
+	return args.Get(0).(predictInterfaces.PredictServiceInterface)
+}
+
+func (m *MockService) UserService() userInterfaces.UserServiceInterface {
+	args := m.Called()
+	return args.Get(0).(userInterfaces.UserServiceInterface)
+}
+
+const testAPIKey = "lsk_test"
+
+var testUser = models.User{UserId: "user-id", Username: "alice"}
+
+type testMocks struct {
+	appCtx     *MockAppContext
+	services   *MockService
+	leagueRepo *interfaces.MockLeagueRepository
+	activeRepo *interfaces.MockActiveLeagueRepository
+	league     *leagueInterfaces.MockLeagueServiceInterface
+	simulation *simulationInterfaces.MockSimulationServiceInterface
+	predict    *predictInterfaces.MockPredictServiceInterface
+	users      *userInterfaces.MockUserServiceInterface
+	cfg        *config.Config
+}
+
+func newTestMocks() *testMocks {
+	m := &testMocks{
+		appCtx:     &MockAppContext{},
+		services:   &MockService{},
+		leagueRepo: &interfaces.MockLeagueRepository{},
+		activeRepo: &interfaces.MockActiveLeagueRepository{},
+		league:     &leagueInterfaces.MockLeagueServiceInterface{},
+		simulation: &simulationInterfaces.MockSimulationServiceInterface{},
+		predict:    &predictInterfaces.MockPredictServiceInterface{},
+		users:      &userInterfaces.MockUserServiceInterface{},
+		cfg:        config.Default(),
+	}
+	m.appCtx.On("LeagueRepository").Return(m.leagueRepo)
+	m.appCtx.On("ActiveLeagueRepository").Return(m.activeRepo)
+	m.appCtx.On("Config").Return(m.cfg)
+	m.services.On("LeagueService").Return(m.league)
+	m.services.On("SimulationService").Return(m.simulation)
+	m.services.On("PredictService").Return(m.predict)
+	m.services.On("UserService").Return(m.users)
+	m.users.On("Authenticate", mock.Anything, testAPIKey).Return(testUser, nil)
+
+	return m
+}
+
+func (m *testMocks) withRole(leagueId string, role string) *testMocks {
+	m.leagueRepo.On("GetMemberRole", mock.Anything, leagueId, testUser.UserId).Return(role, nil)
+	return m
+}
+
+// dial serves the mocks over an in-memory connection and returns a client
+// connection that sends the test API key.
+func (m *testMocks) dial(t *testing.T) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(m.appCtx, m.services, ratelimit.NewMemoryStore())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func authed() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testAPIKey)
+}
+
+func TestAuthentication(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "Missing key", header: ""},
+		{name: "Unknown key", header: "Bearer lsk_unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				m := newTestMocks()
+				m.users.On("Authenticate", mock.Anything, "lsk_unknown").
+					Return(models.User{}, apperrors.Unauthorized("invalid api key"))
+				client := leaguesimv1.NewPredictServiceClient(m.dial(t))
+				ctx := context.Background()
+				if tt.header != "" {
+					ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.header)
+				}
+
+				// Execute
+				_, err := client.PredictChampionship(ctx, &leaguesimv1.PredictChampionshipRequest{LeagueId: "league-1"})
+
+				// Assert
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+				m.predict.AssertNotCalled(t, "PredictChampionShipSession", mock.Anything, mock.Anything)
+			})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		code codes.Code
+	}{
+		{name: "Editor", role: models.RoleEditor, code: codes.OK},
+		{name: "Viewer", role: models.RoleViewer, code: codes.PermissionDenied},
+		{name: "Not a member", role: "", code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				m := newTestMocks().withRole("league-1", tt.role)
+				m.league.On("ResetLeague", mock.Anything, "league-1").Return(nil)
+				client := leaguesimv1.NewLeagueServiceClient(m.dial(t))
+
+				// Execute
+				_, err := client.ResetLeague(authed(), &leaguesimv1.ResetLeagueRequest{LeagueId: "league-1"})
+
+				// Assert
+				assert.Equal(t, tt.code, status.Code(err))
+			})
+	}
+}
+
+func TestCreateLeague_Success(t *testing.T) {
+	// Setup
+	m := newTestMocks()
+	m.league.On("CreateLeague", mock.Anything, "4", "Premier", (*models.LeagueRules)(nil)).
+		Return(models.GetLeaguesIdsWithNameResponse{LeagueId: "league-1", LeagueName: "Premier"}, nil)
+	client := leaguesimv1.NewLeagueServiceClient(m.dial(t))
+
+	// Execute
+	resp, err := client.CreateLeague(authed(), &leaguesimv1.CreateLeagueRequest{TeamCount: 4, LeagueName: "Premier"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "league-1", resp.GetLeagueId())
+	assert.Equal(t, "Premier", resp.GetLeagueName())
+}
+
+func TestCreateLeague_InvalidArgument(t *testing.T) {
+	// Setup
+	m := newTestMocks()
+	client := leaguesimv1.NewLeagueServiceClient(m.dial(t))
+
+	// Execute
+	_, err := client.CreateLeague(authed(), &leaguesimv1.CreateLeagueRequest{TeamCount: 1})
+
+	// Assert
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	var fields []string
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields = append(fields, violation.GetField())
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"leagueName", "teamCount"}, fields)
+	m.league.AssertNotCalled(t, "CreateLeague", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddMember_MapsRole(t *testing.T) {
+	// Setup
+	m := newTestMocks().withRole("league-1", models.RoleOwner)
+	userId := "0f8fad5b-d9cb-469f-a165-70867728950e"
+	m.league.On("AddMember", mock.Anything, "league-1", userId, models.RoleViewer).Return(nil)
+	client := leaguesimv1.NewLeagueServiceClient(m.dial(t))
+
+	// Execute
+	_, err := client.AddMember(
+		authed(), &leaguesimv1.AddMemberRequest{
+			LeagueId: "league-1",
+			UserId:   userId,
+			Role:     leaguesimv1.MemberRole_MEMBER_ROLE_VIEWER,
+		})
+
+	// Assert
+	require.NoError(t, err)
+	m.league.AssertExpectations(t)
+}
+
+func TestSimulate_NothingLeft(t *testing.T) {
+	// Setup
+	m := newTestMocks().withRole("league-1", models.RoleOwner)
+	m.simulation.On("Simulation", mock.Anything, "league-1", true).Return(models.SimulationResponse{}, nil)
+	client := leaguesimv1.NewSimulationServiceClient(m.dial(t))
+
+	// Execute
+	_, err := client.Simulate(authed(), &leaguesimv1.SimulateRequest{LeagueId: "league-1", PlayAllFixtures: true})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestStreamSimulation(t *testing.T) {
+	tests := []struct {
+		name  string
+		weeks int32
+		sent  []int32
+	}{
+		{name: "Rest of the season", weeks: 0, sent: []int32{2, 3}},
+		{name: "Fewer weeks", weeks: 1, sent: []int32{2}},
+		{name: "More weeks than left", weeks: 5, sent: []int32{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				m := newTestMocks().withRole("league-1", models.RoleEditor)
+				m.activeRepo.On("GetActiveLeague", mock.Anything, "league-1").Return(
+					models.League{LeagueID: "league-1", UpcomingFixtures: []models.Week{{Number: 2}, {Number: 3}}}, nil)
+				for _, week := range []int{2, 3} {
+					m.simulation.On("Simulation", mock.Anything, "league-1", false).Return(
+						models.SimulationResponse{
+							Matches: []models.MatchResult{
+								{MatchWeek: week, Home: "Lions", HomeScore: 2, Away: "Tigers", Winner: "Lions"},
+							},
+						}, nil).Once()
+				}
+				m.activeRepo.On("GetActiveLeaguesStandings", mock.Anything, "league-1").Return(
+					[]models.Standings{
+						{Team: models.Team{Name: "Tigers"}, Played: 1, Losses: 1},
+						{Team: models.Team{Name: "Lions"}, Played: 1, Wins: 1, Points: 3},
+					}, nil)
+				client := leaguesimv1.NewSimulationServiceClient(m.dial(t))
+
+				// Execute
+				stream, err := client.StreamSimulation(
+					authed(), &leaguesimv1.StreamSimulationRequest{LeagueId: "league-1", Weeks: tt.weeks})
+				require.NoError(t, err)
+
+				var received []*leaguesimv1.StreamSimulationResponse
+				for {
+					progress, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					require.NoError(t, err)
+					received = append(received, progress)
+				}
+
+				// Assert
+				require.Len(t, received, len(tt.sent))
+				for i, progress := range received {
+					assert.Equal(t, tt.sent[i], progress.GetWeek())
+					assert.Equal(t, int32(i+1), progress.GetWeeksPlayed())
+					assert.Equal(t, int32(len(tt.sent)), progress.GetWeeksTotal())
+					assert.Len(t, progress.GetMatches(), 1)
+					assert.Equal(t, "Lions", progress.GetStandings()[0].GetTeam().GetName())
+				}
+			})
+	}
+}
+
+func TestStreamSimulation_SeasonFinished(t *testing.T) {
+	// Setup
+	m := newTestMocks().withRole("league-1", models.RoleOwner)
+	m.activeRepo.On("GetActiveLeague", mock.Anything, "league-1").Return(models.League{LeagueID: "league-1"}, nil)
+	client := leaguesimv1.NewSimulationServiceClient(m.dial(t))
+
+	// Execute
+	stream, err := client.StreamSimulation(authed(), &leaguesimv1.StreamSimulationRequest{LeagueId: "league-1"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	m.simulation.AssertNotCalled(t, "Simulation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPredictChampionship_Viewer(t *testing.T) {
+	// Setup
+	m := newTestMocks().withRole("league-1", models.RoleViewer)
+	m.predict.On("PredictChampionShipSession", mock.Anything, "league-1").Return(
+		[]models.PredictedStanding{{TeamName: "Lions", Points: 9, Strength: 85, Odds: 72.5}}, nil)
+	client := leaguesimv1.NewPredictServiceClient(m.dial(t))
+
+	// Execute
+	resp, err := client.PredictChampionship(authed(), &leaguesimv1.PredictChampionshipRequest{LeagueId: "league-1"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, resp.GetPredictions(), 1)
+	assert.Equal(t, "Lions", resp.GetPredictions()[0].GetTeamName())
+	assert.Equal(t, 72.5, resp.GetPredictions()[0].GetOdds())
+}
+
+func TestRateLimit(t *testing.T) {
+	// Setup
+	m := newTestMocks().withRole("league-1", models.RoleViewer)
+	m.cfg.RateLimit.PerKey = config.RateConfig{PerMinute: 1, Burst: 1}
+	m.predict.On("PredictChampionShipSession", mock.Anything, "league-1").Return([]models.PredictedStanding{}, nil)
+	client := leaguesimv1.NewPredictServiceClient(m.dial(t))
+	req := &leaguesimv1.PredictChampionshipRequest{LeagueId: "league-1"}
+
+	// Execute
+	_, first := client.PredictChampionship(authed(), req)
+	_, second := client.PredictChampionship(authed(), req)
+
+	// Assert
+	require.NoError(t, first)
+	st := status.Convert(second)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	require.NotNil(t, retryInfo)
+	assert.Equal(t, int64(60), retryInfo.GetRetryDelay().GetSeconds())
+}
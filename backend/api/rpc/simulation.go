@@ -0,0 +1,167 @@
+package rpc
+
+import (
+	"context"
+
+	"league-sim/api/rpc/leaguesimv1"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+
+	"google.golang.org/grpc/status"
+)
+
+type simulationServer struct {
+	leaguesimv1.UnimplementedSimulationServiceServer
+	base
+}
+
+func (s *simulationServer) Simulate(
+	ctx context.Context, req *leaguesimv1.SimulateRequest,
+) (*leaguesimv1.SimulateResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), canEdit); err != nil {
+		return nil, err
+	}
+
+	result, err := s.services.SimulationService().Simulation(ctx, req.GetLeagueId(), req.GetPlayAllFixtures())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Matches) == 0 {
+		return nil, apperrors.Conflict("no matches left to simulate in league %s", req.GetLeagueId())
+	}
+
+	return &leaguesimv1.SimulateResponse{
+		Matches:          matchResultsToProto(result.Matches),
+		UpcomingFixtures: weeksToProto(result.UpcomingFixtures),
+		PlayedFixtures:   weeksToProto(result.PlayedFixtures),
+	}, nil
+}
+
+// StreamSimulation plays the requested weeks one by one, sending the results
+// and the table after each. Every week gets the request timeout of its own, and
+// a client that goes away stops the simulation after the week in progress.
+func (s *simulationServer) StreamSimulation(
+	req *leaguesimv1.StreamSimulationRequest, stream leaguesimv1.SimulationService_StreamSimulationServer,
+) error {
+	ctx := stream.Context()
+	leagueId := req.GetLeagueId()
+	if req.GetWeeks() < 0 {
+		return apperrors.InvalidFields(apperrors.FieldError{Field: "weeks", Message: "must not be negative"})
+	}
+
+	if err := s.requireRole(ctx, leagueId, canEdit); err != nil {
+		return err
+	}
+
+	current, err := s.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
+	if err != nil {
+		return err
+	}
+
+	total := len(current.UpcomingFixtures)
+	if weeks := int(req.GetWeeks()); weeks > 0 && weeks < total {
+		total = weeks
+	}
+
+	if total == 0 {
+		return apperrors.Conflict("no matches left to simulate in league %s", leagueId)
+	}
+
+	for played := 1; played <= total; played++ {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		progress, err := s.simulateWeek(ctx, leagueId)
+		if err != nil {
+			return err
+		}
+
+		progress.WeeksPlayed = int32(played)
+		progress.WeeksTotal = int32(total)
+		if err := stream.Send(progress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *simulationServer) simulateWeek(
+	ctx context.Context, leagueId string,
+) (*leaguesimv1.StreamSimulationResponse, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	result, err := s.services.SimulationService().Simulation(ctx, leagueId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Another client may have finished the season in the meantime.
+	if len(result.Matches) == 0 {
+		return nil, apperrors.Conflict("no matches left to simulate in league %s", leagueId)
+	}
+
+	standings, err := s.appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(ctx, leagueId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.StreamSimulationResponse{
+		Week:      int32(result.Matches[0].MatchWeek),
+		Matches:   matchResultsToProto(result.Matches),
+		Standings: standingsToProto(league.RankStandings(standings)),
+	}, nil
+}
+
+func (s *simulationServer) EditMatch(
+	ctx context.Context, req *leaguesimv1.EditMatchRequest,
+) (*leaguesimv1.EditMatchResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), canEdit); err != nil {
+		return nil, err
+	}
+
+	body := models.EditMatchResult{
+		LeagueId:  req.GetLeagueId(),
+		Home:      req.GetHome(),
+		Away:      req.GetAway(),
+		HomeScore: int(req.GetHomeScore()),
+		AwayScore: int(req.GetAwayScore()),
+		MatchWeek: int(req.GetMatchWeek()),
+	}
+	if err := s.validate.Validate(&body); err != nil {
+		return nil, err
+	}
+
+	if err := s.services.SimulationService().EditMatch(ctx, body); err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.EditMatchResponse{}, nil
+}
+
+func (s *simulationServer) SetTactics(
+	ctx context.Context, req *leaguesimv1.SetTacticsRequest,
+) (*leaguesimv1.SetTacticsResponse, error) {
+	if err := s.requireRole(ctx, req.GetLeagueId(), canEdit); err != nil {
+		return nil, err
+	}
+
+	body := models.SetTacticsRequest{Team: req.GetTeamName(), Tactics: tacticsFromProto(req.GetTactics())}
+	if err := s.validate.Validate(&body); err != nil {
+		return nil, err
+	}
+
+	err := s.services.SimulationService().SetTactics(ctx, req.GetLeagueId(), body.Team, body.Tactics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &leaguesimv1.SetTacticsResponse{}, nil
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"league-sim/api"
 	"league-sim/config"
 	appContext "league-sim/internal/contexts/appContexts"
@@ -8,9 +10,12 @@ import (
 )
 
 func main() {
-	config.LoadConfig()
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		panic(err)
+	}
 
-	appCtx, err := appContext.AppContextInit()
+	appCtx, err := appContext.AppContextInit(cfg)
 	if err != nil {
 		panic(err)
 	}
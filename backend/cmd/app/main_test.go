@@ -10,11 +10,16 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// withArgs replaces os.Args so that main does not see the go test flags
+func withArgs(t testing.TB, args ...string) {
+	original := os.Args
+	os.Args = append([]string{"app"}, args...)
+	t.Cleanup(func() { os.Args = original })
+}
+
 func TestMain_ConfigLoad(t *testing.T) {
 	// Create a temporary .env file for testing
 	envContent := `HTTP_PORT=8080
-REDIS_HOST=localhost
-REDIS_PORT=6379
 MYSQL_HOST=localhost
 MYSQL_PORT=3306
 MYSQL_USER=testuser
@@ -25,37 +30,31 @@ MYSQL_DATABASE=testdb`
 	assert.NoError(t, err)
 	defer os.Remove(".env")
 
-	// Test that config.LoadConfig() doesn't panic
-	assert.NotPanics(t, func() {
-		config.LoadConfig()
-	}, "LoadConfig should not panic with valid .env file")
+	// Test that config.Load() picks up the .env file
+	cfg, err := config.Load(nil)
 
 	// Verify config values are loaded
-	assert.Equal(t, "8080", config.HTTPPort)
-	assert.Equal(t, "localhost", config.RedisHost)
-	assert.Equal(t, "6379", config.RedisPort)
-	assert.Equal(t, "localhost", config.MySQLHost)
-	assert.Equal(t, "3306", config.MySQLPort)
-	assert.Equal(t, "testuser", config.MySQLUser)
-	assert.Equal(t, "testpass", config.MySQLPassword)
-	assert.Equal(t, "testdb", config.MySQLDatabase)
+	assert.NoError(t, err)
+	assert.Equal(t, "8080", cfg.HTTP.Port)
+	assert.Equal(t, "localhost", cfg.MySQL.Host)
+	assert.Equal(t, "3306", cfg.MySQL.Port)
+	assert.Equal(t, "testuser", cfg.MySQL.User)
+	assert.Equal(t, "testpass", cfg.MySQL.Password)
+	assert.Equal(t, "testdb", cfg.MySQL.Database)
 }
 
-func TestMain_ConfigLoadPanic(t *testing.T) {
-	// Remove .env file to cause panic
-	os.Remove(".env")
+func TestMain_InvalidFlagPanics(t *testing.T) {
+	withArgs(t, "--no-such-flag")
 
-	// Test that config.LoadConfig() panics without .env file
+	// Test that main panics when the config cannot be loaded
 	assert.Panics(t, func() {
-		config.LoadConfig()
-	}, "LoadConfig should panic without .env file")
+		main()
+	}, "Main should panic when the config cannot be loaded")
 }
 
 func TestMain_Integration(t *testing.T) {
 	// Create a temporary .env file for testing
 	envContent := `HTTP_PORT=0
-REDIS_HOST=localhost
-REDIS_PORT=6379
 MYSQL_HOST=localhost
 MYSQL_PORT=3306
 MYSQL_USER=testuser
@@ -66,6 +65,8 @@ MYSQL_DATABASE=testdb`
 	assert.NoError(t, err)
 	defer os.Remove(".env")
 
+	withArgs(t)
+
 	// Test main function execution in a goroutine
 	// Note: This will likely fail due to database connection issues, but we're testing the flow
 	done := make(chan bool, 1)
@@ -93,8 +94,6 @@ MYSQL_DATABASE=testdb`
 func TestMain_AppContextInitError(t *testing.T) {
 	// Create .env file with invalid database configuration to force AppContextInit error
 	envContent := `HTTP_PORT=8080
-REDIS_HOST=invalid_host
-REDIS_PORT=invalid_port
 MYSQL_HOST=invalid_host
 MYSQL_PORT=invalid_port
 MYSQL_USER=invalid_user
@@ -105,6 +104,8 @@ MYSQL_DATABASE=invalid_db`
 	assert.NoError(t, err)
 	defer os.Remove(".env")
 
+	withArgs(t)
+
 	// Test that main panics when AppContextInit fails
 	assert.Panics(t, func() {
 		main()
@@ -117,8 +118,6 @@ func TestMain_BuildServiceError(t *testing.T) {
 
 	// Create .env file
 	envContent := `HTTP_PORT=8080
-REDIS_HOST=localhost
-REDIS_PORT=6379
 MYSQL_HOST=localhost
 MYSQL_PORT=3306
 MYSQL_USER=testuser
@@ -129,6 +128,8 @@ MYSQL_DATABASE=testdb`
 	assert.NoError(t, err)
 	defer os.Remove(".env")
 
+	withArgs(t)
+
 	// Test that main function attempts to run
 	// It will likely panic due to database connection issues
 	assert.Panics(t, func() {
@@ -137,10 +138,8 @@ MYSQL_DATABASE=testdb`
 }
 
 func TestMain_StartServerError(t *testing.T) {
-	// Create .env file with invalid port to force StartServer error
+	// Create .env file with invalid port to force a startup error
 	envContent := `HTTP_PORT=invalid_port
-REDIS_HOST=localhost
-REDIS_PORT=6379
 MYSQL_HOST=localhost
 MYSQL_PORT=3306
 MYSQL_USER=testuser
@@ -151,10 +150,12 @@ MYSQL_DATABASE=testdb`
 	assert.NoError(t, err)
 	defer os.Remove(".env")
 
+	withArgs(t)
+
 	// Test that main panics when StartServer fails
 	assert.Panics(t, func() {
 		main()
-	}, "Main should panic when StartServer fails with invalid port")
+	}, "Main should panic when the port is invalid")
 }
 
 func TestMain_EnvironmentVariables(t *testing.T) {
@@ -163,27 +164,18 @@ func TestMain_EnvironmentVariables(t *testing.T) {
 
 	// Set environment variables
 	os.Setenv("HTTP_PORT", "9090")
-	os.Setenv("REDIS_HOST", "test-redis")
 	os.Setenv("MYSQL_HOST", "test-mysql")
 	defer func() {
 		os.Unsetenv("HTTP_PORT")
-		os.Unsetenv("REDIS_HOST")
 		os.Unsetenv("MYSQL_HOST")
 	}()
 
-	// Create empty .env file to prevent panic
-	err := os.WriteFile(".env", []byte(""), 0644)
-	assert.NoError(t, err)
-	defer os.Remove(".env")
-
-	// Test that config loads from environment variables
-	assert.NotPanics(t, func() {
-		config.LoadConfig()
-	})
+	// Test that config loads from environment variables without a .env file
+	cfg, err := config.Load(nil)
 
-	assert.Equal(t, "9090", config.HTTPPort)
-	assert.Equal(t, "test-redis", config.RedisHost)
-	assert.Equal(t, "test-mysql", config.MySQLHost)
+	assert.NoError(t, err)
+	assert.Equal(t, "9090", cfg.HTTP.Port)
+	assert.Equal(t, "test-mysql", cfg.MySQL.Host)
 }
 
 func TestMain_SelectStatement(t *testing.T) {
@@ -192,8 +184,6 @@ func TestMain_SelectStatement(t *testing.T) {
 
 	// Create .env file
 	envContent := `HTTP_PORT=0
-REDIS_HOST=localhost
-REDIS_PORT=6379
 MYSQL_HOST=localhost
 MYSQL_PORT=3306
 MYSQL_USER=testuser
@@ -204,6 +194,8 @@ MYSQL_DATABASE=testdb`
 	assert.NoError(t, err)
 	defer os.Remove(".env")
 
+	withArgs(t)
+
 	// Test that main function includes select{} for blocking
 	// We can't easily test this without complex goroutine management
 	// But we can verify the function structure doesn't panic during setup
@@ -231,8 +223,6 @@ MYSQL_DATABASE=testdb`
 func BenchmarkMain_Setup(b *testing.B) {
 	// Create .env file
 	envContent := `HTTP_PORT=0
-REDIS_HOST=localhost
-REDIS_PORT=6379
 MYSQL_HOST=localhost
 MYSQL_PORT=3306
 MYSQL_USER=testuser
@@ -250,7 +240,7 @@ MYSQL_DATABASE=testdb`
 			defer func() {
 				recover() // Catch expected panics
 			}()
-			config.LoadConfig()
+			config.Load(nil)
 		}()
 	}
 }
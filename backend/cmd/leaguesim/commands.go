@@ -0,0 +1,562 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"text/tabwriter"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	"league-sim/internal/backtest"
+	"league-sim/internal/calibration"
+	"league-sim/internal/experiment"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+)
+
+func (c *cli) register(ctx context.Context, args []string) error {
+	fs := c.flags("register")
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	body := models.RegisterUserRequest{Username: args[0]}
+	if err := c.validate.Validate(&body); err != nil {
+		return err
+	}
+
+	registered, err := c.services.UserService().Register(ctx, body.Username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stderr, "created user %s (%s)\n", registered.Username, registered.UserId)
+	fmt.Fprintln(c.stdout, registered.APIKey)
+	return nil
+}
+
+func (c *cli) create(ctx context.Context, args []string) error {
+	fs := c.flags("create")
+	name := fs.String("name", "", "league name")
+	teams := fs.Int("teams", 0, "number of teams")
+	rulesFile := fs.String("rules", "", "JSON file with the league rules")
+	if _, err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	body := models.CreateLeagueRequest{LeagueName: *name, TeamCount: fmt.Sprint(*teams)}
+	if *rulesFile != "" {
+		var rules models.LeagueRules
+		if err := readJSON(*rulesFile, &rules); err != nil {
+			return err
+		}
+		body.Rules = &rules
+	}
+	if err := c.validate.Validate(&body); err != nil {
+		return err
+	}
+
+	created, err := c.services.LeagueService().CreateLeague(ctx, body.TeamCount, body.LeagueName, body.Rules)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, created.LeagueId)
+	return nil
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	if _, err := c.parse(c.flags("list"), args, 0); err != nil {
+		return err
+	}
+
+	user, _ := auth.UserFrom(ctx)
+	leagues, err := c.appCtx.LeagueRepository().GetLeague(ctx, user.UserId)
+	if err != nil {
+		return err
+	}
+
+	return c.table(
+		func(w io.Writer) {
+			fmt.Fprintln(w, "ID\tNAME")
+			for _, l := range leagues {
+				fmt.Fprintf(w, "%s\t%s\n", l.LeagueId, l.LeagueName)
+			}
+		})
+}
+
+// adopt hands the leagues that have no owner, those created before user
+// accounts, to the acting user. Nobody can reach them through the API until
+// then.
+func (c *cli) adopt(ctx context.Context, args []string) error {
+	if _, err := c.parse(c.flags("adopt"), args, 0); err != nil {
+		return err
+	}
+
+	user, _ := auth.UserFrom(ctx)
+	adopted, err := c.appCtx.LeagueRepository().AdoptUnownedLeagues(ctx, user.UserId)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "adopted %d leagues\n", adopted)
+	return nil
+}
+
+// simulate plays one week at a time so that -weeks can stop early, or the
+// whole season at once with -all.
+func (c *cli) simulate(ctx context.Context, args []string) error {
+	fs := c.flags("simulate")
+	weeks := fs.Int("weeks", 1, "number of weeks to play")
+	all := fs.Bool("all", false, "play the rest of the season")
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	leagueId := args[0]
+	if *weeks < 1 {
+		return apperrors.Validation("-weeks must be at least 1")
+	}
+	if err := c.requireRole(ctx, leagueId, editRoles); err != nil {
+		return err
+	}
+
+	var matches []models.MatchResult
+	for week := 0; week < *weeks; week++ {
+		result, err := c.services.SimulationService().Simulation(ctx, leagueId, *all)
+		if err != nil {
+			return err
+		}
+
+		if len(result.Matches) == 0 {
+			break
+		}
+		matches = append(matches, result.Matches...)
+
+		if *all {
+			break
+		}
+	}
+
+	if len(matches) == 0 {
+		return apperrors.Conflict("no matches left to simulate in league %s", leagueId)
+	}
+
+	return c.printResults(matches)
+}
+
+func (c *cli) standings(ctx context.Context, args []string) error {
+	args, err := c.parse(c.flags("standings"), args, 1)
+	if err != nil {
+		return err
+	}
+	if err := c.requireRole(ctx, args[0], readRoles); err != nil {
+		return err
+	}
+
+	standings, err := c.appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.table(
+		func(w io.Writer) {
+			fmt.Fprintln(w, "POS\tTEAM\tP\tW\tD\tL\tGF\tGA\tGD\tPTS")
+			for i, s := range league.RankStandings(standings) {
+				fmt.Fprintf(
+					w, "%d\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%+d\t%d\n", i+1, s.Team.Name, s.Played, s.Wins,
+					s.Played-s.Wins-s.Losses, s.Losses, s.Goals, s.Against, s.Goals-s.Against, s.Points)
+			}
+		})
+}
+
+func (c *cli) results(ctx context.Context, args []string) error {
+	fs := c.flags("results")
+	week := fs.Int("week", 0, "only this week")
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := c.requireRole(ctx, args[0], readRoles); err != nil {
+		return err
+	}
+
+	results, err := c.appCtx.MatchResultRepository().GetMatchResults(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	var matches []models.MatchResult
+	for _, result := range results {
+		if *week == 0 || result.MatchWeek == *week {
+			matches = append(matches, result)
+		}
+	}
+
+	return c.printResults(matches)
+}
+
+func (c *cli) edit(ctx context.Context, args []string) error {
+	fs := c.flags("edit")
+	week := fs.Int("week", 0, "week of the match")
+	home := fs.String("home", "", "home team")
+	away := fs.String("away", "", "away team")
+	score := fs.String("score", "", "new score as home-away, e.g. 2-1")
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	body := models.EditMatchResult{LeagueId: args[0], Home: *home, Away: *away, MatchWeek: *week}
+	if _, err := fmt.Sscanf(*score, "%d-%d", &body.HomeScore, &body.AwayScore); err != nil {
+		return apperrors.Validation("-score %q is not of the form home-away, e.g. 2-1", *score)
+	}
+	if err := c.validate.Validate(&body); err != nil {
+		return err
+	}
+	if err := c.requireRole(ctx, body.LeagueId, editRoles); err != nil {
+		return err
+	}
+
+	if err := c.services.SimulationService().EditMatch(ctx, body); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "week %d: %s %d-%d %s\n", body.MatchWeek, body.Home, body.HomeScore, body.AwayScore, body.Away)
+	return nil
+}
+
+func (c *cli) predict(ctx context.Context, args []string) error {
+	args, err := c.parse(c.flags("predict"), args, 1)
+	if err != nil {
+		return err
+	}
+	if err := c.requireRole(ctx, args[0], readRoles); err != nil {
+		return err
+	}
+
+	predictions, err := c.services.PredictService().PredictChampionShipSession(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	return c.table(
+		func(w io.Writer) {
+			fmt.Fprintln(w, "TEAM\tPTS\tSTRENGTH\tODDS\t")
+			for _, p := range predictions {
+				status := ""
+				if p.Eliminated {
+					status = "eliminated"
+				}
+				fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f%%\t%s\n", p.TeamName, p.Points, p.Strength, p.Odds, status)
+			}
+		})
+}
+
+// export writes a league as one JSON document or, with -format csv, as a CSV
+// file per table in the -o directory.
+func (c *cli) export(ctx context.Context, args []string) error {
+	fs := c.flags("export")
+	format := fs.String("format", "json", "output format, json or csv")
+	output := fs.String("o", "", "file to write instead of stdout, or the directory for the CSV files")
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if err := c.validate.Validate(&models.ExportLeagueRequest{Format: *format, Table: models.ExportTableStandings}); err != nil {
+		return err
+	}
+	if *format == "csv" && *output == "" {
+		return apperrors.Validation("-o must name a directory with -format csv")
+	}
+	if err := c.requireRole(ctx, args[0], readRoles); err != nil {
+		return err
+	}
+
+	export, err := c.services.LeagueService().ExportLeague(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, export); err != nil {
+			return err
+		}
+
+		return c.write(*output, buf.Bytes())
+	}
+
+	if err := os.MkdirAll(*output, 0o755); err != nil {
+		return err
+	}
+	for _, table := range models.ExportTables {
+		var buf bytes.Buffer
+		if err := league.WriteExportCSV(&buf, export, table); err != nil {
+			return err
+		}
+		if err := c.write(filepath.Join(*output, table+".csv"), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *cli) importLeague(ctx context.Context, args []string) error {
+	fs := c.flags("import")
+	input := fs.String("i", "", "file to read instead of stdin")
+	newID := fs.Bool("new-id", false, "give the league a new ID instead of the exported one")
+	if _, err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	var export models.LeagueExport
+	if *input == "" {
+		if err := json.NewDecoder(c.stdin).Decode(&export); err != nil {
+			return apperrors.Validation("invalid export document: %v", err)
+		}
+	} else if err := readJSON(*input, &export); err != nil {
+		return err
+	}
+
+	imported, err := c.services.LeagueService().ImportLeague(ctx, export, *newID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, imported.LeagueId)
+	return nil
+}
+
+// experiment plays its leagues in this process; nothing is read from or
+// written to the store.
+func (c *cli) experiment(ctx context.Context, args []string) error {
+	fs := c.flags("experiment")
+	leagues := fs.Int("leagues", 100, "number of leagues to play")
+	teams := fs.Int("teams", 0, "number of teams per league")
+	rulesFile := fs.String("rules", "", "JSON file with the league rules")
+	workers := fs.Int("workers", runtime.GOMAXPROCS(0), "number of leagues played at once")
+	format := fs.String("format", "json", "output format, json or csv")
+	output := fs.String("o", "", "file to write instead of stdout")
+	if _, err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	body := models.ExperimentRequest{Leagues: *leagues, TeamCount: *teams}
+	if *rulesFile != "" {
+		var rules models.LeagueRules
+		if err := readJSON(*rulesFile, &rules); err != nil {
+			return err
+		}
+		body.Rules = &rules
+	}
+	if err := c.validate.Validate(&body); err != nil {
+		return err
+	}
+	if err := c.validate.Validate(&models.ExperimentFormatRequest{Format: *format}); err != nil {
+		return err
+	}
+	if *workers < 1 {
+		return apperrors.Validation("-workers must be at least 1")
+	}
+
+	result, err := experiment.Run(ctx, body, *workers)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if *format == "csv" {
+		err = experiment.WriteCSV(&buf, result)
+	} else {
+		err = writeJSON(&buf, result)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.write(*output, buf.Bytes())
+}
+
+// calibrate fits the engine to a results file. -rules-out writes just the
+// fitted rules, ready for create -rules or experiment -rules.
+func (c *cli) calibrate(ctx context.Context, args []string) error {
+	fs := c.flags("calibrate")
+	rulesFile := fs.String("rules", "", "JSON file with the rules to start from")
+	replays := fs.Int("replays", 100, "times every match is replayed to compare the engine with the results")
+	rulesOut := fs.String("rules-out", "", "file to write the fitted rules to")
+	output := fs.String("o", "", "file to write the report to instead of stdout")
+	args, err := c.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	base := models.DefaultLeagueRules()
+	if *rulesFile != "" {
+		if err := readJSON(*rulesFile, &base); err != nil {
+			return err
+		}
+	}
+	if *replays < 0 {
+		return apperrors.Validation("-replays must not be negative")
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	matches, err := calibration.ParseFootballData(file)
+	if err != nil {
+		return err
+	}
+
+	report, err := calibration.Fit(matches, base, *replays)
+	if err != nil {
+		return err
+	}
+
+	if *rulesOut != "" {
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, report.Rules); err != nil {
+			return err
+		}
+		if err := os.WriteFile(*rulesOut, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, report); err != nil {
+		return err
+	}
+
+	return c.write(*output, buf.Bytes())
+}
+
+// backtest replays finished leagues of the store with every prediction
+// strategy. Without league IDs it takes every finished league the user can
+// access.
+func (c *cli) backtest(ctx context.Context, args []string) error {
+	fs := c.flags("backtest")
+	runs := fs.Int("runs", 200, "times the Monte Carlo strategies play the rest of a season")
+	output := fs.String("o", "", "file to write instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *runs < 1 {
+		return apperrors.Validation("-runs must be at least 1")
+	}
+
+	leagueIds := fs.Args()
+	onlyFinished := len(leagueIds) == 0
+	if onlyFinished {
+		user, _ := auth.UserFrom(ctx)
+		leagues, err := c.appCtx.LeagueRepository().GetLeague(ctx, user.UserId)
+		if err != nil {
+			return err
+		}
+		for _, l := range leagues {
+			leagueIds = append(leagueIds, l.LeagueId)
+		}
+	}
+
+	var seasons []backtest.Season
+	for _, leagueId := range leagueIds {
+		if err := c.requireRole(ctx, leagueId, readRoles); err != nil {
+			return err
+		}
+
+		activeLeague, err := c.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
+		if err != nil {
+			return err
+		}
+		if onlyFinished && (len(activeLeague.UpcomingFixtures) > 0 || len(activeLeague.PlayedFixtures) == 0) {
+			continue
+		}
+
+		results, err := c.appCtx.MatchResultRepository().GetMatchResults(ctx, leagueId)
+		if err != nil {
+			return err
+		}
+
+		rules, err := c.appCtx.LeagueRepository().GetLeagueRules(ctx, leagueId)
+		if err != nil {
+			return err
+		}
+
+		seasons = append(seasons, backtest.Season{League: activeLeague, Results: results, Rules: rules})
+	}
+
+	if len(seasons) == 0 {
+		return apperrors.NotFound("no finished leagues to backtest")
+	}
+
+	report, err := backtest.Run(ctx, seasons, *runs, c.appCtx.Config().Predict)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, report); err != nil {
+		return err
+	}
+
+	return c.write(*output, buf.Bytes())
+}
+
+func (c *cli) printResults(matches []models.MatchResult) error {
+	return c.table(
+		func(w io.Writer) {
+			fmt.Fprintln(w, "WEEK\tHOME\tSCORE\tAWAY")
+			for _, m := range matches {
+				fmt.Fprintf(w, "%d\t%s\t%d-%d\t%s\n", m.MatchWeek, m.Home, m.HomeScore, m.AwayScore, m.Away)
+			}
+		})
+}
+
+// table lets write print tab separated columns and aligns them on stdout.
+func (c *cli) table(write func(w io.Writer)) error {
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	write(w)
+	return w.Flush()
+}
+
+// write writes data to the file at path, or to stdout when path is empty.
+func (c *cli) write(path string, data []byte) error {
+	if path == "" {
+		_, err := c.stdout.Write(data)
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return apperrors.Validation("%s is not valid JSON: %v", path, err)
+	}
+
+	return nil
+}
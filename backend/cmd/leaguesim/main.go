@@ -0,0 +1,254 @@
+// Command leaguesim scripts leagues from the shell. It calls the service layer
+// directly against the store of the loaded configuration, acting as the user
+// whose API key is in LEAGUESIM_API_KEY, and checks that user's role in a
+// league as the API does: any member can read a league, only its owner and
+// editors can simulate or edit it. The offline commands work in memory and
+// need neither the store nor an API key.
+//
+//	leaguesim [config flags] <command> [flags] [args]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/audit"
+	"league-sim/internal/auth"
+	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/contexts/services"
+	"league-sim/internal/models"
+	"league-sim/internal/validation"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyEnv names the variable holding the API key of the acting user.
+const apiKeyEnv = "LEAGUESIM_API_KEY"
+
+// Roles a user needs in a league to read it and to change it.
+var (
+	readRoles = []string{models.RoleOwner, models.RoleEditor, models.RoleViewer}
+	editRoles = []string{models.RoleOwner, models.RoleEditor}
+)
+
+const usage = `usage: leaguesim [config flags] <command> [flags] [args]
+
+Config flags are those of the server, e.g. --config, --profile, --mysql-host.
+Every command but register, experiment and calibrate acts as the user whose API
+key is in LEAGUESIM_API_KEY, with that user's role in the league. experiment and
+calibrate do not use the database.
+
+Commands:
+  register <username>                               create a user and print its API key
+  create -name <name> -teams <n> [-rules <file>]    create a league and print its ID
+  list                                              list the leagues you can access
+  adopt                                             become the owner of every league created
+                                                    before user accounts
+  simulate [-weeks <n> | -all] <leagueId>           play weeks and print their results
+  standings <leagueId>                              print the table
+  results [-week <n>] <leagueId>                    print played matches
+  edit -week <n> -home <team> -away <team> -score <h-a> <leagueId>
+                                                    correct the score of a played match
+  predict <leagueId>                                print each team's title chances
+  backtest [-runs <n>] [-o <file>] [leagueId...]    score prediction strategies on finished
+                                                    leagues, by default all of yours
+  export [-format json|csv] [-o <file|dir>] <leagueId>
+                                                    write the league as JSON, or as CSV tables
+  import [-new-id] [-i <file>]                      recreate an exported league
+  experiment -leagues <n> -teams <n> [-rules <file>] [-workers <n>] [-format json|csv] [-o <file>]
+                                                    play synthetic leagues in memory and
+                                                    report how the rules behave
+  calibrate [-rules <file>] [-replays <n>] [-rules-out <file>] [-o <file>] <results.csv>
+                                                    fit the match engine to real results in
+                                                    the football-data.co.uk CSV format
+`
+
+// errUsage reports a command line that could not be understood. The usage has
+// been printed already.
+var errUsage = errors.New("invalid usage")
+
+type command func(c *cli, ctx context.Context, args []string) error
+
+var commands = map[string]command{
+	"register":   (*cli).register,
+	"create":     (*cli).create,
+	"list":       (*cli).list,
+	"adopt":      (*cli).adopt,
+	"simulate":   (*cli).simulate,
+	"standings":  (*cli).standings,
+	"results":    (*cli).results,
+	"edit":       (*cli).edit,
+	"predict":    (*cli).predict,
+	"backtest":   (*cli).backtest,
+	"export":     (*cli).export,
+	"import":     (*cli).importLeague,
+	"experiment": (*cli).experiment,
+	"calibrate":  (*cli).calibrate,
+}
+
+// offline lists the commands that run without the store or a user.
+var offline = map[string]bool{
+	"experiment": true,
+	"calibrate":  true,
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			printError(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
+
+// printError writes err and, for validation failures, each invalid field.
+func printError(w io.Writer, err error) {
+	fmt.Fprintln(w, "leaguesim:", err)
+
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		for _, field := range appErr.Fields {
+			fmt.Fprintf(w, "  %s: %s\n", field.Field, field.Message)
+		}
+	}
+}
+
+func run(args []string) error {
+	cfg, args, err := config.LoadCommand(args)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "help" || commands[args[0]] == nil {
+		fmt.Fprint(os.Stderr, usage)
+		if len(args) > 0 && args[0] == "help" {
+			return nil
+		}
+		return errUsage
+	}
+
+	if offline[args[0]] {
+		c := &cli{validate: validation.New(cfg.Limits), stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+		return c.run(context.Background(), args, "")
+	}
+
+	appCtx, err := appContext.AppContextInit(cfg)
+	if err != nil {
+		return err
+	}
+	defer appCtx.DB().Sql.Close()
+
+	service, err := services.BuildService(appCtx)
+	if err != nil {
+		return err
+	}
+
+	c := newCLI(appCtx, service, os.Stdin, os.Stdout, os.Stderr)
+	return c.run(context.Background(), args, os.Getenv(apiKeyEnv))
+}
+
+// cli runs one command against the app context and services, which are nil
+// for offline commands.
+type cli struct {
+	appCtx   appContext.AppContext
+	services services.Service
+	validate *validation.Validator
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+func newCLI(
+	appCtx appContext.AppContext, services services.Service, stdin io.Reader, stdout io.Writer, stderr io.Writer,
+) *cli {
+	return &cli{
+		appCtx:   appCtx,
+		services: services,
+		validate: validation.New(appCtx.Config().Limits),
+		stdin:    stdin,
+		stdout:   stdout,
+		stderr:   stderr,
+	}
+}
+
+// run authenticates apiKey, unless the command is register or offline, and
+// runs the command named by args[0] with the rest of args. Each run gets its
+// own request ID in the audit log.
+func (c *cli) run(ctx context.Context, args []string, apiKey string) error {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(c.stderr, usage)
+		return errUsage
+	}
+
+	ctx = audit.WithRequestID(ctx, "cli-"+uuid.NewString())
+	if args[0] != "register" && !offline[args[0]] {
+		if apiKey == "" {
+			return apperrors.Unauthorized("%s is not set, create a user with leaguesim register", apiKeyEnv)
+		}
+
+		user, err := c.services.UserService().Authenticate(ctx, apiKey)
+		if err != nil {
+			return err
+		}
+		ctx = auth.WithUser(ctx, user)
+	}
+
+	return cmd(c, ctx, args[1:])
+}
+
+// requireRole only lets the acting user go on with a league in which they hold
+// one of roles, like the API's RequireLeagueRole. Leagues they hold no role in
+// are reported as not found.
+func (c *cli) requireRole(ctx context.Context, leagueId string, roles []string) error {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return apperrors.Unauthorized("authentication required")
+	}
+
+	role, err := c.appCtx.LeagueRepository().GetMemberRole(ctx, leagueId, user.UserId)
+	if err != nil {
+		return err
+	}
+
+	if role == "" {
+		return apperrors.NotFound("league %s not found", leagueId)
+	}
+
+	if !slices.Contains(roles, role) {
+		return apperrors.Forbidden("the %s role may not perform this operation", role)
+	}
+
+	return nil
+}
+
+// flags returns a flag set for a command that prints its errors and the usage
+// to stderr.
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() { fmt.Fprint(c.stderr, usage) }
+	return fs
+}
+
+// parse parses args into fs and checks that exactly want positional arguments
+// follow the flags.
+func (c *cli) parse(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+
+	if fs.NArg() != want {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return fs.Args(), nil
+}
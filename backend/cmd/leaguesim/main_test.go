@@ -0,0 +1,575 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/league"
+	leagueInterfaces "league-sim/internal/league/interfaces"
+	"league-sim/internal/models"
+	predictInterfaces "league-sim/internal/predict/interfaces"
+	"league-sim/internal/repositories/interfaces"
+	"league-sim/internal/simulation"
+	simulationInterfaces "league-sim/internal/simulation/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAppContext for testing
+type MockAppContext struct {
+	mock.Mock
+}
+
+func (m *MockAppContext) LeagueRepository() interfaces.LeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.LeagueRepository)
+}
+
+func (m *MockAppContext) ActiveLeagueRepository() interfaces.ActiveLeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.ActiveLeagueRepository)
+}
+
+func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.MatchResultRepository)
+}
+
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
+func (m *MockAppContext) DB() *appContext.DB {
+	args := m.Called()
+	return args.Get(0).(*appContext.DB)
+}
+
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
+// MockService for testing
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) LeagueService() leagueInterfaces.LeagueServiceInterface {
+	args := m.Called()
+	return args.Get(0).(leagueInterfaces.LeagueServiceInterface)
+}
+
+func (m *MockService) SimulationService() simulationInterfaces.SimulationServiceInterface {
+	args := m.Called()
+	return args.Get(0).(simulationInterfaces.SimulationServiceInterface)
+}
+
+func (m *MockService) PredictService() predictInterfaces.PredictServiceInterface {
+	args := m.Called()
+	return args.Get(0).(predictInterfaces.PredictServiceInterface)
+}
+
+func (m *MockService) UserService() userInterfaces.UserServiceInterface {
+	args := m.Called()
+	return args.Get(0).(userInterfaces.UserServiceInterface)
+}
+
+const testAPIKey = "lsk_test"
+
+var testUser = models.User{UserId: "user-id", Username: "alice"}
+
+type testCLI struct {
+	*cli
+	activeRepo *interfaces.MockActiveLeagueRepository
+	leagueRepo *interfaces.MockLeagueRepository
+	league     *leagueInterfaces.MockLeagueServiceInterface
+	simulation *simulationInterfaces.MockSimulationServiceInterface
+	users      *userInterfaces.MockUserServiceInterface
+	stdin      *bytes.Buffer
+	stdout     *bytes.Buffer
+	stderr     *bytes.Buffer
+}
+
+func newTestCLI() *testCLI {
+	appCtx := &MockAppContext{}
+	services := &MockService{}
+	t := &testCLI{
+		activeRepo: &interfaces.MockActiveLeagueRepository{},
+		leagueRepo: &interfaces.MockLeagueRepository{},
+		league:     &leagueInterfaces.MockLeagueServiceInterface{},
+		simulation: &simulationInterfaces.MockSimulationServiceInterface{},
+		users:      &userInterfaces.MockUserServiceInterface{},
+		stdin:      &bytes.Buffer{},
+		stdout:     &bytes.Buffer{},
+		stderr:     &bytes.Buffer{},
+	}
+	appCtx.On("Config").Return(config.Default())
+	appCtx.On("ActiveLeagueRepository").Return(t.activeRepo)
+	appCtx.On("LeagueRepository").Return(t.leagueRepo)
+	services.On("LeagueService").Return(t.league)
+	services.On("SimulationService").Return(t.simulation)
+	services.On("UserService").Return(t.users)
+	t.users.On("Authenticate", mock.Anything, testAPIKey).Return(testUser, nil)
+	t.cli = newCLI(appCtx, services, t.stdin, t.stdout, t.stderr)
+
+	return t
+}
+
+// member gives the test user role in the league.
+func (t *testCLI) member(leagueId string, role string) {
+	t.leagueRepo.On("GetMemberRole", mock.Anything, leagueId, testUser.UserId).Return(role, nil)
+}
+
+func (t *testCLI) run(args ...string) error {
+	return t.cli.run(context.Background(), args, testAPIKey)
+}
+
+func TestRun_RequiresAPIKey(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.cli.run(context.Background(), []string{"standings", "league-1"}, "")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindUnauthorized))
+	c.users.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.run("promote", "league-1")
+
+	// Assert
+	assert.ErrorIs(t, err, errUsage)
+	assert.Contains(t, c.stderr.String(), "usage: leaguesim")
+}
+
+func TestRun_WrongArgumentCount(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.run("standings")
+
+	// Assert
+	assert.ErrorIs(t, err, errUsage)
+	c.activeRepo.AssertNotCalled(t, "GetActiveLeaguesStandings", mock.Anything, mock.Anything)
+}
+
+func TestRegister_PrintsAPIKey(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.users.On("Register", mock.Anything, "bob").Return(
+		models.RegisterUserResponse{User: models.User{UserId: "bob-id", Username: "bob"}, APIKey: "lsk_new"}, nil)
+
+	// Execute
+	err := c.cli.run(context.Background(), []string{"register", "bob"}, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "lsk_new\n", c.stdout.String())
+	assert.Contains(t, c.stderr.String(), "bob-id")
+}
+
+func TestCreate_Validation(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.run("create", "-teams", "1")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	c.league.AssertNotCalled(t, "CreateLeague", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreate_PrintsLeagueId(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.league.On("CreateLeague", mock.Anything, "4", "Premier", (*models.LeagueRules)(nil)).
+		Return(models.GetLeaguesIdsWithNameResponse{LeagueId: "league-1", LeagueName: "Premier"}, nil)
+
+	// Execute
+	err := c.run("create", "-name", "Premier", "-teams", "4")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "league-1\n", c.stdout.String())
+}
+
+func TestSimulate_StopsAtEndOfSeason(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.member("league-1", models.RoleEditor)
+	c.simulation.On("Simulation", mock.Anything, "league-1", false).Return(
+		models.SimulationResponse{Matches: []models.MatchResult{{MatchWeek: 5, Home: "Lions", HomeScore: 2, Away: "Tigers"}}},
+		nil).Once()
+	c.simulation.On("Simulation", mock.Anything, "league-1", false).Return(models.SimulationResponse{}, nil).Once()
+
+	// Execute
+	err := c.run("simulate", "-weeks", "3", "league-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, c.stdout.String(), "Lions  2-0")
+	c.simulation.AssertNumberOfCalls(t, "Simulation", 2)
+}
+
+func TestSimulate_NothingLeft(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.member("league-1", models.RoleOwner)
+	c.simulation.On("Simulation", mock.Anything, "league-1", true).Return(models.SimulationResponse{}, nil)
+
+	// Execute
+	err := c.run("simulate", "-all", "league-1")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+}
+
+func TestStandings_PrintsRankedTable(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.member("league-1", models.RoleViewer)
+	c.activeRepo.On("GetActiveLeaguesStandings", mock.Anything, "league-1").Return(
+		[]models.Standings{
+			{Team: models.Team{Name: "Tigers"}, Played: 2, Losses: 1, Goals: 1, Against: 3, Points: 1},
+			{Team: models.Team{Name: "Lions"}, Played: 2, Wins: 2, Goals: 4, Against: 1, Points: 6},
+		}, nil)
+
+	// Execute
+	err := c.run("standings", "league-1")
+
+	// Assert
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(c.stdout.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"POS", "TEAM", "P", "W", "D", "L", "GF", "GA", "GD", "PTS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"1", "Lions", "2", "2", "0", "0", "4", "1", "+3", "6"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"2", "Tigers", "2", "0", "1", "1", "1", "3", "-2", "1"}, strings.Fields(lines[2]))
+}
+
+func TestEdit_ParsesScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		score string
+		valid bool
+	}{
+		{name: "Valid", score: "3-1", valid: true},
+		{name: "Missing away score", score: "3", valid: false},
+		{name: "Not a number", score: "three-one", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				c := newTestCLI()
+				c.member("league-1", models.RoleOwner)
+				c.simulation.On(
+					"EditMatch", mock.Anything, models.EditMatchResult{
+						LeagueId: "league-1", Home: "Lions", Away: "Tigers", HomeScore: 3, AwayScore: 1, MatchWeek: 2,
+					}).Return(nil)
+
+				// Execute
+				err := c.run("edit", "-week", "2", "-home", "Lions", "-away", "Tigers", "-score", tt.score, "league-1")
+
+				// Assert
+				if tt.valid {
+					require.NoError(t, err)
+					assert.Equal(t, "week 2: Lions 3-1 Tigers\n", c.stdout.String())
+				} else {
+					assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+					c.simulation.AssertNotCalled(t, "EditMatch", mock.Anything, mock.Anything)
+				}
+			})
+	}
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	export := models.LeagueExport{
+		Version:    models.LeagueExportVersion,
+		ExportedAt: time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC),
+		League:     models.League{LeagueID: "league-1", LeagueName: "Premier", Teams: []models.Team{{Name: "Lions"}}},
+		Results:    []models.MatchResult{{MatchWeek: 1, Home: "Lions", Away: "Tigers"}},
+	}
+	c.member("league-1", models.RoleViewer)
+	c.league.On("ExportLeague", mock.Anything, "league-1").Return(export, nil)
+	c.league.On("ImportLeague", mock.Anything, export, false).
+		Return(models.GetLeaguesIdsWithNameResponse{LeagueId: "league-2", LeagueName: "Premier"}, nil)
+
+	// Execute
+	require.NoError(t, c.run("export", "league-1"))
+	c.stdin.Write(c.stdout.Bytes())
+	c.stdout.Reset()
+	err := c.run("import")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "league-2\n", c.stdout.String())
+	c.league.AssertExpectations(t)
+}
+
+func TestImport_NewID(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	export := models.LeagueExport{Version: models.LeagueExportVersion, League: models.League{LeagueName: "Premier"}}
+	c.league.On("ImportLeague", mock.Anything, export, true).
+		Return(models.GetLeaguesIdsWithNameResponse{LeagueId: "league-2", LeagueName: "Premier"}, nil)
+	require.NoError(t, writeJSON(c.stdin, export))
+
+	// Execute
+	err := c.run("import", "-new-id")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "league-2\n", c.stdout.String())
+	c.league.AssertExpectations(t)
+}
+
+func TestExport_WritesCSVTables(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	activeLeague, results := finishedLeague("league-1")
+	export := models.LeagueExport{Version: models.LeagueExportVersion, League: activeLeague, Results: results}
+	c.member("league-1", models.RoleOwner)
+	c.league.On("ExportLeague", mock.Anything, "league-1").Return(export, nil)
+	dir := filepath.Join(t.TempDir(), "premier")
+
+	// Execute
+	err := c.run("export", "-format", "csv", "-o", dir, "league-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, c.stdout.String())
+	for table, header := range map[string]string{
+		models.ExportTableStandings: "position,team,",
+		models.ExportTableResults:   "week,home,",
+		models.ExportTableFixtures:  "week,status,",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, table+".csv"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), header), table)
+	}
+}
+
+func TestExport_CSVRequiresDirectory(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.run("export", "-format", "csv", "league-1")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	c.league.AssertNotCalled(t, "ExportLeague", mock.Anything, mock.Anything)
+}
+
+func TestAdopt_OwnsUnownedLeagues(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.leagueRepo.On("AdoptUnownedLeagues", mock.Anything, testUser.UserId).Return(3, nil)
+
+	// Execute
+	err := c.run("adopt")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "adopted 3 leagues\n", c.stdout.String())
+	c.leagueRepo.AssertExpectations(t)
+}
+
+func TestLeagueCommands_CheckRoles(t *testing.T) {
+	tests := []struct {
+		name string
+		role string
+		args []string
+		kind apperrors.Kind
+	}{
+		{name: "Viewer cannot simulate", role: models.RoleViewer, args: []string{"simulate", "league-1"}, kind: apperrors.KindForbidden},
+		{name: "Viewer cannot edit", role: models.RoleViewer, args: []string{"edit", "-week", "1", "-home", "Lions", "-away", "Tigers", "-score", "1-0", "league-1"}, kind: apperrors.KindForbidden},
+		{name: "Stranger cannot read standings", args: []string{"standings", "league-1"}, kind: apperrors.KindNotFound},
+		{name: "Stranger cannot export", args: []string{"export", "league-1"}, kind: apperrors.KindNotFound},
+		{name: "Stranger cannot predict", args: []string{"predict", "league-1"}, kind: apperrors.KindNotFound},
+		{name: "Stranger cannot backtest", args: []string{"backtest", "league-1"}, kind: apperrors.KindNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup
+				c := newTestCLI()
+				c.member("league-1", tt.role)
+
+				// Execute
+				err := c.run(tt.args...)
+
+				// Assert
+				assert.True(t, apperrors.Is(err, tt.kind), err)
+				assert.Empty(t, c.stdout.String())
+				c.simulation.AssertNotCalled(t, "Simulation", mock.Anything, mock.Anything, mock.Anything)
+				c.simulation.AssertNotCalled(t, "EditMatch", mock.Anything, mock.Anything)
+				c.league.AssertNotCalled(t, "ExportLeague", mock.Anything, mock.Anything)
+				c.activeRepo.AssertNotCalled(t, "GetActiveLeaguesStandings", mock.Anything, mock.Anything)
+			})
+	}
+}
+
+// finishedLeague plays a league of four random teams to the end.
+func finishedLeague(id string) (models.League, []models.MatchResult) {
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		LeagueID:         id,
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+
+	var results []models.MatchResult
+	season := simulation.NewSeason(&activeLeague, models.DefaultLeagueRules())
+	for !season.Finished() {
+		results = append(results, season.PlayNextWeek()...)
+	}
+
+	return activeLeague, results
+}
+
+func TestBacktest_SkipsUnfinishedLeagues(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	resultRepo := &interfaces.MockMatchResultRepository{}
+	c.appCtx.(*MockAppContext).On("MatchResultRepository").Return(resultRepo)
+	c.member("league-1", models.RoleOwner)
+	c.member("league-2", models.RoleViewer)
+
+	finished, results := finishedLeague("league-1")
+	c.leagueRepo.On("GetLeague", mock.Anything, testUser.UserId).Return(
+		[]models.GetLeaguesIdsWithNameResponse{{LeagueId: "league-1"}, {LeagueId: "league-2"}}, nil)
+	c.leagueRepo.On("GetLeagueRules", mock.Anything, "league-1").Return(models.DefaultLeagueRules(), nil)
+	c.activeRepo.On("GetActiveLeague", mock.Anything, "league-1").Return(finished, nil)
+	c.activeRepo.On("GetActiveLeague", mock.Anything, "league-2").Return(
+		models.League{LeagueID: "league-2", UpcomingFixtures: []models.Week{{Number: 1}}}, nil)
+	resultRepo.On("GetMatchResults", mock.Anything, "league-1").Return(results, nil)
+
+	// Execute
+	err := c.run("backtest", "-runs", "5")
+
+	// Assert
+	require.NoError(t, err)
+	var report models.BacktestReport
+	require.NoError(t, json.Unmarshal(c.stdout.Bytes(), &report))
+	assert.Equal(t, []string{"league-1"}, report.Leagues)
+	require.Len(t, report.Strategies, 3)
+	assert.Equal(t, "heuristic", report.Strategies[0].Strategy)
+	assert.Equal(t, 3, report.Strategies[0].Snapshots)
+	resultRepo.AssertNotCalled(t, "GetMatchResults", mock.Anything, "league-2")
+}
+
+func TestBacktest_NoFinishedLeagues(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	c.leagueRepo.On("GetLeague", mock.Anything, testUser.UserId).Return([]models.GetLeaguesIdsWithNameResponse{}, nil)
+
+	// Execute
+	err := c.run("backtest")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	assert.Empty(t, c.stdout.String())
+}
+
+func TestExperiment_WritesCSV(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.run("experiment", "-leagues", "3", "-teams", "4", "-workers", "2", "-format", "csv")
+
+	// Assert
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(c.stdout.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "league,champion,"))
+}
+
+func TestExperiment_Validation(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+
+	// Execute
+	err := c.run("experiment", "-leagues", "3", "-teams", "4", "-format", "xml")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Empty(t, c.stdout.String())
+}
+
+func TestCalibrate_WritesReportAndRules(t *testing.T) {
+	// Setup
+	c := newTestCLI()
+	dir := t.TempDir()
+	results := filepath.Join(dir, "E0.csv")
+	rulesOut := filepath.Join(dir, "rules.json")
+	require.NoError(
+		t, os.WriteFile(
+			results, []byte(
+				"Div,Date,HomeTeam,AwayTeam,FTHG,FTAG,FTR\n"+
+					"E0,11/08/2023,Lions,Bears,2,0,H\n"+
+					"E0,12/08/2023,Bears,Wolves,1,1,D\n"+
+					"E0,13/08/2023,Wolves,Lions,0,3,A\n"), 0o644))
+
+	// Execute
+	err := c.cli.run(context.Background(), []string{"calibrate", "-replays", "5", "-rules-out", rulesOut, results}, "")
+
+	// Assert
+	require.NoError(t, err)
+	var report models.CalibrationReport
+	require.NoError(t, json.Unmarshal(c.stdout.Bytes(), &report))
+	assert.Equal(t, 3, report.Matches)
+	assert.Equal(t, "Lions", report.Teams[0].Name)
+
+	var rules models.LeagueRules
+	require.NoError(t, readJSON(rulesOut, &rules))
+	assert.Equal(t, report.Rules, rules)
+	c.users.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
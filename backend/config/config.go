@@ -1,48 +1,491 @@
 package config
 
 import (
-	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
-var (
-	WeightPoints    float64
-	WeightsStrength float64
-	HTTPPort        string
-	RedisHost       string
-	RedisPort       string
-	MySQLHost       string
-	MySQLPort       string
-	MySQLUser       string
-	MySQLPassword   string
-	MySQLDatabase   string
-	CTXTimeout      context.Context
+const (
+	DefaultProfile    = "development"
+	DefaultConfigFile = "config.yaml"
+
+	// MaxGeneratedTeams is how many distinct team names the generator can
+	// produce (Team A to Team Z).
+	MaxGeneratedTeams = 26
+)
+
+type Config struct {
+	Profile   string          `yaml:"profile"`
+	HTTP      HTTPConfig      `yaml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	MySQL     MySQLConfig     `yaml:"mysql"`
+	Predict   PredictConfig   `yaml:"predict"`
+	Timeouts  TimeoutsConfig  `yaml:"timeouts"`
+	Limits    LimitsConfig    `yaml:"limits"`
+	RateLimit RateLimitConfig `yaml:"rateLimit"`
+	Quotas    QuotasConfig    `yaml:"quotas"`
+}
+
+type HTTPConfig struct {
+	Port string `yaml:"port"`
+}
+
+// GRPCConfig is the port of the gRPC API, served next to the HTTP one.
+type GRPCConfig struct {
+	Port string `yaml:"port"`
+}
+
+type MySQLConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Database string `yaml:"database"`
+}
+
+// TimeoutsConfig bounds work per HTTP request and per database operation.
+// A zero value disables the corresponding deadline.
+type TimeoutsConfig struct {
+	Request time.Duration `yaml:"request"`
+	Query   time.Duration `yaml:"query"`
+}
+
+// LimitsConfig bounds what API clients may ask for.
+type LimitsConfig struct {
+	MinTeams             int `yaml:"minTeams"`
+	MaxTeams             int `yaml:"maxTeams"`
+	MaxGoals             int `yaml:"maxGoals"`
+	MaxLeagueNameLength  int `yaml:"maxLeagueNameLength"`
+	MaxExperimentLeagues int `yaml:"maxExperimentLeagues"`
+}
+
+// Rate limit stores. The memory store keeps buckets in the process; the mysql
+// store shares them between every instance using the same database.
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreMySQL  = "mysql"
 )
 
-func LoadConfig() {
-	err := godotenv.Load()
+// RateLimitConfig throttles API clients with token buckets, one per client IP
+// and one per API key. Registration has a stricter bucket per client IP of its
+// own, as every new user gets fresh quotas. The client IP is the peer address
+// unless the request comes from one of the TrustedProxies CIDR ranges, in which
+// case it is taken from X-Forwarded-For.
+type RateLimitConfig struct {
+	Store          string     `yaml:"store"`
+	PerIP          RateConfig `yaml:"perIP"`
+	PerKey         RateConfig `yaml:"perKey"`
+	Registration   RateConfig `yaml:"registration"`
+	TrustedProxies []string   `yaml:"trustedProxies"`
+}
+
+// RateConfig is a token bucket refilled at PerMinute tokens a minute and
+// holding at most Burst tokens. A zero PerMinute disables the limit.
+type RateConfig struct {
+	PerMinute float64 `yaml:"perMinute"`
+	Burst     int     `yaml:"burst"`
+}
+
+// QuotasConfig caps what a single user may own. Zero means unlimited.
+type QuotasConfig struct {
+	LeaguesPerUser int `yaml:"leaguesPerUser"`
+	TeamsPerLeague int `yaml:"teamsPerLeague"`
+}
+
+type PredictConfig struct {
+	WeightPoints   float64 `yaml:"weightPoints"`
+	WeightStrength float64 `yaml:"weightStrength"`
+}
+
+func Default() *Config {
+	return &Config{
+		Profile: DefaultProfile,
+		HTTP: HTTPConfig{
+			Port: "8080",
+		},
+		GRPC: GRPCConfig{
+			Port: "50051",
+		},
+		MySQL: MySQLConfig{
+			Host:     "localhost",
+			Port:     "4050",
+			User:     "iboio",
+			Password: "1234",
+			Database: "league_sim",
+		},
+		Predict: PredictConfig{
+			WeightPoints:   0.4,
+			WeightStrength: 0.6,
+		},
+		Timeouts: TimeoutsConfig{
+			Request: 10 * time.Second,
+			Query:   5 * time.Second,
+		},
+		Limits: LimitsConfig{
+			MinTeams:             2,
+			MaxTeams:             MaxGeneratedTeams,
+			MaxGoals:             20,
+			MaxLeagueNameLength:  64,
+			MaxExperimentLeagues: 1000,
+		},
+		RateLimit: RateLimitConfig{
+			Store:  RateLimitStoreMemory,
+			PerIP:  RateConfig{PerMinute: 120, Burst: 30},
+			PerKey: RateConfig{PerMinute: 60, Burst: 20},
+			// Five registrations, then one every ten minutes.
+			Registration: RateConfig{PerMinute: 0.1, Burst: 5},
+		},
+		Quotas: QuotasConfig{
+			LeaguesPerUser: 20,
+			TeamsPerLeague: 20,
+		},
+	}
+}
+
+type flagValues struct {
+	configFile string
+	profile    string
+	set        map[string]string
+	args       []string // what follows the flags
+}
+
+// Load builds the configuration from defaults, then the YAML config file and
+// its profile overlay, then environment variables (including a .env file when
+// present), then command-line flags. Later sources win. The profile comes
+// from --profile, APP_PROFILE or the config file's profile key, in that order.
+func Load(args []string) (*Config, error) {
+	flags, err := parseFlags(args)
 	if err != nil {
-		fmt.Println(err)
-		panic("Error loading .env file")
-	}
-	WeightPoints = 0.4
-	WeightsStrength = 0.6
-	HTTPPort = getEnv("HTTP_PORT", "8080")
-	RedisHost = getEnv("REDIS_HOST", "localhost")
-	RedisPort = getEnv("REDIS_PORT", "4000")
-	MySQLHost = getEnv("MYSQL_HOST", "localhost")
-	MySQLPort = getEnv("MYSQL_PORT", "4050")
-	MySQLUser = getEnv("MYSQL_USER", "iboio")
-	MySQLPassword = getEnv("MYSQL_PASSWORD", "1234")
-	MySQLDatabase = getEnv("MYSQL_DATABASE", "league_sim")
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	CTXTimeout = ctx
+		return nil, err
+	}
+
+	return load(flags)
+}
+
+// LoadCommand is Load for programs with subcommands: the config flags come
+// first, and the arguments from the first one that is not a flag are returned
+// untouched.
+func LoadCommand(args []string) (*Config, []string, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := load(flags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, flags.args, nil
+}
+
+func load(flags flagValues) (*Config, error) {
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("loading .env: %w", err)
+	}
+
+	cfg := Default()
+
+	configFile := firstNonEmpty(flags.configFile, os.Getenv("CONFIG_FILE"), DefaultConfigFile)
+
+	if err := cfg.loadFile(configFile, flags.configFile != "" || os.Getenv("CONFIG_FILE") != ""); err != nil {
+		return nil, err
+	}
+	// The profile picks the overlay, so the base file's profile key applies
+	// when neither the flag nor APP_PROFILE sets one.
+	profile := firstNonEmpty(flags.profile, os.Getenv("APP_PROFILE"), cfg.Profile, DefaultProfile)
+	if err := cfg.loadFile(profileFile(configFile, profile), false); err != nil {
+		return nil, err
+	}
+	cfg.Profile = profile
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.applyFlags(flags.set); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Validate() error {
+	var problems []string
+
+	if err := validatePort(c.HTTP.Port); err != nil {
+		problems = append(problems, "http.port: "+err.Error())
+	}
+	if err := validatePort(c.GRPC.Port); err != nil {
+		problems = append(problems, "grpc.port: "+err.Error())
+	}
+	if c.GRPC.Port == c.HTTP.Port {
+		problems = append(problems, "grpc.port: must differ from http.port")
+	}
+	if err := validatePort(c.MySQL.Port); err != nil {
+		problems = append(problems, "mysql.port: "+err.Error())
+	}
+	if c.MySQL.Host == "" {
+		problems = append(problems, "mysql.host: must not be empty")
+	}
+	if c.MySQL.User == "" {
+		problems = append(problems, "mysql.user: must not be empty")
+	}
+	if c.MySQL.Database == "" {
+		problems = append(problems, "mysql.database: must not be empty")
+	}
+	if c.Predict.WeightPoints < 0 || c.Predict.WeightStrength < 0 {
+		problems = append(problems, "predict: weights must not be negative")
+	} else if c.Predict.WeightPoints+c.Predict.WeightStrength == 0 {
+		problems = append(problems, "predict: at least one weight must be positive")
+	}
+
+	if c.Timeouts.Request < 0 || c.Timeouts.Query < 0 {
+		problems = append(problems, "timeouts: must not be negative")
+	}
+
+	limits := c.Limits
+	if limits.MinTeams < 2 {
+		problems = append(problems, "limits.minTeams: must be at least 2")
+	}
+	if limits.MaxTeams < limits.MinTeams || limits.MaxTeams > MaxGeneratedTeams {
+		problems = append(
+			problems, fmt.Sprintf("limits.maxTeams: must be between minTeams and %d", MaxGeneratedTeams))
+	}
+	if limits.MaxGoals < 0 {
+		problems = append(problems, "limits.maxGoals: must not be negative")
+	}
+	if limits.MaxLeagueNameLength <= 0 {
+		problems = append(problems, "limits.maxLeagueNameLength: must be positive")
+	}
+	if limits.MaxExperimentLeagues <= 0 {
+		problems = append(problems, "limits.maxExperimentLeagues: must be positive")
+	}
+
+	rateLimit := c.RateLimit
+	if rateLimit.Store != RateLimitStoreMemory && rateLimit.Store != RateLimitStoreMySQL {
+		problems = append(
+			problems,
+			fmt.Sprintf("rateLimit.store: must be %s or %s", RateLimitStoreMemory, RateLimitStoreMySQL))
+	}
+	for _, cidr := range rateLimit.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			problems = append(problems, fmt.Sprintf("rateLimit.trustedProxies: %q is not a CIDR range", cidr))
+		}
+	}
+	for _, rate := range []struct {
+		name string
+		RateConfig
+	}{{"perIP", rateLimit.PerIP}, {"perKey", rateLimit.PerKey}, {"registration", rateLimit.Registration}} {
+		if rate.PerMinute < 0 {
+			problems = append(problems, "rateLimit."+rate.name+".perMinute: must not be negative")
+		} else if rate.PerMinute > 0 && rate.Burst < 1 {
+			problems = append(problems, "rateLimit."+rate.name+".burst: must be at least 1")
+		}
+	}
+
+	if c.Quotas.LeaguesPerUser < 0 {
+		problems = append(problems, "quotas.leaguesPerUser: must not be negative")
+	}
+	if c.Quotas.TeamsPerLeague < 0 {
+		problems = append(problems, "quotas.teamsPerLeague: must not be negative")
+	} else if c.Quotas.TeamsPerLeague > 0 && c.Quotas.TeamsPerLeague < limits.MinTeams {
+		problems = append(problems, "quotas.teamsPerLeague: must be at least limits.minTeams")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (c *Config) loadFile(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return nil
+		}
+
+		return fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	c.HTTP.Port = getEnv("HTTP_PORT", c.HTTP.Port)
+	c.GRPC.Port = getEnv("GRPC_PORT", c.GRPC.Port)
+	c.MySQL.Host = getEnv("MYSQL_HOST", c.MySQL.Host)
+	c.MySQL.Port = getEnv("MYSQL_PORT", c.MySQL.Port)
+	c.MySQL.User = getEnv("MYSQL_USER", c.MySQL.User)
+	c.MySQL.Password = getEnv("MYSQL_PASSWORD", c.MySQL.Password)
+	c.MySQL.Database = getEnv("MYSQL_DATABASE", c.MySQL.Database)
+
+	var err error
+	if c.Predict.WeightPoints, err = getFloatEnv("PREDICT_WEIGHT_POINTS", c.Predict.WeightPoints); err != nil {
+		return err
+	}
+	if c.Predict.WeightStrength, err = getFloatEnv("PREDICT_WEIGHT_STRENGTH", c.Predict.WeightStrength); err != nil {
+		return err
+	}
+	if c.Timeouts.Request, err = getDurationEnv("REQUEST_TIMEOUT", c.Timeouts.Request); err != nil {
+		return err
+	}
+	if c.Timeouts.Query, err = getDurationEnv("QUERY_TIMEOUT", c.Timeouts.Query); err != nil {
+		return err
+	}
+
+	c.RateLimit.Store = getEnv("RATE_LIMIT_STORE", c.RateLimit.Store)
+	if c.RateLimit.PerIP.PerMinute, err = getFloatEnv("RATE_LIMIT_PER_IP", c.RateLimit.PerIP.PerMinute); err != nil {
+		return err
+	}
+	if c.RateLimit.PerKey.PerMinute, err = getFloatEnv("RATE_LIMIT_PER_KEY", c.RateLimit.PerKey.PerMinute); err != nil {
+		return err
+	}
+	if c.RateLimit.Registration.PerMinute, err = getFloatEnv(
+		"RATE_LIMIT_REGISTRATION", c.RateLimit.Registration.PerMinute); err != nil {
+		return err
+	}
+	if value, ok := os.LookupEnv("RATE_LIMIT_TRUSTED_PROXIES"); ok {
+		c.RateLimit.TrustedProxies = strings.FieldsFunc(
+			value, func(r rune) bool { return r == ',' || r == ' ' })
+	}
+	if c.Quotas.LeaguesPerUser, err = getIntEnv("QUOTA_LEAGUES_PER_USER", c.Quotas.LeaguesPerUser); err != nil {
+		return err
+	}
+	if c.Quotas.TeamsPerLeague, err = getIntEnv("QUOTA_TEAMS_PER_LEAGUE", c.Quotas.TeamsPerLeague); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Config) applyFlags(set map[string]string) error {
+	for name, value := range set {
+		switch name {
+		case "http-port":
+			c.HTTP.Port = value
+		case "grpc-port":
+			c.GRPC.Port = value
+		case "mysql-host":
+			c.MySQL.Host = value
+		case "mysql-port":
+			c.MySQL.Port = value
+		case "mysql-user":
+			c.MySQL.User = value
+		case "mysql-password":
+			c.MySQL.Password = value
+		case "mysql-database":
+			c.MySQL.Database = value
+		case "predict-weight-points", "predict-weight-strength":
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("flag --%s: %w", name, err)
+			}
+			if name == "predict-weight-points" {
+				c.Predict.WeightPoints = f
+			} else {
+				c.Predict.WeightStrength = f
+			}
+		case "request-timeout", "query-timeout":
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("flag --%s: %w", name, err)
+			}
+			if name == "request-timeout" {
+				c.Timeouts.Request = d
+			} else {
+				c.Timeouts.Query = d
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseFlags(args []string) (flagValues, error) {
+	values := flagValues{set: map[string]string{}}
+
+	fs := flag.NewFlagSet("league-sim", flag.ContinueOnError)
+	fs.StringVar(&values.configFile, "config", "", "path to the YAML config file")
+	fs.StringVar(&values.profile, "profile", "", "config profile, e.g. development or production")
+	for _, name := range []string{
+		"http-port",
+		"grpc-port",
+		"mysql-host",
+		"mysql-port",
+		"mysql-user",
+		"mysql-password",
+		"mysql-database",
+		"predict-weight-points",
+		"predict-weight-strength",
+		"request-timeout",
+		"query-timeout",
+	} {
+		fs.String(name, "", "overrides the "+strings.ReplaceAll(name, "-", " ")+" setting")
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return flagValues{}, err
+	}
+
+	values.args = fs.Args()
+	fs.Visit(
+		func(f *flag.Flag) {
+			if f.Name != "config" && f.Name != "profile" {
+				values.set[f.Name] = f.Value.String()
+			}
+		})
+
+	return values, nil
+}
+
+// profileFile returns the overlay for a profile next to the base file, so
+// config.yaml with the production profile reads config.production.yaml.
+func profileFile(configFile string, profile string) string {
+	ext := filepath.Ext(configFile)
+	return strings.TrimSuffix(configFile, ext) + "." + profile + ext
+}
+
+func validatePort(port string) error {
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", port)
+	}
+	if p < 0 || p > 65535 {
+		return fmt.Errorf("%d is out of range", p)
+	}
+
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
 }
 
 func getEnv(key string, defaultValue string) string {
@@ -52,20 +495,44 @@ func getEnv(key string, defaultValue string) string {
 	return defaultValue
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	if value, exists := os.LookupEnv(key); exists {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
+func getFloatEnv(key string, defaultValue float64) (float64, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
 	}
-	return defaultValue
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("env %s: %w", key, err)
+	}
+
+	return f, nil
 }
 
-func getIntEnv(key string, defaultValue int) int {
-	if value, exists := os.LookupEnv(key); exists {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
+func getIntEnv(key string, defaultValue int) (int, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
 	}
-	return defaultValue
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("env %s: %w", key, err)
+	}
+
+	return i, nil
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) (time.Duration, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("env %s: %w", key, err)
+	}
+
+	return d, nil
 }
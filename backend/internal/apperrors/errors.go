@@ -0,0 +1,120 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindValidation   Kind = "validation"
+	KindConflict     Kind = "conflict"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindRateLimited  Kind = "rate_limited"
+	KindQuota        Kind = "quota_exceeded"
+	KindInternal     Kind = "internal"
+)
+
+// FieldError describes one invalid input field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a failure the domain understands. Message and Fields are safe to
+// show to API clients; Err keeps the underlying cause for logs and errors.Is/As.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(format string, args ...any) *Error {
+	return &Error{Kind: KindNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func Validation(format string, args ...any) *Error {
+	return &Error{Kind: KindValidation, Message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidFields reports a validation failure with a detail per offending field.
+func InvalidFields(fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Message: "request validation failed", Fields: fields}
+}
+
+func Conflict(format string, args ...any) *Error {
+	return &Error{Kind: KindConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// Unauthorized means the caller could not be identified.
+func Unauthorized(format string, args ...any) *Error {
+	return &Error{Kind: KindUnauthorized, Message: fmt.Sprintf(format, args...)}
+}
+
+// Forbidden means the caller is known but may not perform the operation.
+func Forbidden(format string, args ...any) *Error {
+	return &Error{Kind: KindForbidden, Message: fmt.Sprintf(format, args...)}
+}
+
+// RateLimited means the caller sent too many requests and should retry later.
+func RateLimited(format string, args ...any) *Error {
+	return &Error{Kind: KindRateLimited, Message: fmt.Sprintf(format, args...)}
+}
+
+// QuotaExceeded means the caller already owns as much of something as it is
+// allowed to.
+func QuotaExceeded(format string, args ...any) *Error {
+	return &Error{Kind: KindQuota, Message: fmt.Sprintf(format, args...)}
+}
+
+// Internal wraps an unexpected failure. The message is what clients see, so it
+// should not leak details of err.
+func Internal(err error, format string, args ...any) *Error {
+	return &Error{Kind: KindInternal, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// Wrap turns err into a domain error of the given kind, keeping err as the
+// cause. Errors that already carry a kind are returned unchanged.
+func Wrap(kind Kind, err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+// KindOf reports the kind of err, treating anything that is not a domain
+// error as internal.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+
+	return KindInternal
+}
+
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
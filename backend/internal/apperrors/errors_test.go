@@ -0,0 +1,62 @@
+package apperrors
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		kind Kind
+	}{
+		{name: "Not found", err: NotFound("league %s not found", "abc"), kind: KindNotFound},
+		{name: "Validation", err: Validation("team count %d is too small", 1), kind: KindValidation},
+		{name: "Conflict", err: Conflict("league is finished"), kind: KindConflict},
+		{name: "Rate limited", err: RateLimited("slow down"), kind: KindRateLimited},
+		{name: "Quota", err: QuotaExceeded("league quota of %d reached", 5), kind: KindQuota},
+		{name: "Internal", err: Internal(sql.ErrConnDone, "failed to read league"), kind: KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.kind, tt.err.Kind)
+				assert.Equal(t, tt.kind, KindOf(tt.err))
+				assert.True(t, Is(tt.err, tt.kind))
+			})
+	}
+}
+
+func TestError_MessageAndCause(t *testing.T) {
+	err := Internal(sql.ErrConnDone, "failed to read league %s", "abc")
+
+	assert.Equal(t, "failed to read league abc", err.Message)
+	assert.Contains(t, err.Error(), sql.ErrConnDone.Error())
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestKindOf_WrappedAndPlainErrors(t *testing.T) {
+	wrapped := fmt.Errorf("loading: %w", NotFound("league not found"))
+
+	assert.Equal(t, KindNotFound, KindOf(wrapped))
+	assert.Equal(t, KindInternal, KindOf(errors.New("boom")))
+	assert.False(t, Is(nil, KindInternal))
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("bad json")
+
+	err := Wrap(KindValidation, cause, "invalid body")
+	assert.True(t, Is(err, KindValidation))
+	assert.ErrorIs(t, err, cause)
+
+	original := NotFound("league not found")
+	assert.Same(t, original, Wrap(KindInternal, original, "ignored"), "existing kinds are kept")
+	assert.Nil(t, Wrap(KindInternal, nil, "nothing"))
+}
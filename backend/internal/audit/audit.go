@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being
+// served, so that audit entries can be traced back to it.
+func WithRequestID(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestId)
+}
+
+func RequestIDFrom(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIDKey{}).(string)
+	return requestId
+}
+
+// Record appends an audit entry for action on a league, attributed to the user
+// and request carried by ctx. before and after are stored as JSON; pass nil
+// when an operation has no prior or resulting state.
+func Record(
+	ctx context.Context,
+	repo interfaces.AuditRepository,
+	leagueId string,
+	action string,
+	before any,
+	after any,
+) error {
+	beforeJson, err := marshal(before)
+	if err != nil {
+		return apperrors.Internal(err, "failed to encode audit entry")
+	}
+
+	afterJson, err := marshal(after)
+	if err != nil {
+		return apperrors.Internal(err, "failed to encode audit entry")
+	}
+
+	user, _ := auth.UserFrom(ctx)
+
+	return repo.AppendAuditEntry(
+		ctx, models.AuditEntry{
+			LeagueId:  leagueId,
+			Action:    action,
+			ActorId:   user.UserId,
+			RequestId: RequestIDFrom(ctx),
+			Before:    beforeJson,
+			After:     afterJson,
+			CreatedAt: time.Now().UTC(),
+		})
+}
+
+// Snapshot copies the parts of a league recorded around simulations, resets
+// and deletes. The standings are copied so later changes do not leak into it.
+func Snapshot(league models.League) models.LeagueSnapshot {
+	return models.LeagueSnapshot{
+		CurrentWeek: league.CurrentWeek,
+		Standings:   append([]models.Standings(nil), league.Standings...),
+	}
+}
+
+func marshal(value any) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(value)
+}
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecord_AttributesEntryToUserAndRequest(t *testing.T) {
+	// Setup
+	user := models.User{UserId: "user-id", Username: "alice"}
+	ctx := WithRequestID(auth.WithUser(context.Background(), user), "req-1")
+
+	var entry models.AuditEntry
+	mockRepo := &interfaces.MockAuditRepository{}
+	mockRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).
+		Run(
+			func(args mock.Arguments) {
+				entry = args.Get(1).(models.AuditEntry)
+			}).
+		Return(nil)
+
+	// Execute
+	err := Record(
+		ctx, mockRepo, "league-id", models.AuditActionEdit,
+		models.MatchResult{Home: "A", HomeScore: 1}, models.MatchResult{Home: "A", HomeScore: 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "league-id", entry.LeagueId)
+	assert.Equal(t, models.AuditActionEdit, entry.Action)
+	assert.Equal(t, "user-id", entry.ActorId)
+	assert.Equal(t, "req-1", entry.RequestId)
+	assert.JSONEq(t, `{"matchWeek":0,"home":"A","homeScore":1,"away":"","awayScore":0,"winner":""}`, string(entry.Before))
+	assert.JSONEq(t, `{"matchWeek":0,"home":"A","homeScore":3,"away":"","awayScore":0,"winner":""}`, string(entry.After))
+	assert.WithinDuration(t, time.Now(), entry.CreatedAt, time.Second)
+	assert.Equal(t, time.UTC, entry.CreatedAt.Location())
+}
+
+func TestRecord_NilStateIsStoredAsNull(t *testing.T) {
+	// Setup
+	var entry models.AuditEntry
+	mockRepo := &interfaces.MockAuditRepository{}
+	mockRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).
+		Run(
+			func(args mock.Arguments) {
+				entry = args.Get(1).(models.AuditEntry)
+			}).
+		Return(nil)
+
+	// Execute
+	err := Record(context.Background(), mockRepo, "league-id", models.AuditActionDelete, models.LeagueSnapshot{}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, entry.Before)
+	assert.Nil(t, entry.After)
+	assert.Empty(t, entry.ActorId)
+	assert.Empty(t, entry.RequestId)
+}
+
+func TestRecord_RepositoryError(t *testing.T) {
+	// Setup
+	mockRepo := &interfaces.MockAuditRepository{}
+	repoErr := apperrors.Internal(errors.New("disk full"), "failed to write audit entry")
+	mockRepo.On("AppendAuditEntry", mock.Anything, mock.Anything).Return(repoErr)
+
+	// Execute
+	err := Record(context.Background(), mockRepo, "league-id", models.AuditActionReset, nil, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, repoErr)
+}
+
+func TestSnapshot_CopiesStandings(t *testing.T) {
+	// Setup
+	league := models.League{
+		CurrentWeek: 3,
+		Standings:   []models.Standings{{Team: models.Team{Name: "A"}, Points: 6}},
+	}
+
+	// Execute
+	snapshot := Snapshot(league)
+	league.Standings[0].Points = 9
+
+	// Assert
+	assert.Equal(t, 3, snapshot.CurrentWeek)
+	assert.Equal(t, 6, snapshot.Standings[0].Points)
+}
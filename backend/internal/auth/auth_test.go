@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithUser_RoundTrip(t *testing.T) {
+	user := models.User{UserId: "user-id", Username: "alice"}
+
+	// Execute
+	got, ok := UserFrom(WithUser(context.Background(), user))
+	_, missing := UserFrom(context.Background())
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, user, got)
+	assert.False(t, missing)
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	// Execute
+	key, hash, err := GenerateAPIKey()
+	other, _, _ := GenerateAPIKey()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, apiKeyPrefix))
+	assert.Len(t, hash, 64)
+	assert.Equal(t, HashAPIKey(key), hash)
+	assert.NotEqual(t, key, other)
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+		ok       bool
+	}{
+		{header: "Bearer lsk_abc", expected: "lsk_abc", ok: true},
+		{header: "bearer  lsk_abc ", expected: "lsk_abc", ok: true},
+		{header: "Basic dXNlcjpwYXNz", ok: false},
+		{header: "Bearer ", ok: false},
+		{header: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		token, ok := BearerToken(tt.header)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.expected, token, tt.header)
+	}
+}
@@ -0,0 +1,286 @@
+// Package backtest replays finished seasons week by week and scores how well
+// each prediction strategy named the champion before every week, using only
+// the results known at the time.
+package backtest
+
+import (
+	"context"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/forecast"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+	"league-sim/internal/rating"
+	"league-sim/internal/simulation"
+)
+
+const (
+	// StrategyHeuristic is the predictor served by the API.
+	StrategyHeuristic = "heuristic"
+	// StrategyMonteCarlo plays the rest of the season many times with the
+	// engine's static strengths.
+	StrategyMonteCarlo = "monteCarlo"
+	// StrategyElo plays the rest of the season many times with the Elo
+	// ratings earned so far driving the engine.
+	StrategyElo = "elo"
+)
+
+// Strategies are scored in this order.
+var Strategies = []string{StrategyHeuristic, StrategyMonteCarlo, StrategyElo}
+
+// Season is a finished league: its teams, every fixture in PlayedFixtures,
+// and the results of those fixtures.
+type Season struct {
+	League  models.League
+	Results []models.MatchResult
+	Rules   models.LeagueRules
+}
+
+// Run predicts the champion of every season before each of its weeks with
+// every strategy; the Monte Carlo strategies play the rest of the season
+// runs times. Scores are pooled over seasons and the weeks are only listed
+// for a single season. Run stops early when ctx is done.
+func Run(ctx context.Context, seasons []Season, runs int, weights config.PredictConfig) (models.BacktestReport, error) {
+	if runs < 1 {
+		return models.BacktestReport{}, apperrors.Validation("runs must be at least 1")
+	}
+
+	report := models.BacktestReport{Leagues: make([]string, 0, len(seasons)), Runs: runs}
+	accuracies := make([]forecast.Accuracy, len(Strategies))
+	var champion string
+
+	for _, season := range seasons {
+		if len(season.League.PlayedFixtures) == 0 || len(season.League.UpcomingFixtures) > 0 {
+			return models.BacktestReport{}, apperrors.Conflict(
+				"season of league %s is not finished", season.League.LeagueID)
+		}
+
+		replay := newReplay(season)
+		champion = replay.champion()
+		for week := range season.League.PlayedFixtures {
+			if err := ctx.Err(); err != nil {
+				return models.BacktestReport{}, apperrors.Internal(
+					err, "backtest of league %s stopped early", season.League.LeagueID)
+			}
+
+			state := replay.before(week)
+			number := season.League.PlayedFixtures[week].Number
+			accuracies[0].Add(number, forecast.Heuristic(state.standings, state.upcoming, season.Rules, weights), champion)
+			accuracies[1].Add(number, state.monteCarlo(season.Rules, runs, false), champion)
+			accuracies[2].Add(number, state.monteCarlo(season.Rules, runs, true), champion)
+		}
+		report.Leagues = append(report.Leagues, season.League.LeagueID)
+	}
+
+	for i, strategy := range Strategies {
+		result := accuracies[i].Result()
+		result.Strategy = strategy
+		if len(seasons) == 1 {
+			result.Champion = champion
+		} else {
+			result.Weeks = nil
+		}
+		report.Strategies = append(report.Strategies, result)
+	}
+
+	return report, nil
+}
+
+// replay rebuilds the table of a season from its results.
+type replay struct {
+	season Season
+	teams  []models.Team
+	params models.EloRules
+}
+
+// state is a season as it stood before one of its weeks.
+type state struct {
+	teams     []models.Team
+	standings []models.Standings
+	upcoming  []models.Week
+}
+
+func newReplay(season Season) *replay {
+	teams := preSeasonTeams(season.League)
+	for i := range teams {
+		teams[i].Rating = 0
+	}
+	rating.Seed(teams, season.Rules.StrengthWeights)
+
+	return &replay{season: season, teams: teams, params: rating.Parameters(season.Rules)}
+}
+
+// preSeasonTeams returns the teams of a league as they were before its first
+// match. The league's own teams carry the ratings, morale and stamina they
+// ended the season with, so every team is taken from the snapshot the fixtures
+// kept of it when they were drawn up. Tactics stay the league's, as the engine
+// plays every match with a team's current tactics.
+func preSeasonTeams(activeLeague models.League) []models.Team {
+	snapshots := make(map[string]models.Team, len(activeLeague.Teams))
+	for _, week := range activeLeague.PlayedFixtures {
+		for _, match := range week.Matches {
+			for _, team := range []*models.Team{match.Home, match.Away} {
+				if team == nil {
+					continue
+				}
+				if _, ok := snapshots[team.Name]; !ok {
+					snapshots[team.Name] = *team
+				}
+			}
+		}
+	}
+
+	teams := make([]models.Team, len(activeLeague.Teams))
+	for i, team := range activeLeague.Teams {
+		if snapshot, ok := snapshots[team.Name]; ok {
+			snapshot.Tactics = team.Tactics
+			team = snapshot
+		}
+		teams[i] = team
+	}
+
+	return teams
+}
+
+// before returns the season before its week-th played week, counted from
+// zero. Ratings, morale and stamina are those the teams had by then.
+func (r *replay) before(week int) state {
+	fixtures := r.season.League.PlayedFixtures
+	played := map[int]bool{}
+	for _, w := range fixtures[:week] {
+		played[w.Number] = true
+	}
+
+	var results []models.MatchResult
+	for _, result := range r.season.Results {
+		if played[result.MatchWeek] {
+			results = append(results, result)
+		}
+	}
+
+	initial := make(map[string]float64, len(r.teams))
+	for _, team := range r.teams {
+		initial[team.Name] = team.Rating
+	}
+	_, ratings := rating.Replay(initial, results, r.params)
+
+	teams := make([]models.Team, len(r.teams))
+	copy(teams, r.teams)
+	byName := make(map[string]*models.Team, len(teams))
+	for i := range teams {
+		teams[i].Rating = ratings[teams[i].Name]
+		byName[teams[i].Name] = &teams[i]
+	}
+	for _, result := range results {
+		playAttributes(byName, result, r.season.Rules)
+	}
+
+	return state{
+		teams:     teams,
+		standings: standingsAfter(teams, results, r.season.Rules),
+		upcoming:  fixtures[week:],
+	}
+}
+
+func (r *replay) champion() string {
+	final := r.before(len(r.season.League.PlayedFixtures))
+	return league.RankStandings(final.standings)[0].Team.Name
+}
+
+// monteCarlo plays the rest of the season runs times and gives every team the
+// share of runs it won. withElo lets the ratings drive the engine.
+func (s state) monteCarlo(rules models.LeagueRules, runs int, withElo bool) []models.PredictedStanding {
+	rules.Elo = nil
+	if withElo {
+		elo := rating.Parameters(rules)
+		elo.InEngine = true
+		rules.Elo = &elo
+	}
+
+	titles := make(map[string]int, len(s.teams))
+	for range runs {
+		activeLeague := models.League{
+			Teams:            make([]models.Team, len(s.teams)),
+			Standings:        make([]models.Standings, len(s.standings)),
+			UpcomingFixtures: s.upcoming,
+		}
+		copy(activeLeague.Teams, s.teams)
+		copy(activeLeague.Standings, s.standings)
+
+		season := simulation.NewSeason(&activeLeague, rules)
+		for !season.Finished() {
+			season.PlayNextWeek()
+		}
+		titles[league.RankStandings(activeLeague.Standings)[0].Team.Name]++
+	}
+
+	predictions := make([]models.PredictedStanding, 0, len(s.standings))
+	for _, standing := range s.standings {
+		predictions = append(
+			predictions, models.PredictedStanding{
+				TeamName: standing.Team.Name,
+				Points:   standing.Points,
+				Odds:     float64(titles[standing.Team.Name]) / float64(runs) * 100,
+			})
+	}
+
+	return predictions
+}
+
+// playAttributes changes the morale and stamina of the two teams of a result
+// as the engine did when the match was played.
+func playAttributes(teams map[string]*models.Team, result models.MatchResult, rules models.LeagueRules) {
+	home, away := teams[result.Home], teams[result.Away]
+	if home == nil || away == nil {
+		return
+	}
+
+	// Only the teams change; the table is rebuilt from the scores.
+	var standing models.Standings
+	outcome := models.MatchOutcome{IsDraw: result.HomeScore == result.AwayScore}
+	switch {
+	case outcome.IsDraw:
+		simulation.DrawTeamAttributeChanging(&standing, home, outcome, rules)
+		simulation.DrawTeamAttributeChanging(&standing, away, outcome, rules)
+	case result.HomeScore > result.AwayScore:
+		simulation.WinnerTeamAttributeChanging(&standing, home, outcome, rules)
+		simulation.LoserTeamAttributeChanging(&standing, away, outcome, rules)
+	default:
+		simulation.WinnerTeamAttributeChanging(&standing, away, outcome, rules)
+		simulation.LoserTeamAttributeChanging(&standing, home, outcome, rules)
+	}
+}
+
+// standingsAfter builds the table of teams from results alone.
+func standingsAfter(teams []models.Team, results []models.MatchResult, rules models.LeagueRules) []models.Standings {
+	standings := league.CreateStandingsTable(teams)
+	byName := make(map[string]*models.Standings, len(standings))
+	for i := range standings {
+		byName[standings[i].Team.Name] = &standings[i]
+	}
+
+	for _, result := range results {
+		home, away := byName[result.Home], byName[result.Away]
+		if home == nil || away == nil {
+			continue
+		}
+		home.Played++
+		away.Played++
+		home.Goals += result.HomeScore
+		home.Against += result.AwayScore
+		away.Goals += result.AwayScore
+		away.Against += result.HomeScore
+		home.Points += simulation.ResultPoints(rules, result.HomeScore, result.AwayScore)
+		away.Points += simulation.ResultPoints(rules, result.AwayScore, result.HomeScore)
+		if result.HomeScore > result.AwayScore {
+			home.Wins++
+			away.Losses++
+		} else if result.HomeScore < result.AwayScore {
+			away.Wins++
+			home.Losses++
+		}
+	}
+
+	return standings
+}
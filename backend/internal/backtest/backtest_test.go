@@ -0,0 +1,227 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/forecast"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+	"league-sim/internal/simulation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// playedSeason plays a league of teamCount random teams to the end.
+func playedSeason(id string, teamCount int) Season {
+	teams := league.TeamGenerate(teamCount)
+	// A stored league's fixtures keep their own copy of each team, as
+	// decoding it from the database gives them.
+	drawn := make([]models.Team, len(teams))
+	copy(drawn, teams)
+	activeLeague := models.League{
+		LeagueID:         id,
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(drawn),
+	}
+
+	rules := models.DefaultLeagueRules()
+	var results []models.MatchResult
+	season := simulation.NewSeason(&activeLeague, rules)
+	for !season.Finished() {
+		results = append(results, season.PlayNextWeek()...)
+	}
+
+	return Season{League: activeLeague, Results: results, Rules: rules}
+}
+
+func TestRun_ScoresEveryStrategy(t *testing.T) {
+	// Setup
+	season := playedSeason("league-1", 4)
+	champion := league.RankStandings(season.League.Standings)[0].Team.Name
+
+	// Execute
+	report, err := Run(context.Background(), []Season{season}, 20, config.Default().Predict)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"league-1"}, report.Leagues)
+	assert.Equal(t, 20, report.Runs)
+	require.Len(t, report.Strategies, 3)
+	for i, accuracy := range report.Strategies {
+		assert.Equal(t, Strategies[i], accuracy.Strategy)
+		assert.Equal(t, champion, accuracy.Champion)
+		assert.Equal(t, len(season.League.PlayedFixtures), accuracy.Snapshots)
+		require.Len(t, accuracy.Weeks, accuracy.Snapshots)
+		assert.Equal(t, 1, accuracy.Weeks[0].Week)
+		assert.GreaterOrEqual(t, accuracy.LogLoss, 0.0)
+		assert.NotEmpty(t, accuracy.Calibration)
+	}
+}
+
+func TestRun_PoolsSeasons(t *testing.T) {
+	// Setup
+	seasons := []Season{playedSeason("league-1", 4), playedSeason("league-2", 4)}
+
+	// Execute
+	report, err := Run(context.Background(), seasons, 5, config.Default().Predict)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"league-1", "league-2"}, report.Leagues)
+	for _, accuracy := range report.Strategies {
+		assert.Equal(t, 6, accuracy.Snapshots, "three weeks of each league")
+		assert.Empty(t, accuracy.Champion)
+		assert.Nil(t, accuracy.Weeks)
+	}
+}
+
+func TestRun_UnfinishedSeason(t *testing.T) {
+	// Setup
+	season := playedSeason("league-1", 4)
+	season.League.UpcomingFixtures = season.League.PlayedFixtures[2:]
+	season.League.PlayedFixtures = season.League.PlayedFixtures[:2]
+
+	// Execute
+	_, err := Run(context.Background(), []Season{season}, 5, config.Default().Predict)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+}
+
+func TestRun_InvalidRuns(t *testing.T) {
+	// Execute
+	_, err := Run(context.Background(), []Season{playedSeason("league-1", 4)}, 0, config.Default().Predict)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	_, err := Run(ctx, []Season{playedSeason("league-1", 4)}, 5, config.Default().Predict)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestReplay_BeforeMatchesPlayedSeason(t *testing.T) {
+	// Setup
+	season := playedSeason("league-1", 4)
+	replay := newReplay(season)
+
+	// Execute
+	first := replay.before(0)
+	last := replay.before(len(season.League.PlayedFixtures))
+
+	// Assert
+	for _, standing := range first.standings {
+		assert.Zero(t, standing.Played)
+		assert.Zero(t, standing.Points)
+	}
+	assert.Len(t, first.upcoming, len(season.League.PlayedFixtures))
+	assert.Empty(t, last.upcoming)
+
+	played := make(map[string]models.Standings, len(season.League.Standings))
+	for _, standing := range season.League.Standings {
+		played[standing.Team.Name] = standing
+	}
+	for _, standing := range last.standings {
+		want := played[standing.Team.Name]
+		assert.Equal(t, want.Points, standing.Points, standing.Team.Name)
+		assert.Equal(t, want.Goals, standing.Goals, standing.Team.Name)
+		assert.Equal(t, want.Against, standing.Against, standing.Team.Name)
+		assert.Equal(t, want.Wins, standing.Wins, standing.Team.Name)
+		assert.Equal(t, want.Team.Morale, standing.Team.Morale, standing.Team.Name)
+		assert.Equal(t, want.Team.Stamina, standing.Team.Stamina, standing.Team.Name)
+	}
+	for _, team := range last.teams {
+		for _, played := range season.League.Teams {
+			if played.Name == team.Name {
+				assert.InDelta(t, played.Rating, team.Rating, 1e-9, team.Name)
+			}
+		}
+	}
+}
+
+func TestReplay_TeamsStartFromFixtureSnapshots(t *testing.T) {
+	// Setup
+	season := playedSeason("league-1", 4)
+	for i := range season.League.Teams {
+		season.League.Teams[i].Morale, season.League.Teams[i].Stamina = 0, 0
+	}
+	replay := newReplay(season)
+
+	// Execute
+	first := replay.before(0)
+
+	// Assert
+	for _, match := range season.League.PlayedFixtures[0].Matches {
+		for _, snapshot := range []*models.Team{match.Home, match.Away} {
+			for _, team := range first.teams {
+				if team.Name == snapshot.Name {
+					assert.Equal(t, snapshot.Morale, team.Morale, team.Name)
+					assert.Equal(t, snapshot.Stamina, team.Stamina, team.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestReplay_LaterResultsDoNotChangeEarlierState(t *testing.T) {
+	// Setup
+	season := playedSeason("league-1", 4)
+	want := newReplay(season).before(1)
+
+	changed := season
+	changed.League.Teams = make([]models.Team, len(season.League.Teams))
+	copy(changed.League.Teams, season.League.Teams)
+	for i := range changed.League.Teams {
+		changed.League.Teams[i].Morale, changed.League.Teams[i].Stamina = 100, 0
+	}
+	changed.Results = make([]models.MatchResult, len(season.Results))
+	copy(changed.Results, season.Results)
+	for i, result := range changed.Results {
+		if result.MatchWeek == season.League.PlayedFixtures[len(season.League.PlayedFixtures)-1].Number {
+			changed.Results[i].HomeScore, changed.Results[i].AwayScore = result.AwayScore+3, result.HomeScore
+		}
+	}
+
+	// Execute
+	got := newReplay(changed).before(1)
+
+	// Assert
+	assert.Equal(t, want.teams, got.teams)
+	assert.Equal(t, want.standings, got.standings)
+	weights := config.Default().Predict
+	assert.Equal(t,
+		forecast.Heuristic(want.standings, want.upcoming, season.Rules, weights),
+		forecast.Heuristic(got.standings, got.upcoming, changed.Rules, weights))
+}
+
+func TestStandingsAfter(t *testing.T) {
+	// Setup
+	teams := []models.Team{{Name: "A"}, {Name: "B"}}
+	results := []models.MatchResult{
+		{MatchWeek: 1, Home: "A", HomeScore: 2, Away: "B", AwayScore: 0},
+		{MatchWeek: 2, Home: "B", HomeScore: 1, Away: "A", AwayScore: 1},
+	}
+
+	// Execute
+	standings := standingsAfter(teams, results, models.DefaultLeagueRules())
+
+	// Assert
+	assert.Equal(
+		t, []models.Standings{
+			{Team: teams[0], Goals: 3, Against: 1, Played: 2, Wins: 1, Points: 4},
+			{Team: teams[1], Goals: 1, Against: 3, Played: 2, Losses: 1, Points: 1},
+		}, standings)
+}
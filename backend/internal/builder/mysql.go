@@ -9,14 +9,14 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
-func SqlConnectionInit() (*sql.DB, error) {
+func SqlConnectionInit(cfg config.MySQLConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf(
-		"%s:%s@tcp(%s:%s)/%s",
-		config.MySQLUser,
-		config.MySQLPassword,
-		config.MySQLHost,
-		config.MySQLPort,
-		config.MySQLDatabase)
+		"%s:%s@tcp(%s:%s)/%s?parseTime=true",
+		cfg.User,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.Database)
 
 	db, err := sql.Open("mysql", dsn)
 
@@ -9,32 +9,18 @@ import (
 )
 
 func TestSqlConnectionInit_Success(t *testing.T) {
-	// Setup test environment variables
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set test values - using a mock/test database
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
 	// Note: This test will fail if no actual MySQL server is running
 	// In a real scenario, you might want to use a test container or mock
-	db, err := SqlConnectionInit()
+	db, err := SqlConnectionInit(cfg)
 
 	// If we have a real MySQL server running, test the connection
 	if err == nil {
@@ -56,30 +42,16 @@ func TestSqlConnectionInit_Success(t *testing.T) {
 }
 
 func TestSqlConnectionInit_InvalidCredentials(t *testing.T) {
-	// Setup test environment variables
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set invalid credentials
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "invalid_user"
-	config.MySQLPassword = "invalid_password"
-	config.MySQLDatabase = "invalid_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "invalid_user",
+		Password: "invalid_password",
+		Database: "invalid_db",
+	}
 
-	db, err := SqlConnectionInit()
+	db, err := SqlConnectionInit(cfg)
 
 	// Should return an error with invalid credentials
 	assert.Nil(t, db)
@@ -87,30 +59,16 @@ func TestSqlConnectionInit_InvalidCredentials(t *testing.T) {
 }
 
 func TestSqlConnectionInit_InvalidHost(t *testing.T) {
-	// Setup test environment variables
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set invalid host
-	config.MySQLHost = "invalid_host_that_does_not_exist"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "invalid_host_that_does_not_exist",
+		Port:     "3306",
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
-	db, err := SqlConnectionInit()
+	db, err := SqlConnectionInit(cfg)
 
 	// Should return an error with invalid host
 	assert.Nil(t, db)
@@ -118,30 +76,16 @@ func TestSqlConnectionInit_InvalidHost(t *testing.T) {
 }
 
 func TestSqlConnectionInit_InvalidPort(t *testing.T) {
-	// Setup test environment variables
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set invalid port
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "99999" // Invalid port
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "99999", // Invalid port
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
-	db, err := SqlConnectionInit()
+	db, err := SqlConnectionInit(cfg)
 
 	// Should return an error with invalid port
 	assert.Nil(t, db)
@@ -152,32 +96,18 @@ func TestSqlConnectionInit_DSNFormat(t *testing.T) {
 	// This test verifies that the DSN is formatted correctly
 	// We can test this by checking the error message when connection fails
 
-	// Setup test environment variables
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set test values
-	config.MySQLHost = "testhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "testuser"
-	config.MySQLPassword = "testpass"
-	config.MySQLDatabase = "testdb"
+	cfg := config.MySQLConfig{
+		Host:     "testhost",
+		Port:     "3306",
+		User:     "testuser",
+		Password: "testpass",
+		Database: "testdb",
+	}
 
 	// The function should at least attempt to connect with the correct DSN format
 	// Even if it fails, we can verify the DSN was constructed properly
-	db, err := SqlConnectionInit()
+	db, err := SqlConnectionInit(cfg)
 
 	// We expect an error since this is likely not a real server
 	assert.Error(t, err)
@@ -190,33 +120,19 @@ func TestSqlConnectionInit_DSNFormat(t *testing.T) {
 
 // Benchmark test for connection initialization
 func BenchmarkSqlConnectionInit(b *testing.B) {
-	// Setup test environment variables
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after benchmark
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set test values
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		db, err := SqlConnectionInit()
+		db, err := SqlConnectionInit(cfg)
 		if err == nil && db != nil {
 			db.Close()
 		}
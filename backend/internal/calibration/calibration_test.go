@@ -0,0 +1,175 @@
+package calibration
+
+import (
+	"strings"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFootballData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "Main leagues",
+			data: "\ufeffDiv,Date,HomeTeam,AwayTeam,FTHG,FTAG,FTR\n" +
+				"E0,11/08/2023,Burnley,Man City,0,3,A\n" +
+				"E0,12/08/2023,Arsenal,Nott'm Forest,2,1,H\n" +
+				"E0,19/05/2024,Arsenal,Everton,,,\n",
+		},
+		{
+			name: "Extra leagues",
+			data: "Country,League,Season,Date,Time,Home,Away,HG,AG,Res\n" +
+				"Brazil,Serie A,2023,11/08/2023,20:00,Burnley,Man City,0,3,A\n" +
+				"Brazil,Serie A,2023,12/08/2023,20:00,Arsenal,Nott'm Forest,2,1,H\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Execute
+				matches, err := ParseFootballData(strings.NewReader(tt.data))
+
+				// Assert
+				require.NoError(t, err)
+				assert.Equal(
+					t, []Match{
+						{Date: "11/08/2023", Home: "Burnley", Away: "Man City", HomeGoals: 0, AwayGoals: 3},
+						{Date: "12/08/2023", Home: "Arsenal", Away: "Nott'm Forest", HomeGoals: 2, AwayGoals: 1},
+					}, matches)
+			})
+	}
+}
+
+func TestParseFootballData_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		message string
+	}{
+		{name: "Empty", data: "", message: "results file is empty"},
+		{name: "Missing column", data: "Date,HomeTeam,AwayTeam,FTHG\n", message: "no FTAG or AG column"},
+		{name: "Bad score", data: "HomeTeam,AwayTeam,FTHG,FTAG\nA,B,1,0\nA,C,x,1\n", message: "line 3"},
+		{name: "No results", data: "HomeTeam,AwayTeam,FTHG,FTAG\nA,B,,\n", message: "no finished matches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Execute
+				_, err := ParseFootballData(strings.NewReader(tt.data))
+
+				// Assert
+				assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+				assert.ErrorContains(t, err, tt.message)
+			})
+	}
+}
+
+// season plays a double round robin in which Lions beat everybody, Bears
+// beat Wolves at home and draw away, and Wolves lose every other match.
+func season() []Match {
+	return []Match{
+		{Home: "Lions", Away: "Bears", HomeGoals: 3, AwayGoals: 1},
+		{Home: "Bears", Away: "Lions", HomeGoals: 0, AwayGoals: 2},
+		{Home: "Lions", Away: "Wolves", HomeGoals: 4, AwayGoals: 0},
+		{Home: "Wolves", Away: "Lions", HomeGoals: 1, AwayGoals: 2},
+		{Home: "Bears", Away: "Wolves", HomeGoals: 2, AwayGoals: 1},
+		{Home: "Wolves", Away: "Bears", HomeGoals: 1, AwayGoals: 1},
+	}
+}
+
+func TestFit(t *testing.T) {
+	// Execute
+	report, err := Fit(season(), models.DefaultLeagueRules(), 200)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 6, report.Matches)
+	assert.Equal(t, 3, report.Rules.PointsForWin, "points come from the base rules")
+
+	assert.InDelta(t, 1.0/6, report.Rules.Draw.Chance, 1e-9)
+	assert.Equal(t, 1, report.Rules.Draw.MaxGoals)
+	require.NotNil(t, report.Rules.GoalRates)
+	assert.InDelta(t, 1.0, report.Rules.GoalRates.Draw, 1e-9)
+	assert.InDelta(t, 13.0/5-1, report.Rules.GoalRates.Winner, 1e-9)
+	assert.InDelta(t, 3.0/8, report.Rules.GoalRates.Loser, 1e-9)
+	assert.Greater(t, report.Rules.HomeAdvantage, 1.0, "Bears won at home and lost away")
+
+	require.Len(t, report.Teams, 3)
+	assert.Equal(
+		t, []string{"Lions", "Bears", "Wolves"},
+		[]string{report.Teams[0].Name, report.Teams[1].Name, report.Teams[2].Name})
+	assert.Equal(t, 4, report.Teams[0].Wins)
+	assert.Equal(
+		t, models.TeamRating{
+			Name: "Wolves", Rating: report.Teams[2].Rating, Strength: report.Teams[2].Strength,
+			Played: 4, Draws: 1, Losses: 3,
+		}, report.Teams[2])
+	assert.InDelta(t, 1.0, report.Teams[0].Rating*report.Teams[1].Rating*report.Teams[2].Rating, 1e-9)
+	assert.InDelta(t, 85*report.Teams[0].Rating, report.Teams[0].Strength, 1e-9, "default weights sum to one")
+
+	assert.Less(t, report.BrierScore, report.BaselineBrierScore)
+	assert.Positive(t, report.LogLoss)
+	assert.InDelta(t, 0.5, report.Observed.HomeWinRate, 1e-9)
+	assert.InDelta(t, 3.0, report.Observed.GoalsPerGame, 1e-9)
+	assert.InDelta(t, report.Rules.Draw.Chance, report.Simulated.DrawRate, 0.05)
+
+	require.GreaterOrEqual(t, len(report.Goals), 5)
+	var observed, simulated float64
+	for i, bucket := range report.Goals {
+		assert.Equal(t, i, bucket.Goals)
+		observed += bucket.Observed
+		simulated += bucket.Simulated
+	}
+	assert.InDelta(t, 1.0, observed, 1e-9)
+	assert.InDelta(t, 1.0, simulated, 1e-9)
+}
+
+func TestFit_GoalHistogramCoversSimulatedTotals(t *testing.T) {
+	// Setup: low-scoring matches, replayed with rules that score a lot more
+	matches := []Match{
+		{Home: "Lions", Away: "Bears", HomeGoals: 1, AwayGoals: 0},
+		{Home: "Bears", Away: "Lions", HomeGoals: 0, AwayGoals: 0},
+	}
+	teams := map[string]models.Team{"Lions": {Name: "Lions"}, "Bears": {Name: "Bears"}}
+	rules := models.DefaultLeagueRules()
+	rules.GoalRates = &models.GoalRates{Winner: 6, Loser: 0.9, Draw: 4}
+
+	// Execute
+	_, simulated, goals := replay(matches, teams, rules, 200)
+
+	// Assert
+	require.Greater(t, len(goals), 2, "simulated totals go past the observed maximum")
+	var observed, goalsPerGame float64
+	for i, bucket := range goals {
+		assert.Equal(t, i, bucket.Goals)
+		observed += bucket.Observed
+		goalsPerGame += float64(bucket.Goals) * bucket.Simulated
+	}
+	assert.InDelta(t, 1.0, observed, 1e-9)
+	assert.InDelta(t, simulated.GoalsPerGame, goalsPerGame, 1e-9, "no bucket holds more goals than its label")
+	assert.Zero(t, goals[len(goals)-1].Observed)
+	assert.Positive(t, goals[len(goals)-1].Simulated)
+}
+
+func TestFit_Invalid(t *testing.T) {
+	// Setup
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 0
+
+	// Execute
+	_, noMatches := Fit(nil, models.DefaultLeagueRules(), 1)
+	_, badRules := Fit(season(), rules, 1)
+
+	// Assert
+	assert.True(t, apperrors.Is(noMatches, apperrors.KindValidation))
+	assert.True(t, apperrors.Is(badRules, apperrors.KindValidation))
+}
@@ -0,0 +1,325 @@
+package calibration
+
+import (
+	"math"
+	"sort"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+	"league-sim/internal/simulation"
+)
+
+// averageAttribute is the mean of the 70 to 100 attributes of generated teams.
+// A team with a rating of one gets it for every attribute.
+const averageAttribute = 85
+
+const (
+	maxIterations = 1000
+	tolerance     = 1e-9
+)
+
+// Fit fits the match engine to matches by maximum likelihood, on top of base
+// for everything results say nothing about, such as points. It then replays
+// every match replays times with GenerateMatchResult to compare the engine's
+// outcomes and goals with the real ones.
+//
+// GenerateMatchResult decides a draw with a fixed chance and otherwise lets
+// the home team win with probability h*s/(h*s + t) for strengths s and t and
+// home advantage h. The draw chance is the share of draws, h and the team
+// ratings come from a Bradley-Terry fit of the decided matches, and the goal
+// rates are the maximum likelihood estimates for the model of
+// models.GoalRates. Every team plays a virtual win and loss against an
+// average team, and the home side a virtual win and loss against the away
+// side, so that unbeaten or winless teams still get finite ratings.
+func Fit(matches []Match, base models.LeagueRules, replays int) (models.CalibrationReport, error) {
+	if len(matches) == 0 {
+		return models.CalibrationReport{}, apperrors.Validation("no matches to fit")
+	}
+	if err := league.ValidateRules(base); err != nil {
+		return models.CalibrationReport{}, apperrors.Validation("invalid rules: %v", err)
+	}
+
+	rules := base
+	rules.Draw, rules.GoalRates = fitGoals(matches)
+	ratings, homeAdvantage := fitRatings(matches)
+	rules.HomeAdvantage = homeAdvantage
+	if err := league.ValidateRules(rules); err != nil {
+		return models.CalibrationReport{}, apperrors.Validation("results cannot be reproduced by the engine: %v", err)
+	}
+
+	teams := make(map[string]models.Team, len(ratings))
+	for name, rating := range ratings {
+		attribute := averageAttribute * rating
+		teams[name] = models.Team{
+			Name:         name,
+			AttackPower:  attribute,
+			DefensePower: attribute,
+			Morale:       attribute,
+			Stamina:      attribute,
+		}
+	}
+
+	report := models.CalibrationReport{
+		Matches: len(matches),
+		Rules:   rules,
+		Teams:   teamRatings(matches, ratings, teams, rules.StrengthWeights),
+	}
+	report.BrierScore, report.BaselineBrierScore, report.LogLoss = scores(matches, ratings, rules)
+	report.Observed, report.Simulated, report.Goals = replay(matches, teams, rules, replays)
+
+	return report, nil
+}
+
+// fitGoals estimates the draw model and goal rates. Each estimate is the
+// sample mean the likelihood is maximised at.
+func fitGoals(matches []Match) (models.DrawModel, *models.GoalRates) {
+	var draws, drawGoals, maxDrawGoals int
+	var decided, winnerGoals, loserGoals, loserChances int
+	for _, m := range matches {
+		winner, loser := max(m.HomeGoals, m.AwayGoals), min(m.HomeGoals, m.AwayGoals)
+		if winner == loser {
+			draws++
+			drawGoals += winner
+			maxDrawGoals = max(maxDrawGoals, winner)
+			continue
+		}
+		decided++
+		winnerGoals += winner
+		loserGoals += loser
+		loserChances += winner - 1
+	}
+
+	// The engine needs a chance of a decided match, however small.
+	chance := math.Min(float64(draws)/float64(len(matches)), 0.99)
+	rates := &models.GoalRates{}
+	if draws > 0 {
+		rates.Draw = float64(drawGoals) / float64(draws)
+	}
+	if decided > 0 {
+		rates.Winner = float64(winnerGoals)/float64(decided) - 1
+	}
+	if loserChances > 0 {
+		rates.Loser = float64(loserGoals) / float64(loserChances)
+	}
+
+	return models.DrawModel{Chance: chance, MaxGoals: maxDrawGoals}, rates
+}
+
+// fitRatings runs the minorization-maximization algorithm for the
+// Bradley-Terry model with home advantage on the decided matches, and returns
+// ratings normalised to a geometric mean of one.
+func fitRatings(matches []Match) (map[string]float64, float64) {
+	ratings := map[string]float64{}
+	wins := map[string]float64{}
+	for _, m := range matches {
+		ratings[m.Home], ratings[m.Away] = 1, 1
+		if m.HomeGoals > m.AwayGoals {
+			wins[m.Home]++
+		} else if m.AwayGoals > m.HomeGoals {
+			wins[m.Away]++
+		}
+	}
+
+	var homeWins float64
+	var decided []Match
+	for _, m := range matches {
+		if m.HomeGoals != m.AwayGoals {
+			decided = append(decided, m)
+			if m.HomeGoals > m.AwayGoals {
+				homeWins++
+			}
+		}
+	}
+
+	homeAdvantage := 1.0
+	for range maxIterations {
+		// The virtual win and loss against a team rated one.
+		weights := make(map[string]float64, len(ratings))
+		for name, rating := range ratings {
+			weights[name] = 2 / (rating + 1)
+		}
+		for _, m := range decided {
+			total := homeAdvantage*ratings[m.Home] + ratings[m.Away]
+			weights[m.Home] += homeAdvantage / total
+			weights[m.Away] += 1 / total
+		}
+
+		change := 0.0
+		next := make(map[string]float64, len(ratings))
+		for name, rating := range ratings {
+			next[name] = (wins[name] + 1) / weights[name]
+			change = math.Max(change, math.Abs(next[name]-rating)/rating)
+		}
+		ratings = next
+
+		homeWeight := 2 / (homeAdvantage + 1)
+		for _, m := range decided {
+			homeWeight += ratings[m.Home] / (homeAdvantage*ratings[m.Home] + ratings[m.Away])
+		}
+		nextAdvantage := (homeWins + 1) / homeWeight
+		change = math.Max(change, math.Abs(nextAdvantage-homeAdvantage)/homeAdvantage)
+		homeAdvantage = nextAdvantage
+
+		if change < tolerance {
+			break
+		}
+	}
+
+	var logSum float64
+	for _, rating := range ratings {
+		logSum += math.Log(rating)
+	}
+	mean := math.Exp(logSum / float64(len(ratings)))
+	for name := range ratings {
+		ratings[name] /= mean
+	}
+
+	return ratings, homeAdvantage
+}
+
+// outcomeProbabilities are the engine's home win, draw and away win chances.
+func outcomeProbabilities(m Match, ratings map[string]float64, rules models.LeagueRules) [3]float64 {
+	home := rules.HomeAdvantage * ratings[m.Home]
+	homeWin := (1 - rules.Draw.Chance) * home / (home + ratings[m.Away])
+	return [3]float64{homeWin, rules.Draw.Chance, 1 - rules.Draw.Chance - homeWin}
+}
+
+func outcome(homeGoals int, awayGoals int) int {
+	switch {
+	case homeGoals > awayGoals:
+		return 0
+	case homeGoals == awayGoals:
+		return 1
+	}
+
+	return 2
+}
+
+// scores rates the fitted outcome probabilities with the Brier score, summed
+// over the three outcomes and averaged over the matches, and the log loss.
+func scores(matches []Match, ratings map[string]float64, rules models.LeagueRules) (float64, float64, float64) {
+	var frequencies [3]float64
+	for _, m := range matches {
+		frequencies[outcome(m.HomeGoals, m.AwayGoals)] += 1 / float64(len(matches))
+	}
+
+	var brier, baseline, logLoss float64
+	for _, m := range matches {
+		observed := outcome(m.HomeGoals, m.AwayGoals)
+		probabilities := outcomeProbabilities(m, ratings, rules)
+		for k := range probabilities {
+			hit := 0.0
+			if k == observed {
+				hit = 1
+			}
+			brier += (probabilities[k] - hit) * (probabilities[k] - hit)
+			baseline += (frequencies[k] - hit) * (frequencies[k] - hit)
+		}
+		logLoss -= math.Log(math.Max(probabilities[observed], 1e-15))
+	}
+
+	n := float64(len(matches))
+	return brier / n, baseline / n, logLoss / n
+}
+
+// replay plays every match again replays times and compares the engine's
+// results with the real ones.
+func replay(
+	matches []Match, teams map[string]models.Team, rules models.LeagueRules, replays int,
+) (models.MatchStatistics, models.MatchStatistics, []models.GoalFrequency) {
+	var observed, simulated models.MatchStatistics
+	var observedGoals, simulatedGoals []float64
+	count := func(stats *models.MatchStatistics, histogram *[]float64, homeGoals int, awayGoals int, weight float64) {
+		switch outcome(homeGoals, awayGoals) {
+		case 0:
+			stats.HomeWinRate += weight
+		case 1:
+			stats.DrawRate += weight
+		default:
+			stats.AwayWinRate += weight
+		}
+		total := homeGoals + awayGoals
+		stats.GoalsPerGame += float64(total) * weight
+		for len(*histogram) <= total {
+			*histogram = append(*histogram, 0)
+		}
+		(*histogram)[total] += weight
+	}
+
+	for _, m := range matches {
+		count(&observed, &observedGoals, m.HomeGoals, m.AwayGoals, 1/float64(len(matches)))
+	}
+
+	weight := 1 / float64(len(matches)*max(replays, 1))
+	for range replays {
+		for _, m := range matches {
+			result := simulation.GenerateMatchResult(teams[m.Home], teams[m.Away], rules)
+			homeGoals, awayGoals := result.WinnerGoals, result.LoserGoals
+			if !result.IsDraw && result.Winner.Name != m.Home {
+				homeGoals, awayGoals = awayGoals, homeGoals
+			}
+			count(&simulated, &simulatedGoals, homeGoals, awayGoals, weight)
+		}
+	}
+
+	// The histogram runs to the highest total seen on either side, so every
+	// bucket holds exactly its number of goals.
+	goals := make([]models.GoalFrequency, max(len(observedGoals), len(simulatedGoals)))
+	for total := range goals {
+		goals[total].Goals = total
+	}
+	for total, share := range observedGoals {
+		goals[total].Observed = share
+	}
+	for total, share := range simulatedGoals {
+		goals[total].Simulated = share
+	}
+
+	return observed, simulated, goals
+}
+
+// teamRatings lists every team with its record, strongest first.
+func teamRatings(
+	matches []Match, ratings map[string]float64, teams map[string]models.Team, weights models.StrengthWeights,
+) []models.TeamRating {
+	records := make(map[string]*models.TeamRating, len(ratings))
+	for name, rating := range ratings {
+		records[name] = &models.TeamRating{
+			Name:     name,
+			Rating:   rating,
+			Strength: league.CalculateStrength(teams[name], weights),
+		}
+	}
+
+	for _, m := range matches {
+		home, away := records[m.Home], records[m.Away]
+		home.Played++
+		away.Played++
+		switch outcome(m.HomeGoals, m.AwayGoals) {
+		case 0:
+			home.Wins++
+			away.Losses++
+		case 1:
+			home.Draws++
+			away.Draws++
+		default:
+			home.Losses++
+			away.Wins++
+		}
+	}
+
+	list := make([]models.TeamRating, 0, len(records))
+	for _, record := range records {
+		list = append(list, *record)
+	}
+	sort.Slice(
+		list, func(i, j int) bool {
+			if list[i].Rating != list[j].Rating {
+				return list[i].Rating > list[j].Rating
+			}
+			return list[i].Name < list[j].Name
+		})
+
+	return list
+}
@@ -0,0 +1,139 @@
+// Package calibration fits the match engine to historical results so that
+// simulated seasons look like real ones.
+package calibration
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"league-sim/internal/apperrors"
+)
+
+// Match is one finished historical match.
+type Match struct {
+	Date      string
+	Home      string
+	Away      string
+	HomeGoals int
+	AwayGoals int
+}
+
+// footballDataColumns names the columns read from a football-data.co.uk
+// results file, in the main league layout first and the extra league layout
+// second.
+var footballDataColumns = []struct {
+	name     string
+	aliases  []string
+	optional bool
+}{
+	{name: "date", aliases: []string{"Date"}, optional: true},
+	{name: "home", aliases: []string{"HomeTeam", "Home"}},
+	{name: "away", aliases: []string{"AwayTeam", "Away"}},
+	{name: "homeGoals", aliases: []string{"FTHG", "HG"}},
+	{name: "awayGoals", aliases: []string{"FTAG", "AG"}},
+}
+
+// ParseFootballData reads results in the football-data.co.uk CSV format: a
+// header row followed by one row per match, with the full-time score in
+// FTHG and FTAG (or HG and AG). Rows without a score, such as fixtures not
+// played yet, are skipped.
+func ParseFootballData(r io.Reader) ([]Match, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperrors.Validation("results file is empty")
+	}
+	if err != nil {
+		return nil, apperrors.Validation("results file is not valid CSV: %v", err)
+	}
+
+	columns, err := findColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, apperrors.Validation("results file is not valid CSV: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i := columns[name]; i >= 0 && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		homeGoals, awayGoals := field("homeGoals"), field("awayGoals")
+		if homeGoals == "" && awayGoals == "" {
+			continue
+		}
+
+		match := Match{Date: field("date"), Home: field("home"), Away: field("away")}
+		if match.Home == "" || match.Away == "" {
+			return nil, apperrors.Validation("line %d: home and away teams are required", line)
+		}
+		if match.HomeGoals, err = score(homeGoals); err != nil {
+			return nil, apperrors.Validation("line %d: home goals %q are not a score", line, homeGoals)
+		}
+		if match.AwayGoals, err = score(awayGoals); err != nil {
+			return nil, apperrors.Validation("line %d: away goals %q are not a score", line, awayGoals)
+		}
+
+		matches = append(matches, match)
+	}
+
+	if len(matches) == 0 {
+		return nil, apperrors.Validation("results file has no finished matches")
+	}
+
+	return matches, nil
+}
+
+// findColumns maps every name of footballDataColumns to its index in header,
+// or to -1 for optional columns that are missing.
+func findColumns(header []string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+
+	columns := make(map[string]int, len(footballDataColumns))
+	for _, column := range footballDataColumns {
+		columns[column.name] = -1
+		for _, alias := range column.aliases {
+			if i, ok := index[alias]; ok {
+				columns[column.name] = i
+				break
+			}
+		}
+		if columns[column.name] < 0 && !column.optional {
+			return nil, apperrors.Validation("results file has no %s column", strings.Join(column.aliases, " or "))
+		}
+	}
+
+	return columns, nil
+}
+
+func score(value string) (int, error) {
+	goals, err := strconv.Atoi(value)
+	if err != nil || goals < 0 {
+		return 0, errors.New("not a score")
+	}
+
+	return goals, nil
+}
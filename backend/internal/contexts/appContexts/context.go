@@ -3,6 +3,7 @@ package appContext
 import (
 	"database/sql"
 
+	"league-sim/config"
 	"league-sim/internal/builder"
 	"league-sim/internal/repositories"
 	"league-sim/internal/repositories/interfaces"
@@ -12,16 +13,28 @@ type AppContext interface {
 	LeagueRepository() interfaces.LeagueRepository
 	ActiveLeagueRepository() interfaces.ActiveLeagueRepository
 	MatchResultRepository() interfaces.MatchResultRepository
+	UserRepository() interfaces.UserRepository
+	AuditRepository() interfaces.AuditRepository
+	RatingRepository() interfaces.RatingRepository
+	PredictionRepository() interfaces.PredictionRepository
+	Transactor() interfaces.Transactor
 	DB() *DB
+	Config() *config.Config
 }
 type DB struct {
 	Sql *sql.DB
 }
 type AppContextImpl struct {
+	config                 *config.Config
 	db                     *DB
 	leagueRepository       interfaces.LeagueRepository
 	activeLeagueRepository interfaces.ActiveLeagueRepository
 	matchResultRepository  interfaces.MatchResultRepository
+	userRepository         interfaces.UserRepository
+	auditRepository        interfaces.AuditRepository
+	ratingRepository       interfaces.RatingRepository
+	predictionRepository   interfaces.PredictionRepository
+	transactor             interfaces.Transactor
 }
 
 func (a *AppContextImpl) DB() *DB {
@@ -29,6 +42,11 @@ func (a *AppContextImpl) DB() *DB {
 	return a.db
 }
 
+func (a *AppContextImpl) Config() *config.Config {
+
+	return a.config
+}
+
 func (a *AppContextImpl) LeagueRepository() interfaces.LeagueRepository {
 
 	return a.leagueRepository
@@ -43,26 +61,63 @@ func (a *AppContextImpl) MatchResultRepository() interfaces.MatchResultRepositor
 	return a.matchResultRepository
 }
 
-func AppContextInit() (*AppContextImpl, error) {
-	db, err := AppContextDBInit()
+func (a *AppContextImpl) UserRepository() interfaces.UserRepository {
+
+	return a.userRepository
+}
+
+func (a *AppContextImpl) AuditRepository() interfaces.AuditRepository {
+
+	return a.auditRepository
+}
+
+func (a *AppContextImpl) RatingRepository() interfaces.RatingRepository {
+
+	return a.ratingRepository
+}
+
+func (a *AppContextImpl) PredictionRepository() interfaces.PredictionRepository {
+
+	return a.predictionRepository
+}
+
+func (a *AppContextImpl) Transactor() interfaces.Transactor {
+
+	return a.transactor
+}
+
+func AppContextInit(cfg *config.Config) (*AppContextImpl, error) {
+	db, err := AppContextDBInit(cfg.MySQL)
 	if err != nil {
 		return nil, err
 	}
 
-	leagueRepository := repositories.NewLeagueRepository(db.Sql)
-	activeLeagueRepository := repositories.NewActiveLeagueRepository(db.Sql)
-	matchResultRepository := repositories.NewMatchResultRepository(db.Sql)
+	queryTimeout := cfg.Timeouts.Query
+	leagueRepository := repositories.NewLeagueRepository(db.Sql, queryTimeout)
+	activeLeagueRepository := repositories.NewActiveLeagueRepository(db.Sql, queryTimeout)
+	matchResultRepository := repositories.NewMatchResultRepository(db.Sql, queryTimeout)
+	userRepository := repositories.NewUserRepository(db.Sql, queryTimeout)
+	auditRepository := repositories.NewAuditRepository(db.Sql, queryTimeout)
+	ratingRepository := repositories.NewRatingRepository(db.Sql, queryTimeout)
+	predictionRepository := repositories.NewPredictionRepository(db.Sql, queryTimeout)
+	transactor := repositories.NewTransactor(db.Sql)
 
 	return &AppContextImpl{
+		config:                 cfg,
 		db:                     db,
 		activeLeagueRepository: activeLeagueRepository,
 		leagueRepository:       leagueRepository,
 		matchResultRepository:  matchResultRepository,
+		userRepository:         userRepository,
+		auditRepository:        auditRepository,
+		ratingRepository:       ratingRepository,
+		predictionRepository:   predictionRepository,
+		transactor:             transactor,
 	}, nil
 }
 
-func AppContextDBInit() (*DB, error) {
-	sql, err := builder.SqlConnectionInit()
+func AppContextDBInit(cfg config.MySQLConfig) (*DB, error) {
+	sql, err := builder.SqlConnectionInit(cfg)
 	if err != nil {
 
 		return nil, err
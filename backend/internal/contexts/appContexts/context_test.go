@@ -32,6 +32,19 @@ func TestAppContextImpl_DB(t *testing.T) {
 	assert.Equal(t, mockDB.Sql, result.Sql)
 }
 
+func TestAppContextImpl_Config(t *testing.T) {
+	// Create AppContext with config
+	cfg := config.Default()
+	appCtx := &AppContextImpl{
+		config: cfg,
+	}
+
+	// Test Config() method
+	result := appCtx.Config()
+
+	assert.Equal(t, cfg, result)
+}
+
 func TestAppContextImpl_LeagueRepository(t *testing.T) {
 	// Create mock repository
 	mockRepo := &interfaces.MockLeagueRepository{}
@@ -80,32 +93,66 @@ func TestAppContextImpl_MatchResultRepository(t *testing.T) {
 	assert.Equal(t, mockRepo, result)
 }
 
-func TestAppContextDBInit_Success(t *testing.T) {
-	// Save original config values
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
+func TestAppContextImpl_UserRepository(t *testing.T) {
+	// Create mock repository
+	mockRepo := &interfaces.MockUserRepository{}
+
+	// Create AppContext with mock repository
+	appCtx := &AppContextImpl{
+		userRepository: mockRepo,
+	}
+
+	// Test UserRepository() method
+	result := appCtx.UserRepository()
+
+	assert.NotNil(t, result)
+	assert.Equal(t, mockRepo, result)
+}
+
+func TestAppContextImpl_AuditRepository(t *testing.T) {
+	// Create mock repository
+	mockRepo := &interfaces.MockAuditRepository{}
+
+	// Create AppContext with mock repository
+	appCtx := &AppContextImpl{
+		auditRepository: mockRepo,
+	}
 
+	// Test AuditRepository() method
+	result := appCtx.AuditRepository()
+
+	assert.NotNil(t, result)
+	assert.Equal(t, mockRepo, result)
+}
+
+func TestAppContextImpl_Transactor(t *testing.T) {
+	// Create mock transactor
+	mockTx := &interfaces.MockTransactor{}
+
+	// Create AppContext with mock transactor
+	appCtx := &AppContextImpl{
+		transactor: mockTx,
+	}
+
+	// Test Transactor() method
+	result := appCtx.Transactor()
+
+	assert.NotNil(t, result)
+	assert.Equal(t, mockTx, result)
+}
+
+func TestAppContextDBInit_Success(t *testing.T) {
 	// Set test values
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
 	// Test AppContextDBInit
-	db, err := AppContextDBInit()
+	db, err := AppContextDBInit(cfg)
 
 	// Since we don't have a real MySQL server, we expect an error
 	// but we can test that the function handles it gracefully
@@ -125,31 +172,17 @@ func TestAppContextDBInit_Success(t *testing.T) {
 }
 
 func TestAppContextDBInit_DatabaseConnectionError(t *testing.T) {
-	// Save original config values
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set invalid connection parameters
-	config.MySQLHost = "invalid_host_that_does_not_exist"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "invalid_user"
-	config.MySQLPassword = "invalid_password"
-	config.MySQLDatabase = "invalid_db"
+	cfg := config.MySQLConfig{
+		Host:     "invalid_host_that_does_not_exist",
+		Port:     "3306",
+		User:     "invalid_user",
+		Password: "invalid_password",
+		Database: "invalid_db",
+	}
 
 	// Test AppContextDBInit with invalid parameters
-	db, err := AppContextDBInit()
+	db, err := AppContextDBInit(cfg)
 
 	// Should return an error
 	assert.Nil(t, db)
@@ -157,31 +190,17 @@ func TestAppContextDBInit_DatabaseConnectionError(t *testing.T) {
 }
 
 func TestAppContextInit_Success(t *testing.T) {
-	// Save original config values
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set test values
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
 	// Test AppContextInit
-	appCtx, err := AppContextInit()
+	appCtx, err := AppContextInit(&config.Config{MySQL: cfg})
 
 	// Since we don't have a real MySQL server, we expect an error
 	if err != nil {
@@ -208,31 +227,17 @@ func TestAppContextInit_Success(t *testing.T) {
 }
 
 func TestAppContextInit_DatabaseError(t *testing.T) {
-	// Save original config values
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after test
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set invalid connection parameters to force an error
-	config.MySQLHost = "invalid_host_that_does_not_exist"
-	config.MySQLPort = "99999"
-	config.MySQLUser = "invalid_user"
-	config.MySQLPassword = "invalid_password"
-	config.MySQLDatabase = "invalid_db"
+	cfg := config.MySQLConfig{
+		Host:     "invalid_host_that_does_not_exist",
+		Port:     "99999",
+		User:     "invalid_user",
+		Password: "invalid_password",
+		Database: "invalid_db",
+	}
 
 	// Test AppContextInit with invalid parameters
-	appCtx, err := AppContextInit()
+	appCtx, err := AppContextInit(&config.Config{MySQL: cfg})
 
 	// Should return an error and nil context
 	assert.Nil(t, appCtx)
@@ -257,33 +262,19 @@ func TestDB_Structure(t *testing.T) {
 
 // Benchmark test for AppContextInit
 func BenchmarkAppContextInit(b *testing.B) {
-	// Save original config values
-	originalHost := config.MySQLHost
-	originalPort := config.MySQLPort
-	originalUser := config.MySQLUser
-	originalPassword := config.MySQLPassword
-	originalDatabase := config.MySQLDatabase
-
-	// Restore original values after benchmark
-	defer func() {
-		config.MySQLHost = originalHost
-		config.MySQLPort = originalPort
-		config.MySQLUser = originalUser
-		config.MySQLPassword = originalPassword
-		config.MySQLDatabase = originalDatabase
-	}()
-
 	// Set test values
-	config.MySQLHost = "localhost"
-	config.MySQLPort = "3306"
-	config.MySQLUser = "test_user"
-	config.MySQLPassword = "test_password"
-	config.MySQLDatabase = "test_db"
+	cfg := config.MySQLConfig{
+		Host:     "localhost",
+		Port:     "3306",
+		User:     "test_user",
+		Password: "test_password",
+		Database: "test_db",
+	}
 
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		appCtx, err := AppContextInit()
+		appCtx, err := AppContextInit(&config.Config{MySQL: cfg})
 		if err == nil && appCtx != nil && appCtx.db != nil && appCtx.db.Sql != nil {
 			appCtx.db.Sql.Close()
 		}
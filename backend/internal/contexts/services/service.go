@@ -8,18 +8,22 @@ import (
 	interfaces2 "league-sim/internal/predict/interfaces"
 	"league-sim/internal/simulation"
 	interfaces3 "league-sim/internal/simulation/interfaces"
+	"league-sim/internal/user"
+	interfaces4 "league-sim/internal/user/interfaces"
 )
 
 type Service interface {
 	LeagueService() interfaces1.LeagueServiceInterface
 	PredictService() interfaces2.PredictServiceInterface
 	SimulationService() interfaces3.SimulationServiceInterface
+	UserService() interfaces4.UserServiceInterface
 }
 
 type ServiceImpl struct {
 	leagueService     interfaces1.LeagueServiceInterface
 	predictService    interfaces2.PredictServiceInterface
 	simulationService interfaces3.SimulationServiceInterface
+	userService       interfaces4.UserServiceInterface
 }
 
 func (s *ServiceImpl) LeagueService() interfaces1.LeagueServiceInterface {
@@ -37,13 +41,20 @@ func (s *ServiceImpl) SimulationService() interfaces3.SimulationServiceInterface
 	return s.simulationService
 }
 
+func (s *ServiceImpl) UserService() interfaces4.UserServiceInterface {
+
+	return s.userService
+}
+
 func BuildService(ctx appContext.AppContext) (*ServiceImpl, error) {
 	newLeagueService := league.NewLeagueService(ctx)
 	newPredictService := predict.NewPredictService(ctx)
 	newSimulationService := simulation.NewSimulationService(ctx)
+	newUserService := user.NewUserService(ctx)
 	return &ServiceImpl{
 		leagueService:     newLeagueService,
 		predictService:    newPredictService,
 		simulationService: newSimulationService,
+		userService:       newUserService,
 	}, nil
 }
@@ -3,11 +3,13 @@ package services
 import (
 	"testing"
 
+	"league-sim/config"
 	appContext "league-sim/internal/contexts/appContexts"
 	leagueInterfaces "league-sim/internal/league/interfaces"
 	predictInterfaces "league-sim/internal/predict/interfaces"
 	"league-sim/internal/repositories/interfaces"
 	simulationInterfaces "league-sim/internal/simulation/interfaces"
+	userInterfaces "league-sim/internal/user/interfaces"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,11 +35,41 @@ func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepositor
 	return args.Get(0).(interfaces.MatchResultRepository)
 }
 
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
 func (m *MockAppContext) DB() *appContext.DB {
 	args := m.Called()
 	return args.Get(0).(*appContext.DB)
 }
 
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
 func TestServiceImpl_LeagueService(t *testing.T) {
 	// Create mock league service
 	mockLeagueService := &leagueInterfaces.MockLeagueServiceInterface{}
@@ -86,6 +118,22 @@ func TestServiceImpl_SimulationService(t *testing.T) {
 	assert.Equal(t, mockSimulationService, result)
 }
 
+func TestServiceImpl_UserService(t *testing.T) {
+	// Create mock user service
+	mockUserService := &userInterfaces.MockUserServiceInterface{}
+
+	// Create ServiceImpl with mock user service
+	service := &ServiceImpl{
+		userService: mockUserService,
+	}
+
+	// Test UserService() method
+	result := service.UserService()
+
+	assert.NotNil(t, result)
+	assert.Equal(t, mockUserService, result)
+}
+
 func TestBuildService_Success(t *testing.T) {
 	// Create mock repositories
 	mockLeagueRepo := &interfaces.MockLeagueRepository{}
@@ -110,11 +158,13 @@ func TestBuildService_Success(t *testing.T) {
 	assert.NotNil(t, service.leagueService)
 	assert.NotNil(t, service.predictService)
 	assert.NotNil(t, service.simulationService)
+	assert.NotNil(t, service.userService)
 
 	// Test that all service getters work
 	assert.NotNil(t, service.LeagueService())
 	assert.NotNil(t, service.PredictService())
 	assert.NotNil(t, service.SimulationService())
+	assert.NotNil(t, service.UserService())
 
 	// Note: We don't assert expectations here because the actual service constructors
 	// may or may not call the repository methods depending on their implementation
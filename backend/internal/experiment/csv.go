@@ -0,0 +1,49 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"league-sim/internal/models"
+)
+
+var csvHeader = []string{
+	"league",
+	"champion",
+	"champion_strength_rank",
+	"champion_points",
+	"points_spread",
+	"matches",
+	"goals_per_game",
+	"draw_rate",
+}
+
+// WriteCSV writes one row per league of result, under a header row, for
+// analysis in a spreadsheet or a statistics package.
+func WriteCSV(w io.Writer, result models.ExperimentResult) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, run := range result.Runs {
+		err := out.Write(
+			[]string{
+				strconv.Itoa(run.League),
+				run.Champion,
+				strconv.Itoa(run.ChampionStrengthRank),
+				strconv.Itoa(run.ChampionPoints),
+				strconv.Itoa(run.PointsSpread),
+				strconv.Itoa(run.Matches),
+				strconv.FormatFloat(run.GoalsPerGame, 'f', 4, 64),
+				strconv.FormatFloat(run.DrawRate, 'f', 4, 64),
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,183 @@
+// Package experiment plays batches of synthetic leagues in memory to measure
+// how a rule set behaves, e.g. how often the strongest team wins the league.
+package experiment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+	"league-sim/internal/simulation"
+)
+
+// Run generates req.Leagues leagues of req.TeamCount random teams, plays each
+// season to the end on workers goroutines and aggregates the outcomes. The
+// request is expected to be validated already; nil rules mean the defaults.
+// Run stops early when ctx is done.
+func Run(ctx context.Context, req models.ExperimentRequest, workers int) (models.ExperimentResult, error) {
+	rules, err := league.ResolveRules(req.Rules)
+	if err != nil {
+		return models.ExperimentResult{}, apperrors.Validation("invalid rules: %v", err)
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+	workers = min(workers, req.Leagues)
+
+	runs := make([]models.ExperimentRun, req.Leagues)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runs[i] = playLeague(i+1, req.TeamCount, rules)
+			}
+		}()
+	}
+
+send:
+	for i := range runs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return models.ExperimentResult{}, apperrors.Internal(err, "experiment of %d leagues stopped early", req.Leagues)
+	}
+
+	return aggregate(req.TeamCount, rules, runs), nil
+}
+
+// playLeague plays one synthetic season and measures it.
+func playLeague(number int, teamCount int, rules models.LeagueRules) models.ExperimentRun {
+	teams := league.TeamGenerate(teamCount)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+
+	// Strength is ranked before the first match, as stamina and morale change
+	// once the season is under way.
+	strengthRank := make(map[string]int, len(teams))
+	byStrength := make([]models.Team, len(teams))
+	copy(byStrength, teams)
+	sort.SliceStable(
+		byStrength, func(i, j int) bool {
+			return league.CalculateStrength(byStrength[i], rules.StrengthWeights) >
+				league.CalculateStrength(byStrength[j], rules.StrengthWeights)
+		})
+	for i, team := range byStrength {
+		strengthRank[team.Name] = i + 1
+	}
+
+	var matches, goals, draws int
+	season := simulation.NewSeason(&activeLeague, rules)
+	for !season.Finished() {
+		for _, match := range season.PlayNextWeek() {
+			matches++
+			goals += match.HomeScore + match.AwayScore
+			if match.HomeScore == match.AwayScore {
+				draws++
+			}
+		}
+	}
+
+	table := league.RankStandings(activeLeague.Standings)
+	champion, last := table[0], table[len(table)-1]
+	run := models.ExperimentRun{
+		League:               number,
+		Champion:             champion.Team.Name,
+		ChampionStrengthRank: strengthRank[champion.Team.Name],
+		ChampionPoints:       champion.Points,
+		PointsSpread:         champion.Points - last.Points,
+		Matches:              matches,
+	}
+	if matches > 0 {
+		run.GoalsPerGame = float64(goals) / float64(matches)
+		run.DrawRate = float64(draws) / float64(matches)
+	}
+
+	return run
+}
+
+func aggregate(teamCount int, rules models.LeagueRules, runs []models.ExperimentRun) models.ExperimentResult {
+	ranks := make([]models.RankFrequency, teamCount)
+	for i := range ranks {
+		ranks[i].Rank = i + 1
+	}
+
+	championPoints := make([]float64, len(runs))
+	pointsSpread := make([]float64, len(runs))
+	goalsPerGame := make([]float64, len(runs))
+	drawRate := make([]float64, len(runs))
+	for i, run := range runs {
+		ranks[run.ChampionStrengthRank-1].Count++
+		championPoints[i] = float64(run.ChampionPoints)
+		pointsSpread[i] = float64(run.PointsSpread)
+		goalsPerGame[i] = run.GoalsPerGame
+		drawRate[i] = run.DrawRate
+	}
+	for i := range ranks {
+		ranks[i].Share = float64(ranks[i].Count) / float64(len(runs))
+	}
+
+	return models.ExperimentResult{
+		Leagues:              len(runs),
+		TeamCount:            teamCount,
+		Rules:                rules,
+		StrongestTeamWinRate: ranks[0].Share,
+		ChampionStrengthRank: ranks,
+		ChampionPoints:       summarize(championPoints),
+		PointsSpread:         summarize(pointsSpread),
+		GoalsPerGame:         summarize(goalsPerGame),
+		DrawRate:             summarize(drawRate),
+		Runs:                 runs,
+	}
+}
+
+// summarize describes values, which must not be empty. Percentiles use the
+// nearest-rank method.
+func summarize(values []float64) models.Distribution {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+
+	var squares float64
+	for _, v := range sorted {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return models.Distribution{
+		Mean:   mean,
+		StdDev: math.Sqrt(squares / float64(len(sorted))),
+		Min:    sorted[0],
+		P5:     percentile(sorted, 5),
+		Median: percentile(sorted, 50),
+		P95:    percentile(sorted, 95),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,140 @@
+package experiment
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_PlaysEveryLeague(t *testing.T) {
+	// Setup
+	req := models.ExperimentRequest{Leagues: 40, TeamCount: 6}
+
+	// Execute
+	result, err := Run(context.Background(), req, 4)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 40, result.Leagues)
+	assert.Equal(t, models.DefaultLeagueRules(), result.Rules)
+	require.Len(t, result.Runs, 40)
+	require.Len(t, result.ChampionStrengthRank, 6)
+
+	total := 0
+	for i, rank := range result.ChampionStrengthRank {
+		assert.Equal(t, i+1, rank.Rank)
+		total += rank.Count
+	}
+	assert.Equal(t, 40, total)
+	assert.Equal(t, result.ChampionStrengthRank[0].Share, result.StrongestTeamWinRate)
+
+	for i, run := range result.Runs {
+		assert.Equal(t, i+1, run.League)
+		assert.Equal(t, 15, run.Matches, "a single round robin of 6 teams")
+		assert.GreaterOrEqual(t, run.PointsSpread, 0)
+		assert.LessOrEqual(t, float64(run.ChampionPoints), result.ChampionPoints.Max)
+	}
+	assert.LessOrEqual(t, result.GoalsPerGame.Min, result.GoalsPerGame.Median)
+	assert.LessOrEqual(t, result.GoalsPerGame.Median, result.GoalsPerGame.Max)
+}
+
+func TestRun_OddTeamCount(t *testing.T) {
+	// Execute
+	result, err := Run(context.Background(), models.ExperimentRequest{Leagues: 5, TeamCount: 5}, 2)
+
+	// Assert
+	require.NoError(t, err)
+	for _, run := range result.Runs {
+		assert.Equal(t, 10, run.Matches, "byes are not matches")
+	}
+}
+
+func TestRun_AppliesRules(t *testing.T) {
+	// Setup
+	rules := models.DefaultLeagueRules()
+	rules.Draw = models.DrawModel{Chance: 0, MaxGoals: 2}
+
+	// Execute
+	result, err := Run(context.Background(), models.ExperimentRequest{Leagues: 10, TeamCount: 4, Rules: &rules}, 3)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, rules, result.Rules)
+	assert.Equal(t, models.Distribution{}, result.DrawRate)
+	assert.GreaterOrEqual(t, result.GoalsPerGame.Min, 1.0, "every match has a winner")
+	assert.Positive(t, result.PointsSpread.Min)
+}
+
+func TestRun_InvalidRules(t *testing.T) {
+	// Setup
+	rules := models.DefaultLeagueRules()
+	rules.HomeAdvantage = 0
+
+	// Execute
+	_, err := Run(context.Background(), models.ExperimentRequest{Leagues: 1, TeamCount: 4, Rules: &rules}, 1)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestRun_StopsWhenCancelled(t *testing.T) {
+	// Setup
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	_, err := Run(ctx, models.ExperimentRequest{Leagues: 1000, TeamCount: 20}, 2)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSummarize(t *testing.T) {
+	// Execute
+	got := summarize([]float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10})
+
+	// Assert
+	assert.Equal(t, 5.5, got.Mean)
+	assert.InDelta(t, 2.872, got.StdDev, 0.001)
+	assert.Equal(t, 1.0, got.Min)
+	assert.Equal(t, 1.0, got.P5)
+	assert.Equal(t, 5.0, got.Median)
+	assert.Equal(t, 10.0, got.P95)
+	assert.Equal(t, 10.0, got.Max)
+}
+
+func TestWriteCSV(t *testing.T) {
+	// Setup
+	result := models.ExperimentResult{
+		Runs: []models.ExperimentRun{
+			{
+				League: 1, Champion: "Team B", ChampionStrengthRank: 2, ChampionPoints: 7, PointsSpread: 6,
+				Matches: 6, GoalsPerGame: 2.5, DrawRate: 1.0 / 3,
+			},
+		},
+	}
+	var buf bytes.Buffer
+
+	// Execute
+	err := WriteCSV(&buf, result)
+
+	// Assert
+	require.NoError(t, err)
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(
+		t, [][]string{
+			{
+				"league", "champion", "champion_strength_rank", "champion_points", "points_spread", "matches",
+				"goals_per_game", "draw_rate",
+			},
+			{"1", "Team B", "2", "7", "6", "6", "2.5000", "0.3333"},
+		}, rows)
+}
@@ -0,0 +1,97 @@
+package forecast
+
+import (
+	"math"
+
+	"league-sim/internal/models"
+)
+
+// calibrationBins splits odds into bins of ten percentage points.
+const calibrationBins = 10
+
+// minProbability floors the odds of the champion in the log loss. Monte Carlo
+// odds from a limited number of runs can be zero for a team that goes on to
+// win, which would otherwise make the loss infinite.
+const minProbability = 1e-4
+
+// Accuracy accumulates the scores of title predictions, possibly of several
+// leagues.
+type Accuracy struct {
+	snapshots int
+	brier     float64
+	logLoss   float64
+	bins      [calibrationBins]struct {
+		count     int
+		predicted float64
+		won       int
+	}
+	weeks []models.WeekAccuracy
+}
+
+// Add scores the predictions made before week against the league's champion.
+func (a *Accuracy) Add(week int, predictions []models.PredictedStanding, champion string) {
+	var brier, championOdds float64
+	for _, p := range predictions {
+		odds := p.Odds / 100
+		won := 0.0
+		if p.TeamName == champion {
+			won = 1
+			championOdds = odds
+		}
+		brier += (odds - won) * (odds - won)
+
+		bin := &a.bins[min(int(odds*calibrationBins), calibrationBins-1)]
+		bin.count++
+		bin.predicted += odds
+		bin.won += int(won)
+	}
+	logLoss := -math.Log(math.Max(championOdds, minProbability))
+
+	a.snapshots++
+	a.brier += brier
+	a.logLoss += logLoss
+	a.weeks = append(
+		a.weeks, models.WeekAccuracy{Week: week, ChampionOdds: championOdds, BrierScore: brier, LogLoss: logLoss})
+}
+
+// Result averages the scores added so far. Only bins with predictions are
+// listed.
+func (a *Accuracy) Result() models.PredictionAccuracy {
+	result := models.PredictionAccuracy{
+		Snapshots:   a.snapshots,
+		Calibration: []models.CalibrationBin{},
+		Weeks:       a.weeks,
+	}
+	if a.snapshots > 0 {
+		result.BrierScore = a.brier / float64(a.snapshots)
+		result.LogLoss = a.logLoss / float64(a.snapshots)
+	}
+
+	for i, bin := range a.bins {
+		if bin.count == 0 {
+			continue
+		}
+		result.Calibration = append(
+			result.Calibration, models.CalibrationBin{
+				From:          float64(i) / calibrationBins,
+				To:            float64(i+1) / calibrationBins,
+				Predictions:   bin.count,
+				MeanPredicted: bin.predicted / float64(bin.count),
+				Observed:      float64(bin.won) / float64(bin.count),
+			})
+	}
+
+	return result
+}
+
+// Score rates the snapshots of one league against its champion.
+func Score(snapshots []models.PredictionSnapshot, champion string) models.PredictionAccuracy {
+	var accuracy Accuracy
+	for _, snapshot := range snapshots {
+		accuracy.Add(snapshot.Week, snapshot.Predictions, champion)
+	}
+
+	result := accuracy.Result()
+	result.Champion = champion
+	return result
+}
@@ -0,0 +1,76 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	// Setup
+	snapshots := []models.PredictionSnapshot{
+		{Week: 1, Predictions: []models.PredictedStanding{{TeamName: "A", Odds: 40}, {TeamName: "B", Odds: 60}}},
+		{Week: 2, Predictions: []models.PredictedStanding{{TeamName: "A", Odds: 80}, {TeamName: "B", Odds: 20}}},
+	}
+
+	// Execute
+	accuracy := Score(snapshots, "A")
+
+	// Assert
+	assert.Equal(t, "A", accuracy.Champion)
+	assert.Equal(t, 2, accuracy.Snapshots)
+	assert.InDelta(t, (0.72+0.08)/2, accuracy.BrierScore, 1e-9)
+	assert.InDelta(t, -(math.Log(0.4)+math.Log(0.8))/2, accuracy.LogLoss, 1e-9)
+	if assert.Len(t, accuracy.Weeks, 2) {
+		assert.Equal(t, 2, accuracy.Weeks[1].Week)
+		assert.InDelta(t, 0.8, accuracy.Weeks[1].ChampionOdds, 1e-9)
+	}
+}
+
+func TestScore_CalibrationBins(t *testing.T) {
+	// Setup
+	snapshots := []models.PredictionSnapshot{
+		{Week: 1, Predictions: []models.PredictedStanding{{TeamName: "A", Odds: 75}, {TeamName: "B", Odds: 25}}},
+		{Week: 2, Predictions: []models.PredictedStanding{{TeamName: "A", Odds: 100}, {TeamName: "B", Odds: 0}}},
+	}
+
+	// Execute
+	accuracy := Score(snapshots, "B")
+
+	// Assert
+	assert.Equal(
+		t, []models.CalibrationBin{
+			{From: 0, To: 0.1, Predictions: 1, MeanPredicted: 0, Observed: 1},
+			{From: 0.2, To: 0.3, Predictions: 1, MeanPredicted: 0.25, Observed: 1},
+			{From: 0.7, To: 0.8, Predictions: 1, MeanPredicted: 0.75, Observed: 0},
+			{From: 0.9, To: 1, Predictions: 1, MeanPredicted: 1, Observed: 0},
+		}, accuracy.Calibration)
+}
+
+func TestScore_ZeroOddsForChampion(t *testing.T) {
+	// Setup
+	snapshots := []models.PredictionSnapshot{
+		{Week: 1, Predictions: []models.PredictedStanding{{TeamName: "A", Odds: 100}, {TeamName: "B", Odds: 0}}},
+	}
+
+	// Execute
+	accuracy := Score(snapshots, "B")
+
+	// Assert
+	assert.InDelta(t, -math.Log(minProbability), accuracy.LogLoss, 1e-9)
+	assert.False(t, math.IsInf(accuracy.LogLoss, 0))
+}
+
+func TestAccuracy_Empty(t *testing.T) {
+	// Execute
+	var accuracy Accuracy
+	result := accuracy.Result()
+
+	// Assert
+	assert.Zero(t, result.Snapshots)
+	assert.Zero(t, result.LogLoss)
+	assert.Empty(t, result.Calibration)
+}
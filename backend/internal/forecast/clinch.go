@@ -0,0 +1,361 @@
+package forecast
+
+import (
+	"sort"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+)
+
+// MaxExhaustiveMatches bounds the exhaustive search, which plays out every
+// win, draw and loss of the remaining matches: 3^12 is about half a million.
+const MaxExhaustiveMatches = 12
+
+// fixture is a remaining match between two teams of the table, by index.
+type fixture struct {
+	home int
+	away int
+}
+
+// table is a league as the clinch analysis sees it: points so far and the
+// matches left to play.
+type table struct {
+	rules    models.LeagueRules
+	names    []string
+	points   []int
+	fixtures []fixture
+	// remaining counts each team's matches left and against counts those
+	// against each opponent.
+	remaining []int
+	against   [][]int
+}
+
+func newTable(standings []models.Standings, upcoming []models.Week, rules models.LeagueRules) *table {
+	t := &table{
+		rules:     rules,
+		names:     make([]string, len(standings)),
+		points:    make([]int, len(standings)),
+		remaining: make([]int, len(standings)),
+		against:   make([][]int, len(standings)),
+	}
+
+	index := make(map[string]int, len(standings))
+	for i, s := range standings {
+		t.names[i] = s.Team.Name
+		t.points[i] = s.Points
+		t.against[i] = make([]int, len(standings))
+		index[s.Team.Name] = i
+	}
+
+	for _, week := range upcoming {
+		for _, match := range week.Matches {
+			if match.Home == nil || match.Away == nil {
+				continue
+			}
+			home, okHome := index[match.Home.Name]
+			away, okAway := index[match.Away.Name]
+			if !okHome || !okAway {
+				continue
+			}
+			t.fixtures = append(t.fixtures, fixture{home: home, away: away})
+			t.remaining[home]++
+			t.remaining[away]++
+			t.against[home][away]++
+			t.against[away][home]++
+		}
+	}
+
+	return t
+}
+
+// bonus is what a team can add to the points of any result by scoring enough.
+func (t *table) bonus() int {
+	if t.rules.BonusPoints.Points > 0 && t.rules.BonusPoints.GoalThreshold > 0 {
+		return t.rules.BonusPoints.Points
+	}
+
+	return 0
+}
+
+// leastPerMatch is the fewest points a result can give a team.
+func (t *table) leastPerMatch() int {
+	return min(t.rules.PointsForLoss, t.rules.PointsForDraw)
+}
+
+func (t *table) maxPoints(i int) int {
+	return t.points[i] + t.remaining[i]*(max(t.rules.PointsForWin, t.rules.PointsForDraw)+t.bonus())
+}
+
+// minPointsBeatenBy is the fewest points team i ends with when it loses every
+// match against j.
+func (t *table) minPointsBeatenBy(i int, j int) int {
+	games := t.against[i][j]
+	return t.points[i] + games*t.rules.PointsForLoss + (t.remaining[i]-games)*t.leastPerMatch()
+}
+
+// Positions returns the best and worst position every team of standings can
+// still finish in, in the order of standings, given the upcoming fixtures.
+//
+// Bounds compares teams pair by pair, taking their matches against each other
+// into account. Max-flow also checks, for the title, whether the other teams
+// can share out the points of their remaining matches without passing a team
+// that wins all of its own; the points a match hands out are relaxed to any
+// split of the fewest it can give, so an elimination it finds is certain. The
+// exhaustive search plays out every result and is exact.
+func Positions(
+	standings []models.Standings, upcoming []models.Week, rules models.LeagueRules, method string,
+) (best []int, worst []int, err error) {
+	t := newTable(standings, upcoming, rules)
+
+	switch method {
+	case models.ClinchMethodExhaustive:
+		if len(t.fixtures) > MaxExhaustiveMatches {
+			return nil, nil, apperrors.Validation(
+				"exhaustive search is limited to %d remaining matches, %d are left",
+				MaxExhaustiveMatches, len(t.fixtures))
+		}
+		best, worst = t.exhaustive()
+	case models.ClinchMethodMaxFlow:
+		best, worst = t.bounds()
+		for i := range best {
+			if best[i] == 1 && !t.canWinTitle(i) {
+				best[i] = 2
+			}
+		}
+	default:
+		best, worst = t.bounds()
+	}
+
+	return best, worst, nil
+}
+
+func (t *table) bounds() (best []int, worst []int) {
+	n := len(t.names)
+	best = make([]int, n)
+	worst = make([]int, n)
+	for i := 0; i < n; i++ {
+		best[i], worst[i] = 1, 1
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			// j finishes above i even if i wins everything and j loses to it.
+			if t.minPointsBeatenBy(j, i) > t.maxPoints(i) {
+				best[i]++
+			}
+			// j can draw level with i by winning everything, i losing to it.
+			if t.maxPoints(j) >= t.minPointsBeatenBy(i, j) {
+				worst[i]++
+			}
+		}
+	}
+
+	return best, worst
+}
+
+// canWinTitle reports whether team i can finish level with or above every
+// other team, as far as the relaxed max-flow can tell.
+func (t *table) canWinTitle(i int) bool {
+	n := len(t.names)
+	top := t.maxPoints(i)
+	least := t.leastPerMatch()
+
+	// Every other team takes the fewest points from each match, the loss from
+	// its matches against i, and has room up to top for more.
+	room := make([]int, n)
+	for j := 0; j < n; j++ {
+		if j == i {
+			continue
+		}
+		room[j] = top - t.minPointsBeatenBy(j, i)
+		if room[j] < 0 {
+			return false
+		}
+	}
+
+	// Beyond the fewest for each side, a match between two other teams hands
+	// out at least its cheapest result's points.
+	extra := min(t.rules.PointsForWin+t.rules.PointsForLoss, 2*t.rules.PointsForDraw) - 2*least
+	var matches []fixture
+	for _, f := range t.fixtures {
+		if f.home != i && f.away != i && extra > 0 {
+			matches = append(matches, f)
+		}
+	}
+	if len(matches) == 0 {
+		return true
+	}
+
+	// source -> match -> either side -> sink, limited by each team's room.
+	source, sink := 0, 1+len(matches)+n
+	g := newFlowGraph(sink + 1)
+	for m, f := range matches {
+		g.add(source, 1+m, extra)
+		g.add(1+m, 1+len(matches)+f.home, extra)
+		g.add(1+m, 1+len(matches)+f.away, extra)
+	}
+	for j := 0; j < n; j++ {
+		if j != i && room[j] > 0 {
+			g.add(1+len(matches)+j, sink, room[j])
+		}
+	}
+
+	return g.maxFlow(source, sink) == extra*len(matches)
+}
+
+func (t *table) exhaustive() (best []int, worst []int) {
+	n := len(t.names)
+	best = make([]int, n)
+	worst = make([]int, n)
+	for i := range best {
+		best[i] = n + 1
+	}
+
+	bonus := make([]int, n)
+	for i := range bonus {
+		bonus[i] = t.remaining[i] * t.bonus()
+	}
+
+	points := make([]int, n)
+	copy(points, t.points)
+	outcomes := [][2]int{
+		{t.rules.PointsForWin, t.rules.PointsForLoss},
+		{t.rules.PointsForDraw, t.rules.PointsForDraw},
+		{t.rules.PointsForLoss, t.rules.PointsForWin},
+	}
+
+	var play func(m int)
+	play = func(m int) {
+		if m == len(t.fixtures) {
+			for i := 0; i < n; i++ {
+				// Bonus points go to i alone for its best position and to
+				// everyone else for its worst.
+				above, levelOrAbove := 1, 1
+				for j := 0; j < n; j++ {
+					if j == i {
+						continue
+					}
+					if points[j] > points[i]+bonus[i] {
+						above++
+					}
+					if points[j]+bonus[j] >= points[i] {
+						levelOrAbove++
+					}
+				}
+				best[i] = min(best[i], above)
+				worst[i] = max(worst[i], levelOrAbove)
+			}
+			return
+		}
+
+		f := t.fixtures[m]
+		for _, outcome := range outcomes {
+			points[f.home] += outcome[0]
+			points[f.away] += outcome[1]
+			play(m + 1)
+			points[f.home] -= outcome[0]
+			points[f.away] -= outcome[1]
+		}
+	}
+	play(0)
+
+	return best, worst
+}
+
+// Clinch analyses where every team of standings can finish for the title, the
+// top places and, when relegation is set, the places above the relegation
+// zone. An empty method means bounds.
+func Clinch(
+	standings []models.Standings, upcoming []models.Week, rules models.LeagueRules, req models.GetClinchRequest,
+) (models.ClinchAnalysis, error) {
+	method := req.Method
+	if method == "" {
+		method = models.ClinchMethodBounds
+	}
+	if req.Top >= len(standings) && req.Top > 0 {
+		return models.ClinchAnalysis{}, apperrors.Validation(
+			"top must be below the number of teams, %d", len(standings))
+	}
+	if req.Relegation >= len(standings) && req.Relegation > 0 {
+		return models.ClinchAnalysis{}, apperrors.Validation(
+			"relegation must be below the number of teams, %d", len(standings))
+	}
+
+	best, worst, err := Positions(standings, upcoming, rules, method)
+	if err != nil {
+		return models.ClinchAnalysis{}, err
+	}
+
+	type target struct {
+		name   string
+		places int
+	}
+	targets := []target{{models.ClinchTargetTitle, 1}}
+	if req.Top > 1 {
+		targets = append(targets, target{models.ClinchTargetTop, req.Top})
+	}
+	if req.Relegation > 0 {
+		targets = append(targets, target{models.ClinchTargetSafety, len(standings) - req.Relegation})
+	}
+
+	t := newTable(standings, upcoming, rules)
+	analysis := models.ClinchAnalysis{
+		Method:           method,
+		RemainingMatches: len(t.fixtures),
+		Teams:            make([]models.TeamClinch, 0, len(standings)),
+	}
+	for i := range standings {
+		team := models.TeamClinch{
+			Team:          t.names[i],
+			Points:        t.points[i],
+			MaxPoints:     t.maxPoints(i),
+			BestPosition:  best[i],
+			WorstPosition: worst[i],
+		}
+		for _, target := range targets {
+			team.Targets = append(team.Targets, t.status(i, target.name, target.places, best[i], worst[i]))
+		}
+		analysis.Teams = append(analysis.Teams, team)
+	}
+
+	sort.SliceStable(
+		analysis.Teams, func(a, b int) bool { return analysis.Teams[a].Points > analysis.Teams[b].Points })
+
+	return analysis, nil
+}
+
+// status judges team i against the top places. Its magic number is measured
+// against the rival whose best total decides the last of those places, and
+// its elimination number against the rival now holding it.
+func (t *table) status(i int, name string, places int, best int, worst int) models.ClinchStatus {
+	status := models.ClinchStatus{
+		Target:     name,
+		Places:     places,
+		Clinched:   worst <= places,
+		Eliminated: best > places,
+	}
+
+	var rivalsMax, rivalsPoints []int
+	for j := range t.names {
+		if j != i {
+			rivalsMax = append(rivalsMax, t.maxPoints(j))
+			rivalsPoints = append(rivalsPoints, t.points[j])
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(rivalsMax)))
+	sort.Sort(sort.Reverse(sort.IntSlice(rivalsPoints)))
+
+	if len(rivalsMax) < places {
+		status.Clinched, status.Eliminated = true, false
+		return status
+	}
+
+	if !status.Clinched {
+		status.MagicNumber = max(1, rivalsMax[places-1]+1-t.points[i])
+	}
+	if !status.Eliminated {
+		status.EliminationNumber = max(1, t.maxPoints(i)-rivalsPoints[places-1]+1)
+	}
+
+	return status
+}
@@ -0,0 +1,165 @@
+package forecast
+
+import (
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clinchLeague builds standings from points and the weeks of upcoming pairs
+// of team names.
+func clinchLeague(points map[string]int, order []string, weeks ...[][2]string) ([]models.Standings, []models.Week) {
+	teams := make(map[string]*models.Team, len(order))
+	standings := make([]models.Standings, 0, len(order))
+	for _, name := range order {
+		teams[name] = &models.Team{Name: name}
+		standings = append(standings, models.Standings{Team: *teams[name], Points: points[name]})
+	}
+
+	upcoming := make([]models.Week, 0, len(weeks))
+	for i, pairs := range weeks {
+		week := models.Week{Number: i + 1}
+		for _, pair := range pairs {
+			week.Matches = append(week.Matches, models.Match{Home: teams[pair[0]], Away: teams[pair[1]]})
+		}
+		upcoming = append(upcoming, week)
+	}
+
+	return standings, upcoming
+}
+
+func TestPositions_BoundsUseHeadToHead(t *testing.T) {
+	// Setup: A leads B by 3 with only their meeting left, so B can only draw
+	// level by beating A.
+	standings, upcoming := clinchLeague(
+		map[string]int{"A": 9, "B": 6, "C": 1}, []string{"A", "B", "C"}, [][2]string{{"A", "B"}})
+
+	// Execute
+	best, worst, err := Positions(standings, upcoming, models.DefaultLeagueRules(), models.ClinchMethodBounds)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 3}, best)
+	assert.Equal(t, []int{2, 2, 3}, worst)
+}
+
+func TestPositions_MaxFlowFindsEliminationBoundsMiss(t *testing.T) {
+	// Setup: C can reach 6 by winning its last match, and A and B stay on 6
+	// only by losing, but one of them must take points when they meet.
+	standings, upcoming := clinchLeague(
+		map[string]int{"A": 6, "B": 6, "C": 3, "D": 0}, []string{"A", "B", "C", "D"},
+		[][2]string{{"A", "B"}, {"C", "D"}})
+
+	// Execute
+	boundsBest, _, err := Positions(standings, upcoming, models.DefaultLeagueRules(), models.ClinchMethodBounds)
+	require.NoError(t, err)
+	flowBest, _, err := Positions(standings, upcoming, models.DefaultLeagueRules(), models.ClinchMethodMaxFlow)
+	require.NoError(t, err)
+	exactBest, _, err := Positions(standings, upcoming, models.DefaultLeagueRules(), models.ClinchMethodExhaustive)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, boundsBest[2], "bounds miss the meeting of A and B")
+	assert.Equal(t, 2, flowBest[2])
+	assert.Equal(t, 2, exactBest[2])
+}
+
+func TestPositions_ExhaustiveIsExact(t *testing.T) {
+	// Setup
+	standings, upcoming := clinchLeague(
+		map[string]int{"A": 7, "B": 6, "C": 4, "D": 0}, []string{"A", "B", "C", "D"},
+		[][2]string{{"A", "D"}, {"B", "C"}}, [][2]string{{"A", "C"}, {"B", "D"}})
+
+	// Execute
+	best, worst, err := Positions(standings, upcoming, models.DefaultLeagueRules(), models.ClinchMethodExhaustive)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 1, 1, 3}, best)
+	assert.Equal(t, []int{3, 4, 4, 4}, worst)
+}
+
+func TestPositions_ExhaustiveLimit(t *testing.T) {
+	// Setup
+	order := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	var weeks [][][2]string
+	for range 4 {
+		weeks = append(weeks, [][2]string{{"A", "B"}, {"C", "D"}, {"E", "F"}, {"G", "H"}})
+	}
+	standings, upcoming := clinchLeague(map[string]int{}, order, weeks...)
+
+	// Execute
+	_, _, err := Positions(standings, upcoming, models.DefaultLeagueRules(), models.ClinchMethodExhaustive)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestClinch_MagicNumbers(t *testing.T) {
+	// Setup: A can be caught by B, which can reach 12.
+	standings, upcoming := clinchLeague(
+		map[string]int{"A": 10, "B": 6, "C": 0, "D": 0}, []string{"A", "B", "C", "D"},
+		[][2]string{{"A", "C"}, {"B", "D"}}, [][2]string{{"A", "D"}, {"B", "C"}})
+
+	// Execute
+	analysis, err := Clinch(
+		standings, upcoming, models.DefaultLeagueRules(), models.GetClinchRequest{Top: 2, Relegation: 1})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.ClinchMethodBounds, analysis.Method)
+	assert.Equal(t, 4, analysis.RemainingMatches)
+	require.Len(t, analysis.Teams, 4)
+
+	leader := analysis.Teams[0]
+	assert.Equal(t, "A", leader.Team)
+	assert.Equal(t, 16, leader.MaxPoints)
+	require.Len(t, leader.Targets, 3)
+	title := leader.Targets[0]
+	assert.Equal(t, models.ClinchTargetTitle, title.Target)
+	assert.False(t, title.Clinched)
+	assert.Equal(t, 3, title.MagicNumber, "B can reach 12, so A needs 13")
+	top := leader.Targets[1]
+	assert.Equal(t, models.ClinchTargetTop, top.Target)
+	assert.True(t, top.Clinched)
+	assert.Zero(t, top.MagicNumber)
+	safety := leader.Targets[2]
+	assert.Equal(t, models.ClinchTargetSafety, safety.Target)
+	assert.Equal(t, 3, safety.Places)
+	assert.True(t, safety.Clinched)
+
+	last := analysis.Teams[3]
+	assert.True(t, last.Targets[0].Eliminated, "6 points at most cannot catch A's 10")
+	assert.Zero(t, last.Targets[0].EliminationNumber)
+	assert.False(t, last.Targets[2].Eliminated)
+}
+
+func TestClinch_InvalidTop(t *testing.T) {
+	// Setup
+	standings, upcoming := clinchLeague(map[string]int{}, []string{"A", "B"}, [][2]string{{"A", "B"}})
+
+	// Execute
+	_, err := Clinch(standings, upcoming, models.DefaultLeagueRules(), models.GetClinchRequest{Top: 2})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestMaxFlow(t *testing.T) {
+	// Setup: two paths of 3 and 2 share a last edge of 4.
+	g := newFlowGraph(4)
+	g.add(0, 1, 3)
+	g.add(0, 2, 2)
+	g.add(1, 3, 4)
+	g.add(2, 1, 2)
+
+	// Execute
+	flow := g.maxFlow(0, 3)
+
+	// Assert
+	assert.Equal(t, 4, flow)
+}
@@ -0,0 +1,61 @@
+package forecast
+
+// flowGraph is a small capacity matrix for the max-flow elimination check.
+// The graphs are a few hundred nodes at most: one per remaining match and one
+// per team.
+type flowGraph struct {
+	capacity [][]int
+}
+
+func newFlowGraph(nodes int) *flowGraph {
+	capacity := make([][]int, nodes)
+	for i := range capacity {
+		capacity[i] = make([]int, nodes)
+	}
+
+	return &flowGraph{capacity: capacity}
+}
+
+func (g *flowGraph) add(from int, to int, capacity int) {
+	g.capacity[from][to] += capacity
+}
+
+// maxFlow runs Edmonds-Karp, augmenting along shortest paths, and leaves the
+// residual capacities behind.
+func (g *flowGraph) maxFlow(source int, sink int) int {
+	flow := 0
+	parent := make([]int, len(g.capacity))
+	for {
+		for i := range parent {
+			parent[i] = -1
+		}
+		parent[source] = source
+		queue := []int{source}
+		for len(queue) > 0 && parent[sink] == -1 {
+			node := queue[0]
+			queue = queue[1:]
+			for next, c := range g.capacity[node] {
+				if c > 0 && parent[next] == -1 {
+					parent[next] = node
+					queue = append(queue, next)
+				}
+			}
+		}
+		if parent[sink] == -1 {
+			return flow
+		}
+
+		bottleneck := -1
+		for node := sink; node != source; node = parent[node] {
+			c := g.capacity[parent[node]][node]
+			if bottleneck == -1 || c < bottleneck {
+				bottleneck = c
+			}
+		}
+		for node := sink; node != source; node = parent[node] {
+			g.capacity[parent[node]][node] -= bottleneck
+			g.capacity[node][parent[node]] += bottleneck
+		}
+		flow += bottleneck
+	}
+}
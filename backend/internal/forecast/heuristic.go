@@ -0,0 +1,134 @@
+// Package forecast predicts who wins a league and scores those predictions
+// once the league's season is over.
+package forecast
+
+import (
+	"league-sim/config"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+	"league-sim/internal/rating"
+)
+
+// Heuristic gives every team a score from its points and strength, weighted
+// by weights, and shares the title odds out in proportion to the scores of
+// teams that can still win the title with the upcoming fixtures, as far as
+// the max-flow check of Positions can tell. Once every match is played the
+// leader, on goal difference if points are level, has all of the odds.
+func Heuristic(
+	standings []models.Standings, upcoming []models.Week, rules models.LeagueRules, weights config.PredictConfig,
+) []models.PredictedStanding {
+	if len(standings) == 0 {
+		return []models.PredictedStanding{}
+	}
+
+	totalScore := 0.0
+
+	type scoredTeam struct {
+		TeamName string
+		Score    float64
+		Adjusted float64
+		Points   int
+		Strength float64
+		Played   int
+		Goals    int
+		Against  int
+		Team     models.Team
+	}
+
+	var scored []scoredTeam
+
+	for _, s := range standings {
+		str := teamStrength(s.Team, rules)
+		score := float64(s.Points)*weights.WeightPoints + str*weights.WeightStrength
+		scored = append(
+			scored, scoredTeam{
+				TeamName: s.Team.Name,
+				Score:    score,
+				Points:   s.Points,
+				Strength: str,
+				Played:   s.Played,
+				Goals:    s.Goals,
+				Against:  s.Against,
+				Team:     s.Team,
+			})
+		totalScore += score
+	}
+
+	avgScore := totalScore / float64(len(scored))
+	totalAdjusted := 0.0
+
+	best, _, _ := Positions(standings, upcoming, rules, models.ClinchMethodMaxFlow)
+	allRemainingMatchesZero := len(newTable(standings, upcoming, rules).fixtures) == 0
+
+	if allRemainingMatchesZero {
+
+		leader := scored[0]
+		for _, s := range scored[1:] {
+			if s.Points > leader.Points {
+				leader = s
+			} else if s.Points == leader.Points {
+				if (s.Goals - s.Against) > (leader.Goals - leader.Against) {
+					leader = s
+				}
+			}
+		}
+
+		var result []models.PredictedStanding
+		for _, s := range scored {
+			eliminated := s.TeamName != leader.TeamName
+			odds := 0.0
+			if !eliminated {
+				odds = 100.0
+			}
+			result = append(
+				result, models.PredictedStanding{
+					TeamName:   s.TeamName,
+					Points:     s.Points,
+					Strength:   s.Strength,
+					Odds:       odds,
+					Eliminated: eliminated,
+				})
+		}
+
+		return result
+	}
+
+	for i := range scored {
+		adjusted := scored[i].Score / avgScore
+		if best[i] > 1 {
+			adjusted = 0
+		}
+		scored[i].Adjusted = adjusted
+		totalAdjusted += adjusted
+	}
+
+	var result []models.PredictedStanding
+
+	for _, s := range scored {
+		eliminated := s.Adjusted == 0
+		odds := 0.0
+		if !eliminated && totalAdjusted > 0 {
+			odds = s.Adjusted / totalAdjusted * 100
+		}
+		result = append(
+			result, models.PredictedStanding{
+				TeamName:   s.TeamName,
+				Points:     s.Points,
+				Strength:   s.Strength,
+				Odds:       odds,
+				Eliminated: eliminated,
+			})
+	}
+
+	return result
+}
+
+// teamStrength is the static strength of team, or the strength its Elo rating
+// stands for when the rules let ratings into the predictor.
+func teamStrength(team models.Team, rules models.LeagueRules) float64 {
+	if rules.Elo != nil && rules.Elo.InPredictor && team.Rating != 0 {
+		return rating.Strength(team.Rating)
+	}
+
+	return league.CalculateStrength(team, rules.StrengthWeights)
+}
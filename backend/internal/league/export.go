@@ -0,0 +1,151 @@
+package league
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ValidateExport checks that an export document describes a league that can
+// be recreated: a supported version, a name, at least two distinct teams, and
+// standings, fixtures and results that only name those teams. keepID also
+// requires the league's ID to be a UUID.
+func ValidateExport(export models.LeagueExport, keepID bool) error {
+	if export.Version != models.LeagueExportVersion {
+		return apperrors.Validation(
+			"unsupported export version %d, expected %d", export.Version, models.LeagueExportVersion)
+	}
+
+	var fields []apperrors.FieldError
+	invalid := func(field string, format string, args ...any) {
+		fields = append(fields, apperrors.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	league := export.League
+	if keepID {
+		if _, err := uuid.Parse(league.LeagueID); err != nil {
+			invalid("league.leagueId", "must be a UUID to keep it, or import under a new ID")
+		}
+	}
+	if league.LeagueName == "" {
+		invalid("league.leagueName", "is required")
+	}
+
+	teams := make(map[string]bool, len(league.Teams))
+	for i, team := range league.Teams {
+		if team.Name == "" || teams[team.Name] {
+			invalid(fmt.Sprintf("league.teams[%d]", i), "must have a name no other team has")
+		}
+		teams[team.Name] = true
+	}
+	if len(league.Teams) < 2 {
+		invalid("league.teams", "must list at least two teams")
+	}
+
+	if len(league.Standings) != len(league.Teams) {
+		invalid("league.standings", "must have one row per team")
+	}
+	for i, s := range league.Standings {
+		if !teams[s.Team.Name] {
+			invalid(fmt.Sprintf("league.standings[%d]", i), "is not a team in this league")
+		}
+	}
+
+	played := map[int]bool{}
+	check := func(field string, weeks []models.Week, isPlayed bool) {
+		for i, week := range weeks {
+			played[week.Number] = played[week.Number] || isPlayed
+			for j, match := range week.Matches {
+				if match.Home == nil || match.Away == nil || !teams[match.Home.Name] || !teams[match.Away.Name] {
+					invalid(fmt.Sprintf("%s[%d].matches[%d]", field, i, j), "is not between teams in this league")
+				}
+			}
+		}
+	}
+	check("league.playedFixtures", league.PlayedFixtures, true)
+	check("league.upcomingFixtures", league.UpcomingFixtures, false)
+
+	for i, result := range export.Results {
+		field := fmt.Sprintf("results[%d]", i)
+		switch {
+		case !teams[result.Home] || !teams[result.Away]:
+			invalid(field, "is not between teams in this league")
+		case result.HomeScore < 0 || result.AwayScore < 0:
+			invalid(field, "must not have a negative score")
+		case !played[result.MatchWeek]:
+			invalid(field, "must be in a played week")
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperrors.InvalidFields(fields...)
+	}
+
+	return nil
+}
+
+// WriteExportCSV writes one table of an export, under a header row.
+func WriteExportCSV(w io.Writer, export models.LeagueExport, table string) error {
+	var rows [][]string
+	switch table {
+	case models.ExportTableStandings:
+		rows = append(
+			rows, []string{"position", "team", "played", "wins", "draws", "losses", "goals", "against", "points"})
+		for i, s := range RankStandings(export.League.Standings) {
+			rows = append(
+				rows, []string{
+					strconv.Itoa(i + 1),
+					s.Team.Name,
+					strconv.Itoa(s.Played),
+					strconv.Itoa(s.Wins),
+					strconv.Itoa(s.Played - s.Wins - s.Losses),
+					strconv.Itoa(s.Losses),
+					strconv.Itoa(s.Goals),
+					strconv.Itoa(s.Against),
+					strconv.Itoa(s.Points),
+				})
+		}
+	case models.ExportTableResults:
+		rows = append(rows, []string{"week", "home", "home_score", "away", "away_score", "winner"})
+		for _, r := range export.Results {
+			rows = append(
+				rows, []string{
+					strconv.Itoa(r.MatchWeek),
+					r.Home,
+					strconv.Itoa(r.HomeScore),
+					r.Away,
+					strconv.Itoa(r.AwayScore),
+					r.Winner,
+				})
+		}
+	case models.ExportTableFixtures:
+		rows = append(rows, []string{"week", "status", "home", "away"})
+		weeks := func(status string, fixtures []models.Week) {
+			for _, week := range fixtures {
+				for _, match := range week.Matches {
+					if match.Home == nil || match.Away == nil {
+						continue
+					}
+					rows = append(rows, []string{strconv.Itoa(week.Number), status, match.Home.Name, match.Away.Name})
+				}
+			}
+		}
+		weeks("played", export.League.PlayedFixtures)
+		weeks("upcoming", export.League.UpcomingFixtures)
+	default:
+		return apperrors.Validation("unknown export table %q", table)
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return out.Error()
+}
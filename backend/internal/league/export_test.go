@@ -0,0 +1,123 @@
+package league
+
+import (
+	"bytes"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testExport builds a two-team league with one week played and one to come.
+func testExport() models.LeagueExport {
+	lions, tigers := models.Team{Name: "Lions"}, models.Team{Name: "Tigers"}
+	return models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League: models.League{
+			LeagueID:   "0b7c6f0e-2d0c-4c53-9a43-6f1d3c1f6a10",
+			LeagueName: "Premier",
+			Teams:      []models.Team{lions, tigers},
+			Standings: []models.Standings{
+				{Team: tigers, Played: 1, Losses: 1, Goals: 1, Against: 2},
+				{Team: lions, Played: 1, Wins: 1, Goals: 2, Against: 1, Points: 3},
+			},
+			PlayedFixtures:   []models.Week{{Number: 1, Matches: []models.Match{{Home: &lions, Away: &tigers}}}},
+			UpcomingFixtures: []models.Week{{Number: 2, Matches: []models.Match{{Home: &tigers, Away: &lions}}}},
+		},
+		Results: []models.MatchResult{
+			{MatchWeek: 1, Home: "Lions", Away: "Tigers", HomeScore: 2, AwayScore: 1, Winner: "Lions"},
+		},
+	}
+}
+
+func TestValidateExport_Valid(t *testing.T) {
+	assert.NoError(t, ValidateExport(testExport(), true))
+}
+
+func TestValidateExport_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		keepID bool
+		mutate func(export *models.LeagueExport)
+		field  string
+	}{
+		{name: "ID not a UUID", keepID: true, mutate: func(e *models.LeagueExport) { e.League.LeagueID = "league-1" }, field: "league.leagueId"},
+		{name: "Missing name", mutate: func(e *models.LeagueExport) { e.League.LeagueName = "" }, field: "league.leagueName"},
+		{name: "Duplicate team", mutate: func(e *models.LeagueExport) { e.League.Teams[1].Name = "Lions" }, field: "league.teams[1]"},
+		{name: "Missing standings row", mutate: func(e *models.LeagueExport) { e.League.Standings = e.League.Standings[:1] }, field: "league.standings"},
+		{name: "Unknown team in standings", mutate: func(e *models.LeagueExport) { e.League.Standings[0].Team.Name = "Bears" }, field: "league.standings[0]"},
+		{name: "Unknown team in fixtures", mutate: func(e *models.LeagueExport) {
+			e.League.UpcomingFixtures[0].Matches[0].Home = &models.Team{Name: "Bears"}
+		}, field: "league.upcomingFixtures[0].matches[0]"},
+		{name: "Negative score", mutate: func(e *models.LeagueExport) { e.Results[0].AwayScore = -1 }, field: "results[0]"},
+		{name: "Result in upcoming week", mutate: func(e *models.LeagueExport) { e.Results[0].MatchWeek = 2 }, field: "results[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				export := testExport()
+				tt.mutate(&export)
+
+				err := ValidateExport(export, tt.keepID)
+
+				var appErr *apperrors.Error
+				require.ErrorAs(t, err, &appErr)
+				require.NotEmpty(t, appErr.Fields)
+				assert.Equal(t, tt.field, appErr.Fields[0].Field)
+			})
+	}
+}
+
+func TestValidateExport_IgnoresIDForNewID(t *testing.T) {
+	export := testExport()
+	export.League.LeagueID = "league-1"
+	assert.NoError(t, ValidateExport(export, false))
+}
+
+func TestValidateExport_UnsupportedVersion(t *testing.T) {
+	export := testExport()
+	export.Version = 99
+	assert.True(t, apperrors.Is(ValidateExport(export, false), apperrors.KindValidation))
+}
+
+func TestWriteExportCSV(t *testing.T) {
+	tests := []struct {
+		table string
+		want  string
+	}{
+		{
+			table: models.ExportTableStandings,
+			want: "position,team,played,wins,draws,losses,goals,against,points\n" +
+				"1,Lions,1,1,0,0,2,1,3\n" +
+				"2,Tigers,1,0,0,1,1,2,0\n",
+		},
+		{
+			table: models.ExportTableResults,
+			want:  "week,home,home_score,away,away_score,winner\n1,Lions,2,Tigers,1,Lions\n",
+		},
+		{
+			table: models.ExportTableFixtures,
+			want:  "week,status,home,away\n1,played,Lions,Tigers\n2,upcoming,Tigers,Lions\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.table, func(t *testing.T) {
+				var buf bytes.Buffer
+				require.NoError(t, WriteExportCSV(&buf, testExport(), tt.table))
+				assert.Equal(t, tt.want, buf.String())
+			})
+	}
+}
+
+func TestWriteExportCSV_UnknownTable(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteExportCSV(&buf, testExport(), "teams")
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Empty(t, buf.String())
+}
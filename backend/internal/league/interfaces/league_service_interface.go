@@ -1,8 +1,19 @@
 package interfaces
 
-import "league-sim/internal/models"
+import (
+	"context"
+
+	"league-sim/internal/models"
+)
 
 type LeagueServiceInterface interface {
-	CreateLeague(n string, leagueName string) (models.GetLeaguesIdsWithNameResponse, error)
-	ResetLeague(leagueId string) error
+	CreateLeague(ctx context.Context, n string, leagueName string, rules *models.LeagueRules) (models.GetLeaguesIdsWithNameResponse, error)
+	ResetLeague(ctx context.Context, leagueId string) error
+	DeleteLeague(ctx context.Context, leagueId string) error
+	AddMember(ctx context.Context, leagueId string, userId string, role string) error
+	RemoveMember(ctx context.Context, leagueId string, userId string) error
+	ExportLeague(ctx context.Context, leagueId string) (models.LeagueExport, error)
+	ImportLeague(ctx context.Context, export models.LeagueExport, newID bool) (models.GetLeaguesIdsWithNameResponse, error)
+	ForkLeague(ctx context.Context, leagueId string, leagueName string) (models.GetLeaguesIdsWithNameResponse, error)
+	DiffStandings(ctx context.Context, leagueId string, otherId string) (models.StandingsDiff, error)
 }
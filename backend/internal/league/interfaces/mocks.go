@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/mock"
@@ -11,12 +13,53 @@ type MockLeagueServiceInterface struct {
 	mock.Mock
 }
 
-func (m *MockLeagueServiceInterface) CreateLeague(n string, leagueName string) (models.GetLeaguesIdsWithNameResponse, error) {
-	args := m.Called(n, leagueName)
+func (m *MockLeagueServiceInterface) CreateLeague(ctx context.Context, n string, leagueName string, rules *models.LeagueRules) (models.GetLeaguesIdsWithNameResponse, error) {
+	args := m.Called(ctx, n, leagueName, rules)
 	return args.Get(0).(models.GetLeaguesIdsWithNameResponse), args.Error(1)
 }
 
-func (m *MockLeagueServiceInterface) ResetLeague(leagueId string) error {
-	args := m.Called(leagueId)
+func (m *MockLeagueServiceInterface) ResetLeague(ctx context.Context, leagueId string) error {
+	args := m.Called(ctx, leagueId)
+	return args.Error(0)
+}
+
+func (m *MockLeagueServiceInterface) DeleteLeague(ctx context.Context, leagueId string) error {
+	args := m.Called(ctx, leagueId)
+	return args.Error(0)
+}
+
+func (m *MockLeagueServiceInterface) AddMember(ctx context.Context, leagueId string, userId string, role string) error {
+	args := m.Called(ctx, leagueId, userId, role)
+	return args.Error(0)
+}
+
+func (m *MockLeagueServiceInterface) RemoveMember(ctx context.Context, leagueId string, userId string) error {
+	args := m.Called(ctx, leagueId, userId)
 	return args.Error(0)
 }
+
+func (m *MockLeagueServiceInterface) ExportLeague(ctx context.Context, leagueId string) (models.LeagueExport, error) {
+	args := m.Called(ctx, leagueId)
+	return args.Get(0).(models.LeagueExport), args.Error(1)
+}
+
+func (m *MockLeagueServiceInterface) ImportLeague(
+	ctx context.Context, export models.LeagueExport, newID bool,
+) (models.GetLeaguesIdsWithNameResponse, error) {
+	args := m.Called(ctx, export, newID)
+	return args.Get(0).(models.GetLeaguesIdsWithNameResponse), args.Error(1)
+}
+
+func (m *MockLeagueServiceInterface) ForkLeague(
+	ctx context.Context, leagueId string, leagueName string,
+) (models.GetLeaguesIdsWithNameResponse, error) {
+	args := m.Called(ctx, leagueId, leagueName)
+	return args.Get(0).(models.GetLeaguesIdsWithNameResponse), args.Error(1)
+}
+
+func (m *MockLeagueServiceInterface) DiffStandings(
+	ctx context.Context, leagueId string, otherId string,
+) (models.StandingsDiff, error) {
+	args := m.Called(ctx, leagueId, otherId)
+	return args.Get(0).(models.StandingsDiff), args.Error(1)
+}
@@ -1,11 +1,13 @@
 package interfaces
 
 import (
+	"context"
 	"testing"
 
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMockLeagueServiceInterface_ImplementsInterface(t *testing.T) {
@@ -26,10 +28,10 @@ func TestMockLeagueServiceInterface_CreateLeague(t *testing.T) {
 		LeagueName: testLeagueName,
 	}
 
-	mockService.On("CreateLeague", testN, testLeagueName).Return(expectedResponse, nil)
+	mockService.On("CreateLeague", mock.Anything, testN, testLeagueName, (*models.LeagueRules)(nil)).Return(expectedResponse, nil)
 
 	// Call method
-	result, err := mockService.CreateLeague(testN, testLeagueName)
+	result, err := mockService.CreateLeague(context.Background(), testN, testLeagueName, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -45,10 +47,10 @@ func TestMockLeagueServiceInterface_ResetLeague(t *testing.T) {
 
 	// Setup expectations
 	testLeagueID := "test-league-id"
-	mockService.On("ResetLeague", testLeagueID).Return(nil)
+	mockService.On("ResetLeague", mock.Anything, testLeagueID).Return(nil)
 
 	// Call method
-	err := mockService.ResetLeague(testLeagueID)
+	err := mockService.ResetLeague(context.Background(), testLeagueID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -0,0 +1,88 @@
+package league
+
+import (
+	"errors"
+	"fmt"
+
+	"league-sim/internal/models"
+)
+
+// maxGoalRate bounds the Poisson goal rates, far above anything seen in
+// real football, so that generated scores stay plausible.
+const maxGoalRate = 10
+
+// maxKFactor bounds the Elo K-factor; beyond it a single result outweighs a
+// season of form.
+const maxKFactor = 100
+
+func ValidateRules(rules models.LeagueRules) error {
+	if rules.PointsForWin <= rules.PointsForDraw {
+		return errors.New("points for a win must be greater than points for a draw")
+	}
+	if rules.PointsForDraw < rules.PointsForLoss {
+		return errors.New("points for a draw must not be less than points for a loss")
+	}
+	if rules.PointsForLoss < 0 {
+		return errors.New("points for a loss must not be negative")
+	}
+	if rules.HomeAdvantage <= 0 {
+		return errors.New("home advantage must be positive")
+	}
+	if rules.SquadCap < 0 {
+		return errors.New("squad cap must not be negative")
+	}
+
+	weights := rules.StrengthWeights
+	if weights.Attack < 0 || weights.Defense < 0 || weights.Morale < 0 || weights.Stamina < 0 {
+		return errors.New("strength weights must not be negative")
+	}
+	if weights.Attack+weights.Defense+weights.Morale+weights.Stamina == 0 {
+		return errors.New("at least one strength weight must be positive")
+	}
+
+	if rules.Draw.Chance < 0 || rules.Draw.Chance >= 1 {
+		return fmt.Errorf("draw chance must be in [0, 1), got %v", rules.Draw.Chance)
+	}
+	if rules.Draw.MaxGoals < 0 {
+		return errors.New("draw max goals must not be negative")
+	}
+
+	if rates := rules.GoalRates; rates != nil {
+		if rates.Winner < 0 || rates.Winner > maxGoalRate || rates.Draw < 0 || rates.Draw > maxGoalRate {
+			return fmt.Errorf("winner and draw goal rates must be between 0 and %v", maxGoalRate)
+		}
+		if rates.Loser < 0 || rates.Loser > 1 {
+			return fmt.Errorf("loser goal rate must be in [0, 1], got %v", rates.Loser)
+		}
+	}
+
+	if elo := rules.Elo; elo != nil {
+		if elo.KFactor < 0 || elo.KFactor > maxKFactor {
+			return fmt.Errorf("elo k-factor must be between 0 and %v, got %v", maxKFactor, elo.KFactor)
+		}
+		if elo.HomeAdvantage < 0 {
+			return errors.New("elo home advantage must not be negative")
+		}
+	}
+
+	if rules.BonusPoints.GoalThreshold < 0 || rules.BonusPoints.Points < 0 {
+		return errors.New("bonus points settings must not be negative")
+	}
+
+	return nil
+}
+
+// ResolveRules returns the rules a league should be created with: the
+// defaults when none were supplied, otherwise the supplied rules once they
+// pass validation.
+func ResolveRules(rules *models.LeagueRules) (models.LeagueRules, error) {
+	if rules == nil {
+		return models.DefaultLeagueRules(), nil
+	}
+
+	if err := ValidateRules(*rules); err != nil {
+		return models.LeagueRules{}, err
+	}
+
+	return *rules, nil
+}
@@ -0,0 +1,69 @@
+package league
+
+import (
+	"testing"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRules_Default(t *testing.T) {
+	assert.NoError(t, ValidateRules(models.DefaultLeagueRules()))
+}
+
+func TestValidateRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(rules *models.LeagueRules)
+	}{
+		{name: "Win not above draw", mutate: func(r *models.LeagueRules) { r.PointsForWin = 1 }},
+		{name: "Draw below loss", mutate: func(r *models.LeagueRules) { r.PointsForLoss = 2; r.PointsForWin = 4 }},
+		{name: "Negative loss points", mutate: func(r *models.LeagueRules) { r.PointsForLoss = -1 }},
+		{name: "Zero home advantage", mutate: func(r *models.LeagueRules) { r.HomeAdvantage = 0 }},
+		{name: "Negative squad cap", mutate: func(r *models.LeagueRules) { r.SquadCap = -2 }},
+		{name: "Negative weight", mutate: func(r *models.LeagueRules) { r.StrengthWeights.Morale = -0.1 }},
+		{name: "All weights zero", mutate: func(r *models.LeagueRules) { r.StrengthWeights = models.StrengthWeights{} }},
+		{name: "Draw chance of one", mutate: func(r *models.LeagueRules) { r.Draw.Chance = 1 }},
+		{name: "Negative draw goals", mutate: func(r *models.LeagueRules) { r.Draw.MaxGoals = -1 }},
+		{name: "Negative bonus", mutate: func(r *models.LeagueRules) { r.BonusPoints.Points = -1 }},
+		{name: "Negative goal rate", mutate: func(r *models.LeagueRules) { r.GoalRates = &models.GoalRates{Winner: -1} }},
+		{name: "Huge goal rate", mutate: func(r *models.LeagueRules) { r.GoalRates = &models.GoalRates{Draw: 11} }},
+		{name: "Loser rate above one", mutate: func(r *models.LeagueRules) { r.GoalRates = &models.GoalRates{Loser: 1.5} }},
+		{name: "Negative k-factor", mutate: func(r *models.LeagueRules) { r.Elo = &models.EloRules{KFactor: -1} }},
+		{name: "Huge k-factor", mutate: func(r *models.LeagueRules) { r.Elo = &models.EloRules{KFactor: 101} }},
+		{name: "Negative elo home advantage", mutate: func(r *models.LeagueRules) { r.Elo = &models.EloRules{HomeAdvantage: -5} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				rules := models.DefaultLeagueRules()
+				tt.mutate(&rules)
+				assert.Error(t, ValidateRules(rules))
+			})
+	}
+}
+
+func TestResolveRules(t *testing.T) {
+	rules, err := ResolveRules(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultLeagueRules(), rules)
+
+	custom := models.DefaultLeagueRules()
+	custom.PointsForWin = 2
+	rules, err = ResolveRules(&custom)
+	assert.NoError(t, err)
+	assert.Equal(t, custom, rules)
+
+	custom.HomeAdvantage = -1
+	_, err = ResolveRules(&custom)
+	assert.Error(t, err)
+}
+
+func TestCalculateStrength_CustomWeights(t *testing.T) {
+	team := models.Team{AttackPower: 100, DefensePower: 50, Morale: 20, Stamina: 10}
+	weights := models.StrengthWeights{Attack: 1}
+
+	assert.InDelta(t, 100.0, CalculateStrength(team, weights), 0.001)
+}
@@ -1,8 +1,13 @@
 package league
 
 import (
+	"context"
 	"strconv"
+	"time"
 
+	"league-sim/internal/apperrors"
+	"league-sim/internal/audit"
+	"league-sim/internal/auth"
 	appContext "league-sim/internal/contexts/appContexts"
 	"league-sim/internal/models"
 
@@ -19,10 +24,37 @@ func NewLeagueService(ctx appContext.AppContext) *LeagueService {
 	}
 }
 
-func (ls *LeagueService) CreateLeague(n string, leagueName string) (models.GetLeaguesIdsWithNameResponse, error) {
+func (ls *LeagueService) CreateLeague(
+	ctx context.Context,
+	n string,
+	leagueName string,
+	customRules *models.LeagueRules,
+) (models.GetLeaguesIdsWithNameResponse, error) {
+	owner, ok := auth.UserFrom(ctx)
+
+	if !ok {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Unauthorized("authentication required")
+	}
+
 	i, err := strconv.Atoi(n)
 
 	if err != nil {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Validation("team count %q is not a number", n)
+	}
+
+	rules, err := ResolveRules(customRules)
+
+	if err != nil {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Validation("invalid rules: %v", err)
+	}
+
+	if rules.SquadCap > 0 && i > rules.SquadCap {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Validation(
+			"team count %d exceeds the league squad cap of %d", i, rules.SquadCap)
+	}
+
+	if err := ls.checkQuotas(ctx, owner.UserId, i); err != nil {
+
 		return models.GetLeaguesIdsWithNameResponse{}, err
 	}
 
@@ -33,6 +65,7 @@ func (ls *LeagueService) CreateLeague(n string, leagueName string) (models.GetLe
 	league := models.League{
 		LeagueID:         leagueId.String(),
 		LeagueName:       leagueName,
+		Rules:            rules,
 		Teams:            teams,
 		Standings:        standings,
 		TotalWeeks:       len(fixtures),
@@ -41,14 +74,30 @@ func (ls *LeagueService) CreateLeague(n string, leagueName string) (models.GetLe
 		PlayedFixtures:   []models.Week{},
 	}
 
-	err = ls.appCtx.LeagueRepository().SetLeague(leagueId.String(), models.CreateLeagueRequest{LeagueName: leagueName})
+	err = ls.appCtx.LeagueRepository().SetLeague(
+		ctx, leagueId.String(), owner.UserId, models.CreateLeagueRequest{
+			LeagueName: leagueName,
+			Rules:      &rules,
+		})
 
 	if err != nil {
 
 		return models.GetLeaguesIdsWithNameResponse{}, err
 	}
 
-	err = ls.appCtx.ActiveLeagueRepository().SetActiveLeague(league)
+	err = ls.appCtx.ActiveLeagueRepository().SetActiveLeague(ctx, league)
+
+	if err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	err = audit.Record(
+		ctx, ls.appCtx.AuditRepository(), leagueId.String(), models.AuditActionCreate, nil, models.CreateLeagueRequest{
+			LeagueName: leagueName,
+			TeamCount:  n,
+			Rules:      &rules,
+		})
 
 	if err != nil {
 
@@ -61,13 +110,324 @@ func (ls *LeagueService) CreateLeague(n string, leagueName string) (models.GetLe
 	}, nil
 }
 
-func (ls *LeagueService) ResetLeague(leagueId string) error {
-	league, err := ls.appCtx.ActiveLeagueRepository().GetActiveLeague(leagueId)
+// checkQuotas refuses a new league of teamCount teams for an owner who is over
+// the configured quotas.
+func (ls *LeagueService) checkQuotas(ctx context.Context, ownerId string, teamCount int) error {
+	quotas := ls.appCtx.Config().Quotas
+
+	if quotas.TeamsPerLeague > 0 && teamCount > quotas.TeamsPerLeague {
+		return apperrors.QuotaExceeded(
+			"team count %d exceeds the quota of %d teams per league", teamCount, quotas.TeamsPerLeague)
+	}
+
+	if quotas.LeaguesPerUser > 0 {
+		owned, err := ls.appCtx.LeagueRepository().CountOwnedLeagues(ctx, ownerId)
+
+		if err != nil {
+
+			return err
+		}
+
+		if owned >= quotas.LeaguesPerUser {
+			return apperrors.QuotaExceeded(
+				"league quota of %d reached, delete a league to create another", quotas.LeaguesPerUser)
+		}
+	}
+
+	return nil
+}
+
+// ExportLeague collects everything stored for a league into one document.
+func (ls *LeagueService) ExportLeague(ctx context.Context, leagueId string) (models.LeagueExport, error) {
+	league, err := ls.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
+	if err != nil {
+
+		return models.LeagueExport{}, err
+	}
+
+	league.LeagueName, err = ls.appCtx.LeagueRepository().GetLeagueName(ctx, leagueId)
+	if err != nil {
+
+		return models.LeagueExport{}, err
+	}
+
+	league.Rules, err = ls.appCtx.LeagueRepository().GetLeagueRules(ctx, leagueId)
+	if err != nil {
+
+		return models.LeagueExport{}, err
+	}
+
+	results, err := ls.appCtx.MatchResultRepository().GetMatchResults(ctx, leagueId)
+	if err != nil {
+
+		return models.LeagueExport{}, err
+	}
+
+	league.TotalWeeks = len(league.PlayedFixtures) + len(league.UpcomingFixtures)
+
+	return models.LeagueExport{
+		Version:    models.LeagueExportVersion,
+		ExportedAt: time.Now().UTC(),
+		League:     league,
+		Results:    results,
+	}, nil
+}
+
+// ImportLeague recreates an exported league, owned by the caller, under the
+// ID it was exported with or, with newID, a new one. Keeping the ID fails with
+// a conflict when a league with that ID already exists. Imports count against
+// the same quotas as new leagues.
+func (ls *LeagueService) ImportLeague(
+	ctx context.Context,
+	export models.LeagueExport,
+	newID bool,
+) (models.GetLeaguesIdsWithNameResponse, error) {
+	owner, ok := auth.UserFrom(ctx)
+
+	if !ok {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Unauthorized("authentication required")
+	}
+
+	if err := ValidateExport(export, !newID); err != nil {
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	// The audit log of a league outlives it. Its ID is not handed out again,
+	// or the new league's members would read the old league's history.
+	if !newID {
+		history, err := ls.appCtx.AuditRepository().GetAuditEntries(ctx, export.League.LeagueID, "")
+
+		if err != nil {
+			return models.GetLeaguesIdsWithNameResponse{}, err
+		}
+
+		if len(history) > 0 {
+			return models.GetLeaguesIdsWithNameResponse{}, apperrors.Conflict(
+				"league %s exists or existed before, import it with a new ID", export.League.LeagueID)
+		}
+	}
+
+	rules, err := ResolveRules(&export.League.Rules)
+
+	if err != nil {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Validation("invalid rules: %v", err)
+	}
+
+	if err := ls.checkQuotas(ctx, owner.UserId, len(export.League.Teams)); err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	league := export.League
+	if newID {
+		league.LeagueID = uuid.New().String()
+	}
+	league.Rules = rules
+
+	if err := ls.store(ctx, owner.UserId, league, export.Results); err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	err = audit.Record(
+		ctx, ls.appCtx.AuditRepository(), league.LeagueID, models.AuditActionImport, nil, audit.Snapshot(league))
+
+	if err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	return models.GetLeaguesIdsWithNameResponse{
+		LeagueName: league.LeagueName,
+		LeagueId:   league.LeagueID,
+	}, nil
+}
+
+// store writes a new league owned by ownerId: its name and rules, its season
+// and its results.
+func (ls *LeagueService) store(
+	ctx context.Context,
+	ownerId string,
+	league models.League,
+	results []models.MatchResult,
+) error {
+	err := ls.appCtx.LeagueRepository().SetLeague(
+		ctx, league.LeagueID, ownerId, models.CreateLeagueRequest{
+			LeagueName: league.LeagueName,
+			Rules:      &league.Rules,
+		})
+
+	if err != nil {
+
+		return err
+	}
+
+	err = ls.appCtx.ActiveLeagueRepository().SetActiveLeague(ctx, league)
+
 	if err != nil {
 
 		return err
 	}
 
+	return ls.appCtx.MatchResultRepository().SetMatchResults(ctx, league.LeagueID, results)
+}
+
+// ForkLeague copies a league as it stands, with its results, rating history
+// and recorded predictions, into a new league owned by the caller and tied to
+// the original as its parent. Forks count against the same quotas as new
+// leagues.
+func (ls *LeagueService) ForkLeague(
+	ctx context.Context,
+	leagueId string,
+	leagueName string,
+) (models.GetLeaguesIdsWithNameResponse, error) {
+	owner, ok := auth.UserFrom(ctx)
+
+	if !ok {
+		return models.GetLeaguesIdsWithNameResponse{}, apperrors.Unauthorized("authentication required")
+	}
+
+	export, err := ls.ExportLeague(ctx, leagueId)
+
+	if err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	if err := ls.checkQuotas(ctx, owner.UserId, len(export.League.Teams)); err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	ratings, err := ls.appCtx.RatingRepository().GetRatingChanges(ctx, leagueId, "")
+
+	if err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	snapshots, err := ls.appCtx.PredictionRepository().GetPredictionSnapshots(ctx, leagueId)
+
+	if err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	fork := export.League
+	fork.LeagueID = uuid.New().String()
+	fork.LeagueName = ls.forkName(export.League.LeagueName, leagueName)
+
+	if err := ls.store(ctx, owner.UserId, fork, export.Results); err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	if err := ls.appCtx.LeagueRepository().SetLeagueParent(ctx, fork.LeagueID, leagueId); err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	if len(ratings) > 0 {
+		if err := ls.appCtx.RatingRepository().AppendRatingChanges(ctx, fork.LeagueID, ratings); err != nil {
+
+			return models.GetLeaguesIdsWithNameResponse{}, err
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		if err := ls.appCtx.PredictionRepository().SavePredictionSnapshot(ctx, fork.LeagueID, snapshot); err != nil {
+
+			return models.GetLeaguesIdsWithNameResponse{}, err
+		}
+	}
+
+	err = audit.Record(
+		ctx, ls.appCtx.AuditRepository(), fork.LeagueID, models.AuditActionFork, nil, audit.Snapshot(fork))
+
+	if err != nil {
+
+		return models.GetLeaguesIdsWithNameResponse{}, err
+	}
+
+	return models.GetLeaguesIdsWithNameResponse{
+		LeagueName: fork.LeagueName,
+		LeagueId:   fork.LeagueID,
+		ParentId:   leagueId,
+	}, nil
+}
+
+// forkName is name, or the parent's name marked as a fork when that still
+// fits the configured limit.
+func (ls *LeagueService) forkName(parentName string, name string) string {
+	if name != "" {
+		return name
+	}
+
+	marked := parentName + " (fork)"
+	if limit := ls.appCtx.Config().Limits.MaxLeagueNameLength; limit > 0 && len(marked) > limit {
+		return parentName
+	}
+
+	return marked
+}
+
+// DiffStandings compares the standings of a league with those of otherId, or
+// of the league it was forked from when otherId is empty. The caller must be a
+// member of the other league too, and both must have the same teams.
+func (ls *LeagueService) DiffStandings(ctx context.Context, leagueId string, otherId string) (models.StandingsDiff, error) {
+	user, ok := auth.UserFrom(ctx)
+
+	if !ok {
+		return models.StandingsDiff{}, apperrors.Unauthorized("authentication required")
+	}
+
+	if otherId == "" {
+		parentId, err := ls.appCtx.LeagueRepository().GetLeagueParent(ctx, leagueId)
+		if err != nil {
+
+			return models.StandingsDiff{}, err
+		}
+
+		if parentId == "" {
+			return models.StandingsDiff{}, apperrors.Validation(
+				"league %s is not a fork, name the league to compare with", leagueId)
+		}
+		otherId = parentId
+	}
+
+	role, err := ls.appCtx.LeagueRepository().GetMemberRole(ctx, otherId, user.UserId)
+	if err != nil {
+
+		return models.StandingsDiff{}, err
+	}
+
+	if role == "" {
+		return models.StandingsDiff{}, apperrors.NotFound("league %s not found", otherId)
+	}
+
+	standings, err := ls.appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(ctx, leagueId)
+	if err != nil {
+
+		return models.StandingsDiff{}, err
+	}
+
+	otherStandings, err := ls.appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(ctx, otherId)
+	if err != nil {
+
+		return models.StandingsDiff{}, err
+	}
+
+	return CompareStandings(leagueId, standings, otherId, otherStandings)
+}
+
+func (ls *LeagueService) ResetLeague(ctx context.Context, leagueId string) error {
+	league, err := ls.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
+	if err != nil {
+
+		return err
+	}
+
+	before := audit.Snapshot(league)
 	teams := TeamGenerate(len(league.Teams))
 	fixtures := GenerateFixtures(teams)
 	standings := CreateStandingsTable(teams)
@@ -79,16 +439,96 @@ func (ls *LeagueService) ResetLeague(leagueId string) error {
 	league.UpcomingFixtures = fixtures
 	league.PlayedFixtures = []models.Week{}
 
-	err = ls.appCtx.ActiveLeagueRepository().SetActiveLeague(league)
+	err = ls.appCtx.ActiveLeagueRepository().SetActiveLeague(ctx, league)
 	if err != nil {
 
 		return err
 	}
-	err = ls.appCtx.MatchResultRepository().DeleteMatchResults(leagueId)
+	err = ls.appCtx.MatchResultRepository().DeleteMatchResults(ctx, leagueId)
 	if err != nil {
 
 		return err
 	}
+	err = ls.appCtx.RatingRepository().DeleteRatingChanges(ctx, leagueId)
+	if err != nil {
 
-	return nil
+		return err
+	}
+	err = ls.appCtx.PredictionRepository().DeletePredictionSnapshots(ctx, leagueId)
+	if err != nil {
+
+		return err
+	}
+
+	return audit.Record(ctx, ls.appCtx.AuditRepository(), leagueId, models.AuditActionReset, before, audit.Snapshot(league))
+}
+
+// DeleteLeague removes a league and everything stored for it except its audit
+// log, which records the final standings.
+func (ls *LeagueService) DeleteLeague(ctx context.Context, leagueId string) error {
+	league, err := ls.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
+	if err != nil {
+
+		return err
+	}
+
+	err = ls.appCtx.LeagueRepository().DeleteLeague(ctx, leagueId)
+	if err != nil {
+
+		return err
+	}
+
+	return audit.Record(ctx, ls.appCtx.AuditRepository(), leagueId, models.AuditActionDelete, audit.Snapshot(league), nil)
+}
+
+// AddMember invites an existing user to the league as an editor or viewer.
+func (ls *LeagueService) AddMember(ctx context.Context, leagueId string, userId string, role string) error {
+	if role != models.RoleEditor && role != models.RoleViewer {
+
+		return apperrors.Validation("role %q cannot be granted", role)
+	}
+
+	if _, err := ls.appCtx.UserRepository().GetUser(ctx, userId); err != nil {
+
+		return err
+	}
+
+	current, err := ls.appCtx.LeagueRepository().GetMemberRole(ctx, leagueId, userId)
+	if err != nil {
+
+		return err
+	}
+
+	if current == models.RoleOwner {
+
+		return apperrors.Conflict("user %s already owns league %s", userId, leagueId)
+	}
+
+	err = ls.appCtx.LeagueRepository().AddLeagueMember(ctx, leagueId, userId, role)
+	if err != nil {
+
+		return err
+	}
+
+	return audit.Record(
+		ctx, ls.appCtx.AuditRepository(), leagueId, models.AuditActionAddMember, nil,
+		models.AddLeagueMemberRequest{UserId: userId, Role: role})
+}
+
+func (ls *LeagueService) RemoveMember(ctx context.Context, leagueId string, userId string) error {
+	role, err := ls.appCtx.LeagueRepository().GetMemberRole(ctx, leagueId, userId)
+	if err != nil {
+
+		return err
+	}
+
+	err = ls.appCtx.LeagueRepository().RemoveLeagueMember(ctx, leagueId, userId)
+	if err != nil {
+
+		return err
+	}
+
+	return audit.Record(
+		ctx, ls.appCtx.AuditRepository(), leagueId, models.AuditActionRemoveMember,
+		models.AddLeagueMemberRequest{UserId: userId, Role: role}, nil)
 }
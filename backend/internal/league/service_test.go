@@ -1,9 +1,14 @@
 package league
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
 	appContext "league-sim/internal/contexts/appContexts"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
@@ -12,6 +17,39 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// testOwner is the authenticated user creating leagues in these tests.
+var testOwner = models.User{UserId: "owner-id", Username: "alice"}
+
+var ownerCtx = auth.WithUser(context.Background(), testOwner)
+
+// Helper function to accept audit entries on a mock AppContext
+func withAudit(mockAppCtx *MockAppContext) *MockAppContext {
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAuditRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).Return(nil).Maybe()
+	mockAuditRepo.On("GetAuditEntries", mock.Anything, mock.Anything, "").Return([]models.AuditEntry{}, nil).Maybe()
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo).Maybe()
+	return mockAppCtx
+}
+
+// Helper function to accept resets of rating history and prediction snapshots
+// on a mock AppContext
+func withRatings(mockAppCtx *MockAppContext) *MockAppContext {
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	mockRatingRepo.On("DeleteRatingChanges", mock.Anything, mock.AnythingOfType("string")).Return(nil).Maybe()
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo).Maybe()
+	mockPredictionRepo := &interfaces.MockPredictionRepository{}
+	mockPredictionRepo.On("DeletePredictionSnapshots", mock.Anything, mock.AnythingOfType("string")).Return(nil).Maybe()
+	mockAppCtx.On("PredictionRepository").Return(mockPredictionRepo).Maybe()
+	return mockAppCtx
+}
+
+// Helper function to give a mock AppContext the default quotas and an owner
+// with no leagues yet
+func withQuotas(mockAppCtx *MockAppContext, mockLeagueRepo *interfaces.MockLeagueRepository) {
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockLeagueRepo.On("CountOwnedLeagues", mock.Anything, testOwner.UserId).Return(0, nil).Maybe()
+}
+
 // MockAppContext is a mock implementation of AppContext for testing
 type MockAppContext struct {
 	mock.Mock
@@ -32,11 +70,41 @@ func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepositor
 	return args.Get(0).(interfaces.MatchResultRepository)
 }
 
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
 func (m *MockAppContext) DB() *appContext.DB {
 	args := m.Called()
 	return args.Get(0).(*appContext.DB)
 }
 
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
 func TestNewLeagueService(t *testing.T) {
 	// Create mock app context
 	mockAppCtx := &MockAppContext{}
@@ -54,13 +122,15 @@ func TestLeagueService_CreateLeague_Success(t *testing.T) {
 	mockLeagueRepo := &interfaces.MockLeagueRepository{}
 	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
 	mockAppCtx := &MockAppContext{}
+	withAudit(mockAppCtx)
 
 	// Configure mock expectations
 	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	withQuotas(mockAppCtx, mockLeagueRepo)
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 
-	mockLeagueRepo.On("SetLeague", mock.AnythingOfType("string"), mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
+	mockLeagueRepo.On("SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
@@ -70,7 +140,7 @@ func TestLeagueService_CreateLeague_Success(t *testing.T) {
 	leagueName := "Test League"
 
 	// Execute
-	result, err := service.CreateLeague(numberOfTeams, leagueName)
+	result, err := service.CreateLeague(ownerCtx, numberOfTeams, leagueName, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -83,6 +153,71 @@ func TestLeagueService_CreateLeague_Success(t *testing.T) {
 	mockActiveLeagueRepo.AssertExpectations(t)
 }
 
+func TestLeagueService_CreateLeague_CustomRules(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+	withAudit(mockAppCtx)
+
+	// Test data
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 2
+	rules.SquadCap = 6
+
+	// Configure mock expectations
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	withQuotas(mockAppCtx, mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockLeagueRepo.On(
+		"SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.MatchedBy(
+			func(request models.CreateLeagueRequest) bool {
+				return request.Rules != nil && *request.Rules == rules
+			})).Return(nil)
+	mockActiveLeagueRepo.On(
+		"SetActiveLeague", mock.Anything, mock.MatchedBy(
+			func(league models.League) bool {
+				return league.Rules == rules
+			})).Return(nil)
+
+	// Execute
+	service := NewLeagueService(mockAppCtx)
+	_, err := service.CreateLeague(ownerCtx, "6", "Rules League", &rules)
+
+	// Assert
+	assert.NoError(t, err)
+	mockLeagueRepo.AssertExpectations(t)
+	mockActiveLeagueRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_CreateLeague_InvalidRules(t *testing.T) {
+	mockAppCtx := &MockAppContext{}
+	service := NewLeagueService(mockAppCtx)
+
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 0
+
+	_, err := service.CreateLeague(ownerCtx, "4", "Rules League", &rules)
+
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	mockAppCtx.AssertNotCalled(t, "LeagueRepository")
+}
+
+func TestLeagueService_CreateLeague_ExceedsSquadCap(t *testing.T) {
+	mockAppCtx := &MockAppContext{}
+	service := NewLeagueService(mockAppCtx)
+
+	rules := models.DefaultLeagueRules()
+	rules.SquadCap = 4
+
+	_, err := service.CreateLeague(ownerCtx, "6", "Rules League", &rules)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "squad cap")
+	mockAppCtx.AssertNotCalled(t, "LeagueRepository")
+}
+
 func TestLeagueService_CreateLeague_InvalidNumberFormat(t *testing.T) {
 	// Setup mocks
 	mockAppCtx := &MockAppContext{}
@@ -95,10 +230,11 @@ func TestLeagueService_CreateLeague_InvalidNumberFormat(t *testing.T) {
 	leagueName := "Test League"
 
 	// Execute
-	result, err := service.CreateLeague(numberOfTeams, leagueName)
+	result, err := service.CreateLeague(ownerCtx, numberOfTeams, leagueName, nil)
 
 	// Assert
 	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
 	assert.Empty(t, result.LeagueName)
 	assert.Empty(t, result.LeagueId)
 }
@@ -110,9 +246,10 @@ func TestLeagueService_CreateLeague_LeagueRepositoryError(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	withQuotas(mockAppCtx, mockLeagueRepo)
 
 	expectedError := errors.New("league repository error")
-	mockLeagueRepo.On("SetLeague", mock.AnythingOfType("string"), mock.AnythingOfType("models.CreateLeagueRequest")).Return(expectedError)
+	mockLeagueRepo.On("SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).Return(expectedError)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
@@ -122,7 +259,7 @@ func TestLeagueService_CreateLeague_LeagueRepositoryError(t *testing.T) {
 	leagueName := "Test League"
 
 	// Execute
-	result, err := service.CreateLeague(numberOfTeams, leagueName)
+	result, err := service.CreateLeague(ownerCtx, numberOfTeams, leagueName, nil)
 
 	// Assert
 	assert.Error(t, err)
@@ -143,12 +280,13 @@ func TestLeagueService_CreateLeague_ActiveLeagueRepositoryError(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	withQuotas(mockAppCtx, mockLeagueRepo)
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 
-	mockLeagueRepo.On("SetLeague", mock.AnythingOfType("string"), mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
+	mockLeagueRepo.On("SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
 
 	expectedError := errors.New("active league repository error")
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(expectedError)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(expectedError)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
@@ -158,7 +296,7 @@ func TestLeagueService_CreateLeague_ActiveLeagueRepositoryError(t *testing.T) {
 	leagueName := "Test League"
 
 	// Execute
-	result, err := service.CreateLeague(numberOfTeams, leagueName)
+	result, err := service.CreateLeague(ownerCtx, numberOfTeams, leagueName, nil)
 
 	// Assert
 	assert.Error(t, err)
@@ -201,24 +339,26 @@ func TestLeagueService_CreateLeague_DifferentTeamCounts(t *testing.T) {
 			mockLeagueRepo := &interfaces.MockLeagueRepository{}
 			mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
 			mockAppCtx := &MockAppContext{}
+			withAudit(mockAppCtx)
 
 			// Configure mock expectations
 			mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+			withQuotas(mockAppCtx, mockLeagueRepo)
 			mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 
-			mockLeagueRepo.On("SetLeague", mock.AnythingOfType("string"), mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
+			mockLeagueRepo.On("SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
 
 			// Capture the league data to verify team count
 			var capturedLeague models.League
-			mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Run(func(args mock.Arguments) {
-				capturedLeague = args.Get(0).(models.League)
+			mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Run(func(args mock.Arguments) {
+				capturedLeague = args.Get(1).(models.League)
 			}).Return(nil)
 
 			// Create service
 			service := NewLeagueService(mockAppCtx)
 
 			// Execute
-			result, err := service.CreateLeague(tt.numberOfTeams, "Test League")
+			result, err := service.CreateLeague(ownerCtx, tt.numberOfTeams, "Test League", nil)
 
 			// Assert
 			assert.NoError(t, err)
@@ -245,6 +385,7 @@ func TestLeagueService_ResetLeague_Success(t *testing.T) {
 	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
 	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
 	mockAppCtx := &MockAppContext{}
+	withRatings(withAudit(mockAppCtx))
 
 	// Test data
 	leagueId := "test-league-id"
@@ -264,15 +405,15 @@ func TestLeagueService_ResetLeague_Success(t *testing.T) {
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
 
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(existingLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("DeleteMatchResults", leagueId).Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(existingLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("DeleteMatchResults", mock.Anything, leagueId).Return(nil)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
 
 	// Execute
-	err := service.ResetLeague(leagueId)
+	err := service.ResetLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -295,13 +436,13 @@ func TestLeagueService_ResetLeague_GetActiveLeagueError(t *testing.T) {
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(models.League{}, expectedError)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{}, expectedError)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
 
 	// Execute
-	err := service.ResetLeague(leagueId)
+	err := service.ResetLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -331,14 +472,14 @@ func TestLeagueService_ResetLeague_SetActiveLeagueError(t *testing.T) {
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(existingLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(expectedError)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(existingLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(expectedError)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
 
 	// Execute
-	err := service.ResetLeague(leagueId)
+	err := service.ResetLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -370,15 +511,15 @@ func TestLeagueService_ResetLeague_DeleteMatchResultsError(t *testing.T) {
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
 
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(existingLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("DeleteMatchResults", leagueId).Return(expectedError)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(existingLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("DeleteMatchResults", mock.Anything, leagueId).Return(expectedError)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
 
 	// Execute
-	err := service.ResetLeague(leagueId)
+	err := service.ResetLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -390,11 +531,59 @@ func TestLeagueService_ResetLeague_DeleteMatchResultsError(t *testing.T) {
 	mockMatchResultRepo.AssertExpectations(t)
 }
 
+func TestLeagueService_ResetLeague_DeletesRatingHistory(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	// Test data
+	leagueId := "test-league-id"
+	existingLeague := models.League{
+		LeagueID: leagueId,
+		Teams:    []models.Team{{Name: "Team A", Rating: 1540}, {Name: "Team B", Rating: 1460}},
+	}
+	expectedError := errors.New("failed to delete rating history")
+
+	// Configure mock expectations
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo)
+
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(existingLeague, nil)
+	mockActiveLeagueRepo.On(
+		"SetActiveLeague", mock.Anything, mock.MatchedBy(
+			func(l models.League) bool {
+				for _, team := range l.Teams {
+					if team.Rating != 0 {
+						return false
+					}
+				}
+				return true
+			})).Return(nil)
+	mockMatchResultRepo.On("DeleteMatchResults", mock.Anything, leagueId).Return(nil)
+	mockRatingRepo.On("DeleteRatingChanges", mock.Anything, leagueId).Return(expectedError)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.ResetLeague(context.Background(), leagueId)
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+	mockAppCtx.AssertExpectations(t)
+	mockActiveLeagueRepo.AssertExpectations(t)
+	mockRatingRepo.AssertExpectations(t)
+}
+
 func TestLeagueService_ResetLeague_VerifyResetData(t *testing.T) {
 	// Setup mocks
 	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
 	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
 	mockAppCtx := &MockAppContext{}
+	withRatings(withAudit(mockAppCtx))
 
 	// Test data
 	leagueId := "test-league-id"
@@ -415,21 +604,21 @@ func TestLeagueService_ResetLeague_VerifyResetData(t *testing.T) {
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
 
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(existingLeague, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(existingLeague, nil)
 
 	// Capture the reset league data
 	var capturedLeague models.League
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Run(func(args mock.Arguments) {
-		capturedLeague = args.Get(0).(models.League)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Run(func(args mock.Arguments) {
+		capturedLeague = args.Get(1).(models.League)
 	}).Return(nil)
 
-	mockMatchResultRepo.On("DeleteMatchResults", leagueId).Return(nil)
+	mockMatchResultRepo.On("DeleteMatchResults", mock.Anything, leagueId).Return(nil)
 
 	// Create service
 	service := NewLeagueService(mockAppCtx)
 
 	// Execute
-	err := service.ResetLeague(leagueId)
+	err := service.ResetLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -472,6 +661,243 @@ func TestLeagueService_ResetLeague_VerifyResetData(t *testing.T) {
 	mockMatchResultRepo.AssertExpectations(t)
 }
 
+func TestLeagueService_CreateLeague_Unauthenticated(t *testing.T) {
+	// Setup mocks
+	mockAppCtx := &MockAppContext{}
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.CreateLeague(context.Background(), "4", "Test League", nil)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindUnauthorized))
+	mockAppCtx.AssertExpectations(t)
+}
+
+func TestLeagueService_CreateLeague_LeagueQuotaReached(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	cfg := config.Default()
+	cfg.Quotas.LeaguesPerUser = 2
+
+	mockAppCtx.On("Config").Return(cfg)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockLeagueRepo.On("CountOwnedLeagues", mock.Anything, testOwner.UserId).Return(2, nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.CreateLeague(ownerCtx, "4", "Test League", nil)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindQuota))
+	assert.Contains(t, err.Error(), "league quota of 2")
+	mockLeagueRepo.AssertNotCalled(t, "SetLeague")
+}
+
+func TestLeagueService_CreateLeague_TeamQuotaExceeded(t *testing.T) {
+	// Setup mocks
+	mockAppCtx := &MockAppContext{}
+
+	cfg := config.Default()
+	cfg.Quotas.TeamsPerLeague = 8
+
+	mockAppCtx.On("Config").Return(cfg)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.CreateLeague(ownerCtx, "10", "Test League", nil)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindQuota))
+	assert.Contains(t, err.Error(), "8 teams per league")
+	mockAppCtx.AssertNotCalled(t, "LeagueRepository")
+}
+
+func TestLeagueService_AddMember_Success(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockUserRepo := &interfaces.MockUserRepository{}
+	mockAppCtx := &MockAppContext{}
+	withAudit(mockAppCtx)
+
+	mockAppCtx.On("UserRepository").Return(mockUserRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockUserRepo.On("GetUser", mock.Anything, "member-id").Return(models.User{UserId: "member-id", Username: "bob"}, nil)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "league-id", "member-id").Return("", nil)
+	mockLeagueRepo.On("AddLeagueMember", mock.Anything, "league-id", "member-id", models.RoleEditor).Return(nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.AddMember(context.Background(), "league-id", "member-id", models.RoleEditor)
+
+	// Assert
+	assert.NoError(t, err)
+	mockUserRepo.AssertExpectations(t)
+	mockLeagueRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_AddMember_UnknownUser(t *testing.T) {
+	// Setup mocks
+	mockUserRepo := &interfaces.MockUserRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("UserRepository").Return(mockUserRepo)
+	mockUserRepo.On("GetUser", mock.Anything, "ghost-id").Return(models.User{}, apperrors.NotFound("user ghost-id not found"))
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.AddMember(context.Background(), "league-id", "ghost-id", models.RoleViewer)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_AddMember_OwnerIsAlreadyMember(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockUserRepo := &interfaces.MockUserRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("UserRepository").Return(mockUserRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockUserRepo.On("GetUser", mock.Anything, testOwner.UserId).Return(testOwner, nil)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "league-id", testOwner.UserId).Return(models.RoleOwner, nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.AddMember(context.Background(), "league-id", testOwner.UserId, models.RoleEditor)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	mockLeagueRepo.AssertNotCalled(t, "AddLeagueMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLeagueService_AddMember_CannotGrantOwnership(t *testing.T) {
+	// Setup mocks
+	mockAppCtx := &MockAppContext{}
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.AddMember(context.Background(), "league-id", "member-id", models.RoleOwner)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	mockAppCtx.AssertExpectations(t)
+}
+
+func TestLeagueService_DeleteLeague_RecordsFinalStandings(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	league := models.League{
+		LeagueID:    "league-id",
+		CurrentWeek: 4,
+		Standings:   []models.Standings{{Team: models.Team{Name: "Team A"}, Points: 10}},
+	}
+
+	var entry models.AuditEntry
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "league-id").Return(league, nil)
+	mockLeagueRepo.On("DeleteLeague", mock.Anything, "league-id").Return(nil)
+	mockAuditRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).
+		Run(
+			func(args mock.Arguments) {
+				entry = args.Get(1).(models.AuditEntry)
+			}).
+		Return(nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.DeleteLeague(ownerCtx, "league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuditActionDelete, entry.Action)
+	assert.Equal(t, testOwner.UserId, entry.ActorId)
+	assert.Nil(t, entry.After)
+
+	var before models.LeagueSnapshot
+	assert.NoError(t, json.Unmarshal(entry.Before, &before))
+	assert.Equal(t, 4, before.CurrentWeek)
+	assert.Equal(t, 10, before.Standings[0].Points)
+	mockLeagueRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_DeleteLeague_NotFound(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "missing").
+		Return(models.League{}, apperrors.NotFound("league missing not found"))
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.DeleteLeague(ownerCtx, "missing")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	mockAppCtx.AssertNotCalled(t, "LeagueRepository")
+}
+
+func TestLeagueService_RemoveMember_RecordsRole(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	var entry models.AuditEntry
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "league-id", "member-id").Return(models.RoleEditor, nil)
+	mockLeagueRepo.On("RemoveLeagueMember", mock.Anything, "league-id", "member-id").Return(nil)
+	mockAuditRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).
+		Run(
+			func(args mock.Arguments) {
+				entry = args.Get(1).(models.AuditEntry)
+			}).
+		Return(nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	err := service.RemoveMember(ownerCtx, "league-id", "member-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuditActionRemoveMember, entry.Action)
+	assert.JSONEq(t, `{"userId":"member-id","role":"editor"}`, string(entry.Before))
+	mockLeagueRepo.AssertExpectations(t)
+}
+
 // Benchmark tests
 func BenchmarkLeagueService_CreateLeague(b *testing.B) {
 	// Setup mocks
@@ -480,15 +906,17 @@ func BenchmarkLeagueService_CreateLeague(b *testing.B) {
 	mockAppCtx := &MockAppContext{}
 
 	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+
+	withQuotas(mockAppCtx, mockLeagueRepo)
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockLeagueRepo.On("SetLeague", mock.AnythingOfType("string"), mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
+	mockLeagueRepo.On("SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
 
 	service := NewLeagueService(mockAppCtx)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		service.CreateLeague("8", "Benchmark League")
+		service.CreateLeague(ownerCtx, "8", "Benchmark League", nil)
 	}
 }
 
@@ -509,14 +937,420 @@ func BenchmarkLeagueService_ResetLeague(b *testing.B) {
 
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockActiveLeagueRepo.On("GetActiveLeague", "test-league").Return(existingLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("DeleteMatchResults", "test-league").Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(existingLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("DeleteMatchResults", mock.Anything, "test-league").Return(nil)
 
 	service := NewLeagueService(mockAppCtx)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		service.ResetLeague("test-league")
+		service.ResetLeague(context.Background(), "test-league")
+	}
+}
+
+func TestLeagueService_ExportLeague_Success(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 2
+	league := models.League{
+		LeagueID:         "league-id",
+		CurrentWeek:      1,
+		PlayedFixtures:   []models.Week{{Number: 1}},
+		UpcomingFixtures: []models.Week{{Number: 2}, {Number: 3}},
+	}
+	results := []models.MatchResult{{MatchWeek: 1, Home: "Team A", HomeScore: 1, Away: "Team B", Winner: "Team A"}}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "league-id").Return(league, nil)
+	mockLeagueRepo.On("GetLeagueName", mock.Anything, "league-id").Return("Premier", nil)
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, "league-id").Return(rules, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "league-id").Return(results, nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	export, err := service.ExportLeague(ownerCtx, "league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.LeagueExportVersion, export.Version)
+	assert.False(t, export.ExportedAt.IsZero())
+	assert.Equal(t, "Premier", export.League.LeagueName)
+	assert.Equal(t, rules, export.League.Rules)
+	assert.Equal(t, 3, export.League.TotalWeeks)
+	assert.Equal(t, results, export.Results)
+}
+
+func TestLeagueService_ImportLeague_Success(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockAppCtx := &MockAppContext{}
+	withAudit(mockAppCtx)
+	withQuotas(mockAppCtx, mockLeagueRepo)
+
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League: models.League{
+			LeagueID:   "original-id",
+			LeagueName: "Premier",
+			Rules:      models.DefaultLeagueRules(),
+			Teams:      []models.Team{{Name: "Team A"}, {Name: "Team B"}},
+			Standings: []models.Standings{
+				{Team: models.Team{Name: "Team A"}, Played: 1}, {Team: models.Team{Name: "Team B"}, Played: 1},
+			},
+			PlayedFixtures: []models.Week{
+				{Number: 1, Matches: []models.Match{{Home: &models.Team{Name: "Team A"}, Away: &models.Team{Name: "Team B"}}}},
+			},
+		},
+		Results: []models.MatchResult{{MatchWeek: 1, Home: "Team A", Away: "Team B"}},
+	}
+
+	var stored models.League
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockLeagueRepo.On("SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).Return(nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).
+		Run(
+			func(args mock.Arguments) {
+				stored = args.Get(1).(models.League)
+			}).
+		Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, mock.AnythingOfType("string"), export.Results).Return(nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	result, err := service.ImportLeague(ownerCtx, export, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Premier", result.LeagueName)
+	assert.NotEqual(t, "original-id", result.LeagueId)
+	assert.Equal(t, result.LeagueId, stored.LeagueID)
+	mockMatchResultRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_ImportLeague_UnsupportedVersion(t *testing.T) {
+	// Setup mocks
+	mockAppCtx := &MockAppContext{}
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.ImportLeague(ownerCtx, models.LeagueExport{Version: 99}, true)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Contains(t, err.Error(), "unsupported export version 99")
+	mockAppCtx.AssertNotCalled(t, "LeagueRepository")
+}
+
+func TestLeagueService_ForkLeague_Success(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	mockPredictionRepo := &interfaces.MockPredictionRepository{}
+	mockAppCtx := &MockAppContext{}
+	withAudit(mockAppCtx)
+	withQuotas(mockAppCtx, mockLeagueRepo)
+
+	rules := models.DefaultLeagueRules()
+	parent := models.League{
+		LeagueID:         "parent-id",
+		CurrentWeek:      1,
+		Teams:            []models.Team{{Name: "Team A", Rating: 1510}, {Name: "Team B", Rating: 1490}},
+		Standings:        []models.Standings{{Team: models.Team{Name: "Team A"}, Points: 3, Played: 1}},
+		PlayedFixtures:   []models.Week{{Number: 1}},
+		UpcomingFixtures: []models.Week{{Number: 2}},
+	}
+	results := []models.MatchResult{{MatchWeek: 1, Home: "Team A", HomeScore: 1, Away: "Team B", Winner: "Team A"}}
+	ratings := []models.RatingChange{{MatchWeek: 1, Team: "Team A", Opponent: "Team B", Before: 1500, After: 1510}}
+	snapshots := []models.PredictionSnapshot{{Week: 1}, {Week: 2}}
+
+	var stored models.League
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo)
+	mockAppCtx.On("PredictionRepository").Return(mockPredictionRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "parent-id").Return(parent, nil)
+	mockLeagueRepo.On("GetLeagueName", mock.Anything, "parent-id").Return("Premier", nil)
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, "parent-id").Return(rules, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "parent-id").Return(results, nil)
+	mockRatingRepo.On("GetRatingChanges", mock.Anything, "parent-id", "").Return(ratings, nil)
+	mockPredictionRepo.On("GetPredictionSnapshots", mock.Anything, "parent-id").Return(snapshots, nil)
+
+	mockLeagueRepo.On(
+		"SetLeague", mock.Anything, mock.AnythingOfType("string"), testOwner.UserId,
+		models.CreateLeagueRequest{LeagueName: "Premier (fork)", Rules: &rules}).Return(nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).
+		Run(
+			func(args mock.Arguments) {
+				stored = args.Get(1).(models.League)
+			}).
+		Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, mock.AnythingOfType("string"), results).Return(nil)
+	mockLeagueRepo.On("SetLeagueParent", mock.Anything, mock.AnythingOfType("string"), "parent-id").Return(nil)
+	mockRatingRepo.On("AppendRatingChanges", mock.Anything, mock.AnythingOfType("string"), ratings).Return(nil)
+	mockPredictionRepo.On("SavePredictionSnapshot", mock.Anything, mock.AnythingOfType("string"), mock.Anything).
+		Return(nil).Times(2)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	result, err := service.ForkLeague(ownerCtx, "parent-id", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Premier (fork)", result.LeagueName)
+	assert.Equal(t, "parent-id", result.ParentId)
+	assert.NotEqual(t, "parent-id", result.LeagueId)
+	assert.Equal(t, result.LeagueId, stored.LeagueID)
+	assert.Equal(t, parent.Teams, stored.Teams)
+	assert.Equal(t, parent.Standings, stored.Standings)
+	assert.Equal(t, parent.UpcomingFixtures, stored.UpcomingFixtures)
+	mockLeagueRepo.AssertCalled(t, "SetLeagueParent", mock.Anything, result.LeagueId, "parent-id")
+	mockRatingRepo.AssertExpectations(t)
+	mockPredictionRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_ForkLeague_QuotaExceeded(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("Config").Return(config.Default())
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "parent-id").Return(models.League{}, nil)
+	mockLeagueRepo.On("GetLeagueName", mock.Anything, "parent-id").Return("Premier", nil)
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, "parent-id").Return(models.DefaultLeagueRules(), nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, "parent-id").Return([]models.MatchResult{}, nil)
+	mockLeagueRepo.On("CountOwnedLeagues", mock.Anything, testOwner.UserId).
+		Return(config.Default().Quotas.LeaguesPerUser, nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.ForkLeague(ownerCtx, "parent-id", "Other timeline")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindQuota))
+	mockLeagueRepo.AssertNotCalled(t, "SetLeague", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLeagueService_DiffStandings_AgainstParent(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	fork := []models.Standings{
+		{Team: models.Team{Name: "Team A"}, Points: 3, Played: 2, Goals: 2, Against: 3},
+		{Team: models.Team{Name: "Team B"}, Points: 4, Played: 2, Goals: 3, Against: 2},
+	}
+	parent := []models.Standings{
+		{Team: models.Team{Name: "Team A"}, Points: 6, Played: 2, Goals: 4, Against: 0},
+		{Team: models.Team{Name: "Team B"}, Points: 0, Played: 2, Goals: 0, Against: 4},
+	}
+
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockLeagueRepo.On("GetLeagueParent", mock.Anything, "fork-id").Return("parent-id", nil)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "parent-id", testOwner.UserId).Return(models.RoleViewer, nil)
+	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", mock.Anything, "fork-id").Return(fork, nil)
+	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", mock.Anything, "parent-id").Return(parent, nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	diff, err := service.DiffStandings(ownerCtx, "fork-id", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "parent-id", diff.OtherLeagueId)
+	assert.Equal(
+		t, []models.TeamStandingsDiff{
+			{
+				Team: "Team B", Position: 1, OtherPosition: 2, Points: 4, OtherPoints: 0, Played: 2, OtherPlayed: 2,
+				PositionChange: 1, PointsDifference: 4, GoalDifferenceDelta: 5,
+			},
+			{
+				Team: "Team A", Position: 2, OtherPosition: 1, Points: 3, OtherPoints: 6, Played: 2, OtherPlayed: 2,
+				PositionChange: -1, PointsDifference: -3, GoalDifferenceDelta: -5,
+			},
+		}, diff.Teams)
+}
+
+func TestLeagueService_DiffStandings_NotAFork(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockLeagueRepo.On("GetLeagueParent", mock.Anything, "league-id").Return("", nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.DiffStandings(ownerCtx, "league-id", "")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestLeagueService_DiffStandings_OtherLeagueNotReadable(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockLeagueRepo.On("GetMemberRole", mock.Anything, "other-id", testOwner.UserId).Return("", nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.DiffStandings(ownerCtx, "league-id", "other-id")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	mockAppCtx.AssertNotCalled(t, "ActiveLeagueRepository")
+}
+
+func TestLeagueService_ImportLeague_KeepsID(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockAppCtx := &MockAppContext{}
+	withAudit(mockAppCtx)
+	withQuotas(mockAppCtx, mockLeagueRepo)
+
+	teams := TeamGenerate(4)
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League: models.League{
+			LeagueID:         "0b7c6f0e-2d0c-4c53-9a43-6f1d3c1f6a10",
+			LeagueName:       "Premier",
+			Rules:            models.DefaultLeagueRules(),
+			Teams:            teams,
+			Standings:        CreateStandingsTable(teams),
+			UpcomingFixtures: GenerateFixtures(teams),
+		},
+	}
+
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockLeagueRepo.On("SetLeague", mock.Anything, export.League.LeagueID, testOwner.UserId, mock.AnythingOfType("models.CreateLeagueRequest")).
+		Return(nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, export.League.LeagueID, mock.Anything).Return(nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	result, err := service.ImportLeague(ownerCtx, export, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, export.League.LeagueID, result.LeagueId)
+	mockLeagueRepo.AssertExpectations(t)
+}
+
+func TestLeagueService_ImportLeague_RejectsUsedID(t *testing.T) {
+	// Setup mocks
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+
+	teams := TeamGenerate(4)
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League: models.League{
+			LeagueID:         "0b7c6f0e-2d0c-4c53-9a43-6f1d3c1f6a10",
+			LeagueName:       "Premier",
+			Rules:            models.DefaultLeagueRules(),
+			Teams:            teams,
+			Standings:        CreateStandingsTable(teams),
+			UpcomingFixtures: GenerateFixtures(teams),
+		},
+	}
+
+	// A deleted league had this ID.
+	mockAuditRepo.On("GetAuditEntries", mock.Anything, export.League.LeagueID, "").Return(
+		[]models.AuditEntry{
+			{Id: 1, LeagueId: export.League.LeagueID, Action: models.AuditActionCreate, ActorId: "previous-owner"},
+			{Id: 2, LeagueId: export.League.LeagueID, Action: models.AuditActionDelete, ActorId: "previous-owner"},
+		}, nil)
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.ImportLeague(ownerCtx, export, false)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict), err)
+	mockLeagueRepo.AssertNotCalled(t, "SetLeague", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockAuditRepo.AssertNotCalled(t, "AppendAuditEntry", mock.Anything, mock.Anything)
+}
+
+func TestLeagueService_ImportLeague_InvalidDocument(t *testing.T) {
+	// Setup mocks
+	mockAppCtx := &MockAppContext{}
+
+	export := models.LeagueExport{
+		Version: models.LeagueExportVersion,
+		League: models.League{
+			LeagueID:   "not-a-uuid",
+			LeagueName: "Premier",
+			Teams:      []models.Team{{Name: "Team A"}, {Name: "Team A"}},
+		},
+		Results: []models.MatchResult{{MatchWeek: 1, Home: "Team A", Away: "Team C"}},
+	}
+
+	// Create service
+	service := NewLeagueService(mockAppCtx)
+
+	// Execute
+	_, err := service.ImportLeague(ownerCtx, export, false)
+
+	// Assert
+	var appErr *apperrors.Error
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.KindValidation, appErr.Kind)
+	var fields []string
+	for _, f := range appErr.Fields {
+		fields = append(fields, f.Field)
 	}
+	assert.Equal(t, []string{"league.leagueId", "league.teams[1]", "league.standings", "results[0]"}, fields)
+	mockAppCtx.AssertNotCalled(t, "LeagueRepository")
 }
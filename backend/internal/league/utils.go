@@ -2,7 +2,9 @@ package league
 
 import (
 	"math/rand"
+	"sort"
 
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 )
 
@@ -13,6 +15,80 @@ func RandomNumberGenerator(min float64, max float64) float64 {
 	return min + rand.Float64()*(max-min)
 }
 
-func CalculateStrength(team models.Team) float64 {
-	return team.AttackPower*0.3 + team.DefensePower*0.3 + team.Morale*0.2 + team.Stamina*0.2
+func CalculateStrength(team models.Team, weights models.StrengthWeights) float64 {
+	return team.AttackPower*weights.Attack +
+		team.DefensePower*weights.Defense +
+		team.Morale*weights.Morale +
+		team.Stamina*weights.Stamina
+}
+
+// RankStandings returns the table in finishing order: points, then goal
+// difference, then goals scored. The input is left untouched.
+func RankStandings(standings []models.Standings) []models.Standings {
+	ranked := make([]models.Standings, len(standings))
+	copy(ranked, standings)
+
+	sort.SliceStable(
+		ranked, func(i, j int) bool {
+			a, b := ranked[i], ranked[j]
+			if a.Points != b.Points {
+				return a.Points > b.Points
+			}
+			if a.Goals-a.Against != b.Goals-b.Against {
+				return a.Goals-a.Against > b.Goals-b.Against
+			}
+			return a.Goals > b.Goals
+		})
+
+	return ranked
+}
+
+// CompareStandings lines up two tables of the same teams, ranked as
+// RankStandings ranks them, in the order of the first.
+func CompareStandings(
+	leagueId string, standings []models.Standings, otherId string, otherStandings []models.Standings,
+) (models.StandingsDiff, error) {
+	type place struct {
+		position int
+		standing models.Standings
+	}
+	others := make(map[string]place, len(otherStandings))
+	for i, s := range RankStandings(otherStandings) {
+		others[s.Team.Name] = place{position: i + 1, standing: s}
+	}
+
+	if len(others) != len(standings) {
+		return models.StandingsDiff{}, apperrors.Conflict(
+			"leagues %s and %s do not have the same teams", leagueId, otherId)
+	}
+
+	diff := models.StandingsDiff{
+		LeagueId:      leagueId,
+		OtherLeagueId: otherId,
+		Teams:         make([]models.TeamStandingsDiff, 0, len(standings)),
+	}
+	for i, s := range RankStandings(standings) {
+		other, ok := others[s.Team.Name]
+		if !ok {
+			return models.StandingsDiff{}, apperrors.Conflict(
+				"leagues %s and %s do not have the same teams", leagueId, otherId)
+		}
+
+		diff.Teams = append(
+			diff.Teams, models.TeamStandingsDiff{
+				Team:             s.Team.Name,
+				Position:         i + 1,
+				OtherPosition:    other.position,
+				Points:           s.Points,
+				OtherPoints:      other.standing.Points,
+				Played:           s.Played,
+				OtherPlayed:      other.standing.Played,
+				PositionChange:   other.position - (i + 1),
+				PointsDifference: s.Points - other.standing.Points,
+				GoalDifferenceDelta: (s.Goals - s.Against) -
+					(other.standing.Goals - other.standing.Against),
+			})
+	}
+
+	return diff, nil
 }
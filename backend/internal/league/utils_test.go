@@ -3,6 +3,7 @@ package league
 import (
 	"testing"
 
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/assert"
@@ -152,7 +153,7 @@ func TestCalculateStrength(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := CalculateStrength(tt.team)
+			result := CalculateStrength(tt.team, models.DefaultLeagueRules().StrengthWeights)
 
 			assert.InDelta(t, tt.expectedResult, result, 0.001, "Calculated strength should match expected value")
 		})
@@ -169,7 +170,7 @@ func TestCalculateStrength_WeightDistribution(t *testing.T) {
 		Morale:       0.0,   // 20% weight
 	}
 
-	result := CalculateStrength(team)
+	result := CalculateStrength(team, models.DefaultLeagueRules().StrengthWeights)
 	expected := 100.0 * 0.3 // Only attack power contributes
 
 	assert.InDelta(t, expected, result, 0.001, "Attack power should contribute 30% to total strength")
@@ -193,6 +194,36 @@ func BenchmarkCalculateStrength(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		CalculateStrength(team)
+		CalculateStrength(team, models.DefaultLeagueRules().StrengthWeights)
 	}
 }
+
+func TestRankStandings(t *testing.T) {
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Team A"}, Goals: 3, Against: 3, Points: 3},
+		{Team: models.Team{Name: "Team B"}, Goals: 4, Against: 1, Points: 4},
+		{Team: models.Team{Name: "Team C"}, Goals: 5, Against: 2, Points: 3},
+		{Team: models.Team{Name: "Team D"}, Goals: 6, Against: 3, Points: 3},
+	}
+
+	ranked := RankStandings(standings)
+
+	names := make([]string, 0, len(ranked))
+	for _, s := range ranked {
+		names = append(names, s.Team.Name)
+	}
+	assert.Equal(t, []string{"Team B", "Team D", "Team C", "Team A"}, names)
+	assert.Equal(t, "Team A", standings[0].Team.Name, "input must not be reordered")
+}
+
+func TestCompareStandings_DifferentTeams(t *testing.T) {
+	// Setup
+	standings := []models.Standings{{Team: models.Team{Name: "Team A"}}, {Team: models.Team{Name: "Team B"}}}
+	other := []models.Standings{{Team: models.Team{Name: "Team A"}}, {Team: models.Team{Name: "Team C"}}}
+
+	// Execute
+	_, err := CompareStandings("league-id", standings, "other-id", other)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditActionCreate       = "create"
+	AuditActionImport       = "import"
+	AuditActionSimulate     = "simulate"
+	AuditActionEdit         = "edit"
+	AuditActionReset        = "reset"
+	AuditActionDelete       = "delete"
+	AuditActionTactics      = "tactics"
+	AuditActionAddMember    = "add_member"
+	AuditActionRemoveMember = "remove_member"
+	AuditActionFork         = "fork"
+)
+
+// AuditActions lists every action the audit log can be filtered by.
+var AuditActions = []string{
+	AuditActionCreate,
+	AuditActionImport,
+	AuditActionSimulate,
+	AuditActionEdit,
+	AuditActionReset,
+	AuditActionDelete,
+	AuditActionTactics,
+	AuditActionAddMember,
+	AuditActionRemoveMember,
+	AuditActionFork,
+}
+
+// AuditEntry is one mutating operation on a league. Before and After hold the
+// JSON state the operation replaced and produced; either may be null.
+type AuditEntry struct {
+	Id        int64           `json:"id"`
+	LeagueId  string          `json:"leagueId"`
+	Action    string          `json:"action"`
+	ActorId   string          `json:"actorId"`
+	RequestId string          `json:"requestId"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type GetAuditLogRequest struct {
+	Action string `json:"action" query:"action" validate:"omitempty,auditaction"`
+}
+
+// LeagueSnapshot is the part of a league's state recorded around simulations,
+// resets and deletes.
+type LeagueSnapshot struct {
+	CurrentWeek int         `json:"currentWeek"`
+	Standings   []Standings `json:"standings"`
+}
+
+type SimulationAudit struct {
+	LeagueSnapshot
+	Matches []MatchResult `json:"matches"`
+}
@@ -0,0 +1,49 @@
+package models
+
+// CalibrationReport holds match engine rules fitted to historical results and
+// how closely the engine then reproduces those results.
+type CalibrationReport struct {
+	Matches int `json:"matches"`
+	// Rules are the base rules with the fitted home advantage, draw model and
+	// goal rates; they can be passed to league creation as they are.
+	Rules LeagueRules  `json:"rules"`
+	Teams []TeamRating `json:"teams"`
+	// BrierScore and LogLoss rate the fitted home/draw/away probabilities of
+	// every match against its result; lower is better. BaselineBrierScore is
+	// the score of predicting the overall outcome frequencies for every match.
+	BrierScore         float64         `json:"brierScore"`
+	BaselineBrierScore float64         `json:"baselineBrierScore"`
+	LogLoss            float64         `json:"logLoss"`
+	Observed           MatchStatistics `json:"observed"`
+	Simulated          MatchStatistics `json:"simulated"`
+	// Goals compares the distribution of goals per match. The last bucket
+	// also counts higher totals.
+	Goals []GoalFrequency `json:"goals"`
+}
+
+// TeamRating is a team's fitted strength. Ratings are relative, with a
+// geometric mean of one; Strength is the rating on the scale of
+// league.CalculateStrength for generated teams.
+type TeamRating struct {
+	Name     string  `json:"name"`
+	Rating   float64 `json:"rating"`
+	Strength float64 `json:"strength"`
+	Played   int     `json:"played"`
+	Wins     int     `json:"wins"`
+	Draws    int     `json:"draws"`
+	Losses   int     `json:"losses"`
+}
+
+type MatchStatistics struct {
+	HomeWinRate  float64 `json:"homeWinRate"`
+	DrawRate     float64 `json:"drawRate"`
+	AwayWinRate  float64 `json:"awayWinRate"`
+	GoalsPerGame float64 `json:"goalsPerGame"`
+}
+
+// GoalFrequency is the share of matches with Goals goals in total.
+type GoalFrequency struct {
+	Goals     int     `json:"goals"`
+	Observed  float64 `json:"observed"`
+	Simulated float64 `json:"simulated"`
+}
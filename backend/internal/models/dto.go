@@ -1,17 +1,23 @@
 package models
 
 type CreateLeagueRequest struct {
-	LeagueName string `json:"leagueName"`
-	TeamCount  string `json:"teamCount"`
+	LeagueName string       `json:"leagueName" validate:"required,leaguename"`
+	TeamCount  string       `json:"teamCount" validate:"required,teamcount"`
+	Rules      *LeagueRules `json:"rules,omitempty" validate:"omitempty,rules"`
 }
 type GetLeaguesIdsWithNameResponse struct {
 	LeagueId   string `json:"leagueId"`
 	LeagueName string `json:"leagueName"`
+	ParentId   string `json:"parentId,omitempty"`
 }
 
 type GetActiveLeagueStandingsResponse struct {
 	Standings []Standings `json:"standings"`
 }
+type GetFixturesRequest struct {
+	Odds bool `json:"odds" query:"odds"`
+}
+
 type GetActiveLeagueFixturesResponse struct {
 	UpcomingFixtures []Week `json:"upcomingFixtures"`
 	PlayedFixtures   []Week `json:"playedFixtures"`
@@ -19,11 +25,11 @@ type GetActiveLeagueFixturesResponse struct {
 
 type EditMatchResult struct {
 	LeagueId  string `json:"leagueId"`
-	Home      string `json:"home"`
-	Away      string `json:"away"`
-	HomeScore int    `json:"homeScore"`
-	AwayScore int    `json:"awayScore"`
-	MatchWeek int    `json:"matchWeek"`
+	Home      string `json:"home" validate:"required"`
+	Away      string `json:"away" validate:"required,nefield=Home"`
+	HomeScore int    `json:"homeScore" validate:"goals"`
+	AwayScore int    `json:"awayScore" validate:"goals"`
+	MatchWeek int    `json:"matchWeek" validate:"gte=1"`
 	Winner    string `json:"winner"`
 }
 
@@ -31,8 +37,49 @@ type SimulateLeagueRequest struct {
 	PlayAllFixture bool `json:"playAllFixture"`
 }
 
+type SetTacticsRequest struct {
+	Team    string  `json:"team" validate:"required"`
+	Tactics Tactics `json:"tactics" validate:"tactics"`
+}
+
 type SimulationResponse struct {
 	Matches          []MatchResult `json:"matches"`
 	UpcomingFixtures []Week        `json:"upcomingFixtures"`
 	PlayedFixtures   []Week        `json:"playedFixtures"`
 }
+
+// ForkLeagueRequest names the fork of a league. Without a name the fork is
+// called after its parent.
+type ForkLeagueRequest struct {
+	LeagueName string `json:"leagueName" validate:"omitempty,leaguename"`
+}
+
+// GetStandingsDiffRequest names the league to compare standings with; a fork
+// is compared with its parent by default.
+type GetStandingsDiffRequest struct {
+	With string `json:"with" query:"with" validate:"omitempty,max=36"`
+}
+
+// StandingsDiff compares the standings of two leagues with the same teams,
+// typically forks of one another, team by team in the order of the first.
+type StandingsDiff struct {
+	LeagueId      string              `json:"leagueId"`
+	OtherLeagueId string              `json:"otherLeagueId"`
+	Teams         []TeamStandingsDiff `json:"teams"`
+}
+
+// TeamStandingsDiff is one team in both leagues of a StandingsDiff. The
+// differences are this league's figure minus the other's, and PositionChange
+// is positive when the team stands higher in this league.
+type TeamStandingsDiff struct {
+	Team                string `json:"team"`
+	Position            int    `json:"position"`
+	OtherPosition       int    `json:"otherPosition"`
+	Points              int    `json:"points"`
+	OtherPoints         int    `json:"otherPoints"`
+	Played              int    `json:"played"`
+	OtherPlayed         int    `json:"otherPlayed"`
+	PositionChange      int    `json:"positionChange"`
+	PointsDifference    int    `json:"pointsDifference"`
+	GoalDifferenceDelta int    `json:"goalDifferenceDelta"`
+}
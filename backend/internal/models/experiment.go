@@ -0,0 +1,63 @@
+package models
+
+// ExperimentRequest asks for a batch of synthetic leagues to be generated and
+// played to the end in memory. Nothing is stored.
+type ExperimentRequest struct {
+	Leagues   int          `json:"leagues" validate:"experimentleagues"`
+	TeamCount int          `json:"teamCount" validate:"required,teamcount"`
+	Rules     *LeagueRules `json:"rules,omitempty" validate:"omitempty,rules"`
+}
+
+// ExperimentFormatRequest picks how an experiment is returned: JSON, the
+// default, or CSV with one row per league.
+type ExperimentFormatRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// ExperimentResult aggregates the outcomes of every league of an experiment.
+type ExperimentResult struct {
+	Leagues   int         `json:"leagues"`
+	TeamCount int         `json:"teamCount"`
+	Rules     LeagueRules `json:"rules"`
+	// StrongestTeamWinRate is the share of leagues won by the team that was
+	// strongest before the first match.
+	StrongestTeamWinRate float64         `json:"strongestTeamWinRate"`
+	ChampionStrengthRank []RankFrequency `json:"championStrengthRank"`
+	ChampionPoints       Distribution    `json:"championPoints"`
+	PointsSpread         Distribution    `json:"pointsSpread"`
+	GoalsPerGame         Distribution    `json:"goalsPerGame"`
+	DrawRate             Distribution    `json:"drawRate"`
+	Runs                 []ExperimentRun `json:"runs"`
+}
+
+// RankFrequency counts the leagues whose champion started with the Rank-th
+// highest strength.
+type RankFrequency struct {
+	Rank  int     `json:"rank"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"`
+}
+
+// Distribution summarises one value measured in every league of an experiment.
+type Distribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Min    float64 `json:"min"`
+	P5     float64 `json:"p5"`
+	Median float64 `json:"median"`
+	P95    float64 `json:"p95"`
+	Max    float64 `json:"max"`
+}
+
+// ExperimentRun is the outcome of one league of an experiment. PointsSpread is
+// the gap between the champion and the last team.
+type ExperimentRun struct {
+	League               int     `json:"league"`
+	Champion             string  `json:"champion"`
+	ChampionStrengthRank int     `json:"championStrengthRank"`
+	ChampionPoints       int     `json:"championPoints"`
+	PointsSpread         int     `json:"pointsSpread"`
+	Matches              int     `json:"matches"`
+	GoalsPerGame         float64 `json:"goalsPerGame"`
+	DrawRate             float64 `json:"drawRate"`
+}
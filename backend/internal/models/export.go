@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// LeagueExportVersion is the version of the LeagueExport format this build
+// writes and reads.
+const LeagueExportVersion = 1
+
+// LeagueExport is everything stored for a league: its name, rules, season and
+// results. It can be imported again, here or in another environment.
+type LeagueExport struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exportedAt"`
+	League     League        `json:"league"`
+	Results    []MatchResult `json:"results"`
+}
+
+// Tables of a league that can be exported as CSV.
+const (
+	ExportTableStandings = "standings"
+	ExportTableResults   = "results"
+	ExportTableFixtures  = "fixtures"
+)
+
+// ExportTables lists every table a league can be exported as, in the order the
+// CLI writes them.
+var ExportTables = []string{ExportTableStandings, ExportTableResults, ExportTableFixtures}
+
+// ExportLeagueRequest picks how a league is exported: the whole LeagueExport as
+// JSON, the default, or one of its tables as CSV.
+type ExportLeagueRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv"`
+	Table  string `query:"table" validate:"required_if=Format csv,omitempty,oneof=standings results fixtures"`
+}
+
+// ImportLeagueRequest says whether an imported league keeps the ID it was
+// exported with or gets a new one.
+type ImportLeagueRequest struct {
+	NewID bool `query:"newId"`
+}
@@ -0,0 +1,152 @@
+package models
+
+// PredictionSnapshot is the title prediction made before a week was played.
+type PredictionSnapshot struct {
+	Week        int                 `json:"week"`
+	Predictions []PredictedStanding `json:"predictions"`
+}
+
+// PredictionAccuracy rates title predictions against the team that won the
+// league. BrierScore sums the squared error of every team's odds and LogLoss
+// is the negative log of the odds given to the champion, both averaged over
+// snapshots; lower is better.
+type PredictionAccuracy struct {
+	Strategy    string           `json:"strategy,omitempty"`
+	Champion    string           `json:"champion,omitempty"`
+	Snapshots   int              `json:"snapshots"`
+	BrierScore  float64          `json:"brierScore"`
+	LogLoss     float64          `json:"logLoss"`
+	Calibration []CalibrationBin `json:"calibration"`
+	Weeks       []WeekAccuracy   `json:"weeks,omitempty"`
+}
+
+// CalibrationBin compares the odds given in [From, To) with how often teams
+// given them won the league. Well calibrated predictions have Observed close
+// to MeanPredicted.
+type CalibrationBin struct {
+	From          float64 `json:"from"`
+	To            float64 `json:"to"`
+	Predictions   int     `json:"predictions"`
+	MeanPredicted float64 `json:"meanPredicted"`
+	Observed      float64 `json:"observed"`
+}
+
+type WeekAccuracy struct {
+	Week         int     `json:"week"`
+	ChampionOdds float64 `json:"championOdds"`
+	BrierScore   float64 `json:"brierScore"`
+	LogLoss      float64 `json:"logLoss"`
+}
+
+// BacktestReport compares prediction strategies replayed over completed
+// seasons, one PredictionAccuracy per strategy.
+type BacktestReport struct {
+	Leagues    []string             `json:"leagues"`
+	Runs       int                  `json:"runs"`
+	Strategies []PredictionAccuracy `json:"strategies"`
+}
+
+// Clinch analysis methods. Bounds and max-flow prove what they report but can
+// miss a clinch or an elimination; exhaustive search is exact.
+const (
+	ClinchMethodBounds     = "bounds"
+	ClinchMethodMaxFlow    = "maxflow"
+	ClinchMethodExhaustive = "exhaustive"
+)
+
+// Clinch targets: finishing first, in the top Places, or above the relegation
+// places.
+const (
+	ClinchTargetTitle  = "title"
+	ClinchTargetTop    = "top"
+	ClinchTargetSafety = "safety"
+)
+
+type GetClinchRequest struct {
+	Method     string `json:"method" query:"method" validate:"omitempty,oneof=bounds maxflow exhaustive"`
+	Top        int    `json:"top" query:"top" validate:"omitempty,min=1,max=100"`
+	Relegation int    `json:"relegation" query:"relegation" validate:"omitempty,min=1,max=100"`
+}
+
+// ClinchAnalysis tells, from the remaining fixtures, where every team can
+// still finish. Points decide positions; a tie on points counts for a team
+// when finding its best position and against it when finding its worst.
+type ClinchAnalysis struct {
+	Method           string       `json:"method"`
+	RemainingMatches int          `json:"remainingMatches"`
+	Teams            []TeamClinch `json:"teams"`
+}
+
+type TeamClinch struct {
+	Team          string         `json:"team"`
+	Points        int            `json:"points"`
+	MaxPoints     int            `json:"maxPoints"`
+	BestPosition  int            `json:"bestPosition"`
+	WorstPosition int            `json:"worstPosition"`
+	Targets       []ClinchStatus `json:"targets"`
+}
+
+// ClinchStatus is a team's standing against finishing in the top Places.
+// MagicNumber is how many points the team must gain, or its rivals drop,
+// before the places are certain; EliminationNumber is how many points it can
+// drop, or its rivals gain, before they are out of reach. Both are zero once
+// the team has clinched or been eliminated.
+type ClinchStatus struct {
+	Target            string `json:"target"`
+	Places            int    `json:"places"`
+	Clinched          bool   `json:"clinched"`
+	Eliminated        bool   `json:"eliminated"`
+	MagicNumber       int    `json:"magicNumber"`
+	EliminationNumber int    `json:"eliminationNumber"`
+}
+
+// GetPositionsRequest asks for the finishing-position distribution of a
+// league, from Runs playouts of its remaining fixtures, as JSON or CSV.
+type GetPositionsRequest struct {
+	Runs   int    `json:"runs" query:"runs" validate:"omitempty,min=1,max=10000"`
+	Format string `json:"format" query:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// PositionProbabilities is the team × position matrix of a league's
+// simulated finishes. Teams are ordered by expected position.
+type PositionProbabilities struct {
+	Runs  int             `json:"runs"`
+	Teams []TeamPositions `json:"teams"`
+}
+
+// TeamPositions is one row of PositionProbabilities. Probabilities[i] is the
+// percentage of runs the team finished in position i+1; the points are those
+// it ended the season with.
+type TeamPositions struct {
+	Team             string    `json:"team"`
+	Points           int       `json:"points"`
+	Probabilities    []float64 `json:"probabilities"`
+	ExpectedPosition float64   `json:"expectedPosition"`
+	ExpectedPoints   float64   `json:"expectedPoints"`
+	PointsP5         int       `json:"pointsP5"`
+	PointsP95        int       `json:"pointsP95"`
+}
+
+// HypotheticalResult fixes the score of an upcoming match for a what-if
+// scenario.
+type HypotheticalResult struct {
+	Home      string `json:"home" validate:"required"`
+	Away      string `json:"away" validate:"required,nefield=Home"`
+	HomeScore int    `json:"homeScore" validate:"goals"`
+	AwayScore int    `json:"awayScore" validate:"goals"`
+}
+
+// WhatIfRequest lists the hypothetical results of a what-if scenario. Each
+// must be a different upcoming match of the league.
+type WhatIfRequest struct {
+	Results []HypotheticalResult `json:"results" validate:"required,min=1,max=500,dive"`
+}
+
+// WhatIfResponse is a league as it would stand after the hypothetical results,
+// with the title odds recomputed. Nothing of it is stored.
+type WhatIfResponse struct {
+	Results          []MatchResult       `json:"results"`
+	Standings        []Standings         `json:"standings"`
+	UpcomingFixtures []Week              `json:"upcomingFixtures"`
+	Predictions      []PredictedStanding `json:"predictions"`
+}
@@ -3,6 +3,7 @@ package models
 type League struct {
 	LeagueID         string              `json:"leagueId"`
 	LeagueName       string              `json:"leagueName"`
+	Rules            LeagueRules         `json:"rules"`
 	Teams            []Team              `json:"teams"`
 	Standings        []Standings         `json:"standings"`
 	TotalWeeks       int                 `json:"totalWeeks"`
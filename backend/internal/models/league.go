@@ -6,6 +6,15 @@ type Team struct {
 	DefensePower float64 `json:"defensePower"`
 	Morale       float64 `json:"morale"`
 	Stamina      float64 `json:"stamina"`
+	Tactics      Tactics `json:"tactics"`
+	// Rating is the team's Elo rating, zero until its league plays a match.
+	Rating float64 `json:"rating,omitempty"`
+}
+
+type Tactics struct {
+	Formation     string `json:"formation"`
+	Pressing      string `json:"pressing"`
+	DefensiveLine string `json:"defensiveLine"`
 }
 
 type Standings struct {
@@ -21,6 +30,25 @@ type Standings struct {
 type Match struct {
 	Home *Team `json:"home"`
 	Away *Team `json:"away"`
+	// Odds are only filled in on request, for upcoming matches.
+	Odds *MatchOdds `json:"odds,omitempty"`
+}
+
+// MatchOdds are the match engine's chances for one match: each outcome, the
+// goals it expects each side to score and its likeliest exact score.
+type MatchOdds struct {
+	HomeWin           float64   `json:"homeWin"`
+	Draw              float64   `json:"draw"`
+	AwayWin           float64   `json:"awayWin"`
+	ExpectedHomeGoals float64   `json:"expectedHomeGoals"`
+	ExpectedAwayGoals float64   `json:"expectedAwayGoals"`
+	LikeliestScore    Scoreline `json:"likeliestScore"`
+}
+
+type Scoreline struct {
+	Home        int     `json:"home"`
+	Away        int     `json:"away"`
+	Probability float64 `json:"probability"`
 }
 
 type Week struct {
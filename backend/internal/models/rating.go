@@ -0,0 +1,29 @@
+package models
+
+// RatingChange is what one match did to one team's rating.
+type RatingChange struct {
+	MatchWeek int     `json:"matchWeek"`
+	Team      string  `json:"team"`
+	Opponent  string  `json:"opponent"`
+	Before    float64 `json:"before"`
+	After     float64 `json:"after"`
+}
+
+type GetRatingHistoryRequest struct {
+	Team string `json:"team" query:"team" validate:"omitempty,max=36"`
+}
+
+// TeamRatingHistory charts a team's rating: the rating it started with at
+// week 0, then one point per match.
+type TeamRatingHistory struct {
+	Team    string        `json:"team"`
+	Rating  float64       `json:"rating"`
+	History []RatingPoint `json:"history"`
+}
+
+type RatingPoint struct {
+	Week     int     `json:"week"`
+	Rating   float64 `json:"rating"`
+	Change   float64 `json:"change"`
+	Opponent string  `json:"opponent,omitempty"`
+}
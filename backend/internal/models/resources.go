@@ -0,0 +1,141 @@
+package models
+
+// Resources served by /api/v2. Every response wraps its payload in Response or
+// ListResponse so that clients always find it under "data". Collections bounded
+// by the size of a league (teams, standings, predictions, members) come whole
+// in a Response; the others are paginated in a ListResponse.
+
+type Response[T any] struct {
+	Data T `json:"data"`
+}
+
+type ListResponse[T any] struct {
+	Data []T      `json:"data"`
+	Page PageInfo `json:"page"`
+}
+
+// PageInfo tells the client how to ask for the next page. NextCursor is empty
+// on the last page.
+type PageInfo struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// PageRequest is the cursor and page size a list endpoint is called with.
+type PageRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Match statuses.
+const (
+	MatchPlayed   = "played"
+	MatchUpcoming = "upcoming"
+)
+
+// Simulation lengths accepted by POST /simulations.
+const (
+	SimulateNextWeek = "next"
+	SimulateAllWeeks = "all"
+)
+
+type LeagueSummary struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type LeagueResource struct {
+	Id        string         `json:"id"`
+	Name      string         `json:"name"`
+	Role      string         `json:"role"`
+	Rules     LeagueRules    `json:"rules"`
+	TeamCount int            `json:"teamCount"`
+	Season    SeasonResource `json:"season"`
+}
+
+// SeasonResource is the season in progress. A league keeps only its current
+// season; starting a new one replaces it.
+type SeasonResource struct {
+	TotalWeeks  int  `json:"totalWeeks"`
+	CurrentWeek int  `json:"currentWeek"`
+	Finished    bool `json:"finished"`
+}
+
+type TeamResource struct {
+	Id           string  `json:"id"`
+	Name         string  `json:"name"`
+	AttackPower  float64 `json:"attackPower"`
+	DefensePower float64 `json:"defensePower"`
+	Morale       float64 `json:"morale"`
+	Stamina      float64 `json:"stamina"`
+	Tactics      Tactics `json:"tactics"`
+}
+
+// MatchResource is a fixture, with its score once it has been played.
+type MatchResource struct {
+	Id        string `json:"id"`
+	Week      int    `json:"week"`
+	Home      string `json:"home"`
+	Away      string `json:"away"`
+	Status    string `json:"status"`
+	HomeScore *int   `json:"homeScore"`
+	AwayScore *int   `json:"awayScore"`
+	Winner    string `json:"winner,omitempty"`
+}
+
+type StandingResource struct {
+	Position       int    `json:"position"`
+	TeamId         string `json:"teamId"`
+	Team           string `json:"team"`
+	Played         int    `json:"played"`
+	Wins           int    `json:"wins"`
+	Draws          int    `json:"draws"`
+	Losses         int    `json:"losses"`
+	GoalsFor       int    `json:"goalsFor"`
+	GoalsAgainst   int    `json:"goalsAgainst"`
+	GoalDifference int    `json:"goalDifference"`
+	Points         int    `json:"points"`
+}
+
+// PredictionResource is a team's chance of winning the league. Unlike the
+// percentage in v1, Probability is between 0 and 1.
+type PredictionResource struct {
+	TeamId      string  `json:"teamId"`
+	Team        string  `json:"team"`
+	Points      int     `json:"points"`
+	Strength    float64 `json:"strength"`
+	Probability float64 `json:"probability"`
+	Eliminated  bool    `json:"eliminated"`
+}
+
+type SimulationResource struct {
+	Matches []MatchResource `json:"matches"`
+	Season  SeasonResource  `json:"season"`
+}
+
+type CreateLeagueV2Request struct {
+	Name      string       `json:"name" validate:"required,leaguename"`
+	TeamCount int          `json:"teamCount" validate:"required,teamcount"`
+	Rules     *LeagueRules `json:"rules,omitempty" validate:"omitempty,rules"`
+}
+
+type ListMatchesRequest struct {
+	PageRequest
+	Week   int    `query:"week" validate:"omitempty,min=1"`
+	Team   string `query:"team"`
+	Status string `query:"status" validate:"omitempty,oneof=played upcoming"`
+}
+
+type ListAuditRequest struct {
+	PageRequest
+	GetAuditLogRequest
+}
+
+type MatchScoreRequest struct {
+	HomeScore int `json:"homeScore" validate:"goals"`
+	AwayScore int `json:"awayScore" validate:"goals"`
+}
+
+type CreateSimulationRequest struct {
+	Weeks string `json:"weeks" validate:"required,oneof=next all"`
+}
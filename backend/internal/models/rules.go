@@ -0,0 +1,74 @@
+package models
+
+type LeagueRules struct {
+	PointsForWin    int             `json:"pointsForWin"`
+	PointsForDraw   int             `json:"pointsForDraw"`
+	PointsForLoss   int             `json:"pointsForLoss"`
+	HomeAdvantage   float64         `json:"homeAdvantage"`
+	SquadCap        int             `json:"squadCap"`
+	StrengthWeights StrengthWeights `json:"strengthWeights"`
+	Draw            DrawModel       `json:"draw"`
+	BonusPoints     BonusPoints     `json:"bonusPoints"`
+	GoalRates       *GoalRates      `json:"goalRates,omitempty"`
+	Elo             *EloRules       `json:"elo,omitempty"`
+}
+
+type StrengthWeights struct {
+	Attack  float64 `json:"attack"`
+	Defense float64 `json:"defense"`
+	Morale  float64 `json:"morale"`
+	Stamina float64 `json:"stamina"`
+}
+
+type DrawModel struct {
+	Chance   float64 `json:"chance"`
+	MaxGoals int     `json:"maxGoals"`
+}
+
+// GoalRates replaces the evenly spread scores of the match engine, usually
+// with rates fitted to real results. The winner of a match scores
+// 1 + Poisson(Winner) goals and the loser scores each of the winner's goals
+// but one with probability Loser. Both sides of a draw score Poisson(Draw).
+type GoalRates struct {
+	Winner float64 `json:"winner"`
+	Loser  float64 `json:"loser"`
+	Draw   float64 `json:"draw"`
+}
+
+// EloRules tunes the Elo ratings every league keeps and lets them replace the
+// static strength of teams in the match engine and the predictor. A zero
+// KFactor means the default; a zero HomeAdvantage, in rating points, is
+// derived from the league's home advantage so that ratings expect what the
+// engine plays.
+type EloRules struct {
+	KFactor       float64 `json:"kFactor"`
+	HomeAdvantage float64 `json:"homeAdvantage"`
+	InEngine      bool    `json:"inEngine"`
+	InPredictor   bool    `json:"inPredictor"`
+}
+
+type BonusPoints struct {
+	GoalThreshold int `json:"goalThreshold"`
+	Points        int `json:"points"`
+}
+
+func DefaultLeagueRules() LeagueRules {
+	return LeagueRules{
+		PointsForWin:  3,
+		PointsForDraw: 1,
+		PointsForLoss: 0,
+		HomeAdvantage: 1.05,
+		SquadCap:      0,
+		StrengthWeights: StrengthWeights{
+			Attack:  0.3,
+			Defense: 0.3,
+			Morale:  0.2,
+			Stamina: 0.2,
+		},
+		Draw: DrawModel{
+			Chance:   0.2,
+			MaxGoals: 2,
+		},
+		BonusPoints: BonusPoints{},
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+// Roles a user can hold in a league. The owner is whoever created the league;
+// editors can simulate and edit results, viewers can only read.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+type User struct {
+	UserId   string `json:"userId"`
+	Username string `json:"username"`
+}
+
+type RegisterUserRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=32,alphanum"`
+}
+
+// RegisterUserResponse carries the only copy of the API key the server ever
+// hands out; just its hash is stored.
+type RegisterUserResponse struct {
+	User
+	APIKey string `json:"apiKey"`
+}
+
+type LeagueMember struct {
+	UserId   string `json:"userId"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+type AddLeagueMemberRequest struct {
+	UserId string `json:"userId" validate:"required,uuid"`
+	Role   string `json:"role" validate:"required,oneof=editor viewer"`
+}
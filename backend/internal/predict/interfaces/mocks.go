@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/mock"
@@ -11,7 +13,40 @@ type MockPredictServiceInterface struct {
 	mock.Mock
 }
 
-func (m *MockPredictServiceInterface) PredictChampionShipSession(id string) ([]models.PredictedStanding, error) {
-	args := m.Called(id)
+func (m *MockPredictServiceInterface) PredictChampionShipSession(ctx context.Context, id string) ([]models.PredictedStanding, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).([]models.PredictedStanding), args.Error(1)
 }
+
+func (m *MockPredictServiceInterface) PredictChampionships(ctx context.Context, ids []string) (map[string][]models.PredictedStanding, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]models.PredictedStanding), args.Error(1)
+}
+
+func (m *MockPredictServiceInterface) PredictFixtures(ctx context.Context, id string) (models.GetActiveLeagueFixturesResponse, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.GetActiveLeagueFixturesResponse), args.Error(1)
+}
+
+func (m *MockPredictServiceInterface) PredictionAccuracy(ctx context.Context, id string) (models.PredictionAccuracy, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.PredictionAccuracy), args.Error(1)
+}
+
+func (m *MockPredictServiceInterface) Clinch(ctx context.Context, id string, req models.GetClinchRequest) (models.ClinchAnalysis, error) {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(models.ClinchAnalysis), args.Error(1)
+}
+
+func (m *MockPredictServiceInterface) PositionProbabilities(ctx context.Context, id string, runs int) (models.PositionProbabilities, error) {
+	args := m.Called(ctx, id, runs)
+	return args.Get(0).(models.PositionProbabilities), args.Error(1)
+}
+
+func (m *MockPredictServiceInterface) WhatIf(ctx context.Context, id string, req models.WhatIfRequest) (models.WhatIfResponse, error) {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(models.WhatIfResponse), args.Error(1)
+}
@@ -1,11 +1,13 @@
 package interfaces
 
 import (
+	"context"
 	"testing"
 
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMockPredictServiceInterface_ImplementsInterface(t *testing.T) {
@@ -31,10 +33,10 @@ func TestMockPredictServiceInterface_PredictChampionShipSession(t *testing.T) {
 		},
 	}
 
-	mockService.On("PredictChampionShipSession", testID).Return(expectedResponse, nil)
+	mockService.On("PredictChampionShipSession", mock.Anything, testID).Return(expectedResponse, nil)
 
 	// Call method
-	result, err := mockService.PredictChampionShipSession(testID)
+	result, err := mockService.PredictChampionShipSession(context.Background(), testID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -44,3 +46,95 @@ func TestMockPredictServiceInterface_PredictChampionShipSession(t *testing.T) {
 	assert.Equal(t, 90, result[0].Points)
 	mockService.AssertExpectations(t)
 }
+
+func TestMockPredictServiceInterface_PredictFixtures(t *testing.T) {
+	// Create mock
+	mockService := &MockPredictServiceInterface{}
+
+	// Setup expectations
+	expected := models.GetActiveLeagueFixturesResponse{
+		UpcomingFixtures: []models.Week{{Number: 1, Matches: []models.Match{{Odds: &models.MatchOdds{HomeWin: 0.5}}}}},
+	}
+	mockService.On("PredictFixtures", mock.Anything, "test-id").Return(expected, nil)
+
+	// Call method
+	result, err := mockService.PredictFixtures(context.Background(), "test-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockService.AssertExpectations(t)
+}
+
+func TestMockPredictServiceInterface_PredictionAccuracy(t *testing.T) {
+	// Create mock
+	mockService := &MockPredictServiceInterface{}
+
+	// Setup expectations
+	expected := models.PredictionAccuracy{Champion: "Team A", Snapshots: 3, LogLoss: 0.4}
+	mockService.On("PredictionAccuracy", mock.Anything, "test-id").Return(expected, nil)
+
+	// Call method
+	result, err := mockService.PredictionAccuracy(context.Background(), "test-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockService.AssertExpectations(t)
+}
+
+func TestMockPredictServiceInterface_Clinch(t *testing.T) {
+	// Create mock
+	mockService := &MockPredictServiceInterface{}
+
+	// Setup expectations
+	req := models.GetClinchRequest{Method: models.ClinchMethodMaxFlow, Top: 2}
+	expected := models.ClinchAnalysis{Method: models.ClinchMethodMaxFlow, RemainingMatches: 4}
+	mockService.On("Clinch", mock.Anything, "test-id", req).Return(expected, nil)
+
+	// Call method
+	result, err := mockService.Clinch(context.Background(), "test-id", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockService.AssertExpectations(t)
+}
+
+func TestMockPredictServiceInterface_PositionProbabilities(t *testing.T) {
+	// Create mock
+	mockService := &MockPredictServiceInterface{}
+
+	// Setup expectations
+	expected := models.PositionProbabilities{
+		Runs:  100,
+		Teams: []models.TeamPositions{{Team: "Team A", Probabilities: []float64{60, 40}, ExpectedPosition: 1.4}},
+	}
+	mockService.On("PositionProbabilities", mock.Anything, "test-id", 100).Return(expected, nil)
+
+	// Call method
+	result, err := mockService.PositionProbabilities(context.Background(), "test-id", 100)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockService.AssertExpectations(t)
+}
+
+func TestMockPredictServiceInterface_WhatIf(t *testing.T) {
+	// Create mock
+	mockService := &MockPredictServiceInterface{}
+
+	// Setup expectations
+	req := models.WhatIfRequest{Results: []models.HypotheticalResult{{Home: "Team A", Away: "Team B", HomeScore: 1}}}
+	expected := models.WhatIfResponse{Results: []models.MatchResult{{Home: "Team A", Away: "Team B", HomeScore: 1}}}
+	mockService.On("WhatIf", mock.Anything, "test-id", req).Return(expected, nil)
+
+	// Call method
+	result, err := mockService.WhatIf(context.Background(), "test-id", req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockService.AssertExpectations(t)
+}
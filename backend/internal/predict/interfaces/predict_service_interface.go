@@ -1,7 +1,17 @@
 package interfaces
 
-import "league-sim/internal/models"
+import (
+	"context"
+
+	"league-sim/internal/models"
+)
 
 type PredictServiceInterface interface {
-	PredictChampionShipSession(id string) ([]models.PredictedStanding, error)
+	PredictChampionShipSession(ctx context.Context, id string) ([]models.PredictedStanding, error)
+	PredictChampionships(ctx context.Context, ids []string) (map[string][]models.PredictedStanding, error)
+	PredictFixtures(ctx context.Context, id string) (models.GetActiveLeagueFixturesResponse, error)
+	PredictionAccuracy(ctx context.Context, id string) (models.PredictionAccuracy, error)
+	PositionProbabilities(ctx context.Context, id string, runs int) (models.PositionProbabilities, error)
+	Clinch(ctx context.Context, id string, req models.GetClinchRequest) (models.ClinchAnalysis, error)
+	WhatIf(ctx context.Context, id string, req models.WhatIfRequest) (models.WhatIfResponse, error)
 }
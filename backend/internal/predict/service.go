@@ -1,12 +1,14 @@
 package predict
 
 import (
-	"fmt"
+	"context"
 
-	"league-sim/config"
+	"league-sim/internal/apperrors"
 	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/forecast"
 	"league-sim/internal/league"
 	"league-sim/internal/models"
+	"league-sim/internal/simulation"
 )
 
 type Predict struct {
@@ -19,129 +21,177 @@ func NewPredictService(ctx appContext.AppContext) *Predict {
 	}
 }
 
-func (a *Predict) PredictChampionShipSession(id string) ([]models.PredictedStanding, error) {
-	standings, err := a.appCtx.ActiveLeagueRepository().GetActiveLeaguesStandings(id)
-
+func (a *Predict) PredictChampionShipSession(ctx context.Context, id string) ([]models.PredictedStanding, error) {
+	activeLeague, err := a.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, id)
 	if err != nil {
-		fmt.Println("Error getting league standings:", err)
 		return nil, err
 	}
 
-	if len(standings) == 0 {
+	if len(activeLeague.Standings) == 0 {
 		return []models.PredictedStanding{}, nil
 	}
 
-	leaderPoints := findLeaderPoints(standings)
-	totalTeams := len(standings)
-	totalScore := 0.0
+	rules, err := a.appCtx.LeagueRepository().GetLeagueRules(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return forecast.Heuristic(
+		activeLeague.Standings, activeLeague.UpcomingFixtures, rules, a.appCtx.Config().Predict), nil
+}
 
-	type scoredTeam struct {
-		TeamName string
-		Score    float64
-		Adjusted float64
-		Points   int
-		Strength float64
-		Played   int
-		Goals    int
-		Against  int
-		Team     models.Team
+// PredictChampionships predicts the title race of several leagues at once,
+// loading their seasons and rules with one query each. Leagues without a
+// season are left out of the map.
+func (a *Predict) PredictChampionships(ctx context.Context, ids []string) (map[string][]models.PredictedStanding, error) {
+	leagues, err := a.appCtx.ActiveLeagueRepository().GetActiveLeagues(ctx, ids)
+	if err != nil {
+		return nil, err
 	}
 
-	var scored []scoredTeam
+	rules, err := a.appCtx.LeagueRepository().GetLeaguesRules(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, s := range standings {
-		str := league.CalculateStrength(s.Team)
-		score := float64(s.Points)*config.WeightPoints + str*config.WeightsStrength
-		scored = append(
-			scored, scoredTeam{
-				TeamName: s.Team.Name,
-				Score:    score,
-				Points:   s.Points,
-				Strength: str,
-				Played:   s.Played,
-				Goals:    s.Goals,
-				Against:  s.Against,
-				Team:     s.Team,
-			})
-		totalScore += score
-	}
-
-	avgScore := totalScore / float64(len(scored))
-	totalAdjusted := 0.0
-
-	allRemainingMatchesZero := true
-	for _, s := range scored {
-		remainingMatches := (totalTeams - 1) - s.Played
-		if remainingMatches > 0 {
-			allRemainingMatchesZero = false
-			break
+	predictions := make(map[string][]models.PredictedStanding, len(leagues))
+	for id, activeLeague := range leagues {
+		leagueRules, ok := rules[id]
+		switch {
+		case !ok:
+			continue
+		case len(activeLeague.Standings) == 0:
+			predictions[id] = []models.PredictedStanding{}
+		default:
+			predictions[id] = forecast.Heuristic(
+				activeLeague.Standings, activeLeague.UpcomingFixtures, leagueRules, a.appCtx.Config().Predict)
 		}
 	}
 
-	if allRemainingMatchesZero {
+	return predictions, nil
+}
 
-		leader := scored[0]
-		for _, s := range scored[1:] {
-			if s.Points > leader.Points {
-				leader = s
-			} else if s.Points == leader.Points {
-				if (s.Goals - s.Against) > (leader.Goals - leader.Against) {
-					leader = s
-				}
-			}
-		}
+// PredictFixtures returns the fixtures of a league with the match engine's
+// odds attached to every upcoming match.
+func (a *Predict) PredictFixtures(ctx context.Context, id string) (models.GetActiveLeagueFixturesResponse, error) {
+	activeLeague, err := a.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, id)
+	if err != nil {
+		return models.GetActiveLeagueFixturesResponse{}, err
+	}
 
-		var result []models.PredictedStanding
-		for _, s := range scored {
-			eliminated := s.TeamName != leader.TeamName
-			odds := 0.0
-			if !eliminated {
-				odds = 100.0
-			}
-			result = append(
-				result, models.PredictedStanding{
-					TeamName:   s.TeamName,
-					Points:     s.Points,
-					Strength:   s.Strength,
-					Odds:       odds,
-					Eliminated: eliminated,
-				})
-		}
+	rules, err := a.appCtx.LeagueRepository().GetLeagueRules(ctx, id)
+	if err != nil {
+		return models.GetActiveLeagueFixturesResponse{}, err
+	}
 
-		return result, nil
+	simulation.FixtureOdds(&activeLeague, rules)
+
+	return models.GetActiveLeagueFixturesResponse{
+		UpcomingFixtures: activeLeague.UpcomingFixtures,
+		PlayedFixtures:   activeLeague.PlayedFixtures,
+	}, nil
+}
+
+// PredictionAccuracy scores the title odds recorded before every simulated
+// week of a league against its champion. It is only known once every match
+// is played.
+func (a *Predict) PredictionAccuracy(ctx context.Context, id string) (models.PredictionAccuracy, error) {
+	activeLeague, err := a.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, id)
+	if err != nil {
+		return models.PredictionAccuracy{}, err
 	}
 
-	for i, s := range scored {
-		remainingMatches := (totalTeams - 1) - s.Played
-		maxPossiblePoints := s.Points + remainingMatches*3
+	if len(activeLeague.UpcomingFixtures) > 0 || len(activeLeague.Standings) == 0 {
+		return models.PredictionAccuracy{}, apperrors.Conflict("season of league %s is not finished", id)
+	}
 
-		adjusted := scored[i].Score / avgScore
-		if maxPossiblePoints < leaderPoints {
-			adjusted = 0
-		}
-		scored[i].Adjusted = adjusted
-		totalAdjusted += adjusted
+	snapshots, err := a.appCtx.PredictionRepository().GetPredictionSnapshots(ctx, id)
+	if err != nil {
+		return models.PredictionAccuracy{}, err
 	}
 
-	var result []models.PredictedStanding
+	if len(snapshots) == 0 {
+		return models.PredictionAccuracy{}, apperrors.NotFound("no predictions were recorded for league %s", id)
+	}
 
-	for _, s := range scored {
-		eliminated := s.Adjusted == 0
-		odds := 0.0
-		if !eliminated && totalAdjusted > 0 {
-			odds = s.Adjusted / totalAdjusted * 100
-		}
-		result = append(
-			result, models.PredictedStanding{
-				TeamName:   s.TeamName,
-				Points:     s.Points,
-				Strength:   s.Strength,
-				Odds:       odds,
-				Eliminated: eliminated,
-			})
+	champion := league.RankStandings(activeLeague.Standings)[0].Team.Name
+
+	return forecast.Score(snapshots, champion), nil
+}
+
+// Clinch works out which teams of a league have clinched or are out of the
+// title, the top places and safety, from the fixtures still to play.
+func (a *Predict) Clinch(ctx context.Context, id string, req models.GetClinchRequest) (models.ClinchAnalysis, error) {
+	activeLeague, err := a.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, id)
+	if err != nil {
+		return models.ClinchAnalysis{}, err
+	}
+
+	if len(activeLeague.Standings) == 0 {
+		return models.ClinchAnalysis{}, apperrors.NotFound("league %s has no standings", id)
+	}
+
+	rules, err := a.appCtx.LeagueRepository().GetLeagueRules(ctx, id)
+	if err != nil {
+		return models.ClinchAnalysis{}, err
+	}
+
+	return forecast.Clinch(activeLeague.Standings, activeLeague.UpcomingFixtures, rules, req)
+}
+
+// PositionProbabilities plays the rest of a league's season runs times, or
+// simulation.DefaultPositionRuns when runs is 0, and returns how often every
+// team finished in every position.
+func (a *Predict) PositionProbabilities(ctx context.Context, id string, runs int) (models.PositionProbabilities, error) {
+	activeLeague, err := a.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, id)
+	if err != nil {
+		return models.PositionProbabilities{}, err
+	}
+
+	if len(activeLeague.Standings) == 0 {
+		return models.PositionProbabilities{}, apperrors.NotFound("league %s has no standings", id)
+	}
+
+	rules, err := a.appCtx.LeagueRepository().GetLeagueRules(ctx, id)
+	if err != nil {
+		return models.PositionProbabilities{}, err
+	}
+
+	if runs == 0 {
+		runs = simulation.DefaultPositionRuns
+	}
+
+	return simulation.PositionProbabilities(ctx, activeLeague, rules, runs)
+}
+
+// WhatIf applies hypothetical results to the upcoming matches of a league and
+// returns the standings and title odds they would lead to. The stored league
+// is left as it is.
+func (a *Predict) WhatIf(ctx context.Context, id string, req models.WhatIfRequest) (models.WhatIfResponse, error) {
+	activeLeague, err := a.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, id)
+	if err != nil {
+		return models.WhatIfResponse{}, err
+	}
+
+	rules, err := a.appCtx.LeagueRepository().GetLeagueRules(ctx, id)
+	if err != nil {
+		return models.WhatIfResponse{}, err
+	}
+
+	scenario := simulation.NewScenario(activeLeague, rules)
+	results, err := scenario.ApplyAll(req.Results)
+	if err != nil {
+		return models.WhatIfResponse{}, err
 	}
 
-	return result, nil
+	what := scenario.League()
+	return models.WhatIfResponse{
+		Results:          results,
+		Standings:        league.RankStandings(what.Standings),
+		UpcomingFixtures: what.UpcomingFixtures,
+		Predictions: forecast.Heuristic(
+			what.Standings, what.UpcomingFixtures, rules, a.appCtx.Config().Predict),
+	}, nil
 }
 
 func findLeaderPoints(standings []models.Standings) int {
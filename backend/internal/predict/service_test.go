@@ -1,13 +1,18 @@
 package predict
 
 import (
+	"context"
 	"errors"
+	"math"
 	"testing"
 
 	"league-sim/config"
+	"league-sim/internal/apperrors"
 	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/league"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
+	"league-sim/internal/simulation"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,11 +38,49 @@ func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepositor
 	return args.Get(0).(interfaces.MatchResultRepository)
 }
 
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
 func (m *MockAppContext) DB() *appContext.DB {
 	args := m.Called()
 	return args.Get(0).(*appContext.DB)
 }
 
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
+// Helper function to register the default league rules on a mock AppContext
+func withDefaultRules(mockAppCtx *MockAppContext) *MockAppContext {
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, mock.AnythingOfType("string")).Return(models.DefaultLeagueRules(), nil).Maybe()
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo).Maybe()
+	return mockAppCtx
+}
+
 func TestNewPredictService(t *testing.T) {
 	// Create mock app context
 	mockAppCtx := &MockAppContext{}
@@ -51,10 +94,6 @@ func TestNewPredictService(t *testing.T) {
 }
 
 func TestPredict_PredictChampionShipSession_Success(t *testing.T) {
-	// Setup config values for testing
-	config.WeightPoints = 0.4
-	config.WeightsStrength = 0.6
-
 	// Setup mocks
 	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
 	mockAppCtx := &MockAppContext{}
@@ -126,13 +165,15 @@ func TestPredict_PredictChampionShipSession_Success(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", leagueId).Return(standings, nil)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{Standings: standings}, nil)
 
 	// Create service
 	service := NewPredictService(mockAppCtx)
 
 	// Execute
-	result, err := service.PredictChampionShipSession(leagueId)
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -204,13 +245,15 @@ func TestPredict_PredictChampionShipSession_AllMatchesPlayed(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", leagueId).Return(standings, nil)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{Standings: standings}, nil)
 
 	// Create service
 	service := NewPredictService(mockAppCtx)
 
 	// Execute
-	result, err := service.PredictChampionShipSession(leagueId)
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -254,13 +297,15 @@ func TestPredict_PredictChampionShipSession_GetStandingsError(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", leagueId).Return([]models.Standings{}, expectedError)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{}, expectedError)
 
 	// Create service
 	service := NewPredictService(mockAppCtx)
 
 	// Execute
-	result, err := service.PredictChampionShipSession(leagueId)
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -272,6 +317,37 @@ func TestPredict_PredictChampionShipSession_GetStandingsError(t *testing.T) {
 	mockActiveLeagueRepo.AssertExpectations(t)
 }
 
+func TestPredict_PredictChampionShipSession_GetRulesError(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	// Test data
+	leagueId := "test-league-id"
+	standings := []models.Standings{{Team: models.Team{Name: "Team A"}}}
+
+	// Configure mock expectations
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{Standings: standings}, nil)
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, leagueId).Return(models.LeagueRules{}, apperrors.NotFound("league %s", leagueId))
+
+	// Create service
+	service := NewPredictService(mockAppCtx)
+
+	// Execute
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	assert.Nil(t, result)
+
+	// Verify mock expectations
+	mockAppCtx.AssertExpectations(t)
+	mockLeagueRepo.AssertExpectations(t)
+}
+
 func TestPredict_PredictChampionShipSession_EmptyStandings(t *testing.T) {
 	// Setup mocks
 	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
@@ -283,13 +359,15 @@ func TestPredict_PredictChampionShipSession_EmptyStandings(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", leagueId).Return(standings, nil)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{Standings: standings}, nil)
 
 	// Create service
 	service := NewPredictService(mockAppCtx)
 
 	// Execute
-	result, err := service.PredictChampionShipSession(leagueId)
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -300,6 +378,451 @@ func TestPredict_PredictChampionShipSession_EmptyStandings(t *testing.T) {
 	mockActiveLeagueRepo.AssertExpectations(t)
 }
 
+func TestPredict_PredictChampionShipSession_UsesConfigWeights(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	// Only points count, so equal points must give equal odds despite different strength
+	cfg := config.Default()
+	cfg.Predict.WeightPoints = 1
+	cfg.Predict.WeightStrength = 0
+
+	leagueId := "test-league-id"
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Strong", AttackPower: 95, DefensePower: 95, Stamina: 95, Morale: 95}, Points: 3, Played: 1},
+		{Team: models.Team{Name: "Weak", AttackPower: 50, DefensePower: 50, Stamina: 50, Morale: 50}, Points: 3, Played: 1},
+		{Team: models.Team{Name: "Other", AttackPower: 70, DefensePower: 70, Stamina: 70, Morale: 70}, Points: 0, Played: 2},
+	}
+	upcoming := []models.Week{
+		{Number: 3, Matches: []models.Match{{Home: &standings[0].Team, Away: &standings[1].Team}}},
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(cfg)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).
+		Return(models.League{Standings: standings, UpcomingFixtures: upcoming}, nil)
+
+	// Execute
+	service := NewPredictService(mockAppCtx)
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.InDelta(t, result[0].Odds, result[1].Odds, 0.001)
+	mockAppCtx.AssertExpectations(t)
+}
+
+func TestPredict_PredictChampionShipSession_UsesEloRatings(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	// Identical attributes, so only the ratings can tell the teams apart
+	rules := models.DefaultLeagueRules()
+	rules.Elo = &models.EloRules{InPredictor: true}
+
+	leagueId := "test-league-id"
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Higher", AttackPower: 80, DefensePower: 80, Rating: 1600}, Played: 1},
+		{Team: models.Team{Name: "Lower", AttackPower: 80, DefensePower: 80, Rating: 1400}, Played: 1},
+	}
+	upcoming := []models.Week{
+		{Number: 2, Matches: []models.Match{{Home: &standings[0].Team, Away: &standings[1].Team}}},
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("Config").Return(config.Default())
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, leagueId).Return(rules, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).
+		Return(models.League{Standings: standings, UpcomingFixtures: upcoming}, nil)
+
+	// Execute
+	service := NewPredictService(mockAppCtx)
+	result, err := service.PredictChampionShipSession(context.Background(), leagueId)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	strength := map[string]float64{}
+	for _, r := range result {
+		strength[r.TeamName] = r.Strength
+	}
+	assert.InDelta(t, 85*math.Pow(10, 0.25), strength["Higher"], 0.001)
+	assert.InDelta(t, 85*math.Pow(10, -0.25), strength["Lower"], 0.001)
+	mockAppCtx.AssertExpectations(t)
+}
+
+func TestPredict_PredictChampionships_LoadsLeaguesInOneBatch(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Lions", AttackPower: 80, DefensePower: 80}, Points: 3, Played: 1},
+		{Team: models.Team{Name: "Tigers", AttackPower: 80, DefensePower: 80}, Played: 1},
+	}
+	upcoming := []models.Week{
+		{Number: 2, Matches: []models.Match{{Home: &standings[1].Team, Away: &standings[0].Team}}},
+	}
+	ids := []string{"league-1", "league-2", "missing"}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("Config").Return(config.Default())
+	mockActiveLeagueRepo.On("GetActiveLeagues", mock.Anything, ids).Return(
+		map[string]models.League{
+			"league-1": {Standings: standings, UpcomingFixtures: upcoming},
+			"league-2": {},
+		}, nil).Once()
+	mockLeagueRepo.On("GetLeaguesRules", mock.Anything, ids).Return(
+		map[string]models.LeagueRules{
+			"league-1": models.DefaultLeagueRules(),
+			"league-2": models.DefaultLeagueRules(),
+		}, nil).Once()
+
+	// Execute
+	service := NewPredictService(mockAppCtx)
+	result, err := service.PredictChampionships(context.Background(), ids)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Len(t, result["league-1"], 2)
+	assert.Empty(t, result["league-2"])
+	assert.NotContains(t, result, "missing")
+	mockActiveLeagueRepo.AssertExpectations(t)
+	mockLeagueRepo.AssertNotCalled(t, "GetLeagueRules", mock.Anything, mock.Anything)
+}
+
+func TestPredict_PredictFixtures_AttachesOdds(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	home := models.Team{Name: "Home", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	away := models.Team{Name: "Away", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	activeLeague := models.League{
+		LeagueID: "test-league-id",
+		Teams:    []models.Team{home, away},
+		UpcomingFixtures: []models.Week{
+			{Number: 2, Matches: []models.Match{{Home: &home, Away: &away}}},
+		},
+		PlayedFixtures: []models.Week{
+			{Number: 1, Matches: []models.Match{{Home: &away, Away: &home}}},
+		},
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+
+	// Execute
+	service := NewPredictService(mockAppCtx)
+	fixtures, err := service.PredictFixtures(context.Background(), "test-league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	odds := fixtures.UpcomingFixtures[0].Matches[0].Odds
+	if assert.NotNil(t, odds) {
+		assert.InDelta(t, 0.8*1.05/2.05, odds.HomeWin, 1e-9)
+		assert.InDelta(t, 0.2, odds.Draw, 1e-9)
+	}
+	assert.Nil(t, fixtures.PlayedFixtures[0].Matches[0].Odds)
+	mockActiveLeagueRepo.AssertExpectations(t)
+}
+
+func TestPredict_PredictFixtures_LeagueNotFound(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+	expectedError := errors.New("league not found")
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "missing").Return(models.League{}, expectedError)
+
+	// Execute
+	_, err := NewPredictService(mockAppCtx).PredictFixtures(context.Background(), "missing")
+
+	// Assert
+	assert.Equal(t, expectedError, err)
+}
+
+func TestPredict_PredictChampionShipSession_EliminatesByRemainingFixtures(t *testing.T) {
+	// Setup mocks: Third can reach 6 points, but Leader and Second already
+	// have 6 and one of them takes points when they meet.
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Leader", AttackPower: 60, DefensePower: 60}, Points: 6, Played: 2},
+		{Team: models.Team{Name: "Second", AttackPower: 60, DefensePower: 60}, Points: 6, Played: 2},
+		{Team: models.Team{Name: "Third", AttackPower: 99, DefensePower: 99}, Points: 3, Played: 2},
+		{Team: models.Team{Name: "Last", AttackPower: 40, DefensePower: 40}, Points: 0, Played: 2},
+	}
+	upcoming := []models.Week{
+		{Number: 3, Matches: []models.Match{
+			{Home: &standings[0].Team, Away: &standings[1].Team},
+			{Home: &standings[2].Team, Away: &standings[3].Team},
+		}},
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").
+		Return(models.League{Standings: standings, UpcomingFixtures: upcoming}, nil)
+
+	// Execute
+	predictions, err := NewPredictService(mockAppCtx).PredictChampionShipSession(context.Background(), "test-league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	for _, p := range predictions {
+		if p.TeamName == "Third" || p.TeamName == "Last" {
+			assert.Zero(t, p.Odds, p.TeamName)
+		}
+	}
+}
+
+func TestPredict_Clinch_Success(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	standings := []models.Standings{
+		{Team: models.Team{Name: "Team A"}, Points: 9},
+		{Team: models.Team{Name: "Team B"}, Points: 3},
+	}
+	upcoming := []models.Week{
+		{Number: 4, Matches: []models.Match{{Home: &standings[0].Team, Away: &standings[1].Team}}},
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").
+		Return(models.League{Standings: standings, UpcomingFixtures: upcoming}, nil)
+
+	// Execute
+	analysis, err := NewPredictService(mockAppCtx).
+		Clinch(context.Background(), "test-league-id", models.GetClinchRequest{Method: models.ClinchMethodExhaustive})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.ClinchMethodExhaustive, analysis.Method)
+	assert.Equal(t, 1, analysis.RemainingMatches)
+	assert.Equal(t, "Team A", analysis.Teams[0].Team)
+	assert.True(t, analysis.Teams[0].Targets[0].Clinched)
+	assert.True(t, analysis.Teams[1].Targets[0].Eliminated)
+}
+
+func TestPredict_Clinch_NoStandings(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(models.League{}, nil)
+
+	// Execute
+	_, err := NewPredictService(mockAppCtx).Clinch(context.Background(), "test-league-id", models.GetClinchRequest{})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+}
+
+func TestPredict_PositionProbabilities_DefaultRuns(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		LeagueID:         "test-league-id",
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+
+	// Execute
+	result, err := NewPredictService(mockAppCtx).PositionProbabilities(context.Background(), "test-league-id", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, simulation.DefaultPositionRuns, result.Runs)
+	assert.Len(t, result.Teams, 4)
+}
+
+func TestPredict_PositionProbabilities_NoStandings(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(models.League{}, nil)
+
+	// Execute
+	_, err := NewPredictService(mockAppCtx).PositionProbabilities(context.Background(), "test-league-id", 10)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+}
+
+func TestPredict_WhatIf(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	teams := league.TeamGenerate(2)
+	activeLeague := models.League{
+		LeagueID:         "test-league-id",
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	first := activeLeague.UpcomingFixtures[0].Matches[0]
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default())
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+
+	// Execute
+	response, err := NewPredictService(mockAppCtx).WhatIf(
+		context.Background(), "test-league-id", models.WhatIfRequest{
+			Results: []models.HypotheticalResult{
+				{Home: first.Home.Name, Away: first.Away.Name, HomeScore: 3, AwayScore: 0},
+			},
+		})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, response.Results, 1)
+	assert.Empty(t, response.UpcomingFixtures)
+	assert.Equal(t, first.Home.Name, response.Standings[0].Team.Name)
+	assert.Equal(t, 3, response.Standings[0].Points)
+	for _, p := range response.Predictions {
+		if p.TeamName == first.Home.Name {
+			assert.Equal(t, 100.0, p.Odds)
+		}
+	}
+	for _, s := range activeLeague.Standings {
+		assert.Zero(t, s.Points)
+	}
+	mockActiveLeagueRepo.AssertNotCalled(t, "SetActiveLeague", mock.Anything, mock.Anything)
+}
+
+func TestPredict_WhatIf_NotUpcoming(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	teams := league.TeamGenerate(2)
+	activeLeague := models.League{Teams: teams, Standings: league.CreateStandingsTable(teams)}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withDefaultRules(mockAppCtx)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+
+	// Execute
+	_, err := NewPredictService(mockAppCtx).WhatIf(
+		context.Background(), "test-league-id", models.WhatIfRequest{
+			Results: []models.HypotheticalResult{{Home: teams[0].Name, Away: teams[1].Name}},
+		})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestPredict_PredictionAccuracy_Success(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockPredictionRepo := &interfaces.MockPredictionRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	activeLeague := models.League{
+		LeagueID: "test-league-id",
+		Standings: []models.Standings{
+			{Team: models.Team{Name: "Team B"}, Points: 3},
+			{Team: models.Team{Name: "Team A"}, Points: 6},
+		},
+		PlayedFixtures: []models.Week{{Number: 1}, {Number: 2}},
+	}
+	snapshots := []models.PredictionSnapshot{
+		{Week: 1, Predictions: []models.PredictedStanding{{TeamName: "Team A", Odds: 50}, {TeamName: "Team B", Odds: 50}}},
+		{Week: 2, Predictions: []models.PredictedStanding{{TeamName: "Team A", Odds: 100}, {TeamName: "Team B", Odds: 0}}},
+	}
+
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("PredictionRepository").Return(mockPredictionRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+	mockPredictionRepo.On("GetPredictionSnapshots", mock.Anything, "test-league-id").Return(snapshots, nil)
+
+	// Execute
+	accuracy, err := NewPredictService(mockAppCtx).PredictionAccuracy(context.Background(), "test-league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Team A", accuracy.Champion)
+	assert.Equal(t, 2, accuracy.Snapshots)
+	assert.InDelta(t, 0.25, accuracy.BrierScore, 1e-9)
+	assert.InDelta(t, math.Log(2)/2, accuracy.LogLoss, 1e-9)
+	mockPredictionRepo.AssertExpectations(t)
+}
+
+func TestPredict_PredictionAccuracy_SeasonNotFinished(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	activeLeague := models.League{
+		LeagueID:         "test-league-id",
+		Standings:        []models.Standings{{Team: models.Team{Name: "Team A"}}},
+		UpcomingFixtures: []models.Week{{Number: 1}},
+	}
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+
+	// Execute
+	_, err := NewPredictService(mockAppCtx).PredictionAccuracy(context.Background(), "test-league-id")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+}
+
+func TestPredict_PredictionAccuracy_NoSnapshots(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockPredictionRepo := &interfaces.MockPredictionRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	activeLeague := models.League{
+		LeagueID:       "test-league-id",
+		Standings:      []models.Standings{{Team: models.Team{Name: "Team A"}}},
+		PlayedFixtures: []models.Week{{Number: 1}},
+	}
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("PredictionRepository").Return(mockPredictionRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+	mockPredictionRepo.On("GetPredictionSnapshots", mock.Anything, "test-league-id").
+		Return([]models.PredictionSnapshot{}, nil)
+
+	// Execute
+	_, err := NewPredictService(mockAppCtx).PredictionAccuracy(context.Background(), "test-league-id")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+}
+
 func TestFindLeaderPoints(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -451,8 +974,6 @@ func TestFindLeader_EmptyStandings(t *testing.T) {
 
 func BenchmarkPredict_PredictChampionShipSession(b *testing.B) {
 	// Setup config values
-	config.WeightPoints = 0.4
-	config.WeightsStrength = 0.6
 
 	// Setup mocks
 	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
@@ -476,13 +997,15 @@ func BenchmarkPredict_PredictChampionShipSession(b *testing.B) {
 	}
 
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeaguesStandings", "test-league").Return(standings, nil)
+	withDefaultRules(mockAppCtx)
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(models.League{Standings: standings}, nil)
 
 	service := NewPredictService(mockAppCtx)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		service.PredictChampionShipSession("test-league")
+		service.PredictChampionShipSession(context.Background(), "test-league")
 	}
 }
 
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store forgets idle clients.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	bucket Bucket
+	full   time.Time // a bucket is as good as absent once it has refilled
+}
+
+// MemoryStore keeps buckets in the process. Limits are per instance, so several
+// instances behind a load balancer each allow the full rate.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: map[string]memoryEntry{},
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, rate Rate) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	entry, ok := s.entries[key]
+	if !ok {
+		entry.bucket = rate.Full(now)
+	}
+
+	bucket, result := rate.Take(entry.bucket, now)
+	s.entries[key] = memoryEntry{bucket: bucket, full: now.Add(result.Reset)}
+
+	return result, nil
+}
+
+// sweep drops the buckets that have refilled completely since their last use.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, entry := range s.entries {
+		if !entry.full.After(now) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMemoryStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestMemoryStore_Take_SeparatesKeys(t *testing.T) {
+	// Setup
+	now := testNow
+	store := newTestMemoryStore(&now)
+	rate := Rate{PerMinute: 60, Burst: 1}
+
+	// Execute
+	first, _ := store.Take(context.Background(), "ip:1.2.3.4", rate)
+	second, _ := store.Take(context.Background(), "ip:1.2.3.4", rate)
+	other, err := store.Take(context.Background(), "ip:5.6.7.8", rate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, first.Allowed)
+	assert.False(t, second.Allowed)
+	assert.True(t, other.Allowed)
+}
+
+func TestMemoryStore_Take_SweepsRefilledBuckets(t *testing.T) {
+	// Setup
+	now := testNow
+	store := newTestMemoryStore(&now)
+	rate := Rate{PerMinute: 60, Burst: 5}
+	store.Take(context.Background(), "idle", rate)
+
+	// Execute
+	now = now.Add(2 * sweepInterval)
+	result, err := store.Take(context.Background(), "active", rate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.NotContains(t, store.entries, "idle")
+	assert.Contains(t, store.entries, "active")
+}
+
+func TestMemoryStore_Take_Concurrent(t *testing.T) {
+	// Setup
+	now := testNow
+	store := newTestMemoryStore(&now)
+	rate := Rate{PerMinute: 1, Burst: 10}
+
+	// Execute
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, _ := store.Take(context.Background(), "key", rate)
+			if result.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 10, allowed)
+}
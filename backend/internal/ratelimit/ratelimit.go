@@ -0,0 +1,71 @@
+// Package ratelimit throttles API clients with token buckets. Each client has
+// a bucket of at most Burst tokens that refills continuously at PerMinute
+// tokens a minute; a request spends one token and is refused when none is left.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Rate describes a token bucket. A zero PerMinute disables the limit.
+type Rate struct {
+	PerMinute float64
+	Burst     int
+}
+
+func (r Rate) Enabled() bool {
+	return r.PerMinute > 0 && r.Burst > 0
+}
+
+// Bucket is the stored state of one client's bucket.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// Result describes how a request was counted, in the terms of the
+// X-RateLimit-* and Retry-After headers.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // until the next token when the request was refused
+	Reset      time.Duration // until the bucket is full again
+}
+
+// Store keeps buckets by key and spends tokens from them.
+type Store interface {
+	Take(ctx context.Context, key string, rate Rate) (Result, error)
+}
+
+// Full returns the bucket of a client that has not been seen before.
+func (r Rate) Full(now time.Time) Bucket {
+	return Bucket{Tokens: float64(r.Burst), Updated: now}
+}
+
+// Take refills b for the time elapsed since it was last used and spends one
+// token from it if there is one.
+func (r Rate) Take(b Bucket, now time.Time) (Bucket, Result) {
+	perSecond := r.PerMinute / 60
+	elapsed := max(now.Sub(b.Updated).Seconds(), 0)
+	tokens := math.Min(float64(r.Burst), b.Tokens+elapsed*perSecond)
+
+	result := Result{Limit: r.Burst}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / perSecond)
+	}
+
+	result.Remaining = int(tokens)
+	result.Reset = seconds((float64(r.Burst) - tokens) / perSecond)
+
+	return Bucket{Tokens: tokens, Updated: now}, result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
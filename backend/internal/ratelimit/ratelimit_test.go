@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestRate_Enabled(t *testing.T) {
+	assert.True(t, Rate{PerMinute: 60, Burst: 1}.Enabled())
+	assert.False(t, Rate{PerMinute: 0, Burst: 10}.Enabled())
+	assert.False(t, Rate{PerMinute: 60, Burst: 0}.Enabled())
+}
+
+func TestRate_Take_SpendsBurstThenRefuses(t *testing.T) {
+	// Setup
+	rate := Rate{PerMinute: 60, Burst: 3}
+	bucket := rate.Full(testNow)
+
+	// Execute
+	var results []Result
+	for i := 0; i < 4; i++ {
+		var result Result
+		bucket, result = rate.Take(bucket, testNow)
+		results = append(results, result)
+	}
+
+	// Assert
+	assert.Equal(t, []int{2, 1, 0, 0}, []int{
+		results[0].Remaining, results[1].Remaining, results[2].Remaining, results[3].Remaining,
+	})
+	assert.True(t, results[2].Allowed)
+	assert.False(t, results[3].Allowed)
+	assert.Equal(t, time.Second, results[3].RetryAfter)
+	assert.Equal(t, 3*time.Second, results[3].Reset)
+	assert.Equal(t, 3, results[3].Limit)
+}
+
+func TestRate_Take_RefillsOverTime(t *testing.T) {
+	// Setup
+	rate := Rate{PerMinute: 30, Burst: 2}
+	empty := Bucket{Tokens: 0, Updated: testNow}
+
+	// Execute
+	_, early := rate.Take(empty, testNow.Add(time.Second))
+	_, later := rate.Take(empty, testNow.Add(2*time.Second))
+	bucket, idle := rate.Take(empty, testNow.Add(time.Hour))
+
+	// Assert
+	assert.False(t, early.Allowed)
+	assert.Equal(t, time.Second, early.RetryAfter)
+	assert.True(t, later.Allowed)
+	assert.True(t, idle.Allowed)
+	assert.Equal(t, 1.0, bucket.Tokens, "refill is capped at the burst")
+}
+
+func TestRate_Take_ClockGoingBackwards(t *testing.T) {
+	rate := Rate{PerMinute: 60, Burst: 1}
+
+	bucket, result := rate.Take(Bucket{Tokens: 0, Updated: testNow}, testNow.Add(-time.Minute))
+
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0.0, bucket.Tokens)
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"league-sim/internal/apperrors"
+)
+
+// SQLStore keeps buckets in the rate_limit_buckets table so that every
+// instance sharing the database also shares the limits. Each request locks its
+// bucket row for the duration of one short transaction.
+type SQLStore struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+	now          func() time.Time
+}
+
+func NewSQLStore(db *sql.DB, queryTimeout time.Duration) *SQLStore {
+	return &SQLStore{
+		db:           db,
+		queryTimeout: queryTimeout,
+		now:          time.Now,
+	}
+}
+
+func (s *SQLStore) Take(ctx context.Context, key string, rate Rate) (Result, error) {
+	if s.queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.queryTimeout)
+		defer cancel()
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+
+		return Result{}, apperrors.Internal(err, "failed to read rate limit %s", key)
+	}
+	defer tx.Rollback()
+
+	now := s.now().UTC()
+	bucket := rate.Full(now)
+
+	err = tx.QueryRowContext(
+		ctx, `SELECT tokens, updatedAt FROM rate_limit_buckets WHERE bucketKey = ? FOR UPDATE`, key).
+		Scan(&bucket.Tokens, &bucket.Updated)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+
+		return Result{}, apperrors.Internal(err, "failed to read rate limit %s", key)
+	}
+
+	bucket, result := rate.Take(bucket, now)
+
+	_, err = tx.ExecContext(
+		ctx, `INSERT INTO rate_limit_buckets (bucketKey, tokens, updatedAt) VALUES (?,?,?)
+		ON DUPLICATE KEY UPDATE tokens = VALUES(tokens), updatedAt = VALUES(updatedAt)`,
+		key, bucket.Tokens, bucket.Updated)
+	if err != nil {
+
+		return Result{}, apperrors.Internal(err, "failed to write rate limit %s", key)
+	}
+
+	if err := tx.Commit(); err != nil {
+
+		return Result{}, apperrors.Internal(err, "failed to write rate limit %s", key)
+	}
+
+	return result, nil
+}
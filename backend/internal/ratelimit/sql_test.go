@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSQLStore(t *testing.T) (*SQLStore, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	store := NewSQLStore(db, time.Second)
+	store.now = func() time.Time { return testNow }
+	return store, mock
+}
+
+func TestSQLStore_Take_NewBucket(t *testing.T) {
+	// Setup
+	store, mock := newTestSQLStore(t)
+	rate := Rate{PerMinute: 60, Burst: 5}
+
+	// Mock expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT tokens, updatedAt FROM rate_limit_buckets").
+		WithArgs("ip:1.2.3.4").
+		WillReturnRows(sqlmock.NewRows([]string{"tokens", "updatedAt"}))
+	mock.ExpectExec("INSERT INTO rate_limit_buckets").
+		WithArgs("ip:1.2.3.4", 4.0, testNow).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// Execute
+	result, err := store.Take(context.Background(), "ip:1.2.3.4", rate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 4, result.Remaining)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLStore_Take_EmptyBucket(t *testing.T) {
+	// Setup
+	store, mock := newTestSQLStore(t)
+	rate := Rate{PerMinute: 60, Burst: 5}
+
+	// Mock expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT tokens, updatedAt FROM rate_limit_buckets").
+		WithArgs("key:user-id").
+		WillReturnRows(sqlmock.NewRows([]string{"tokens", "updatedAt"}).AddRow(0.5, testNow))
+	mock.ExpectExec("INSERT INTO rate_limit_buckets").
+		WithArgs("key:user-id", 0.5, testNow).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	// Execute
+	result, err := store.Take(context.Background(), "key:user-id", rate)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLStore_Take_DatabaseError(t *testing.T) {
+	// Setup
+	store, mock := newTestSQLStore(t)
+
+	// Mock expectations
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT tokens, updatedAt FROM rate_limit_buckets").
+		WillReturnError(errors.New("connection refused"))
+	mock.ExpectRollback()
+
+	// Execute
+	_, err := store.Take(context.Background(), "ip:1.2.3.4", Rate{PerMinute: 60, Burst: 5})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
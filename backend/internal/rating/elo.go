@@ -0,0 +1,194 @@
+// Package rating keeps Elo ratings of teams, updated after every result.
+//
+// Ratings share the scale of the match engine: the engine lets a team of
+// strength s beat one of strength t with probability s/(s + t), which is the
+// Elo expectation when a rating is 400*log10(s) plus a constant. Teams start
+// from their static strength on that scale, so a team of average strength is
+// rated Initial.
+package rating
+
+import (
+	"math"
+	"sort"
+
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+)
+
+const (
+	// Initial is the rating of a team of average strength.
+	Initial = 1500.0
+	// DefaultKFactor is how far a single result moves a rating.
+	DefaultKFactor = 20.0
+	// referenceStrength is the strength of a team rated Initial, the mean
+	// strength of generated teams with weights that sum to one.
+	referenceStrength = 85.0
+)
+
+// Parameters returns the Elo settings of rules with the defaults filled in.
+func Parameters(rules models.LeagueRules) models.EloRules {
+	var params models.EloRules
+	if rules.Elo != nil {
+		params = *rules.Elo
+	}
+	if params.KFactor == 0 {
+		params.KFactor = DefaultKFactor
+	}
+	if params.HomeAdvantage == 0 && rules.HomeAdvantage > 0 {
+		params.HomeAdvantage = 400 * math.Log10(rules.HomeAdvantage)
+	}
+
+	return params
+}
+
+// Strength converts a rating to the scale of league.CalculateStrength.
+func Strength(rating float64) float64 {
+	return referenceStrength * math.Pow(10, (rating-Initial)/400)
+}
+
+// Seed gives every team without a rating one from its strength relative to
+// the geometric mean strength of teams. Teams are changed in place.
+func Seed(teams []models.Team, weights models.StrengthWeights) {
+	var logSum float64
+	var counted int
+	for _, team := range teams {
+		if strength := league.CalculateStrength(team, weights); strength > 0 {
+			logSum += math.Log10(strength)
+			counted++
+		}
+	}
+	if counted == 0 {
+		counted, logSum = 1, math.Log10(referenceStrength)
+	}
+	mean := logSum / float64(counted)
+
+	for i := range teams {
+		if teams[i].Rating != 0 {
+			continue
+		}
+		teams[i].Rating = Initial
+		if strength := league.CalculateStrength(teams[i], weights); strength > 0 {
+			teams[i].Rating += 400 * (math.Log10(strength) - mean)
+		}
+	}
+}
+
+// Expected is the home team's expected score, a win counting one and a draw
+// one half.
+func Expected(home float64, away float64, params models.EloRules) float64 {
+	return 1 / (1 + math.Pow(10, (away-home-params.HomeAdvantage)/400))
+}
+
+// goalDifferenceFactor makes wide margins count for more, as in the World
+// Football Elo ratings.
+func goalDifferenceFactor(margin int) float64 {
+	switch {
+	case margin <= 1:
+		return 1
+	case margin == 2:
+		return 1.5
+	}
+
+	return (11 + float64(margin)) / 8
+}
+
+// Update rates result between teams rated home and away and returns the
+// change of each rating.
+func Update(home float64, away float64, result models.MatchResult, params models.EloRules) []models.RatingChange {
+	actual := 0.5
+	if result.HomeScore > result.AwayScore {
+		actual = 1
+	} else if result.HomeScore < result.AwayScore {
+		actual = 0
+	}
+
+	margin := result.HomeScore - result.AwayScore
+	if margin < 0 {
+		margin = -margin
+	}
+	delta := params.KFactor * goalDifferenceFactor(margin) * (actual - Expected(home, away, params))
+
+	return []models.RatingChange{
+		{MatchWeek: result.MatchWeek, Team: result.Home, Opponent: result.Away, Before: home, After: home + delta},
+		{MatchWeek: result.MatchWeek, Team: result.Away, Opponent: result.Home, Before: away, After: away - delta},
+	}
+}
+
+// Replay rates results in order from the ratings each team started with and
+// returns every change and the final ratings. Teams missing from initial
+// start at Initial.
+func Replay(initial map[string]float64, results []models.MatchResult, params models.EloRules) (
+	[]models.RatingChange, map[string]float64,
+) {
+	ratings := make(map[string]float64, len(initial))
+	for team, r := range initial {
+		ratings[team] = r
+	}
+	current := func(team string) float64 {
+		if r, ok := ratings[team]; ok {
+			return r
+		}
+		return Initial
+	}
+
+	sorted := make([]models.MatchResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].MatchWeek < sorted[j].MatchWeek })
+
+	changes := make([]models.RatingChange, 0, 2*len(sorted))
+	for _, result := range sorted {
+		update := Update(current(result.Home), current(result.Away), result, params)
+		for _, change := range update {
+			ratings[change.Team] = change.After
+		}
+		changes = append(changes, update...)
+	}
+
+	return changes, ratings
+}
+
+// Starting returns the rating each team had before its first change.
+func Starting(changes []models.RatingChange) map[string]float64 {
+	ratings := map[string]float64{}
+	for _, change := range changes {
+		if _, ok := ratings[change.Team]; !ok {
+			ratings[change.Team] = change.Before
+		}
+	}
+
+	return ratings
+}
+
+// History groups changes, in the order they happened, by team, sorted by
+// current rating.
+func History(changes []models.RatingChange) []models.TeamRatingHistory {
+	byTeam := map[string]*models.TeamRatingHistory{}
+	var order []string
+	for _, change := range changes {
+		history, ok := byTeam[change.Team]
+		if !ok {
+			history = &models.TeamRatingHistory{
+				Team:    change.Team,
+				History: []models.RatingPoint{{Week: 0, Rating: change.Before}},
+			}
+			byTeam[change.Team] = history
+			order = append(order, change.Team)
+		}
+		history.Rating = change.After
+		history.History = append(
+			history.History, models.RatingPoint{
+				Week:     change.MatchWeek,
+				Rating:   change.After,
+				Change:   change.After - change.Before,
+				Opponent: change.Opponent,
+			})
+	}
+
+	histories := make([]models.TeamRatingHistory, 0, len(order))
+	for _, team := range order {
+		histories = append(histories, *byTeam[team])
+	}
+	sort.SliceStable(histories, func(i, j int) bool { return histories[i].Rating > histories[j].Rating })
+
+	return histories
+}
@@ -0,0 +1,150 @@
+package rating
+
+import (
+	"math"
+	"testing"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// even are Elo settings without home advantage, to keep the numbers round.
+var even = models.EloRules{KFactor: DefaultKFactor}
+
+func TestParameters_Defaults(t *testing.T) {
+	rules := models.DefaultLeagueRules()
+	rules.HomeAdvantage = 1.2
+
+	params := Parameters(rules)
+
+	assert.Equal(t, DefaultKFactor, params.KFactor)
+	assert.InDelta(t, 400*math.Log10(1.2), params.HomeAdvantage, 1e-9)
+}
+
+func TestParameters_Explicit(t *testing.T) {
+	rules := models.DefaultLeagueRules()
+	rules.Elo = &models.EloRules{KFactor: 32, HomeAdvantage: 65, InEngine: true}
+
+	assert.Equal(t, *rules.Elo, Parameters(rules))
+}
+
+func TestExpected_MatchesEngineOdds(t *testing.T) {
+	// A team twice as strong wins twice as often in the engine.
+	params := models.EloRules{}
+	home, away := 1500+400*math.Log10(2), 1500.0
+
+	assert.InDelta(t, 2.0/3, Expected(home, away, params), 1e-9)
+	assert.InDelta(t, 85*2, Strength(home), 1e-9)
+	assert.InDelta(t, 85, Strength(Initial), 1e-9)
+}
+
+func TestSeed(t *testing.T) {
+	// Setup
+	weights := models.StrengthWeights{Attack: 1}
+	teams := []models.Team{
+		{Name: "Strong", AttackPower: 100},
+		{Name: "Weak", AttackPower: 25},
+		{Name: "Rated", AttackPower: 100, Rating: 1620},
+	}
+
+	// Execute
+	Seed(teams, weights)
+
+	// Assert
+	mean := (2*math.Log10(100) + math.Log10(25)) / 3
+	assert.InDelta(t, Initial+400*(2-mean), teams[0].Rating, 1e-9)
+	assert.InDelta(t, 400*math.Log10(4), teams[0].Rating-teams[1].Rating, 1e-9)
+	assert.Equal(t, 1620.0, teams[2].Rating)
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name      string
+		homeScore int
+		awayScore int
+		change    float64
+	}{
+		{name: "Narrow home win", homeScore: 1, awayScore: 0, change: 10},
+		{name: "Two goal away win", homeScore: 0, awayScore: 2, change: -15},
+		{name: "Rout", homeScore: 5, awayScore: 0, change: 20},
+		{name: "Draw", homeScore: 2, awayScore: 2, change: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				result := models.MatchResult{MatchWeek: 3, Home: "A", Away: "B", HomeScore: tt.homeScore, AwayScore: tt.awayScore}
+
+				changes := Update(1500, 1500, result, even)
+
+				require.Len(t, changes, 2)
+				assert.Equal(t, "A", changes[0].Team)
+				assert.Equal(t, 1500+tt.change, changes[0].After)
+				assert.Equal(
+					t, models.RatingChange{MatchWeek: 3, Team: "B", Opponent: "A", Before: 1500, After: 1500 - tt.change},
+					changes[1])
+			})
+	}
+}
+
+func TestUpdate_FavouriteGainsLittle(t *testing.T) {
+	result := models.MatchResult{Home: "A", Away: "B", HomeScore: 1, AwayScore: 0}
+
+	changes := Update(1700, 1500, result, even)
+
+	assert.InDelta(t, 20*(1-Expected(1700, 1500, even)), changes[0].After-changes[0].Before, 1e-9)
+	assert.Less(t, changes[0].After-changes[0].Before, 5.0)
+}
+
+func TestReplay(t *testing.T) {
+	// Setup
+	results := []models.MatchResult{
+		{MatchWeek: 2, Home: "B", Away: "A", HomeScore: 1, AwayScore: 0},
+		{MatchWeek: 1, Home: "A", Away: "B", HomeScore: 1, AwayScore: 0},
+	}
+
+	// Execute
+	changes, ratings := Replay(map[string]float64{"A": 1500}, results, even)
+
+	// Assert
+	require.Len(t, changes, 4)
+	assert.Equal(t, 1, changes[0].MatchWeek)
+	assert.Equal(t, 1510.0, changes[0].After)
+	assert.Equal(t, 1490.0, changes[1].After)
+	assert.Equal(t, "B", changes[2].Team)
+	assert.InDelta(t, 3000, ratings["A"]+ratings["B"], 1e-9)
+	assert.Greater(t, ratings["B"], 1500.0)
+	assert.Equal(t, map[string]float64{"A": 1500, "B": 1500}, Starting(changes))
+}
+
+func TestHistory(t *testing.T) {
+	changes := []models.RatingChange{
+		{MatchWeek: 1, Team: "A", Opponent: "B", Before: 1500, After: 1490},
+		{MatchWeek: 1, Team: "B", Opponent: "A", Before: 1500, After: 1510},
+		{MatchWeek: 2, Team: "A", Opponent: "B", Before: 1490, After: 1495},
+		{MatchWeek: 2, Team: "B", Opponent: "A", Before: 1510, After: 1505},
+	}
+
+	history := History(changes)
+
+	assert.Equal(
+		t, []models.TeamRatingHistory{
+			{
+				Team: "B", Rating: 1505, History: []models.RatingPoint{
+					{Week: 0, Rating: 1500},
+					{Week: 1, Rating: 1510, Change: 10, Opponent: "A"},
+					{Week: 2, Rating: 1505, Change: -5, Opponent: "A"},
+				},
+			},
+			{
+				Team: "A", Rating: 1495, History: []models.RatingPoint{
+					{Week: 0, Rating: 1500},
+					{Week: 1, Rating: 1490, Change: -10, Opponent: "B"},
+					{Week: 2, Rating: 1495, Change: 5, Opponent: "B"},
+				},
+			},
+		}, history)
+	assert.Empty(t, History(nil))
+}
@@ -1,7 +1,11 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
@@ -9,55 +13,115 @@ import (
 )
 
 type activeLeagueRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
-func NewActiveLeagueRepository(db *sql.DB) interfaces.ActiveLeagueRepository {
+func NewActiveLeagueRepository(db *sql.DB, queryTimeout time.Duration) interfaces.ActiveLeagueRepository {
 	return &activeLeagueRepository{
-		db: db,
+		db:           db,
+		queryTimeout: queryTimeout,
 	}
 }
 
-func (alr *activeLeagueRepository) GetActiveLeague(id string) (models.League, error) {
+func (alr *activeLeagueRepository) GetActiveLeague(ctx context.Context, id string) (models.League, error) {
+	ctx, cancel := withTimeout(ctx, alr.queryTimeout)
+	defer cancel()
+
 	query := `SELECT upcomingFixtures, playedFixtures, currentWeek,teams, standings FROM active_league WHERE leagueId = ? order by createdAt desc limit 1`
-	row := alr.db.QueryRow(query, id)
+	row := conn(ctx, alr.db).QueryRowContext(ctx, query, id)
 
 	var upcomingFixturesJson, playedFixturesJson, standingsJson, teamsJson string
 	var CurrentWeek int
 	err := row.Scan(&upcomingFixturesJson, &playedFixturesJson, &CurrentWeek, &teamsJson, &standingsJson)
 	if err != nil {
-		return models.League{}, err
+		return models.League{}, queryError(err, "league %s", id)
+	}
+
+	return decodeLeague(id, CurrentWeek, upcomingFixturesJson, playedFixturesJson, teamsJson, standingsJson)
+}
+
+// GetActiveLeagues loads the current state of several leagues in one query.
+// Leagues without a season are left out of the map.
+func (alr *activeLeagueRepository) GetActiveLeagues(ctx context.Context, ids []string) (map[string]models.League, error) {
+	leagues := make(map[string]models.League, len(ids))
+	if len(ids) == 0 {
+		return leagues, nil
 	}
 
+	ctx, cancel := withTimeout(ctx, alr.queryTimeout)
+	defer cancel()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT leagueId, upcomingFixtures, playedFixtures, currentWeek, teams, standings FROM active_league
+		WHERE id IN (SELECT MAX(id) FROM active_league WHERE leagueId IN (%s) GROUP BY leagueId)`, placeholders)
+
+	rows, err := conn(ctx, alr.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, queryError(err, "leagues %s", strings.Join(ids, ", "))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, upcomingFixturesJson, playedFixturesJson, teamsJson, standingsJson string
+		var currentWeek int
+		err := rows.Scan(&id, &upcomingFixturesJson, &playedFixturesJson, &currentWeek, &teamsJson, &standingsJson)
+		if err != nil {
+			return nil, queryError(err, "leagues %s", strings.Join(ids, ", "))
+		}
+
+		league, err := decodeLeague(id, currentWeek, upcomingFixturesJson, playedFixturesJson, teamsJson, standingsJson)
+		if err != nil {
+			return nil, err
+		}
+		leagues[id] = league
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, queryError(err, "leagues %s", strings.Join(ids, ", "))
+	}
+
+	return leagues, nil
+}
+
+func decodeLeague(
+	id string, currentWeek int, upcomingFixturesJson, playedFixturesJson, teamsJson, standingsJson string,
+) (models.League, error) {
 	var league models.League
-	league.CurrentWeek = CurrentWeek
+	league.CurrentWeek = currentWeek
 
 	upcomingFixtures, err := utils.StringToStruct[[]models.Week](upcomingFixturesJson)
 
 	if err != nil {
 
-		return models.League{}, err
+		return models.League{}, decodeError(err, "upcoming fixtures of league %s", id)
 	}
 
 	playedFixtures, err := utils.StringToStruct[[]models.Week](playedFixturesJson)
 
 	if err != nil {
 
-		return models.League{}, err
+		return models.League{}, decodeError(err, "played fixtures of league %s", id)
 	}
 
 	standings, err := utils.StringToStruct[[]models.Standings](standingsJson)
 
 	if err != nil {
 
-		return models.League{}, err
+		return models.League{}, decodeError(err, "standings of league %s", id)
 	}
 
 	teams, err := utils.StringToStruct[[]models.Team](teamsJson)
 
 	if err != nil {
 
-		return models.League{}, err
+		return models.League{}, decodeError(err, "teams of league %s", id)
 	}
 
 	league.UpcomingFixtures = upcomingFixtures
@@ -69,30 +133,34 @@ func (alr *activeLeagueRepository) GetActiveLeague(id string) (models.League, er
 	return league, nil
 }
 
-func (alr *activeLeagueRepository) GetActiveLeagueTeams(id string) ([]models.Team, error) {
+func (alr *activeLeagueRepository) GetActiveLeagueTeams(ctx context.Context, id string) ([]models.Team, error) {
+	ctx, cancel := withTimeout(ctx, alr.queryTimeout)
+	defer cancel()
+
 	query := `SELECT teams FROM active_league WHERE leagueId = ? order by createdAt desc limit 1`
-	row := alr.db.QueryRow(query, id)
+	row := conn(ctx, alr.db).QueryRowContext(ctx, query, id)
 	var teamsJson string
 
 	err := row.Scan(&teamsJson)
 	if err != nil {
-		panic(err)
 
-		return nil, err
+		return nil, queryError(err, "league %s", id)
 	}
 
 	teams, err := utils.StringToStruct[[]models.Team](teamsJson)
 
 	if err != nil {
-		panic(err)
 
-		return nil, err
+		return nil, decodeError(err, "teams of league %s", id)
 	}
 
 	return teams, nil
 }
 
-func (alr *activeLeagueRepository) SetActiveLeague(data models.League) error {
+func (alr *activeLeagueRepository) SetActiveLeague(ctx context.Context, data models.League) error {
+	ctx, cancel := withTimeout(ctx, alr.queryTimeout)
+	defer cancel()
+
 	upcomingFixtures := utils.StructToString[[]models.Week](data.UpcomingFixtures)
 	playedFixtures := utils.StructToString[[]models.Week](data.PlayedFixtures)
 	standings := utils.StructToString[[]models.Standings](data.Standings)
@@ -100,7 +168,8 @@ func (alr *activeLeagueRepository) SetActiveLeague(data models.League) error {
 
 	query := `INSERT INTO active_league (leagueId, upcomingFixtures ,playedFixtures,teams, currentWeek, standings) VALUES (?, ?, ?, ?, ?,?)`
 
-	_, err := alr.db.Exec(
+	_, err := conn(ctx, alr.db).ExecContext(
+		ctx,
 		query,
 		data.LeagueID,
 		upcomingFixtures,
@@ -110,39 +179,41 @@ func (alr *activeLeagueRepository) SetActiveLeague(data models.League) error {
 		standings)
 
 	if err != nil {
-		panic(err)
 
-		return err
+		return writeError(err, "league %s", data.LeagueID)
 	}
 
 	return nil
 }
 
-func (alr *activeLeagueRepository) GetActiveLeaguesFixtures(id string) (models.GetActiveLeagueFixturesResponse, error) {
+func (alr *activeLeagueRepository) GetActiveLeaguesFixtures(ctx context.Context, id string) (models.GetActiveLeagueFixturesResponse, error) {
+	ctx, cancel := withTimeout(ctx, alr.queryTimeout)
+	defer cancel()
+
 	query := `SELECT upcomingFixtures, playedFixtures FROM active_league WHERE leagueId = ? order by createdAt desc limit 1`
 
-	row := alr.db.QueryRow(query, id)
+	row := conn(ctx, alr.db).QueryRowContext(ctx, query, id)
 	var upcomingFixturesJson, playedFixturesJson string
 
 	err := row.Scan(&upcomingFixturesJson, &playedFixturesJson)
 
 	if err != nil {
 
-		return models.GetActiveLeagueFixturesResponse{}, err
+		return models.GetActiveLeagueFixturesResponse{}, queryError(err, "league %s", id)
 	}
 
 	upcomingFixtures, err := utils.StringToStruct[[]models.Week](upcomingFixturesJson)
 
 	if err != nil {
 
-		return models.GetActiveLeagueFixturesResponse{}, err
+		return models.GetActiveLeagueFixturesResponse{}, decodeError(err, "upcoming fixtures of league %s", id)
 	}
 
 	playedFixtures, err := utils.StringToStruct[[]models.Week](playedFixturesJson)
 
 	if err != nil {
 
-		return models.GetActiveLeagueFixturesResponse{}, err
+		return models.GetActiveLeagueFixturesResponse{}, decodeError(err, "played fixtures of league %s", id)
 	}
 
 	return models.GetActiveLeagueFixturesResponse{
@@ -151,26 +222,29 @@ func (alr *activeLeagueRepository) GetActiveLeaguesFixtures(id string) (models.G
 	}, nil
 }
 
-func (alr *activeLeagueRepository) GetActiveLeaguesStandings(id string) (
+func (alr *activeLeagueRepository) GetActiveLeaguesStandings(ctx context.Context, id string) (
 	[]models.Standings, error,
 ) {
+	ctx, cancel := withTimeout(ctx, alr.queryTimeout)
+	defer cancel()
+
 	query := `SELECT standings FROM active_league WHERE leagueId = ? order by createdAt desc limit 1`
 
-	row := alr.db.QueryRow(query, id)
+	row := conn(ctx, alr.db).QueryRowContext(ctx, query, id)
 	var standingsJson string
 
 	err := row.Scan(&standingsJson)
 
 	if err != nil {
 
-		return nil, err
+		return nil, queryError(err, "league %s", id)
 	}
 
 	standings, err := utils.StringToStruct[[]models.Standings](standingsJson)
 
 	if err != nil {
 
-		return nil, err
+		return nil, decodeError(err, "standings of league %s", id)
 	}
 
 	return standings, nil
@@ -1,10 +1,12 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
 
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
 
@@ -17,7 +19,7 @@ func TestNewActiveLeagueRepository(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 	assert.NotNil(t, repo)
 	assert.Implements(t, (*interfaces.ActiveLeagueRepository)(nil), repo)
 }
@@ -27,7 +29,7 @@ func TestActiveLeagueRepository_GetActiveLeague_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -46,7 +48,7 @@ func TestActiveLeagueRepository_GetActiveLeague_Success(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetActiveLeague(leagueId)
+	result, err := repo.GetActiveLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -65,7 +67,7 @@ func TestActiveLeagueRepository_GetActiveLeague_NotFound(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "non-existent-league"
@@ -76,11 +78,12 @@ func TestActiveLeagueRepository_GetActiveLeague_NotFound(t *testing.T) {
 		WillReturnError(sql.ErrNoRows)
 
 	// Execute
-	result, err := repo.GetActiveLeague(leagueId)
+	result, err := repo.GetActiveLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, sql.ErrNoRows, err)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
 	assert.Equal(t, models.League{}, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -90,7 +93,7 @@ func TestActiveLeagueRepository_GetActiveLeague_InvalidJSON(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -105,7 +108,7 @@ func TestActiveLeagueRepository_GetActiveLeague_InvalidJSON(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetActiveLeague(leagueId)
+	result, err := repo.GetActiveLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -118,7 +121,7 @@ func TestActiveLeagueRepository_GetActiveLeagueTeams_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -133,7 +136,7 @@ func TestActiveLeagueRepository_GetActiveLeagueTeams_Success(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetActiveLeagueTeams(leagueId)
+	result, err := repo.GetActiveLeagueTeams(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -148,7 +151,7 @@ func TestActiveLeagueRepository_GetActiveLeagueTeams_ScanError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -158,10 +161,12 @@ func TestActiveLeagueRepository_GetActiveLeagueTeams_ScanError(t *testing.T) {
 		WithArgs(leagueId).
 		WillReturnError(sql.ErrNoRows)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		repo.GetActiveLeagueTeams(leagueId)
-	}, "Should panic when scan fails")
+	// Execute
+	_, err = repo.GetActiveLeagueTeams(context.Background(), leagueId)
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -171,7 +176,7 @@ func TestActiveLeagueRepository_GetActiveLeagueTeams_InvalidJSON(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -185,10 +190,12 @@ func TestActiveLeagueRepository_GetActiveLeagueTeams_InvalidJSON(t *testing.T) {
 		WithArgs(leagueId).
 		WillReturnRows(rows)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		repo.GetActiveLeagueTeams(leagueId)
-	}, "Should panic when JSON parsing fails")
+	// Execute
+	_, err = repo.GetActiveLeagueTeams(context.Background(), leagueId)
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -198,7 +205,7 @@ func TestActiveLeagueRepository_SetActiveLeague_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	league := models.League{
@@ -229,7 +236,7 @@ func TestActiveLeagueRepository_SetActiveLeague_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Execute
-	err = repo.SetActiveLeague(league)
+	err = repo.SetActiveLeague(context.Background(), league)
 
 	// Assert
 	assert.NoError(t, err)
@@ -241,7 +248,7 @@ func TestActiveLeagueRepository_SetActiveLeague_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	league := models.League{
@@ -255,10 +262,12 @@ func TestActiveLeagueRepository_SetActiveLeague_DatabaseError(t *testing.T) {
 		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnError(expectedError)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		repo.SetActiveLeague(league)
-	}, "Should panic when database insert fails")
+	// Execute
+	err = repo.SetActiveLeague(context.Background(), league)
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -268,7 +277,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesFixtures_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -284,7 +293,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesFixtures_Success(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetActiveLeaguesFixtures(leagueId)
+	result, err := repo.GetActiveLeaguesFixtures(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -300,7 +309,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesFixtures_DatabaseError(t *testin
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -312,11 +321,12 @@ func TestActiveLeagueRepository_GetActiveLeaguesFixtures_DatabaseError(t *testin
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.GetActiveLeaguesFixtures(leagueId)
+	result, err := repo.GetActiveLeaguesFixtures(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 	assert.Equal(t, models.GetActiveLeagueFixturesResponse{}, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -326,7 +336,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesStandings_Success(t *testing.T)
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -341,7 +351,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesStandings_Success(t *testing.T)
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetActiveLeaguesStandings(leagueId)
+	result, err := repo.GetActiveLeaguesStandings(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -358,7 +368,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesStandings_DatabaseError(t *testi
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -370,11 +380,12 @@ func TestActiveLeagueRepository_GetActiveLeaguesStandings_DatabaseError(t *testi
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.GetActiveLeaguesStandings(leagueId)
+	result, err := repo.GetActiveLeaguesStandings(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 	assert.Nil(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -384,7 +395,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesStandings_InvalidJSON(t *testing
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -399,7 +410,7 @@ func TestActiveLeagueRepository_GetActiveLeaguesStandings_InvalidJSON(t *testing
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetActiveLeaguesStandings(leagueId)
+	result, err := repo.GetActiveLeaguesStandings(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -415,7 +426,7 @@ func BenchmarkActiveLeagueRepository_GetActiveLeague(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	for i := 0; i < b.N; i++ {
 		rows := sqlmock.NewRows([]string{"upcomingFixtures", "playedFixtures", "currentWeek", "teams", "standings"}).
@@ -425,7 +436,7 @@ func BenchmarkActiveLeagueRepository_GetActiveLeague(b *testing.B) {
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnRows(rows)
 
-		repo.GetActiveLeague("benchmark-league")
+		repo.GetActiveLeague(context.Background(), "benchmark-league")
 	}
 }
 
@@ -436,7 +447,7 @@ func BenchmarkActiveLeagueRepository_SetActiveLeague(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 	league := models.League{
 		LeagueID:    "benchmark-league",
 		CurrentWeek: 1,
@@ -447,7 +458,7 @@ func BenchmarkActiveLeagueRepository_SetActiveLeague(b *testing.B) {
 			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repo.SetActiveLeague(league)
+		repo.SetActiveLeague(context.Background(), league)
 	}
 }
 
@@ -458,7 +469,7 @@ func BenchmarkActiveLeagueRepository_GetActiveLeaguesStandings(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewActiveLeagueRepository(db)
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
 
 	for i := 0; i < b.N; i++ {
 		rows := sqlmock.NewRows([]string{"standings"}).
@@ -468,6 +479,52 @@ func BenchmarkActiveLeagueRepository_GetActiveLeaguesStandings(b *testing.B) {
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnRows(rows)
 
-		repo.GetActiveLeaguesStandings("benchmark-league")
+		repo.GetActiveLeaguesStandings(context.Background(), "benchmark-league")
 	}
 }
+
+func TestActiveLeagueRepository_GetActiveLeagues_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	rows := sqlmock.NewRows(
+		[]string{"leagueId", "upcomingFixtures", "playedFixtures", "currentWeek", "teams", "standings"}).
+		AddRow("league-1", `[]`, `[]`, 1, `[{"Name":"Team A"}]`, `[]`).
+		AddRow("league-2", `[]`, `[]`, 3, `[{"Name":"Team B"},{"Name":"Team C"}]`, `[]`)
+
+	mock.ExpectQuery("SELECT leagueId, upcomingFixtures, playedFixtures, currentWeek, teams, standings FROM active_league\\s+WHERE id IN \\(SELECT MAX\\(id\\) FROM active_league WHERE leagueId IN \\(\\?,\\?,\\?\\) GROUP BY leagueId\\)").
+		WithArgs("league-1", "league-2", "league-3").
+		WillReturnRows(rows)
+
+	// Execute
+	result, err := repo.GetActiveLeagues(context.Background(), []string{"league-1", "league-2", "league-3"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "league-1", result["league-1"].LeagueID)
+	assert.Equal(t, 3, result["league-2"].CurrentWeek)
+	assert.Len(t, result["league-2"].Teams, 2)
+	assert.NotContains(t, result, "league-3")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActiveLeagueRepository_GetActiveLeagues_NoIds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
+
+	// Execute
+	result, err := repo.GetActiveLeagues(context.Background(), nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
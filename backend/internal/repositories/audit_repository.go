@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+)
+
+type auditRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewAuditRepository(db *sql.DB, queryTimeout time.Duration) interfaces.AuditRepository {
+	return &auditRepository{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+func (ar *auditRepository) AppendAuditEntry(ctx context.Context, entry models.AuditEntry) error {
+	ctx, cancel := withTimeout(ctx, ar.queryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO audit_log (leagueId, action, actorId, requestId, beforeValue, afterValue, createdAt)
+		VALUES (?,?,?,?,?,?,?)`
+
+	_, err := conn(ctx, ar.db).ExecContext(
+		ctx, query,
+		entry.LeagueId,
+		entry.Action,
+		nullString(entry.ActorId),
+		nullString(entry.RequestId),
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+		entry.CreatedAt)
+
+	if err != nil {
+
+		return writeError(err, "audit entry for league %s", entry.LeagueId)
+	}
+
+	return nil
+}
+
+// GetAuditEntries lists a league's audit entries oldest first. An empty action
+// returns every action.
+func (ar *auditRepository) GetAuditEntries(ctx context.Context, leagueId string, action string) ([]models.AuditEntry, error) {
+	ctx, cancel := withTimeout(ctx, ar.queryTimeout)
+	defer cancel()
+
+	query := `SELECT id, leagueId, action, actorId, requestId, beforeValue, afterValue, createdAt FROM audit_log
+		WHERE leagueId = ? AND (? = '' OR action = ?) ORDER BY id`
+
+	rows, err := conn(ctx, ar.db).QueryContext(ctx, query, leagueId, action, action)
+
+	if err != nil {
+
+		return nil, queryError(err, "audit log of league %s", leagueId)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+
+	for rows.Next() {
+		var entry models.AuditEntry
+		var actorId, requestId sql.NullString
+		var before, after []byte
+
+		err := rows.Scan(
+			&entry.Id,
+			&entry.LeagueId,
+			&entry.Action,
+			&actorId,
+			&requestId,
+			&before,
+			&after,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+
+			return nil, queryError(err, "audit log of league %s", leagueId)
+		}
+
+		entry.ActorId = actorId.String
+		entry.RequestId = requestId.String
+		entry.Before = before
+		entry.After = after
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+
+		return nil, queryError(err, "audit log of league %s", leagueId)
+	}
+
+	return entries, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return string(raw)
+}
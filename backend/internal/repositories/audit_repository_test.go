@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditRepository(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepository(db, testQueryTimeout)
+	assert.NotNil(t, repo)
+	assert.Implements(t, (*interfaces.AuditRepository)(nil), repo)
+}
+
+func TestAuditRepository_AppendAuditEntry_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepository(db, testQueryTimeout)
+
+	// Test data
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	entry := models.AuditEntry{
+		LeagueId:  "league-id",
+		Action:    models.AuditActionReset,
+		ActorId:   "user-id",
+		RequestId: "req-1",
+		Before:    json.RawMessage(`{"currentWeek":3}`),
+		CreatedAt: createdAt,
+	}
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO audit_log").
+		WithArgs("league-id", models.AuditActionReset, "user-id", "req-1", `{"currentWeek":3}`, nil, createdAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Execute
+	err = repo.AppendAuditEntry(context.Background(), entry)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepository_AppendAuditEntry_DatabaseError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	expectedError := errors.New("database connection failed")
+	mock.ExpectExec("INSERT INTO audit_log").WillReturnError(expectedError)
+
+	// Execute
+	err = repo.AppendAuditEntry(context.Background(), models.AuditEntry{LeagueId: "league-id"})
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepository_GetAuditEntries_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "leagueId", "action", "actorId", "requestId", "beforeValue", "afterValue", "createdAt"}).
+		AddRow(1, "league-id", models.AuditActionCreate, "user-id", "req-1", nil, `{"leagueName":"L"}`, createdAt).
+		AddRow(2, "league-id", models.AuditActionEdit, nil, nil, `{"homeScore":1}`, `{"homeScore":2}`, createdAt)
+	mock.ExpectQuery("SELECT id, leagueId, action, actorId, requestId, beforeValue, afterValue, createdAt FROM audit_log").
+		WithArgs("league-id", "", "").
+		WillReturnRows(rows)
+
+	// Execute
+	entries, err := repo.GetAuditEntries(context.Background(), "league-id", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, []models.AuditEntry{
+			{
+				Id: 1, LeagueId: "league-id", Action: models.AuditActionCreate, ActorId: "user-id", RequestId: "req-1",
+				After: json.RawMessage(`{"leagueName":"L"}`), CreatedAt: createdAt,
+			},
+			{
+				Id: 2, LeagueId: "league-id", Action: models.AuditActionEdit,
+				Before: json.RawMessage(`{"homeScore":1}`), After: json.RawMessage(`{"homeScore":2}`), CreatedAt: createdAt,
+			},
+		}, entries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditRepository_GetAuditEntries_FilterByAction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewAuditRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("FROM audit_log").
+		WithArgs("league-id", models.AuditActionDelete, models.AuditActionDelete).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "leagueId", "action", "actorId", "requestId", "beforeValue", "afterValue", "createdAt"}))
+
+	// Execute
+	entries, err := repo.GetAuditEntries(context.Background(), "league-id", models.AuditActionDelete)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NotNil(t, entries, "an empty log is returned as an empty list")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
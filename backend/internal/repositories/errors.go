@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"league-sim/internal/apperrors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDuplicateEntry is the MySQL error number for a unique key violation.
+const mysqlDuplicateEntry = 1062
+
+// queryError classifies a failed read of the record described by format: a
+// missing row is NotFound, anything else is Internal.
+func queryError(err error, format string, args ...any) error {
+	subject := fmt.Sprintf(format, args...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &apperrors.Error{Kind: apperrors.KindNotFound, Message: subject + " not found", Err: err}
+	}
+
+	return apperrors.Internal(err, "failed to read %s", subject)
+}
+
+// writeError classifies a failed insert, update or delete.
+func writeError(err error, format string, args ...any) error {
+	return apperrors.Internal(err, "failed to write %s", fmt.Sprintf(format, args...))
+}
+
+// decodeError reports a stored JSON column that no longer decodes.
+func decodeError(err error, format string, args ...any) error {
+	return apperrors.Internal(err, "stored %s is corrupt", fmt.Sprintf(format, args...))
+}
+
+// isDuplicateKey reports whether err is a MySQL unique key violation.
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
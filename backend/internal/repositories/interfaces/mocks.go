@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/mock"
@@ -11,18 +13,76 @@ type MockLeagueRepository struct {
 	mock.Mock
 }
 
-func (m *MockLeagueRepository) SetLeague(id string, data models.CreateLeagueRequest) error {
-	args := m.Called(id, data)
+func (m *MockLeagueRepository) SetLeague(ctx context.Context, id string, ownerId string, data models.CreateLeagueRequest) error {
+	args := m.Called(ctx, id, ownerId, data)
 	return args.Error(0)
 }
 
-func (m *MockLeagueRepository) GetLeague() ([]models.GetLeaguesIdsWithNameResponse, error) {
-	args := m.Called()
+func (m *MockLeagueRepository) GetLeague(ctx context.Context, userId string) ([]models.GetLeaguesIdsWithNameResponse, error) {
+	args := m.Called(ctx, userId)
 	return args.Get(0).([]models.GetLeaguesIdsWithNameResponse), args.Error(1)
 }
 
-func (m *MockLeagueRepository) DeleteLeague(id string) error {
-	args := m.Called(id)
+func (m *MockLeagueRepository) GetLeagueName(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLeagueRepository) GetLeagueRules(ctx context.Context, id string) (models.LeagueRules, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.LeagueRules), args.Error(1)
+}
+
+func (m *MockLeagueRepository) GetLeaguesRules(ctx context.Context, ids []string) (map[string]models.LeagueRules, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]models.LeagueRules), args.Error(1)
+}
+
+func (m *MockLeagueRepository) DeleteLeague(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLeagueRepository) SetLeagueParent(ctx context.Context, id string, parentId string) error {
+	args := m.Called(ctx, id, parentId)
+	return args.Error(0)
+}
+
+func (m *MockLeagueRepository) GetLeagueParent(ctx context.Context, id string) (string, error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLeagueRepository) CountOwnedLeagues(ctx context.Context, ownerId string) (int, error) {
+	args := m.Called(ctx, ownerId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLeagueRepository) AdoptUnownedLeagues(ctx context.Context, ownerId string) (int, error) {
+	args := m.Called(ctx, ownerId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLeagueRepository) GetMemberRole(ctx context.Context, leagueId string, userId string) (string, error) {
+	args := m.Called(ctx, leagueId, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLeagueRepository) GetLeagueMembers(ctx context.Context, leagueId string) ([]models.LeagueMember, error) {
+	args := m.Called(ctx, leagueId)
+	return args.Get(0).([]models.LeagueMember), args.Error(1)
+}
+
+func (m *MockLeagueRepository) AddLeagueMember(ctx context.Context, leagueId string, userId string, role string) error {
+	args := m.Called(ctx, leagueId, userId, role)
+	return args.Error(0)
+}
+
+func (m *MockLeagueRepository) RemoveLeagueMember(ctx context.Context, leagueId string, userId string) error {
+	args := m.Called(ctx, leagueId, userId)
 	return args.Error(0)
 }
 
@@ -31,28 +91,33 @@ type MockActiveLeagueRepository struct {
 	mock.Mock
 }
 
-func (m *MockActiveLeagueRepository) GetActiveLeague(id string) (models.League, error) {
-	args := m.Called(id)
+func (m *MockActiveLeagueRepository) GetActiveLeague(ctx context.Context, id string) (models.League, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(models.League), args.Error(1)
 }
 
-func (m *MockActiveLeagueRepository) SetActiveLeague(data models.League) error {
-	args := m.Called(data)
+func (m *MockActiveLeagueRepository) GetActiveLeagues(ctx context.Context, ids []string) (map[string]models.League, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(map[string]models.League), args.Error(1)
+}
+
+func (m *MockActiveLeagueRepository) SetActiveLeague(ctx context.Context, data models.League) error {
+	args := m.Called(ctx, data)
 	return args.Error(0)
 }
 
-func (m *MockActiveLeagueRepository) GetActiveLeagueTeams(id string) ([]models.Team, error) {
-	args := m.Called(id)
+func (m *MockActiveLeagueRepository) GetActiveLeagueTeams(ctx context.Context, id string) ([]models.Team, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).([]models.Team), args.Error(1)
 }
 
-func (m *MockActiveLeagueRepository) GetActiveLeaguesFixtures(id string) (models.GetActiveLeagueFixturesResponse, error) {
-	args := m.Called(id)
+func (m *MockActiveLeagueRepository) GetActiveLeaguesFixtures(ctx context.Context, id string) (models.GetActiveLeagueFixturesResponse, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(models.GetActiveLeagueFixturesResponse), args.Error(1)
 }
 
-func (m *MockActiveLeagueRepository) GetActiveLeaguesStandings(id string) ([]models.Standings, error) {
-	args := m.Called(id)
+func (m *MockActiveLeagueRepository) GetActiveLeaguesStandings(ctx context.Context, id string) ([]models.Standings, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).([]models.Standings), args.Error(1)
 }
 
@@ -61,27 +126,131 @@ type MockMatchResultRepository struct {
 	mock.Mock
 }
 
-func (m *MockMatchResultRepository) EditMatchScore(data models.EditMatchResult) error {
-	args := m.Called(data)
+func (m *MockMatchResultRepository) EditMatchScore(ctx context.Context, data models.EditMatchResult) error {
+	args := m.Called(ctx, data)
 	return args.Error(0)
 }
 
-func (m *MockMatchResultRepository) SetMatchResults(leagueId string, matchResults []models.MatchResult) error {
-	args := m.Called(leagueId, matchResults)
+func (m *MockMatchResultRepository) SetMatchResults(ctx context.Context, leagueId string, matchResults []models.MatchResult) error {
+	args := m.Called(ctx, leagueId, matchResults)
 	return args.Error(0)
 }
 
-func (m *MockMatchResultRepository) GetMatchResults(leagueId string) ([]models.MatchResult, error) {
-	args := m.Called(leagueId)
+func (m *MockMatchResultRepository) GetMatchResults(ctx context.Context, leagueId string) ([]models.MatchResult, error) {
+	args := m.Called(ctx, leagueId)
 	return args.Get(0).([]models.MatchResult), args.Error(1)
 }
 
-func (m *MockMatchResultRepository) DeleteMatchResults(leagueId string) error {
-	args := m.Called(leagueId)
+func (m *MockMatchResultRepository) GetMatchResultsByLeague(ctx context.Context, leagueIds []string) (map[string][]models.MatchResult, error) {
+	args := m.Called(ctx, leagueIds)
+	return args.Get(0).(map[string][]models.MatchResult), args.Error(1)
+}
+
+func (m *MockMatchResultRepository) DeleteMatchResults(ctx context.Context, leagueId string) error {
+	args := m.Called(ctx, leagueId)
 	return args.Error(0)
 }
 
-func (m *MockMatchResultRepository) GetMatchResultByWeekAndTeam(data models.EditMatchResult) (models.MatchResult, error) {
-	args := m.Called(data)
+func (m *MockMatchResultRepository) GetMatchResultByWeekAndTeam(ctx context.Context, data models.EditMatchResult) (models.MatchResult, error) {
+	args := m.Called(ctx, data)
 	return args.Get(0).(models.MatchResult), args.Error(1)
 }
+
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, user models.User, apiKeyHash string) error {
+	args := m.Called(ctx, user, apiKeyHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetUser(ctx context.Context, userId string) (models.User, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetUserByAPIKeyHash(ctx context.Context, apiKeyHash string) (models.User, error) {
+	args := m.Called(ctx, apiKeyHash)
+	return args.Get(0).(models.User), args.Error(1)
+}
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) AppendAuditEntry(ctx context.Context, entry models.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) GetAuditEntries(ctx context.Context, leagueId string, action string) ([]models.AuditEntry, error) {
+	args := m.Called(ctx, leagueId, action)
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
+
+// MockRatingRepository is a mock implementation of RatingRepository
+type MockRatingRepository struct {
+	mock.Mock
+}
+
+func (m *MockRatingRepository) AppendRatingChanges(ctx context.Context, leagueId string, changes []models.RatingChange) error {
+	args := m.Called(ctx, leagueId, changes)
+	return args.Error(0)
+}
+
+func (m *MockRatingRepository) GetRatingChanges(ctx context.Context, leagueId string, team string) ([]models.RatingChange, error) {
+	args := m.Called(ctx, leagueId, team)
+	return args.Get(0).([]models.RatingChange), args.Error(1)
+}
+
+func (m *MockRatingRepository) DeleteRatingChanges(ctx context.Context, leagueId string) error {
+	args := m.Called(ctx, leagueId)
+	return args.Error(0)
+}
+
+// MockPredictionRepository is a mock implementation of PredictionRepository
+type MockPredictionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPredictionRepository) SavePredictionSnapshot(ctx context.Context, leagueId string, snapshot models.PredictionSnapshot) error {
+	args := m.Called(ctx, leagueId, snapshot)
+	return args.Error(0)
+}
+
+func (m *MockPredictionRepository) GetPredictionSnapshots(ctx context.Context, leagueId string) ([]models.PredictionSnapshot, error) {
+	args := m.Called(ctx, leagueId)
+	return args.Get(0).([]models.PredictionSnapshot), args.Error(1)
+}
+
+func (m *MockPredictionRepository) DeletePredictionSnapshots(ctx context.Context, leagueId string) error {
+	args := m.Called(ctx, leagueId)
+	return args.Error(0)
+}
+
+// MockTransactor is a mock implementation of Transactor. It runs fn unless
+// the call is set up to fail, and returns what fn returns.
+type MockTransactor struct {
+	mock.Mock
+}
+
+type mockTxKey struct{}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+
+	return fn(context.WithValue(ctx, mockTxKey{}, true))
+}
+
+// InMockTx reports whether ctx is one a MockTransactor handed to fn, to match
+// the repository calls that must run inside the transaction.
+func InMockTx(ctx context.Context) bool {
+	inTx, _ := ctx.Value(mockTxKey{}).(bool)
+	return inTx
+}
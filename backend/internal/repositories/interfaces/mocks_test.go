@@ -1,11 +1,14 @@
 package interfaces
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMockLeagueRepository_ImplementsInterface(t *testing.T) {
@@ -20,12 +23,30 @@ func TestMockActiveLeagueRepository_ImplementsInterface(t *testing.T) {
 	assert.True(t, true, "MockActiveLeagueRepository implements ActiveLeagueRepository interface")
 }
 
+func TestMockAuditRepository_ImplementsInterface(t *testing.T) {
+	// Test that MockAuditRepository implements AuditRepository interface
+	var _ AuditRepository = (*MockAuditRepository)(nil)
+	assert.True(t, true, "MockAuditRepository implements AuditRepository interface")
+}
+
+func TestMockUserRepository_ImplementsInterface(t *testing.T) {
+	// Test that MockUserRepository implements UserRepository interface
+	var _ UserRepository = (*MockUserRepository)(nil)
+	assert.True(t, true, "MockUserRepository implements UserRepository interface")
+}
+
 func TestMockMatchResultRepository_ImplementsInterface(t *testing.T) {
 	// Test that MockMatchResultRepository implements MatchResultRepository interface
 	var _ MatchResultRepository = (*MockMatchResultRepository)(nil)
 	assert.True(t, true, "MockMatchResultRepository implements MatchResultRepository interface")
 }
 
+func TestMockRatingRepository_ImplementsInterface(t *testing.T) {
+	// Test that MockRatingRepository implements RatingRepository interface
+	var _ RatingRepository = (*MockRatingRepository)(nil)
+	assert.True(t, true, "MockRatingRepository implements RatingRepository interface")
+}
+
 func TestMockLeagueRepository_SetLeague(t *testing.T) {
 	// Create mock
 	mockRepo := &MockLeagueRepository{}
@@ -34,26 +55,43 @@ func TestMockLeagueRepository_SetLeague(t *testing.T) {
 	testID := "test-id"
 	testData := models.CreateLeagueRequest{} // Assuming this struct exists
 
-	mockRepo.On("SetLeague", testID, testData).Return(nil)
+	mockRepo.On("SetLeague", mock.Anything, testID, "owner-id", testData).Return(nil)
 
 	// Call method
-	err := mockRepo.SetLeague(testID, testData)
+	err := mockRepo.SetLeague(context.Background(), testID, "owner-id", testData)
 
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMockLeagueRepository_GetLeagueRules(t *testing.T) {
+	// Create mock
+	mockRepo := &MockLeagueRepository{}
+
+	// Setup expectations
+	expectedResult := models.DefaultLeagueRules()
+	mockRepo.On("GetLeagueRules", mock.Anything, "test-id").Return(expectedResult, nil)
+
+	// Call method
+	result, err := mockRepo.GetLeagueRules(context.Background(), "test-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResult, result)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestMockLeagueRepository_GetLeague(t *testing.T) {
 	// Create mock
 	mockRepo := &MockLeagueRepository{}
 
 	// Setup expectations
 	expectedResult := []models.GetLeaguesIdsWithNameResponse{}
-	mockRepo.On("GetLeague").Return(expectedResult, nil)
+	mockRepo.On("GetLeague", mock.Anything, "user-id").Return(expectedResult, nil)
 
 	// Call method
-	result, err := mockRepo.GetLeague()
+	result, err := mockRepo.GetLeague(context.Background(), "user-id")
 
 	// Assert
 	assert.NoError(t, err)
@@ -61,22 +99,63 @@ func TestMockLeagueRepository_GetLeague(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMockLeagueRepository_CountOwnedLeagues(t *testing.T) {
+	mockRepo := &MockLeagueRepository{}
+	mockRepo.On("CountOwnedLeagues", mock.Anything, "user-id").Return(2, nil)
+
+	count, err := mockRepo.CountOwnedLeagues(context.Background(), "user-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMockLeagueRepository_AdoptUnownedLeagues(t *testing.T) {
+	mockRepo := &MockLeagueRepository{}
+	mockRepo.On("AdoptUnownedLeagues", mock.Anything, "user-id").Return(4, nil)
+
+	adopted, err := mockRepo.AdoptUnownedLeagues(context.Background(), "user-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, adopted)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestMockLeagueRepository_DeleteLeague(t *testing.T) {
 	// Create mock
 	mockRepo := &MockLeagueRepository{}
 
 	// Setup expectations
 	testID := "test-id"
-	mockRepo.On("DeleteLeague", testID).Return(nil)
+	mockRepo.On("DeleteLeague", mock.Anything, testID).Return(nil)
 
 	// Call method
-	err := mockRepo.DeleteLeague(testID)
+	err := mockRepo.DeleteLeague(context.Background(), testID)
 
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMockLeagueRepository_LeagueParent(t *testing.T) {
+	// Create mock
+	mockRepo := &MockLeagueRepository{}
+
+	// Setup expectations
+	mockRepo.On("SetLeagueParent", mock.Anything, "fork-id", "parent-id").Return(nil)
+	mockRepo.On("GetLeagueParent", mock.Anything, "fork-id").Return("parent-id", nil)
+
+	// Call method
+	err := mockRepo.SetLeagueParent(context.Background(), "fork-id", "parent-id")
+	parentId, getErr := mockRepo.GetLeagueParent(context.Background(), "fork-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, getErr)
+	assert.Equal(t, "parent-id", parentId)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestMockActiveLeagueRepository_GetActiveLeague(t *testing.T) {
 	// Create mock
 	mockRepo := &MockActiveLeagueRepository{}
@@ -84,10 +163,10 @@ func TestMockActiveLeagueRepository_GetActiveLeague(t *testing.T) {
 	// Setup expectations
 	testID := "test-id"
 	expectedLeague := models.League{}
-	mockRepo.On("GetActiveLeague", testID).Return(expectedLeague, nil)
+	mockRepo.On("GetActiveLeague", mock.Anything, testID).Return(expectedLeague, nil)
 
 	// Call method
-	result, err := mockRepo.GetActiveLeague(testID)
+	result, err := mockRepo.GetActiveLeague(context.Background(), testID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -101,10 +180,10 @@ func TestMockActiveLeagueRepository_SetActiveLeague(t *testing.T) {
 
 	// Setup expectations
 	testData := models.League{}
-	mockRepo.On("SetActiveLeague", testData).Return(nil)
+	mockRepo.On("SetActiveLeague", mock.Anything, testData).Return(nil)
 
 	// Call method
-	err := mockRepo.SetActiveLeague(testData)
+	err := mockRepo.SetActiveLeague(context.Background(), testData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -117,10 +196,10 @@ func TestMockMatchResultRepository_EditMatchScore(t *testing.T) {
 
 	// Setup expectations
 	testData := models.EditMatchResult{}
-	mockRepo.On("EditMatchScore", testData).Return(nil)
+	mockRepo.On("EditMatchScore", mock.Anything, testData).Return(nil)
 
 	// Call method
-	err := mockRepo.EditMatchScore(testData)
+	err := mockRepo.EditMatchScore(context.Background(), testData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -134,10 +213,10 @@ func TestMockMatchResultRepository_SetMatchResults(t *testing.T) {
 	// Setup expectations
 	testLeagueID := "test-league-id"
 	testResults := []models.MatchResult{}
-	mockRepo.On("SetMatchResults", testLeagueID, testResults).Return(nil)
+	mockRepo.On("SetMatchResults", mock.Anything, testLeagueID, testResults).Return(nil)
 
 	// Call method
-	err := mockRepo.SetMatchResults(testLeagueID, testResults)
+	err := mockRepo.SetMatchResults(context.Background(), testLeagueID, testResults)
 
 	// Assert
 	assert.NoError(t, err)
@@ -151,10 +230,10 @@ func TestMockMatchResultRepository_GetMatchResults(t *testing.T) {
 	// Setup expectations
 	testLeagueID := "test-league-id"
 	expectedResults := []models.MatchResult{}
-	mockRepo.On("GetMatchResults", testLeagueID).Return(expectedResults, nil)
+	mockRepo.On("GetMatchResults", mock.Anything, testLeagueID).Return(expectedResults, nil)
 
 	// Call method
-	result, err := mockRepo.GetMatchResults(testLeagueID)
+	result, err := mockRepo.GetMatchResults(context.Background(), testLeagueID)
 
 	// Assert
 	assert.NoError(t, err)
@@ -168,12 +247,44 @@ func TestMockMatchResultRepository_DeleteMatchResults(t *testing.T) {
 
 	// Setup expectations
 	testLeagueID := "test-league-id"
-	mockRepo.On("DeleteMatchResults", testLeagueID).Return(nil)
+	mockRepo.On("DeleteMatchResults", mock.Anything, testLeagueID).Return(nil)
 
 	// Call method
-	err := mockRepo.DeleteMatchResults(testLeagueID)
+	err := mockRepo.DeleteMatchResults(context.Background(), testLeagueID)
 
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestMockPredictionRepository_ImplementsInterface(t *testing.T) {
+	// Test that MockPredictionRepository implements PredictionRepository interface
+	var _ PredictionRepository = (*MockPredictionRepository)(nil)
+	assert.True(t, true, "MockPredictionRepository implements PredictionRepository interface")
+}
+
+func TestMockTransactor_WithinTx(t *testing.T) {
+	// Setup
+	mockTx := &MockTransactor{}
+	mockTx.On("WithinTx", mock.Anything).Return(nil)
+	fnErr := errors.New("write failed")
+
+	// Execute
+	inTx := false
+	err := mockTx.WithinTx(
+		context.Background(), func(ctx context.Context) error {
+			inTx = InMockTx(ctx)
+			return fnErr
+		})
+
+	// Assert
+	assert.ErrorIs(t, err, fnErr)
+	assert.True(t, inTx)
+	assert.False(t, InMockTx(context.Background()))
+	mockTx.AssertExpectations(t)
+}
+
+func TestMockTransactor_ImplementsInterface(t *testing.T) {
+	var _ Transactor = (*MockTransactor)(nil)
+	assert.True(t, true, "MockTransactor implements Transactor interface")
+}
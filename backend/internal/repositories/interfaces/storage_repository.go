@@ -1,27 +1,75 @@
 package interfaces
 
 import (
+	"context"
+
 	"league-sim/internal/models"
 )
 
 type LeagueRepository interface {
-	SetLeague(id string, data models.CreateLeagueRequest) error
-	GetLeague() ([]models.GetLeaguesIdsWithNameResponse, error)
-	DeleteLeague(id string) error
+	SetLeague(ctx context.Context, id string, ownerId string, data models.CreateLeagueRequest) error
+	GetLeague(ctx context.Context, userId string) ([]models.GetLeaguesIdsWithNameResponse, error)
+	CountOwnedLeagues(ctx context.Context, ownerId string) (int, error)
+	AdoptUnownedLeagues(ctx context.Context, ownerId string) (int, error)
+	GetLeagueName(ctx context.Context, id string) (string, error)
+	GetLeagueRules(ctx context.Context, id string) (models.LeagueRules, error)
+	GetLeaguesRules(ctx context.Context, ids []string) (map[string]models.LeagueRules, error)
+	DeleteLeague(ctx context.Context, id string) error
+	SetLeagueParent(ctx context.Context, id string, parentId string) error
+	GetLeagueParent(ctx context.Context, id string) (string, error)
+	GetMemberRole(ctx context.Context, leagueId string, userId string) (string, error)
+	GetLeagueMembers(ctx context.Context, leagueId string) ([]models.LeagueMember, error)
+	AddLeagueMember(ctx context.Context, leagueId string, userId string, role string) error
+	RemoveLeagueMember(ctx context.Context, leagueId string, userId string) error
+}
+
+// AuditRepository is append-only: entries can be added and read, never changed.
+type AuditRepository interface {
+	AppendAuditEntry(ctx context.Context, entry models.AuditEntry) error
+	GetAuditEntries(ctx context.Context, leagueId string, action string) ([]models.AuditEntry, error)
+}
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, user models.User, apiKeyHash string) error
+	GetUser(ctx context.Context, userId string) (models.User, error)
+	GetUserByAPIKeyHash(ctx context.Context, apiKeyHash string) (models.User, error)
 }
 
 type ActiveLeagueRepository interface {
-	GetActiveLeague(id string) (models.League, error)
-	SetActiveLeague(data models.League) error
-	GetActiveLeagueTeams(id string) ([]models.Team, error)
-	GetActiveLeaguesFixtures(id string) (models.GetActiveLeagueFixturesResponse, error)
-	GetActiveLeaguesStandings(id string) ([]models.Standings, error)
+	GetActiveLeague(ctx context.Context, id string) (models.League, error)
+	GetActiveLeagues(ctx context.Context, ids []string) (map[string]models.League, error)
+	SetActiveLeague(ctx context.Context, data models.League) error
+	GetActiveLeagueTeams(ctx context.Context, id string) ([]models.Team, error)
+	GetActiveLeaguesFixtures(ctx context.Context, id string) (models.GetActiveLeagueFixturesResponse, error)
+	GetActiveLeaguesStandings(ctx context.Context, id string) ([]models.Standings, error)
 }
 
 type MatchResultRepository interface {
-	EditMatchScore(data models.EditMatchResult) error
-	SetMatchResults(leagueId string, matchResults []models.MatchResult) error
-	GetMatchResults(leagueId string) ([]models.MatchResult, error)
-	DeleteMatchResults(leagueId string) error
-	GetMatchResultByWeekAndTeam(data models.EditMatchResult) (models.MatchResult, error)
+	EditMatchScore(ctx context.Context, data models.EditMatchResult) error
+	SetMatchResults(ctx context.Context, leagueId string, matchResults []models.MatchResult) error
+	GetMatchResults(ctx context.Context, leagueId string) ([]models.MatchResult, error)
+	GetMatchResultsByLeague(ctx context.Context, leagueIds []string) (map[string][]models.MatchResult, error)
+	DeleteMatchResults(ctx context.Context, leagueId string) error
+	GetMatchResultByWeekAndTeam(ctx context.Context, data models.EditMatchResult) (models.MatchResult, error)
+}
+
+// RatingRepository keeps every rating change of a league in match order.
+type RatingRepository interface {
+	AppendRatingChanges(ctx context.Context, leagueId string, changes []models.RatingChange) error
+	GetRatingChanges(ctx context.Context, leagueId string, team string) ([]models.RatingChange, error)
+	DeleteRatingChanges(ctx context.Context, leagueId string) error
+}
+
+// PredictionRepository keeps the title prediction made before each week of a
+// league.
+type PredictionRepository interface {
+	SavePredictionSnapshot(ctx context.Context, leagueId string, snapshot models.PredictionSnapshot) error
+	GetPredictionSnapshots(ctx context.Context, leagueId string) ([]models.PredictionSnapshot, error)
+	DeletePredictionSnapshots(ctx context.Context, leagueId string) error
+}
+
+// Transactor runs several repository calls as one transaction: the calls fn
+// makes with the context it is given either all take effect or none do.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -1,44 +1,74 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
+	"league-sim/utils"
 )
 
 type leagueRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
-func NewLeagueRepository(db *sql.DB) interfaces.LeagueRepository {
+func NewLeagueRepository(db *sql.DB, queryTimeout time.Duration) interfaces.LeagueRepository {
 	return &leagueRepository{
-		db: db,
+		db:           db,
+		queryTimeout: queryTimeout,
 	}
 }
 
-func (lr *leagueRepository) SetLeague(id string, data models.CreateLeagueRequest) error {
-	query := `INSERT INTO league (leagueId, name) VALUES (?,?)`
+func (lr *leagueRepository) SetLeague(ctx context.Context, id string, ownerId string, data models.CreateLeagueRequest) error {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
 
-	_, err := lr.db.Exec(query, id, data.LeagueName)
+	query := `INSERT INTO league (leagueId, name, ownerId, rules) VALUES (?,?,?,?)`
+
+	owner := nullString(ownerId)
+
+	rules := data.Rules
+	if rules == nil {
+		defaultRules := models.DefaultLeagueRules()
+		rules = &defaultRules
+	}
+
+	_, err := conn(ctx, lr.db).ExecContext(ctx, query, id, data.LeagueName, owner, utils.StructToString[models.LeagueRules](*rules))
+
+	if isDuplicateKey(err) {
+
+		return apperrors.Conflict("league %s already exists", id)
+	}
 
 	if err != nil {
 
-		return err
+		return writeError(err, "league %s", id)
 	}
 
 	return nil
 }
 
-func (lr *leagueRepository) GetLeague() ([]models.GetLeaguesIdsWithNameResponse, error) {
-	query := `SELECT leagueId, name FROM league`
+// GetLeague lists the leagues userId owns or has been invited to.
+func (lr *leagueRepository) GetLeague(ctx context.Context, userId string) ([]models.GetLeaguesIdsWithNameResponse, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT leagueId, name FROM league
+		WHERE ownerId = ? OR leagueId IN (SELECT leagueId FROM league_members WHERE userId = ?)`
 
-	rows, err := lr.db.Query(query)
+	rows, err := conn(ctx, lr.db).QueryContext(ctx, query, userId, userId)
 
 	if err != nil {
-		panic(err)
-		return nil, err
+
+		return nil, queryError(err, "leagues")
 	}
+	defer rows.Close()
 
 	var leagues []models.GetLeaguesIdsWithNameResponse
 
@@ -46,24 +76,315 @@ func (lr *leagueRepository) GetLeague() ([]models.GetLeaguesIdsWithNameResponse,
 		var league models.GetLeaguesIdsWithNameResponse
 		err := rows.Scan(&league.LeagueId, &league.LeagueName)
 		if err != nil {
-			panic(err)
-			return nil, err
+
+			return nil, queryError(err, "leagues")
 		}
 		leagues = append(leagues, league)
 	}
 
+	if err = rows.Err(); err != nil {
+
+		return nil, queryError(err, "leagues")
+	}
+
 	return leagues, nil
 }
 
-func (lr *leagueRepository) DeleteLeague(id string) error {
+func (lr *leagueRepository) GetLeagueName(ctx context.Context, id string) (string, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT name FROM league WHERE leagueId = ?`
+	row := conn(ctx, lr.db).QueryRowContext(ctx, query, id)
+
+	var name string
+	if err := row.Scan(&name); err != nil {
+
+		return "", queryError(err, "league %s", id)
+	}
+
+	return name, nil
+}
+
+func (lr *leagueRepository) GetLeagueRules(ctx context.Context, id string) (models.LeagueRules, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT rules FROM league WHERE leagueId = ?`
+	row := conn(ctx, lr.db).QueryRowContext(ctx, query, id)
+
+	var rulesJson sql.NullString
+	err := row.Scan(&rulesJson)
+
+	if err != nil {
+
+		return models.LeagueRules{}, queryError(err, "league %s", id)
+	}
+
+	return decodeRules(id, rulesJson)
+}
+
+// GetLeaguesRules loads the rules of several leagues in one query. Leagues
+// that do not exist are left out of the map.
+func (lr *leagueRepository) GetLeaguesRules(ctx context.Context, ids []string) (map[string]models.LeagueRules, error) {
+	rules := make(map[string]models.LeagueRules, len(ids))
+	if len(ids) == 0 {
+		return rules, nil
+	}
+
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`SELECT leagueId, rules FROM league WHERE leagueId IN (%s)`, placeholders)
+	rows, err := conn(ctx, lr.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, queryError(err, "leagues %s", strings.Join(ids, ", "))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var rulesJson sql.NullString
+		if err := rows.Scan(&id, &rulesJson); err != nil {
+			return nil, queryError(err, "leagues %s", strings.Join(ids, ", "))
+		}
+
+		if rules[id], err = decodeRules(id, rulesJson); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, queryError(err, "leagues %s", strings.Join(ids, ", "))
+	}
+
+	return rules, nil
+}
+
+// decodeRules reads a league's stored rules. Leagues created before rules were
+// configurable have none and play by the defaults.
+func decodeRules(id string, rulesJson sql.NullString) (models.LeagueRules, error) {
+	if !rulesJson.Valid || rulesJson.String == "" {
+
+		return models.DefaultLeagueRules(), nil
+	}
+
+	rules, err := utils.StringToStruct[models.LeagueRules](rulesJson.String)
+
+	if err != nil {
+
+		return models.LeagueRules{}, decodeError(err, "rules of league %s", id)
+	}
+
+	return rules, nil
+}
+
+func (lr *leagueRepository) DeleteLeague(ctx context.Context, id string) error {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
 	query := `DELETE FROM league WHERE leagueId = ?`
 
-	_, err := lr.db.Exec(query, id)
+	_, err := conn(ctx, lr.db).ExecContext(ctx, query, id)
+
+	if err != nil {
+
+		return writeError(err, "league %s", id)
+	}
+
+	return nil
+}
+
+// SetLeagueParent records that the league id was forked from parentId.
+func (lr *leagueRepository) SetLeagueParent(ctx context.Context, id string, parentId string) error {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `UPDATE league SET parentId = ? WHERE leagueId = ?`
+
+	_, err := conn(ctx, lr.db).ExecContext(ctx, query, nullString(parentId), id)
+
+	if err != nil {
+
+		return writeError(err, "parent of league %s", id)
+	}
+
+	return nil
+}
+
+// GetLeagueParent returns the league id was forked from, or an empty string
+// when it is not a fork or its parent was deleted.
+func (lr *leagueRepository) GetLeagueParent(ctx context.Context, id string) (string, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT parentId FROM league WHERE leagueId = ?`
+
+	var parentId sql.NullString
+	if err := conn(ctx, lr.db).QueryRowContext(ctx, query, id).Scan(&parentId); err != nil {
+
+		return "", queryError(err, "league %s", id)
+	}
+
+	return parentId.String, nil
+}
+
+// CountOwnedLeagues returns how many leagues ownerId owns.
+func (lr *leagueRepository) CountOwnedLeagues(ctx context.Context, ownerId string) (int, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT COUNT(*) FROM league WHERE ownerId = ?`
+
+	var count int
+	err := conn(ctx, lr.db).QueryRowContext(ctx, query, ownerId).Scan(&count)
+
+	if err != nil {
+
+		return 0, queryError(err, "leagues of %s", ownerId)
+	}
+
+	return count, nil
+}
+
+// AdoptUnownedLeagues makes ownerId the owner of every league that has none,
+// the leagues created before user accounts, and returns how many it adopted.
+func (lr *leagueRepository) AdoptUnownedLeagues(ctx context.Context, ownerId string) (int, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `UPDATE league SET ownerId = ? WHERE ownerId IS NULL`
+
+	result, err := conn(ctx, lr.db).ExecContext(ctx, query, ownerId)
+
+	if err != nil {
+
+		return 0, writeError(err, "leagues without an owner")
+	}
+
+	adopted, err := result.RowsAffected()
+
+	if err != nil {
+
+		return 0, writeError(err, "leagues without an owner")
+	}
+
+	return int(adopted), nil
+}
+
+// GetMemberRole returns the role userId holds in the league, or an empty string
+// when they hold none. A missing league is NotFound.
+func (lr *leagueRepository) GetMemberRole(ctx context.Context, leagueId string, userId string) (string, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT l.ownerId, m.role FROM league l
+		LEFT JOIN league_members m ON m.leagueId = l.leagueId AND m.userId = ?
+		WHERE l.leagueId = ?`
+
+	var ownerId, memberRole sql.NullString
+	err := conn(ctx, lr.db).QueryRowContext(ctx, query, userId, leagueId).Scan(&ownerId, &memberRole)
+
+	if err != nil {
+
+		return "", queryError(err, "league %s", leagueId)
+	}
+
+	switch {
+	case ownerId.Valid && ownerId.String == userId:
+		return models.RoleOwner, nil
+	case memberRole.Valid:
+		return memberRole.String, nil
+	}
+
+	return "", nil
+}
+
+func (lr *leagueRepository) GetLeagueMembers(ctx context.Context, leagueId string) ([]models.LeagueMember, error) {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT u.userId, u.username, ? FROM league l JOIN users u ON u.userId = l.ownerId WHERE l.leagueId = ?
+		UNION ALL
+		SELECT u.userId, u.username, m.role FROM league_members m JOIN users u ON u.userId = m.userId WHERE m.leagueId = ?`
+
+	rows, err := conn(ctx, lr.db).QueryContext(ctx, query, models.RoleOwner, leagueId, leagueId)
+
+	if err != nil {
+
+		return nil, queryError(err, "members of league %s", leagueId)
+	}
+	defer rows.Close()
+
+	members := []models.LeagueMember{}
+
+	for rows.Next() {
+		var member models.LeagueMember
+		if err := rows.Scan(&member.UserId, &member.Username, &member.Role); err != nil {
+
+			return nil, queryError(err, "members of league %s", leagueId)
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+
+		return nil, queryError(err, "members of league %s", leagueId)
+	}
+
+	return members, nil
+}
+
+func (lr *leagueRepository) AddLeagueMember(ctx context.Context, leagueId string, userId string, role string) error {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO league_members (leagueId, userId, role) VALUES (?,?,?)`
+
+	_, err := conn(ctx, lr.db).ExecContext(ctx, query, leagueId, userId, role)
+
+	if isDuplicateKey(err) {
+
+		return apperrors.Conflict("user %s is already a member of league %s", userId, leagueId)
+	}
+
+	if err != nil {
+
+		return writeError(err, "member %s of league %s", userId, leagueId)
+	}
+
+	return nil
+}
+
+func (lr *leagueRepository) RemoveLeagueMember(ctx context.Context, leagueId string, userId string) error {
+	ctx, cancel := withTimeout(ctx, lr.queryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM league_members WHERE leagueId = ? AND userId = ?`
+
+	result, err := conn(ctx, lr.db).ExecContext(ctx, query, leagueId, userId)
+
+	if err != nil {
+
+		return writeError(err, "member %s of league %s", userId, leagueId)
+	}
+
+	affected, err := result.RowsAffected()
 
 	if err != nil {
-		panic(err)
 
-		return err
+		return writeError(err, "member %s of league %s", userId, leagueId)
+	}
+
+	if affected == 0 {
+
+		return apperrors.NotFound("user %s is not a member of league %s", userId, leagueId)
 	}
 
 	return nil
@@ -1,22 +1,30 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
+	"league-sim/utils"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 
+// testQueryTimeout is the per-operation deadline handed to repositories under test
+const testQueryTimeout = time.Second
+
 func TestNewLeagueRepository(t *testing.T) {
 	db, _, err := sqlmock.New()
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 	assert.NotNil(t, repo)
 	assert.Implements(t, (*interfaces.LeagueRepository)(nil), repo)
 }
@@ -26,7 +34,7 @@ func TestLeagueRepository_SetLeague_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -36,12 +44,12 @@ func TestLeagueRepository_SetLeague_Success(t *testing.T) {
 	}
 
 	// Mock expectations
-	mock.ExpectExec("INSERT INTO league \\(leagueId, name\\) VALUES \\(\\?,\\?\\)").
-		WithArgs(leagueId, request.LeagueName).
+	mock.ExpectExec("INSERT INTO league \\(leagueId, name, ownerId, rules\\) VALUES \\(\\?,\\?,\\?,\\?\\)").
+		WithArgs(leagueId, request.LeagueName, "owner-id", sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Execute
-	err = repo.SetLeague(leagueId, request)
+	err = repo.SetLeague(context.Background(), leagueId, "owner-id", request)
 
 	// Assert
 	assert.NoError(t, err)
@@ -53,7 +61,7 @@ func TestLeagueRepository_SetLeague_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -64,16 +72,268 @@ func TestLeagueRepository_SetLeague_DatabaseError(t *testing.T) {
 
 	// Mock expectations
 	expectedError := errors.New("database connection failed")
-	mock.ExpectExec("INSERT INTO league \\(leagueId, name\\) VALUES \\(\\?,\\?\\)").
-		WithArgs(leagueId, request.LeagueName).
+	mock.ExpectExec("INSERT INTO league \\(leagueId, name, ownerId, rules\\) VALUES \\(\\?,\\?,\\?,\\?\\)").
+		WithArgs(leagueId, request.LeagueName, "owner-id", sqlmock.AnyArg()).
 		WillReturnError(expectedError)
 
 	// Execute
-	err = repo.SetLeague(leagueId, request)
+	err = repo.SetLeague(context.Background(), leagueId, "owner-id", request)
+
+	// Assert
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_SetLeague_StoresRules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Test data
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 2
+	request := models.CreateLeagueRequest{LeagueName: "Test League", Rules: &rules}
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO league \\(leagueId, name, ownerId, rules\\) VALUES \\(\\?,\\?,\\?,\\?\\)").
+		WithArgs("test-league-id", request.LeagueName, nil, utils.StructToString[models.LeagueRules](rules)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Execute
+	err = repo.SetLeague(context.Background(), "test-league-id", "", request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueName_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT name FROM league WHERE leagueId = \\?").
+		WithArgs("test-league-id").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Premier"))
+
+	// Execute
+	name, err := repo.GetLeagueName(context.Background(), "test-league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Premier", name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueName_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT name FROM league WHERE leagueId = \\?").
+		WithArgs("missing-league-id").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+
+	// Execute
+	_, err = repo.GetLeagueName(context.Background(), "missing-league-id")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_SetLeague_DuplicateID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO league").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'league-id'"})
+
+	// Execute
+	err = repo.SetLeague(context.Background(), "league-id", "owner-id", models.CreateLeagueRequest{LeagueName: "Premier"})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_SetLeagueParent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectExec("UPDATE league SET parentId = \\? WHERE leagueId = \\?").
+		WithArgs("parent-id", "fork-id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execute
+	err = repo.SetLeagueParent(context.Background(), "fork-id", "parent-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueParent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT parentId FROM league WHERE leagueId = \\?").
+		WithArgs("fork-id").
+		WillReturnRows(sqlmock.NewRows([]string{"parentId"}).AddRow("parent-id"))
+	mock.ExpectQuery("SELECT parentId FROM league WHERE leagueId = \\?").
+		WithArgs("root-id").
+		WillReturnRows(sqlmock.NewRows([]string{"parentId"}).AddRow(nil))
+	mock.ExpectQuery("SELECT parentId FROM league WHERE leagueId = \\?").
+		WithArgs("missing-id").
+		WillReturnRows(sqlmock.NewRows([]string{"parentId"}))
+
+	// Execute
+	parentId, err := repo.GetLeagueParent(context.Background(), "fork-id")
+	rootParent, rootErr := repo.GetLeagueParent(context.Background(), "root-id")
+	_, missingErr := repo.GetLeagueParent(context.Background(), "missing-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "parent-id", parentId)
+	assert.NoError(t, rootErr)
+	assert.Empty(t, rootParent)
+	assert.True(t, apperrors.Is(missingErr, apperrors.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueRules_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Test data
+	rules := models.DefaultLeagueRules()
+	rules.HomeAdvantage = 1.2
+	rules.BonusPoints = models.BonusPoints{GoalThreshold: 4, Points: 1}
+
+	// Mock expectations
+	rows := sqlmock.NewRows([]string{"rules"}).AddRow(utils.StructToString[models.LeagueRules](rules))
+	mock.ExpectQuery("SELECT rules FROM league WHERE leagueId = \\?").
+		WithArgs("test-league-id").
+		WillReturnRows(rows)
+
+	// Execute
+	result, err := repo.GetLeagueRules(context.Background(), "test-league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, rules, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueRules_NullFallsBackToDefaults(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	rows := sqlmock.NewRows([]string{"rules"}).AddRow(nil)
+	mock.ExpectQuery("SELECT rules FROM league WHERE leagueId = \\?").
+		WithArgs("legacy-league-id").
+		WillReturnRows(rows)
+
+	// Execute
+	result, err := repo.GetLeagueRules(context.Background(), "legacy-league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultLeagueRules(), result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueRules_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT rules FROM league WHERE leagueId = \\?").
+		WithArgs("missing-league-id").
+		WillReturnRows(sqlmock.NewRows([]string{"rules"}))
+
+	// Execute
+	_, err = repo.GetLeagueRules(context.Background(), "missing-league-id")
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeaguesRules_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Test data
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 2
+
+	// Mock expectations
+	rows := sqlmock.NewRows([]string{"leagueId", "rules"}).
+		AddRow("league-1", utils.StructToString[models.LeagueRules](rules)).
+		AddRow("league-2", nil)
+	mock.ExpectQuery("SELECT leagueId, rules FROM league WHERE leagueId IN \\(\\?,\\?,\\?\\)").
+		WithArgs("league-1", "league-2", "league-3").
+		WillReturnRows(rows)
+
+	// Execute
+	result, err := repo.GetLeaguesRules(context.Background(), []string{"league-1", "league-2", "league-3"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, map[string]models.LeagueRules{"league-1": rules, "league-2": models.DefaultLeagueRules()}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeaguesRules_NoIds(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Execute
+	result, err := repo.GetLeaguesRules(context.Background(), nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -82,7 +342,7 @@ func TestLeagueRepository_GetLeague_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	expectedLeagues := []models.GetLeaguesIdsWithNameResponse{
@@ -96,10 +356,11 @@ func TestLeagueRepository_GetLeague_Success(t *testing.T) {
 		AddRow("league2", "League 2")
 
 	mock.ExpectQuery("SELECT leagueId, name FROM league").
+		WithArgs("user-id", "user-id").
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetLeague()
+	result, err := repo.GetLeague(context.Background(), "user-id")
 
 	// Assert
 	assert.NoError(t, err)
@@ -113,15 +374,16 @@ func TestLeagueRepository_GetLeague_EmptyResult(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Mock expectations
 	rows := sqlmock.NewRows([]string{"leagueId", "name"})
 	mock.ExpectQuery("SELECT leagueId, name FROM league").
+		WithArgs("user-id", "user-id").
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetLeague()
+	result, err := repo.GetLeague(context.Background(), "user-id")
 
 	// Assert
 	assert.NoError(t, err)
@@ -134,17 +396,19 @@ func TestLeagueRepository_GetLeague_QueryError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Mock expectations
 	expectedError := errors.New("query failed")
 	mock.ExpectQuery("SELECT leagueId, name FROM league").
 		WillReturnError(expectedError)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		repo.GetLeague()
-	}, "Should panic when query fails")
+	// Execute
+	_, err = repo.GetLeague(context.Background(), "user-id")
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -154,19 +418,22 @@ func TestLeagueRepository_GetLeague_ScanError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Mock expectations - invalid data type for scan
 	rows := sqlmock.NewRows([]string{"leagueId", "name"}).
 		AddRow(123, nil) // Invalid types that will cause scan error
 
 	mock.ExpectQuery("SELECT leagueId, name FROM league").
+		WithArgs("user-id", "user-id").
 		WillReturnRows(rows)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		repo.GetLeague()
-	}, "Should panic when scan fails")
+	// Execute
+	_, err = repo.GetLeague(context.Background(), "user-id")
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -176,7 +443,7 @@ func TestLeagueRepository_DeleteLeague_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -187,7 +454,7 @@ func TestLeagueRepository_DeleteLeague_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// Execute
-	err = repo.DeleteLeague(leagueId)
+	err = repo.DeleteLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -199,7 +466,7 @@ func TestLeagueRepository_DeleteLeague_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -210,10 +477,12 @@ func TestLeagueRepository_DeleteLeague_DatabaseError(t *testing.T) {
 		WithArgs(leagueId).
 		WillReturnError(expectedError)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		repo.DeleteLeague(leagueId)
-	}, "Should panic when delete fails")
+	// Execute
+	err = repo.DeleteLeague(context.Background(), leagueId)
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -223,7 +492,7 @@ func TestLeagueRepository_DeleteLeague_NoRowsAffected(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "non-existent-league"
@@ -234,13 +503,208 @@ func TestLeagueRepository_DeleteLeague_NoRowsAffected(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
 	// Execute
-	err = repo.DeleteLeague(leagueId)
+	err = repo.DeleteLeague(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err) // Should not error even if no rows affected
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestLeagueRepository_CountOwnedLeagues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM league WHERE ownerId = \\?").
+		WithArgs("user-id").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	// Execute
+	count, err := repo.CountOwnedLeagues(context.Background(), "user-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_AdoptUnownedLeagues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectExec("UPDATE league SET ownerId = \\? WHERE ownerId IS NULL").
+		WithArgs("user-id").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	// Execute
+	adopted, err := repo.AdoptUnownedLeagues(context.Background(), "user-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, adopted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetMemberRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownerId    any
+		memberRole any
+		expected   string
+	}{
+		{name: "owner", ownerId: "user-id", memberRole: nil, expected: models.RoleOwner},
+		{name: "editor", ownerId: "other-id", memberRole: models.RoleEditor, expected: models.RoleEditor},
+		{name: "viewer", ownerId: "other-id", memberRole: models.RoleViewer, expected: models.RoleViewer},
+		{name: "no access", ownerId: "other-id", memberRole: nil, expected: ""},
+		{name: "unowned league", ownerId: nil, memberRole: nil, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				db, mock, err := sqlmock.New()
+				assert.NoError(t, err)
+				defer db.Close()
+
+				repo := NewLeagueRepository(db, testQueryTimeout)
+
+				// Mock expectations
+				rows := sqlmock.NewRows([]string{"ownerId", "role"}).AddRow(tt.ownerId, tt.memberRole)
+				mock.ExpectQuery("SELECT l.ownerId, m.role FROM league l").
+					WithArgs("user-id", "league-id").
+					WillReturnRows(rows)
+
+				// Execute
+				role, err := repo.GetMemberRole(context.Background(), "league-id", "user-id")
+
+				// Assert
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, role)
+				assert.NoError(t, mock.ExpectationsWereMet())
+			})
+	}
+}
+
+func TestLeagueRepository_GetMemberRole_LeagueNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT l.ownerId, m.role FROM league l").
+		WithArgs("user-id", "missing-league").
+		WillReturnRows(sqlmock.NewRows([]string{"ownerId", "role"}))
+
+	// Execute
+	_, err = repo.GetMemberRole(context.Background(), "missing-league", "user-id")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_GetLeagueMembers_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	rows := sqlmock.NewRows([]string{"userId", "username", "role"}).
+		AddRow("owner-id", "alice", models.RoleOwner).
+		AddRow("member-id", "bob", models.RoleViewer)
+	mock.ExpectQuery("SELECT u.userId, u.username, \\? FROM league l").
+		WithArgs(models.RoleOwner, "league-id", "league-id").
+		WillReturnRows(rows)
+
+	// Execute
+	members, err := repo.GetLeagueMembers(context.Background(), "league-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, []models.LeagueMember{
+			{UserId: "owner-id", Username: "alice", Role: models.RoleOwner},
+			{UserId: "member-id", Username: "bob", Role: models.RoleViewer},
+		}, members)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_AddLeagueMember_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO league_members \\(leagueId, userId, role\\) VALUES \\(\\?,\\?,\\?\\)").
+		WithArgs("league-id", "user-id", models.RoleEditor).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Execute
+	err = repo.AddLeagueMember(context.Background(), "league-id", "user-id", models.RoleEditor)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_AddLeagueMember_AlreadyMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO league_members").
+		WithArgs("league-id", "user-id", models.RoleViewer).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+
+	// Execute
+	err = repo.AddLeagueMember(context.Background(), "league-id", "user-id", models.RoleViewer)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLeagueRepository_RemoveLeagueMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewLeagueRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectExec("DELETE FROM league_members WHERE leagueId = \\? AND userId = \\?").
+		WithArgs("league-id", "user-id").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM league_members WHERE leagueId = \\? AND userId = \\?").
+		WithArgs("league-id", "stranger-id").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execute
+	removeErr := repo.RemoveLeagueMember(context.Background(), "league-id", "user-id")
+	missingErr := repo.RemoveLeagueMember(context.Background(), "league-id", "stranger-id")
+
+	// Assert
+	assert.NoError(t, removeErr)
+	assert.True(t, apperrors.Is(missingErr, apperrors.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // Benchmark tests
 func BenchmarkLeagueRepository_SetLeague(b *testing.B) {
 	db, mock, err := sqlmock.New()
@@ -249,18 +713,18 @@ func BenchmarkLeagueRepository_SetLeague(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 	request := models.CreateLeagueRequest{
 		LeagueName: "Benchmark League",
 		TeamCount:  "8",
 	}
 
 	for i := 0; i < b.N; i++ {
-		mock.ExpectExec("INSERT INTO league \\(leagueId, name\\) VALUES \\(\\?,\\?\\)").
-			WithArgs(sqlmock.AnyArg(), request.LeagueName).
+		mock.ExpectExec("INSERT INTO league \\(leagueId, name, ownerId, rules\\) VALUES \\(\\?,\\?,\\?,\\?\\)").
+			WithArgs(sqlmock.AnyArg(), request.LeagueName, "owner-id", sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repo.SetLeague("benchmark-league", request)
+		repo.SetLeague(context.Background(), "benchmark-league", "owner-id", request)
 	}
 }
 
@@ -271,7 +735,7 @@ func BenchmarkLeagueRepository_GetLeague(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	for i := 0; i < b.N; i++ {
 		rows := sqlmock.NewRows([]string{"leagueId", "name"}).
@@ -279,9 +743,10 @@ func BenchmarkLeagueRepository_GetLeague(b *testing.B) {
 			AddRow("league2", "League 2")
 
 		mock.ExpectQuery("SELECT leagueId, name FROM league").
+			WithArgs("user-id", "user-id").
 			WillReturnRows(rows)
 
-		repo.GetLeague()
+		repo.GetLeague(context.Background(), "user-id")
 	}
 }
 
@@ -292,13 +757,13 @@ func BenchmarkLeagueRepository_DeleteLeague(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewLeagueRepository(db)
+	repo := NewLeagueRepository(db, testQueryTimeout)
 
 	for i := 0; i < b.N; i++ {
 		mock.ExpectExec("DELETE FROM league WHERE leagueId = \\?").
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		repo.DeleteLeague("benchmark-league")
+		repo.DeleteLeague(context.Background(), "benchmark-league")
 	}
 }
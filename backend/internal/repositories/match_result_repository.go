@@ -1,28 +1,33 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
 	"strings"
+	"time"
 )
 
 type matchResultRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
 }
 
-func NewMatchResultRepository(db *sql.DB) interfaces.MatchResultRepository {
-	return &matchResultRepository{db: db}
+func NewMatchResultRepository(db *sql.DB, queryTimeout time.Duration) interfaces.MatchResultRepository {
+	return &matchResultRepository{db: db, queryTimeout: queryTimeout}
 }
 
-func (mrr *matchResultRepository) GetMatchResults(leagueId string) ([]models.MatchResult, error) {
+func (mrr *matchResultRepository) GetMatchResults(ctx context.Context, leagueId string) ([]models.MatchResult, error) {
+	ctx, cancel := withTimeout(ctx, mrr.queryTimeout)
+	defer cancel()
+
 	query := `SELECT homeTeam, homeGoals, awayTeam, awayGoals, winnerName, matchWeek FROM match_results WHERE leagueId = ? ORDER BY matchWeek`
 
-	rows, err := mrr.db.Query(query, leagueId)
+	rows, err := conn(ctx, mrr.db).QueryContext(ctx, query, leagueId)
 	if err != nil {
-		return nil, err
+		return nil, queryError(err, "match results of league %s", leagueId)
 	}
 	defer rows.Close()
 
@@ -39,19 +44,77 @@ func (mrr *matchResultRepository) GetMatchResults(leagueId string) ([]models.Mat
 			&mr.MatchWeek,
 		)
 		if err != nil {
-			return nil, err
+			return nil, queryError(err, "match results of league %s", leagueId)
 		}
 		results = append(results, mr)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, err
+		return nil, queryError(err, "match results of league %s", leagueId)
 	}
 
 	return results, nil
 }
 
-func (mrr *matchResultRepository) SetMatchResults(leagueId string, matchResults []models.MatchResult) error {
+// GetMatchResultsByLeague loads the results of several leagues in one query,
+// keyed by league.
+func (mrr *matchResultRepository) GetMatchResultsByLeague(ctx context.Context, leagueIds []string) (
+	map[string][]models.MatchResult, error,
+) {
+	results := make(map[string][]models.MatchResult, len(leagueIds))
+	if len(leagueIds) == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := withTimeout(ctx, mrr.queryTimeout)
+	defer cancel()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(leagueIds)), ",")
+	args := make([]interface{}, 0, len(leagueIds))
+	for _, id := range leagueIds {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT leagueId, homeTeam, homeGoals, awayTeam, awayGoals, winnerName, matchWeek FROM match_results WHERE leagueId IN (%s) ORDER BY matchWeek",
+		placeholders,
+	)
+
+	rows, err := conn(ctx, mrr.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, queryError(err, "match results of leagues %s", strings.Join(leagueIds, ", "))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var leagueId string
+		var mr models.MatchResult
+		err := rows.Scan(
+			&leagueId,
+			&mr.Home,
+			&mr.HomeScore,
+			&mr.Away,
+			&mr.AwayScore,
+			&mr.Winner,
+			&mr.MatchWeek,
+		)
+		if err != nil {
+			return nil, queryError(err, "match results of leagues %s", strings.Join(leagueIds, ", "))
+		}
+		results[leagueId] = append(results[leagueId], mr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, queryError(err, "match results of leagues %s", strings.Join(leagueIds, ", "))
+	}
+
+	return results, nil
+}
+
+func (mrr *matchResultRepository) SetMatchResults(ctx context.Context, leagueId string, matchResults []models.MatchResult) error {
+	ctx, cancel := withTimeout(ctx, mrr.queryTimeout)
+	defer cancel()
+
 	if len(matchResults) == 0 {
 		return nil
 	}
@@ -78,17 +141,21 @@ func (mrr *matchResultRepository) SetMatchResults(leagueId string, matchResults
 		strings.Join(placeholders, ","),
 	)
 
-	_, err := mrr.db.Exec(query, args...)
+	_, err := conn(ctx, mrr.db).ExecContext(ctx, query, args...)
 	if err != nil {
 
-		return err
+		return writeError(err, "match results of league %s", leagueId)
 	}
 	return nil
 }
 
-func (mrr *matchResultRepository) EditMatchScore(data models.EditMatchResult) error {
+func (mrr *matchResultRepository) EditMatchScore(ctx context.Context, data models.EditMatchResult) error {
+	ctx, cancel := withTimeout(ctx, mrr.queryTimeout)
+	defer cancel()
+
 	query := `UPDATE match_results SET homeGoals = ?, awayGoals = ?, winnerName = ? WHERE leagueId = ? AND matchWeek = ? AND homeTeam = ? AND awayTeam = ?`
-	_, err := mrr.db.Exec(
+	_, err := conn(ctx, mrr.db).ExecContext(
+		ctx,
 		query,
 		data.HomeScore,
 		data.AwayScore,
@@ -98,27 +165,31 @@ func (mrr *matchResultRepository) EditMatchScore(data models.EditMatchResult) er
 		data.Home,
 		data.Away)
 	if err != nil {
-		fmt.Println("Error updating match score:", err)
-		return err
+		return writeError(err, "match score of league %s", data.LeagueId)
 	}
 	return nil
 }
 
-func (mrr *matchResultRepository) DeleteMatchResults(leagueId string) error {
+func (mrr *matchResultRepository) DeleteMatchResults(ctx context.Context, leagueId string) error {
+	ctx, cancel := withTimeout(ctx, mrr.queryTimeout)
+	defer cancel()
+
 	query := `DELETE FROM match_results WHERE leagueId = ?`
 
-	_, err := mrr.db.Exec(query, leagueId)
+	_, err := conn(ctx, mrr.db).ExecContext(ctx, query, leagueId)
 
 	if err != nil {
 
-		return err
+		return writeError(err, "match results of league %s", leagueId)
 	}
 
 	return nil
 }
 
-func (mmr *matchResultRepository) GetMatchResultByWeekAndTeam(data models.EditMatchResult) (
+func (mmr *matchResultRepository) GetMatchResultByWeekAndTeam(ctx context.Context, data models.EditMatchResult) (
 	models.MatchResult, error) {
+	ctx, cancel := withTimeout(ctx, mmr.queryTimeout)
+	defer cancel()
 
 	query := `
 		SELECT homeTeam, homeGoals, awayTeam, awayGoals, winnerName, matchWeek
@@ -126,7 +197,7 @@ func (mmr *matchResultRepository) GetMatchResultByWeekAndTeam(data models.EditMa
 		WHERE leagueId = ? AND matchWeek = ? AND homeTeam = ? AND awayTeam = ?
 	`
 
-	row := mmr.db.QueryRow(query, data.LeagueId, data.MatchWeek, data.Home, data.Away)
+	row := conn(ctx, mmr.db).QueryRowContext(ctx, query, data.LeagueId, data.MatchWeek, data.Home, data.Away)
 
 	var queryData models.MatchResult
 	err := row.Scan(
@@ -137,12 +208,8 @@ func (mmr *matchResultRepository) GetMatchResultByWeekAndTeam(data models.EditMa
 		&queryData.Winner,
 		&queryData.MatchWeek)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			fmt.Println("No match found for the given week and teams")
-			return models.MatchResult{}, err
-		}
-		fmt.Println("Error scanning match result:", err)
-		return models.MatchResult{}, err
+		return models.MatchResult{}, queryError(
+			err, "match %s vs %s in week %d of league %s", data.Home, data.Away, data.MatchWeek, data.LeagueId)
 	}
 
 	return queryData, nil
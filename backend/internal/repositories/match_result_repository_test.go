@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	"league-sim/internal/apperrors"
 	"league-sim/internal/models"
 	"league-sim/internal/repositories/interfaces"
 
@@ -16,7 +18,7 @@ func TestNewMatchResultRepository(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 	assert.NotNil(t, repo)
 	assert.Implements(t, (*interfaces.MatchResultRepository)(nil), repo)
 }
@@ -26,7 +28,7 @@ func TestMatchResultRepository_GetMatchResults_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -59,7 +61,7 @@ func TestMatchResultRepository_GetMatchResults_Success(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetMatchResults(leagueId)
+	result, err := repo.GetMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -73,7 +75,7 @@ func TestMatchResultRepository_GetMatchResults_EmptyResult(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "empty-league-id"
@@ -86,7 +88,7 @@ func TestMatchResultRepository_GetMatchResults_EmptyResult(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetMatchResults(leagueId)
+	result, err := repo.GetMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -99,7 +101,7 @@ func TestMatchResultRepository_GetMatchResults_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -111,11 +113,12 @@ func TestMatchResultRepository_GetMatchResults_DatabaseError(t *testing.T) {
 		WillReturnError(expectedError)
 
 	// Execute
-	result, err := repo.GetMatchResults(leagueId)
+	result, err := repo.GetMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 	assert.Nil(t, result)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -125,7 +128,7 @@ func TestMatchResultRepository_GetMatchResults_ScanError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -139,7 +142,7 @@ func TestMatchResultRepository_GetMatchResults_ScanError(t *testing.T) {
 		WillReturnRows(rows)
 
 	// Execute
-	result, err := repo.GetMatchResults(leagueId)
+	result, err := repo.GetMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
@@ -152,7 +155,7 @@ func TestMatchResultRepository_SetMatchResults_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -184,7 +187,7 @@ func TestMatchResultRepository_SetMatchResults_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 2))
 
 	// Execute
-	err = repo.SetMatchResults(leagueId, matchResults)
+	err = repo.SetMatchResults(context.Background(), leagueId, matchResults)
 
 	// Assert
 	assert.NoError(t, err)
@@ -196,14 +199,14 @@ func TestMatchResultRepository_SetMatchResults_EmptyResults(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
 	matchResults := []models.MatchResult{}
 
 	// Execute
-	err = repo.SetMatchResults(leagueId, matchResults)
+	err = repo.SetMatchResults(context.Background(), leagueId, matchResults)
 
 	// Assert
 	assert.NoError(t, err) // Should return nil for empty results
@@ -214,7 +217,7 @@ func TestMatchResultRepository_SetMatchResults_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -236,11 +239,12 @@ func TestMatchResultRepository_SetMatchResults_DatabaseError(t *testing.T) {
 		WillReturnError(expectedError)
 
 	// Execute
-	err = repo.SetMatchResults(leagueId, matchResults)
+	err = repo.SetMatchResults(context.Background(), leagueId, matchResults)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -249,7 +253,7 @@ func TestMatchResultRepository_EditMatchScore_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	editData := models.EditMatchResult{
@@ -268,7 +272,7 @@ func TestMatchResultRepository_EditMatchScore_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// Execute
-	err = repo.EditMatchScore(editData)
+	err = repo.EditMatchScore(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -280,7 +284,7 @@ func TestMatchResultRepository_EditMatchScore_AwayTeam(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	editData := models.EditMatchResult{
@@ -299,7 +303,7 @@ func TestMatchResultRepository_EditMatchScore_AwayTeam(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// Execute
-	err = repo.EditMatchScore(editData)
+	err = repo.EditMatchScore(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -311,7 +315,7 @@ func TestMatchResultRepository_EditMatchScore_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	editData := models.EditMatchResult{
@@ -331,11 +335,12 @@ func TestMatchResultRepository_EditMatchScore_DatabaseError(t *testing.T) {
 		WillReturnError(expectedError)
 
 	// Execute
-	err = repo.EditMatchScore(editData)
+	err = repo.EditMatchScore(context.Background(), editData)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -344,7 +349,7 @@ func TestMatchResultRepository_DeleteMatchResults_Success(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -355,7 +360,7 @@ func TestMatchResultRepository_DeleteMatchResults_Success(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 5)) // 5 rows deleted
 
 	// Execute
-	err = repo.DeleteMatchResults(leagueId)
+	err = repo.DeleteMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err)
@@ -367,7 +372,7 @@ func TestMatchResultRepository_DeleteMatchResults_NoRowsAffected(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "non-existent-league"
@@ -378,7 +383,7 @@ func TestMatchResultRepository_DeleteMatchResults_NoRowsAffected(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(0, 0)) // No rows deleted
 
 	// Execute
-	err = repo.DeleteMatchResults(leagueId)
+	err = repo.DeleteMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.NoError(t, err) // Should not error even if no rows affected
@@ -390,7 +395,7 @@ func TestMatchResultRepository_DeleteMatchResults_DatabaseError(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -402,11 +407,12 @@ func TestMatchResultRepository_DeleteMatchResults_DatabaseError(t *testing.T) {
 		WillReturnError(expectedError)
 
 	// Execute
-	err = repo.DeleteMatchResults(leagueId)
+	err = repo.DeleteMatchResults(context.Background(), leagueId)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, expectedError, err)
+	assert.ErrorIs(t, err, expectedError)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -415,7 +421,7 @@ func TestMatchResultRepository_SetMatchResults_SingleResult(t *testing.T) {
 	assert.NoError(t, err)
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	// Test data
 	leagueId := "test-league-id"
@@ -436,7 +442,7 @@ func TestMatchResultRepository_SetMatchResults_SingleResult(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Execute
-	err = repo.SetMatchResults(leagueId, matchResults)
+	err = repo.SetMatchResults(context.Background(), leagueId, matchResults)
 
 	// Assert
 	assert.NoError(t, err)
@@ -451,7 +457,7 @@ func BenchmarkMatchResultRepository_GetMatchResults(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	for i := 0; i < b.N; i++ {
 		rows := sqlmock.NewRows([]string{"homeTeam", "homeGoals", "awayTeam", "awayGoals", "winnerName", "matchWeek"}).
@@ -461,7 +467,7 @@ func BenchmarkMatchResultRepository_GetMatchResults(b *testing.B) {
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnRows(rows)
 
-		repo.GetMatchResults("benchmark-league")
+		repo.GetMatchResults(context.Background(), "benchmark-league")
 	}
 }
 
@@ -472,7 +478,7 @@ func BenchmarkMatchResultRepository_SetMatchResults(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 	matchResults := []models.MatchResult{
 		{
 			Home:      "Team A",
@@ -489,7 +495,7 @@ func BenchmarkMatchResultRepository_SetMatchResults(b *testing.B) {
 			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		repo.SetMatchResults("benchmark-league", matchResults)
+		repo.SetMatchResults(context.Background(), "benchmark-league", matchResults)
 	}
 }
 
@@ -500,13 +506,70 @@ func BenchmarkMatchResultRepository_DeleteMatchResults(b *testing.B) {
 	}
 	defer db.Close()
 
-	repo := NewMatchResultRepository(db)
+	repo := NewMatchResultRepository(db, testQueryTimeout)
 
 	for i := 0; i < b.N; i++ {
 		mock.ExpectExec("DELETE FROM match_results WHERE leagueId = \\?").
 			WithArgs(sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		repo.DeleteMatchResults("benchmark-league")
+		repo.DeleteMatchResults(context.Background(), "benchmark-league")
 	}
 }
+
+func TestMatchResultRepository_GetMatchResultsByLeague_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMatchResultRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	rows := sqlmock.NewRows(
+		[]string{"leagueId", "homeTeam", "homeGoals", "awayTeam", "awayGoals", "winnerName", "matchWeek"}).
+		AddRow("league-1", "Team A", 2, "Team B", 1, "Team A", 1).
+		AddRow("league-2", "Team C", 0, "Team D", 0, "", 1).
+		AddRow("league-1", "Team B", 1, "Team A", 3, "Team A", 2)
+
+	mock.ExpectQuery("SELECT leagueId, homeTeam, homeGoals, awayTeam, awayGoals, winnerName, matchWeek FROM match_results WHERE leagueId IN \\(\\?,\\?\\) ORDER BY matchWeek").
+		WithArgs("league-1", "league-2").
+		WillReturnRows(rows)
+
+	// Execute
+	result, err := repo.GetMatchResultsByLeague(context.Background(), []string{"league-1", "league-2"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, map[string][]models.MatchResult{
+			"league-1": {
+				{Home: "Team A", HomeScore: 2, Away: "Team B", AwayScore: 1, Winner: "Team A", MatchWeek: 1},
+				{Home: "Team B", HomeScore: 1, Away: "Team A", AwayScore: 3, Winner: "Team A", MatchWeek: 2},
+			},
+			"league-2": {
+				{Home: "Team C", HomeScore: 0, Away: "Team D", AwayScore: 0, MatchWeek: 1},
+			},
+		}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMatchResultRepository_GetMatchResultsByLeague_DatabaseError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMatchResultRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT leagueId, homeTeam").
+		WithArgs("league-1").
+		WillReturnError(errors.New("database error"))
+
+	// Execute
+	result, err := repo.GetMatchResultsByLeague(context.Background(), []string{"league-1"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
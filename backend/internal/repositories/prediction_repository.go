@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+	"league-sim/utils"
+)
+
+type predictionRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewPredictionRepository(db *sql.DB, queryTimeout time.Duration) interfaces.PredictionRepository {
+	return &predictionRepository{db: db, queryTimeout: queryTimeout}
+}
+
+// SavePredictionSnapshot stores the prediction made before snapshot.Week,
+// replacing an earlier one of the same week.
+func (pr *predictionRepository) SavePredictionSnapshot(
+	ctx context.Context, leagueId string, snapshot models.PredictionSnapshot,
+) error {
+	ctx, cancel := withTimeout(ctx, pr.queryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO prediction_snapshots (leagueId, matchWeek, predictions) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE predictions = VALUES(predictions)`
+
+	predictions := utils.StructToString[[]models.PredictedStanding](snapshot.Predictions)
+	_, err := conn(ctx, pr.db).ExecContext(ctx, query, leagueId, snapshot.Week, predictions)
+	if err != nil {
+		return writeError(err, "prediction snapshot of league %s", leagueId)
+	}
+
+	return nil
+}
+
+// GetPredictionSnapshots returns the snapshots of a league by week.
+func (pr *predictionRepository) GetPredictionSnapshots(ctx context.Context, leagueId string) (
+	[]models.PredictionSnapshot, error,
+) {
+	ctx, cancel := withTimeout(ctx, pr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT matchWeek, predictions FROM prediction_snapshots WHERE leagueId = ? ORDER BY matchWeek`
+
+	rows, err := conn(ctx, pr.db).QueryContext(ctx, query, leagueId)
+	if err != nil {
+		return nil, queryError(err, "prediction snapshots of league %s", leagueId)
+	}
+	defer rows.Close()
+
+	snapshots := []models.PredictionSnapshot{}
+
+	for rows.Next() {
+		var snapshot models.PredictionSnapshot
+		var predictionsJson string
+		if err := rows.Scan(&snapshot.Week, &predictionsJson); err != nil {
+			return nil, queryError(err, "prediction snapshots of league %s", leagueId)
+		}
+
+		snapshot.Predictions, err = utils.StringToStruct[[]models.PredictedStanding](predictionsJson)
+		if err != nil {
+			return nil, decodeError(err, "prediction snapshot of week %d of league %s", snapshot.Week, leagueId)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, queryError(err, "prediction snapshots of league %s", leagueId)
+	}
+
+	return snapshots, nil
+}
+
+func (pr *predictionRepository) DeletePredictionSnapshots(ctx context.Context, leagueId string) error {
+	ctx, cancel := withTimeout(ctx, pr.queryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM prediction_snapshots WHERE leagueId = ?`
+
+	_, err := conn(ctx, pr.db).ExecContext(ctx, query, leagueId)
+	if err != nil {
+		return writeError(err, "prediction snapshots of league %s", leagueId)
+	}
+
+	return nil
+}
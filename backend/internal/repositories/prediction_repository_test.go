@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPredictionRepository(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPredictionRepository(db, testQueryTimeout)
+	assert.NotNil(t, repo)
+	assert.Implements(t, (*interfaces.PredictionRepository)(nil), repo)
+}
+
+func TestPredictionRepository_SavePredictionSnapshot_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPredictionRepository(db, testQueryTimeout)
+
+	// Setup
+	snapshot := models.PredictionSnapshot{
+		Week:        3,
+		Predictions: []models.PredictedStanding{{TeamName: "Team A", Odds: 60}},
+	}
+	mock.ExpectExec("INSERT INTO prediction_snapshots \\(leagueId, matchWeek, predictions\\) VALUES").
+		WithArgs("league-1", 3, `[{"team_name":"Team A","points":0,"strength":0,"odds":60,"eliminated":false}]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Execute
+	err = repo.SavePredictionSnapshot(context.Background(), "league-1", snapshot)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPredictionRepository_SavePredictionSnapshot_DatabaseError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPredictionRepository(db, testQueryTimeout)
+
+	// Setup
+	mock.ExpectExec("INSERT INTO prediction_snapshots").
+		WillReturnError(errors.New("connection lost"))
+
+	// Execute
+	err = repo.SavePredictionSnapshot(context.Background(), "league-1", models.PredictionSnapshot{Week: 1})
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPredictionRepository_GetPredictionSnapshots_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPredictionRepository(db, testQueryTimeout)
+
+	// Setup
+	rows := sqlmock.NewRows([]string{"matchWeek", "predictions"}).
+		AddRow(1, `[{"team_name":"Team A","odds":50}]`).
+		AddRow(2, `[{"team_name":"Team A","odds":70}]`)
+	mock.ExpectQuery("SELECT matchWeek, predictions FROM prediction_snapshots WHERE leagueId = \\? ORDER BY matchWeek").
+		WithArgs("league-1").
+		WillReturnRows(rows)
+
+	// Execute
+	snapshots, err := repo.GetPredictionSnapshots(context.Background(), "league-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, []models.PredictionSnapshot{
+			{Week: 1, Predictions: []models.PredictedStanding{{TeamName: "Team A", Odds: 50}}},
+			{Week: 2, Predictions: []models.PredictedStanding{{TeamName: "Team A", Odds: 70}}},
+		}, snapshots)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPredictionRepository_GetPredictionSnapshots_CorruptJSON(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPredictionRepository(db, testQueryTimeout)
+
+	// Setup
+	rows := sqlmock.NewRows([]string{"matchWeek", "predictions"}).AddRow(1, `{not json`)
+	mock.ExpectQuery("FROM prediction_snapshots").
+		WithArgs("league-1").
+		WillReturnRows(rows)
+
+	// Execute
+	_, err = repo.GetPredictionSnapshots(context.Background(), "league-1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPredictionRepository_DeletePredictionSnapshots(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPredictionRepository(db, testQueryTimeout)
+
+	// Setup
+	mock.ExpectExec("DELETE FROM prediction_snapshots WHERE leagueId = \\?").
+		WithArgs("league-1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// Execute
+	err = repo.DeletePredictionSnapshots(context.Background(), "league-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+	"strings"
+	"time"
+)
+
+type ratingRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewRatingRepository(db *sql.DB, queryTimeout time.Duration) interfaces.RatingRepository {
+	return &ratingRepository{db: db, queryTimeout: queryTimeout}
+}
+
+func (rr *ratingRepository) AppendRatingChanges(ctx context.Context, leagueId string, changes []models.RatingChange) error {
+	ctx, cancel := withTimeout(ctx, rr.queryTimeout)
+	defer cancel()
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(changes))
+	args := make([]interface{}, 0, len(changes)*6)
+
+	for _, change := range changes {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+		args = append(
+			args,
+			leagueId,
+			change.MatchWeek,
+			change.Team,
+			change.Opponent,
+			change.Before,
+			change.After,
+		)
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO rating_history (leagueId, matchWeek, team, opponent, ratingBefore, ratingAfter) VALUES %s",
+		strings.Join(placeholders, ","),
+	)
+
+	_, err := conn(ctx, rr.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return writeError(err, "rating history of league %s", leagueId)
+	}
+	return nil
+}
+
+// GetRatingChanges returns the changes of one team, or of every team when
+// team is empty, in the order they happened.
+func (rr *ratingRepository) GetRatingChanges(ctx context.Context, leagueId string, team string) (
+	[]models.RatingChange, error,
+) {
+	ctx, cancel := withTimeout(ctx, rr.queryTimeout)
+	defer cancel()
+
+	query := `SELECT matchWeek, team, opponent, ratingBefore, ratingAfter FROM rating_history WHERE leagueId = ?`
+	args := []interface{}{leagueId}
+	if team != "" {
+		query += ` AND team = ?`
+		args = append(args, team)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := conn(ctx, rr.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, queryError(err, "rating history of league %s", leagueId)
+	}
+	defer rows.Close()
+
+	var changes []models.RatingChange
+
+	for rows.Next() {
+		var change models.RatingChange
+		err := rows.Scan(
+			&change.MatchWeek,
+			&change.Team,
+			&change.Opponent,
+			&change.Before,
+			&change.After,
+		)
+		if err != nil {
+			return nil, queryError(err, "rating history of league %s", leagueId)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, queryError(err, "rating history of league %s", leagueId)
+	}
+
+	return changes, nil
+}
+
+func (rr *ratingRepository) DeleteRatingChanges(ctx context.Context, leagueId string) error {
+	ctx, cancel := withTimeout(ctx, rr.queryTimeout)
+	defer cancel()
+
+	query := `DELETE FROM rating_history WHERE leagueId = ?`
+
+	_, err := conn(ctx, rr.db).ExecContext(ctx, query, leagueId)
+	if err != nil {
+		return writeError(err, "rating history of league %s", leagueId)
+	}
+
+	return nil
+}
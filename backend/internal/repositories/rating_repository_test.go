@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRatingRepository(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+	assert.NotNil(t, repo)
+	assert.Implements(t, (*interfaces.RatingRepository)(nil), repo)
+}
+
+func TestRatingRepository_AppendRatingChanges_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	changes := []models.RatingChange{
+		{MatchWeek: 1, Team: "Team A", Opponent: "Team B", Before: 1500, After: 1510},
+		{MatchWeek: 1, Team: "Team B", Opponent: "Team A", Before: 1500, After: 1490},
+	}
+	mock.ExpectExec("INSERT INTO rating_history \\(leagueId, matchWeek, team, opponent, ratingBefore, ratingAfter\\) VALUES").
+		WithArgs(
+			"league-1", 1, "Team A", "Team B", 1500.0, 1510.0,
+			"league-1", 1, "Team B", "Team A", 1500.0, 1490.0).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+
+	// Execute
+	err = repo.AppendRatingChanges(context.Background(), "league-1", changes)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRatingRepository_AppendRatingChanges_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+
+	// Execute
+	err = repo.AppendRatingChanges(context.Background(), "league-1", nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRatingRepository_GetRatingChanges_AllTeams(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	rows := sqlmock.NewRows([]string{"matchWeek", "team", "opponent", "ratingBefore", "ratingAfter"}).
+		AddRow(1, "Team A", "Team B", 1500.0, 1510.0).
+		AddRow(1, "Team B", "Team A", 1500.0, 1490.0)
+	mock.ExpectQuery("SELECT matchWeek, team, opponent, ratingBefore, ratingAfter FROM rating_history WHERE leagueId = \\? ORDER BY id").
+		WithArgs("league-1").
+		WillReturnRows(rows)
+
+	// Execute
+	changes, err := repo.GetRatingChanges(context.Background(), "league-1", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(
+		t, []models.RatingChange{
+			{MatchWeek: 1, Team: "Team A", Opponent: "Team B", Before: 1500, After: 1510},
+			{MatchWeek: 1, Team: "Team B", Opponent: "Team A", Before: 1500, After: 1490},
+		}, changes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRatingRepository_GetRatingChanges_OneTeam(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	rows := sqlmock.NewRows([]string{"matchWeek", "team", "opponent", "ratingBefore", "ratingAfter"}).
+		AddRow(1, "Team A", "Team B", 1500.0, 1510.0)
+	mock.ExpectQuery("FROM rating_history WHERE leagueId = \\? AND team = \\? ORDER BY id").
+		WithArgs("league-1", "Team A").
+		WillReturnRows(rows)
+
+	// Execute
+	changes, err := repo.GetRatingChanges(context.Background(), "league-1", "Team A")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRatingRepository_GetRatingChanges_DatabaseError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	mock.ExpectQuery("FROM rating_history").
+		WithArgs("league-1").
+		WillReturnError(errors.New("connection lost"))
+
+	// Execute
+	_, err = repo.GetRatingChanges(context.Background(), "league-1", "")
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRatingRepository_DeleteRatingChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	mock.ExpectExec("DELETE FROM rating_history WHERE leagueId = \\?").
+		WithArgs("league-1").
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	// Execute
+	err = repo.DeleteRatingChanges(context.Background(), "league-1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeout_SetsDeadline(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestWithTimeout_ZeroKeepsParentDeadline(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), 0)
+	defer cancel()
+
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+}
+
+func TestMatchResultRepository_QueryTimeout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewMatchResultRepository(db, 10*time.Millisecond)
+
+	// Mock a query slower than the repository deadline
+	mock.ExpectQuery("SELECT (.+) FROM match_results").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"data"}))
+
+	// Execute
+	start := time.Now()
+	_, err = repo.GetMatchResults(context.Background(), "test-league-id")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestActiveLeagueRepository_CancelledContext(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewActiveLeagueRepository(db, testQueryTimeout)
+	mock.ExpectQuery("SELECT (.+) FROM active_league").
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows([]string{"data"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	_, err = repo.GetActiveLeague(ctx, "test-league-id")
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransactor(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	transactor := NewTransactor(db)
+	assert.NotNil(t, transactor)
+	assert.Implements(t, (*interfaces.Transactor)(nil), transactor)
+}
+
+func TestTransactor_WithinTx_Commits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ratings := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM rating_history WHERE leagueId = \\?").
+		WithArgs("league-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO rating_history").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	// Execute
+	err = NewTransactor(db).WithinTx(
+		context.Background(), func(ctx context.Context) error {
+			if err := ratings.DeleteRatingChanges(ctx, "league-1"); err != nil {
+				return err
+			}
+			return ratings.AppendRatingChanges(
+				ctx, "league-1", []models.RatingChange{{MatchWeek: 1, Team: "A", Opponent: "B", Before: 1500, After: 1510}})
+		})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactor_WithinTx_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ratings := NewRatingRepository(db, testQueryTimeout)
+
+	// Setup
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM rating_history WHERE leagueId = \\?").
+		WithArgs("league-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO rating_history").
+		WillReturnError(errors.New("connection lost"))
+	mock.ExpectRollback()
+
+	// Execute
+	err = NewTransactor(db).WithinTx(
+		context.Background(), func(ctx context.Context) error {
+			if err := ratings.DeleteRatingChanges(ctx, "league-1"); err != nil {
+				return err
+			}
+			return ratings.AppendRatingChanges(
+				ctx, "league-1", []models.RatingChange{{MatchWeek: 1, Team: "A", Opponent: "B", Before: 1500, After: 1510}})
+		})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactor_WithinTx_JoinsOpenTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	transactor := NewTransactor(db)
+
+	// Setup
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	// Execute
+	calls := 0
+	err = transactor.WithinTx(
+		context.Background(), func(ctx context.Context) error {
+			return transactor.WithinTx(
+				ctx, func(ctx context.Context) error {
+					calls++
+					return nil
+				})
+		})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactor_WithinTx_BeginError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Setup
+	mock.ExpectBegin().WillReturnError(errors.New("too many connections"))
+
+	// Execute
+	called := false
+	err = NewTransactor(db).WithinTx(
+		context.Background(), func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+	assert.False(t, called)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+)
+
+type userRepository struct {
+	db           *sql.DB
+	queryTimeout time.Duration
+}
+
+func NewUserRepository(db *sql.DB, queryTimeout time.Duration) interfaces.UserRepository {
+	return &userRepository{
+		db:           db,
+		queryTimeout: queryTimeout,
+	}
+}
+
+func (ur *userRepository) CreateUser(ctx context.Context, user models.User, apiKeyHash string) error {
+	ctx, cancel := withTimeout(ctx, ur.queryTimeout)
+	defer cancel()
+
+	query := `INSERT INTO users (userId, username, apiKeyHash) VALUES (?,?,?)`
+
+	_, err := conn(ctx, ur.db).ExecContext(ctx, query, user.UserId, user.Username, apiKeyHash)
+
+	if isDuplicateKey(err) {
+
+		return apperrors.Conflict("username %q is already taken", user.Username)
+	}
+
+	if err != nil {
+
+		return writeError(err, "user %s", user.Username)
+	}
+
+	return nil
+}
+
+func (ur *userRepository) GetUser(ctx context.Context, userId string) (models.User, error) {
+	ctx, cancel := withTimeout(ctx, ur.queryTimeout)
+	defer cancel()
+
+	query := `SELECT userId, username FROM users WHERE userId = ?`
+
+	var user models.User
+	err := conn(ctx, ur.db).QueryRowContext(ctx, query, userId).Scan(&user.UserId, &user.Username)
+
+	if err != nil {
+
+		return models.User{}, queryError(err, "user %s", userId)
+	}
+
+	return user, nil
+}
+
+func (ur *userRepository) GetUserByAPIKeyHash(ctx context.Context, apiKeyHash string) (models.User, error) {
+	ctx, cancel := withTimeout(ctx, ur.queryTimeout)
+	defer cancel()
+
+	query := `SELECT userId, username FROM users WHERE apiKeyHash = ?`
+
+	var user models.User
+	err := conn(ctx, ur.db).QueryRowContext(ctx, query, apiKeyHash).Scan(&user.UserId, &user.Username)
+
+	if err != nil {
+
+		return models.User{}, queryError(err, "user")
+	}
+
+	return user, nil
+}
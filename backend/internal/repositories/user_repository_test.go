@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUserRepository(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(db, testQueryTimeout)
+	assert.NotNil(t, repo)
+	assert.Implements(t, (*interfaces.UserRepository)(nil), repo)
+}
+
+func TestUserRepository_CreateUser_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(db, testQueryTimeout)
+	user := models.User{UserId: "user-id", Username: "alice"}
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO users \\(userId, username, apiKeyHash\\) VALUES \\(\\?,\\?,\\?\\)").
+		WithArgs("user-id", "alice", "key-hash").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Execute
+	err = repo.CreateUser(context.Background(), user, "key-hash")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_CreateUser_UsernameTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(db, testQueryTimeout)
+	user := models.User{UserId: "user-id", Username: "alice"}
+
+	// Mock expectations
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs("user-id", "alice", "key-hash").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'alice'"})
+
+	// Execute
+	err = repo.CreateUser(context.Background(), user, "key-hash")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetUser_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	rows := sqlmock.NewRows([]string{"userId", "username"}).AddRow("user-id", "alice")
+	mock.ExpectQuery("SELECT userId, username FROM users WHERE userId = \\?").
+		WithArgs("user-id").
+		WillReturnRows(rows)
+
+	// Execute
+	user, err := repo.GetUser(context.Background(), "user-id")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.User{UserId: "user-id", Username: "alice"}, user)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_GetUserByAPIKeyHash_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserRepository(db, testQueryTimeout)
+
+	// Mock expectations
+	mock.ExpectQuery("SELECT userId, username FROM users WHERE apiKeyHash = \\?").
+		WithArgs("unknown-hash").
+		WillReturnRows(sqlmock.NewRows([]string{"userId", "username"}))
+
+	// Execute
+	_, err = repo.GetUserByAPIKeyHash(context.Background(), "unknown-hash")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,8 @@
 package interfaces
 
 import (
+	"context"
+
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/mock"
@@ -11,12 +13,17 @@ type MockSimulationServiceInterface struct {
 	mock.Mock
 }
 
-func (m *MockSimulationServiceInterface) Simulation(leagueId string, playAllFixture bool) (models.SimulationResponse, error) {
-	args := m.Called(leagueId, playAllFixture)
+func (m *MockSimulationServiceInterface) Simulation(ctx context.Context, leagueId string, playAllFixture bool) (models.SimulationResponse, error) {
+	args := m.Called(ctx, leagueId, playAllFixture)
 	return args.Get(0).(models.SimulationResponse), args.Error(1)
 }
 
-func (m *MockSimulationServiceInterface) EditMatch(data models.EditMatchResult) error {
-	args := m.Called(data)
+func (m *MockSimulationServiceInterface) EditMatch(ctx context.Context, data models.EditMatchResult) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockSimulationServiceInterface) SetTactics(ctx context.Context, leagueId string, teamName string, tactics models.Tactics) error {
+	args := m.Called(ctx, leagueId, teamName, tactics)
 	return args.Error(0)
 }
@@ -1,11 +1,13 @@
 package interfaces
 
 import (
+	"context"
 	"testing"
 
 	"league-sim/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestMockSimulationServiceInterface_ImplementsInterface(t *testing.T) {
@@ -36,10 +38,10 @@ func TestMockSimulationServiceInterface_Simulation(t *testing.T) {
 		PlayedFixtures:   []models.Week{},
 	}
 
-	mockService.On("Simulation", testLeagueID, testPlayAllFixture).Return(expectedResponse, nil)
+	mockService.On("Simulation", mock.Anything, testLeagueID, testPlayAllFixture).Return(expectedResponse, nil)
 
 	// Call method
-	result, err := mockService.Simulation(testLeagueID, testPlayAllFixture)
+	result, err := mockService.Simulation(context.Background(), testLeagueID, testPlayAllFixture)
 
 	// Assert
 	assert.NoError(t, err)
@@ -65,10 +67,26 @@ func TestMockSimulationServiceInterface_EditMatch(t *testing.T) {
 		Winner:    "Team A",
 	}
 
-	mockService.On("EditMatch", testData).Return(nil)
+	mockService.On("EditMatch", mock.Anything, testData).Return(nil)
 
 	// Call method
-	err := mockService.EditMatch(testData)
+	err := mockService.EditMatch(context.Background(), testData)
+
+	// Assert
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestMockSimulationServiceInterface_SetTactics(t *testing.T) {
+	// Create mock
+	mockService := &MockSimulationServiceInterface{}
+
+	// Setup expectations
+	tactics := models.Tactics{Formation: "4-3-3", Pressing: "high", DefensiveLine: "high"}
+	mockService.On("SetTactics", mock.Anything, "test-league-id", "Team A", tactics).Return(nil)
+
+	// Call method
+	err := mockService.SetTactics(context.Background(), "test-league-id", "Team A", tactics)
 
 	// Assert
 	assert.NoError(t, err)
@@ -1,8 +1,13 @@
 package interfaces
 
-import "league-sim/internal/models"
+import (
+	"context"
+
+	"league-sim/internal/models"
+)
 
 type SimulationServiceInterface interface {
-	Simulation(leagueId string, playAllFixture bool) (models.SimulationResponse, error)
-	EditMatch(data models.EditMatchResult) error
+	Simulation(ctx context.Context, leagueId string, playAllFixture bool) (models.SimulationResponse, error)
+	EditMatch(ctx context.Context, data models.EditMatchResult) error
+	SetTactics(ctx context.Context, leagueId string, teamName string, tactics models.Tactics) error
 }
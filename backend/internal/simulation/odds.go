@@ -0,0 +1,181 @@
+package simulation
+
+import (
+	"math"
+
+	"league-sim/internal/models"
+	"league-sim/internal/rating"
+)
+
+// maxOddsGoals bounds the scores considered for the likeliest scoreline. Goal
+// rates would need to be far above real football for a side to score more.
+const maxOddsGoals = 10
+
+// MatchOdds are the chances GenerateMatchResult gives each outcome of home
+// against away, with the goals it expects each side to score and its likeliest
+// exact score.
+func MatchOdds(home models.Team, away models.Team, rules models.LeagueRules) models.MatchOdds {
+	homeModifier, awayModifier := MatchupModifiers(home.Tactics, away.Tactics)
+	homeScore := matchStrength(home, homeModifier, rules) * rules.HomeAdvantage
+	awayScore := matchStrength(away, awayModifier, rules)
+
+	// Teams without any strength lose every match at home, as in the engine.
+	homeChance := 0.0
+	if total := homeScore + awayScore; total > 0 {
+		homeChance = homeScore / total
+	}
+
+	draw := rules.Draw.Chance
+	odds := models.MatchOdds{
+		HomeWin: (1 - draw) * homeChance,
+		Draw:    draw,
+		AwayWin: (1 - draw) * (1 - homeChance),
+	}
+
+	// The scores the goal model draws, scaled by tactics as in the engine.
+	homeFactor, awayFactor := goalFactors(homeModifier, awayModifier)
+	var scores scoreDistribution
+	for goals, p := range drawScores(rules) {
+		for _, scaled := range scaledGoals(goals, (homeFactor+awayFactor)/2) {
+			scores.add(scaled.goals, scaled.goals, odds.Draw*p*scaled.p)
+		}
+	}
+	for _, score := range decidedScores(rules) {
+		for _, winner := range scaledGoals(score.winner, homeFactor) {
+			for _, loser := range scaledGoals(score.loser, awayFactor) {
+				home, away := keepDecided(winner.goals, loser.goals)
+				scores.add(home, away, odds.HomeWin*score.p*winner.p*loser.p)
+			}
+		}
+		for _, winner := range scaledGoals(score.winner, awayFactor) {
+			for _, loser := range scaledGoals(score.loser, homeFactor) {
+				away, home := keepDecided(winner.goals, loser.goals)
+				scores.add(home, away, odds.AwayWin*score.p*winner.p*loser.p)
+			}
+		}
+	}
+
+	for _, score := range scores {
+		odds.ExpectedHomeGoals += float64(score.Home) * score.Probability
+		odds.ExpectedAwayGoals += float64(score.Away) * score.Probability
+		odds.LikeliestScore = likelier(odds.LikeliestScore, score.Home, score.Away, score.Probability)
+	}
+
+	return odds
+}
+
+// scoreDistribution is the chance of every scoreline, in the order they were
+// first seen so that the likeliest of several equal ones is always the same.
+type scoreDistribution []models.Scoreline
+
+func (d *scoreDistribution) add(home int, away int, p float64) {
+	for i := range *d {
+		if (*d)[i].Home == home && (*d)[i].Away == away {
+			(*d)[i].Probability += p
+			return
+		}
+	}
+	*d = append(*d, models.Scoreline{Home: home, Away: away, Probability: p})
+}
+
+// FixtureOdds attaches MatchOdds to every upcoming match of activeLeague. The
+// teams play with their current tactics and ratings, as Season plays them.
+func FixtureOdds(activeLeague *models.League, rules models.LeagueRules) {
+	current := make([]models.Team, len(activeLeague.Teams))
+	copy(current, activeLeague.Teams)
+	rating.Seed(current, rules.StrengthWeights)
+
+	teams := make(map[string]*models.Team, len(current))
+	for i := range current {
+		teams[current[i].Name] = &current[i]
+	}
+	withCurrentState := func(team models.Team) models.Team {
+		team = withCurrentTactics(team, teams)
+		if t, ok := teams[team.Name]; ok {
+			team.Rating = t.Rating
+		}
+		return team
+	}
+
+	for i := range activeLeague.UpcomingFixtures {
+		matches := activeLeague.UpcomingFixtures[i].Matches
+		for j := range matches {
+			if matches[j].Home == nil || matches[j].Away == nil {
+				continue
+			}
+			odds := MatchOdds(withCurrentState(*matches[j].Home), withCurrentState(*matches[j].Away), rules)
+			matches[j].Odds = &odds
+		}
+	}
+}
+
+func likelier(best models.Scoreline, home int, away int, p float64) models.Scoreline {
+	if p > best.Probability {
+		return models.Scoreline{Home: home, Away: away, Probability: p}
+	}
+
+	return best
+}
+
+// drawScores is the distribution of drawGoals.
+func drawScores(rules models.LeagueRules) []float64 {
+	if rules.GoalRates != nil {
+		return poissonProbabilities(rules.GoalRates.Draw)
+	}
+
+	p := make([]float64, rules.Draw.MaxGoals+1)
+	for goals := range p {
+		p[goals] = 1 / float64(len(p))
+	}
+
+	return p
+}
+
+type decidedScore struct {
+	winner int
+	loser  int
+	p      float64
+}
+
+// decidedScores is the distribution of decidedGoals.
+func decidedScores(rules models.LeagueRules) []decidedScore {
+	var scores []decidedScore
+	if rates := rules.GoalRates; rates != nil {
+		for extra, p := range poissonProbabilities(rates.Winner) {
+			winner := 1 + extra
+			// Each of the winner's goals after the first gives the loser a
+			// chance of one.
+			for loser := 0; loser < winner; loser++ {
+				scores = append(scores, decidedScore{winner, loser, p * binomial(winner-1, loser, rates.Loser)})
+			}
+		}
+		return scores
+	}
+
+	for winner := 1; winner <= 5; winner++ {
+		for loser := 0; loser < winner; loser++ {
+			scores = append(scores, decidedScore{winner, loser, 1.0 / 5 / float64(winner)})
+		}
+	}
+
+	return scores
+}
+
+func poissonProbabilities(lambda float64) []float64 {
+	p := make([]float64, maxOddsGoals+1)
+	p[0] = math.Exp(-lambda)
+	for k := 1; k < len(p); k++ {
+		p[k] = p[k-1] * lambda / float64(k)
+	}
+
+	return p
+}
+
+func binomial(n int, k int, p float64) float64 {
+	coefficient := 1.0
+	for i := 0; i < k; i++ {
+		coefficient = coefficient * float64(n-i) / float64(i+1)
+	}
+
+	return coefficient * math.Pow(p, float64(k)) * math.Pow(1-p, float64(n-k))
+}
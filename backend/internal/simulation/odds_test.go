@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"testing"
+
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchOdds_DefaultRules(t *testing.T) {
+	// Setup
+	home := models.Team{Name: "Home", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	away := models.Team{Name: "Away", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	rules := models.DefaultLeagueRules()
+
+	// Execute
+	odds := MatchOdds(home, away, rules)
+
+	// Assert
+	assert.InDelta(t, 0.8*1.05/2.05, odds.HomeWin, 1e-9)
+	assert.InDelta(t, 0.2, odds.Draw, 1e-9)
+	assert.InDelta(t, 1, odds.HomeWin+odds.Draw+odds.AwayWin, 1e-9)
+	assert.InDelta(t, 0.2*1+odds.HomeWin*3+odds.AwayWin*1, odds.ExpectedHomeGoals, 1e-9)
+	assert.Greater(t, odds.ExpectedHomeGoals, odds.ExpectedAwayGoals)
+	// Every 1-0 win comes from a winner scoring one: a fifth of home wins.
+	assert.Equal(t, 1, odds.LikeliestScore.Home)
+	assert.Equal(t, 0, odds.LikeliestScore.Away)
+	assert.InDelta(t, odds.HomeWin/5, odds.LikeliestScore.Probability, 1e-9)
+}
+
+func TestMatchOdds_AgreesWithEngine(t *testing.T) {
+	// Setup
+	home := models.Team{Name: "Home", AttackPower: 90, DefensePower: 70, Stamina: 85, Morale: 75}
+	away := models.Team{Name: "Away", AttackPower: 75, DefensePower: 85, Stamina: 80, Morale: 90}
+	home.Tactics = models.Tactics{Formation: "4-3-3", Pressing: "high", DefensiveLine: "high"}
+	rules := models.DefaultLeagueRules()
+	rules.GoalRates = &models.GoalRates{Winner: 1.2, Loser: 0.3, Draw: 1.1}
+
+	// Execute
+	odds := MatchOdds(home, away, rules)
+	const n = 20000
+	var homeWins, draws, homeGoals, awayGoals float64
+	for i := 0; i < n; i++ {
+		result := GenerateMatchResult(home, away, rules)
+		h, a := result.WinnerGoals, result.LoserGoals
+		switch {
+		case result.IsDraw:
+			draws++
+		case result.Winner.Name == "Home":
+			homeWins++
+		default:
+			h, a = a, h
+		}
+		homeGoals += float64(h)
+		awayGoals += float64(a)
+	}
+
+	// Assert
+	assert.InDelta(t, odds.HomeWin, homeWins/n, 0.02)
+	assert.InDelta(t, odds.Draw, draws/n, 0.02)
+	assert.InDelta(t, odds.ExpectedHomeGoals, homeGoals/n, 0.05)
+	assert.InDelta(t, odds.ExpectedAwayGoals, awayGoals/n, 0.05)
+}
+
+func TestFixtureOdds(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	season := NewSeason(&activeLeague, models.DefaultLeagueRules())
+	season.PlayNextWeek()
+
+	// Execute
+	FixtureOdds(&activeLeague, models.DefaultLeagueRules())
+
+	// Assert
+	require.NotEmpty(t, activeLeague.UpcomingFixtures)
+	for _, week := range activeLeague.UpcomingFixtures {
+		for _, match := range week.Matches {
+			require.NotNil(t, match.Odds)
+			assert.InDelta(t, 1, match.Odds.HomeWin+match.Odds.Draw+match.Odds.AwayWin, 1e-9)
+		}
+	}
+	for _, week := range activeLeague.PlayedFixtures {
+		for _, match := range week.Matches {
+			assert.Nil(t, match.Odds)
+		}
+	}
+}
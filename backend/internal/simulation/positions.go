@@ -0,0 +1,136 @@
+package simulation
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+)
+
+// DefaultPositionRuns is how many times the rest of a season is played when
+// the finishing positions are asked for without a count.
+const DefaultPositionRuns = 1000
+
+// PositionProbabilities plays the upcoming fixtures of activeLeague runs times
+// under rules, leaving activeLeague untouched, and counts where every team
+// finished and with how many points. It stops early when ctx is done.
+func PositionProbabilities(
+	ctx context.Context, activeLeague models.League, rules models.LeagueRules, runs int,
+) (models.PositionProbabilities, error) {
+	if runs < 1 {
+		return models.PositionProbabilities{}, apperrors.Validation("runs must be at least 1")
+	}
+
+	n := len(activeLeague.Standings)
+	finishes := make(map[string][]int, n)
+	points := make(map[string][]float64, n)
+	for _, s := range activeLeague.Standings {
+		finishes[s.Team.Name] = make([]int, n)
+		points[s.Team.Name] = make([]float64, 0, runs)
+	}
+
+	for range runs {
+		if err := ctx.Err(); err != nil {
+			return models.PositionProbabilities{}, apperrors.Internal(
+				err, "simulation of league %s stopped early", activeLeague.LeagueID)
+		}
+
+		run := models.League{
+			LeagueID:         activeLeague.LeagueID,
+			Teams:            make([]models.Team, len(activeLeague.Teams)),
+			Standings:        make([]models.Standings, n),
+			UpcomingFixtures: activeLeague.UpcomingFixtures,
+		}
+		copy(run.Teams, activeLeague.Teams)
+		copy(run.Standings, activeLeague.Standings)
+
+		season := NewSeason(&run, rules)
+		for !season.Finished() {
+			season.PlayNextWeek()
+		}
+
+		for position, s := range league.RankStandings(run.Standings) {
+			finishes[s.Team.Name][position]++
+			points[s.Team.Name] = append(points[s.Team.Name], float64(s.Points))
+		}
+	}
+
+	result := models.PositionProbabilities{Runs: runs, Teams: make([]models.TeamPositions, 0, n)}
+	for _, s := range activeLeague.Standings {
+		row := models.TeamPositions{
+			Team:          s.Team.Name,
+			Points:        s.Points,
+			Probabilities: make([]float64, n),
+		}
+		for position, count := range finishes[s.Team.Name] {
+			share := float64(count) / float64(runs)
+			row.Probabilities[position] = share * 100
+			row.ExpectedPosition += share * float64(position+1)
+		}
+
+		final := points[s.Team.Name]
+		sort.Float64s(final)
+		for _, p := range final {
+			row.ExpectedPoints += p / float64(runs)
+		}
+		row.PointsP5 = int(percentile(final, 5))
+		row.PointsP95 = int(percentile(final, 95))
+
+		result.Teams = append(result.Teams, row)
+	}
+
+	sort.SliceStable(
+		result.Teams, func(i, j int) bool {
+			return result.Teams[i].ExpectedPosition < result.Teams[j].ExpectedPosition
+		})
+
+	return result, nil
+}
+
+// percentile uses the nearest-rank method on sorted, which must not be empty.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// WritePositionsCSV writes one row per team of result, with a column for the
+// percentage of runs it finished in each position, under a header row.
+func WritePositionsCSV(w io.Writer, result models.PositionProbabilities) error {
+	header := []string{"team", "points", "expected_position", "expected_points", "points_p5", "points_p95"}
+	if len(result.Teams) > 0 {
+		for position := range result.Teams[0].Probabilities {
+			header = append(header, "position_"+strconv.Itoa(position+1))
+		}
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, team := range result.Teams {
+		row := []string{
+			team.Team,
+			strconv.Itoa(team.Points),
+			strconv.FormatFloat(team.ExpectedPosition, 'f', 4, 64),
+			strconv.FormatFloat(team.ExpectedPoints, 'f', 4, 64),
+			strconv.Itoa(team.PointsP5),
+			strconv.Itoa(team.PointsP95),
+		}
+		for _, p := range team.Probabilities {
+			row = append(row, strconv.FormatFloat(p, 'f', 2, 64))
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,131 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionProbabilities(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+
+	// Execute
+	result, err := PositionProbabilities(context.Background(), activeLeague, models.DefaultLeagueRules(), 200)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 200, result.Runs)
+	require.Len(t, result.Teams, 4)
+
+	perPosition := make([]float64, 4)
+	for i, team := range result.Teams {
+		require.Len(t, team.Probabilities, 4)
+		var total, expected float64
+		for position, p := range team.Probabilities {
+			total += p
+			expected += p / 100 * float64(position+1)
+			perPosition[position] += p
+		}
+		assert.InDelta(t, 100, total, 1e-9, team.Team)
+		assert.InDelta(t, expected, team.ExpectedPosition, 1e-9, team.Team)
+		assert.LessOrEqual(t, team.PointsP5, team.PointsP95, team.Team)
+		assert.LessOrEqual(t, float64(team.PointsP5), team.ExpectedPoints, team.Team)
+		assert.GreaterOrEqual(t, float64(team.PointsP95), team.ExpectedPoints, team.Team)
+		if i > 0 {
+			assert.LessOrEqual(t, result.Teams[i-1].ExpectedPosition, team.ExpectedPosition)
+		}
+	}
+	for _, total := range perPosition {
+		assert.InDelta(t, 100, total, 1e-9)
+	}
+
+	// The league itself is not played.
+	assert.Len(t, activeLeague.UpcomingFixtures, 3)
+	for _, s := range activeLeague.Standings {
+		assert.Zero(t, s.Played)
+	}
+}
+
+func TestPositionProbabilities_FinishedLeague(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(2)
+	activeLeague := models.League{
+		Teams: teams,
+		Standings: []models.Standings{
+			{Team: teams[0], Points: 1, Played: 2},
+			{Team: teams[1], Points: 4, Played: 2},
+		},
+	}
+
+	// Execute
+	result, err := PositionProbabilities(context.Background(), activeLeague, models.DefaultLeagueRules(), 10)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, teams[1].Name, result.Teams[0].Team)
+	assert.Equal(t, []float64{100, 0}, result.Teams[0].Probabilities)
+	assert.Equal(t, 1.0, result.Teams[0].ExpectedPosition)
+	assert.Equal(t, 4, result.Teams[0].PointsP5)
+	assert.Equal(t, 4, result.Teams[0].PointsP95)
+	assert.Equal(t, []float64{0, 100}, result.Teams[1].Probabilities)
+}
+
+func TestPositionProbabilities_Cancelled(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(2)
+	activeLeague := models.League{Teams: teams, Standings: league.CreateStandingsTable(teams)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Execute
+	_, err := PositionProbabilities(ctx, activeLeague, models.DefaultLeagueRules(), 10)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindInternal))
+}
+
+func TestWritePositionsCSV(t *testing.T) {
+	// Setup
+	result := models.PositionProbabilities{
+		Runs: 10,
+		Teams: []models.TeamPositions{
+			{
+				Team: "Team A", Points: 6, Probabilities: []float64{70, 30}, ExpectedPosition: 1.3,
+				ExpectedPoints: 8.5, PointsP5: 6, PointsP95: 12,
+			},
+			{
+				Team: "Team B", Points: 3, Probabilities: []float64{30, 70}, ExpectedPosition: 1.7,
+				ExpectedPoints: 5, PointsP5: 3, PointsP95: 9,
+			},
+		},
+	}
+	var buf bytes.Buffer
+
+	// Execute
+	err := WritePositionsCSV(&buf, result)
+
+	// Assert
+	require.NoError(t, err)
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(
+		t, [][]string{
+			{"team", "points", "expected_position", "expected_points", "points_p5", "points_p95", "position_1", "position_2"},
+			{"Team A", "6", "1.3000", "8.5000", "6", "12", "70.00", "30.00"},
+			{"Team B", "3", "1.7000", "5.0000", "3", "9", "30.00", "70.00"},
+		}, rows)
+}
@@ -0,0 +1,162 @@
+package simulation
+
+import (
+	"fmt"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+	"league-sim/internal/rating"
+)
+
+// Scenario is a what-if view of a league. It shares the league's teams,
+// standings and fixtures until the first result is applied and then works on
+// its own copies, so the league it was made from is never modified.
+type Scenario struct {
+	league models.League
+	rules  models.LeagueRules
+	copied bool
+}
+
+// NewScenario starts a scenario from activeLeague as it stands.
+func NewScenario(activeLeague models.League, rules models.LeagueRules) *Scenario {
+	return &Scenario{league: activeLeague, rules: rules}
+}
+
+// League returns the league as the scenario has it. Until a result is applied
+// it shares its slices with the league the scenario was made from.
+func (s *Scenario) League() models.League {
+	return s.league
+}
+
+// write copies what a result changes before the first change.
+func (s *Scenario) write() {
+	if s.copied {
+		return
+	}
+
+	teams := make([]models.Team, len(s.league.Teams))
+	copy(teams, s.league.Teams)
+	rating.Seed(teams, s.rules.StrengthWeights)
+
+	standings := make([]models.Standings, len(s.league.Standings))
+	copy(standings, s.league.Standings)
+
+	upcoming := make([]models.Week, len(s.league.UpcomingFixtures))
+	for i, week := range s.league.UpcomingFixtures {
+		upcoming[i] = models.Week{Number: week.Number, Matches: make([]models.Match, len(week.Matches))}
+		copy(upcoming[i].Matches, week.Matches)
+	}
+
+	s.league.Teams, s.league.Standings, s.league.UpcomingFixtures = teams, standings, upcoming
+	s.copied = true
+}
+
+// Apply plays result as the outcome of its upcoming match: the match leaves
+// the fixtures and the teams' standings, stamina, morale and ratings change
+// as if the engine had produced that score. It fails when the match is not
+// upcoming, including when it was already applied.
+func (s *Scenario) Apply(result models.HypotheticalResult) (models.MatchResult, error) {
+	week, index := s.find(result.Home, result.Away)
+	if week < 0 {
+		return models.MatchResult{}, apperrors.Validation(
+			"%s vs %s is not an upcoming match of league %s", result.Home, result.Away, s.league.LeagueID)
+	}
+
+	s.write()
+
+	teams := make(map[string]*models.Team, len(s.league.Teams))
+	for i := range s.league.Teams {
+		teams[s.league.Teams[i].Name] = &s.league.Teams[i]
+	}
+	standings := make(map[string]*models.Standings, len(s.league.Standings))
+	for i := range s.league.Standings {
+		standings[s.league.Standings[i].Team.Name] = &s.league.Standings[i]
+	}
+
+	home, away := teams[result.Home], teams[result.Away]
+	homeStanding, awayStanding := standings[result.Home], standings[result.Away]
+	if home == nil || away == nil || homeStanding == nil || awayStanding == nil {
+		return models.MatchResult{}, apperrors.Conflict(
+			"match %s vs %s is not part of the current standings of league %s",
+			result.Home, result.Away, s.league.LeagueID)
+	}
+
+	played := models.MatchResult{
+		MatchWeek: s.league.UpcomingFixtures[week].Number,
+		Home:      result.Home,
+		HomeScore: result.HomeScore,
+		Away:      result.Away,
+		AwayScore: result.AwayScore,
+		Winner:    "draw",
+	}
+
+	switch {
+	case result.HomeScore == result.AwayScore:
+		outcome := models.MatchOutcome{
+			Winner: *home, Loser: *away, IsDraw: true, WinnerGoals: result.HomeScore, LoserGoals: result.AwayScore,
+		}
+		DrawTeamAttributeChanging(homeStanding, home, outcome, s.rules)
+		DrawTeamAttributeChanging(awayStanding, away, outcome, s.rules)
+	case result.HomeScore > result.AwayScore:
+		outcome := models.MatchOutcome{
+			Winner: *home, Loser: *away, WinnerGoals: result.HomeScore, LoserGoals: result.AwayScore,
+		}
+		WinnerTeamAttributeChanging(homeStanding, home, outcome, s.rules)
+		LoserTeamAttributeChanging(awayStanding, away, outcome, s.rules)
+		played.Winner = result.Home
+	default:
+		outcome := models.MatchOutcome{
+			Winner: *away, Loser: *home, WinnerGoals: result.AwayScore, LoserGoals: result.HomeScore,
+		}
+		WinnerTeamAttributeChanging(awayStanding, away, outcome, s.rules)
+		LoserTeamAttributeChanging(homeStanding, home, outcome, s.rules)
+		played.Winner = result.Away
+	}
+
+	for _, change := range rating.Update(home.Rating, away.Rating, played, rating.Parameters(s.rules)) {
+		teams[change.Team].Rating = change.After
+	}
+	homeStanding.Team, awayStanding.Team = *home, *away
+
+	matches := s.league.UpcomingFixtures[week].Matches
+	s.league.UpcomingFixtures[week].Matches = append(matches[:index:index], matches[index+1:]...)
+	if len(s.league.UpcomingFixtures[week].Matches) == 0 {
+		s.league.UpcomingFixtures = append(
+			s.league.UpcomingFixtures[:week:week], s.league.UpcomingFixtures[week+1:]...)
+	}
+
+	return played, nil
+}
+
+// find returns the week and position of the upcoming match of home against
+// away, or -1 when there is none.
+func (s *Scenario) find(home string, away string) (week int, index int) {
+	for w, fixtures := range s.league.UpcomingFixtures {
+		for i, match := range fixtures.Matches {
+			if match.Home != nil && match.Away != nil && match.Home.Name == home && match.Away.Name == away {
+				return w, i
+			}
+		}
+	}
+
+	return -1, -1
+}
+
+// ApplyAll applies every result in order. A failure names the offending
+// result by its position.
+func (s *Scenario) ApplyAll(results []models.HypotheticalResult) ([]models.MatchResult, error) {
+	played := make([]models.MatchResult, 0, len(results))
+	for i, result := range results {
+		match, err := s.Apply(result)
+		if err != nil {
+			if apperrors.Is(err, apperrors.KindValidation) {
+				return nil, apperrors.InvalidFields(
+					apperrors.FieldError{Field: fmt.Sprintf("results[%d]", i), Message: "is not an upcoming match"})
+			}
+			return nil, err
+		}
+		played = append(played, match)
+	}
+
+	return played, nil
+}
@@ -0,0 +1,125 @@
+package simulation
+
+import (
+	"testing"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenario_ApplyLeavesLeagueUntouched(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		LeagueID:         "test-league",
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	match := activeLeague.UpcomingFixtures[0].Matches[0]
+	scenario := NewScenario(activeLeague, models.DefaultLeagueRules())
+
+	// Execute
+	played, err := scenario.Apply(
+		models.HypotheticalResult{Home: match.Home.Name, Away: match.Away.Name, HomeScore: 2, AwayScore: 0})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, match.Home.Name, played.Winner)
+	assert.Equal(t, 1, played.MatchWeek)
+
+	what := scenario.League()
+	assert.Len(t, what.UpcomingFixtures[0].Matches, 1)
+	for _, s := range what.Standings {
+		switch s.Team.Name {
+		case match.Home.Name:
+			assert.Equal(t, 3, s.Points)
+			assert.Equal(t, 1, s.Wins)
+		case match.Away.Name:
+			assert.Equal(t, 0, s.Points)
+			assert.Equal(t, 1, s.Losses)
+			assert.Equal(t, 2, s.Against)
+		default:
+			assert.Zero(t, s.Played)
+		}
+	}
+
+	assert.Len(t, activeLeague.UpcomingFixtures[0].Matches, 2)
+	for _, s := range activeLeague.Standings {
+		assert.Zero(t, s.Played, s.Team.Name)
+		assert.Zero(t, s.Points, s.Team.Name)
+	}
+	assert.Equal(t, teams, activeLeague.Teams)
+}
+
+func TestScenario_SharesUntilWritten(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(2)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+
+	// Execute
+	scenario := NewScenario(activeLeague, models.DefaultLeagueRules())
+
+	// Assert
+	assert.Same(t, &activeLeague.Standings[0], &scenario.League().Standings[0])
+}
+
+func TestScenario_DropsPlayedOutWeeksAndRatings(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(2)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	first := activeLeague.UpcomingFixtures[0].Matches[0]
+	scenario := NewScenario(activeLeague, models.DefaultLeagueRules())
+
+	// Execute
+	played, err := scenario.ApplyAll(
+		[]models.HypotheticalResult{{Home: first.Home.Name, Away: first.Away.Name, HomeScore: 1, AwayScore: 1}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "draw", played[0].Winner)
+	what := scenario.League()
+	assert.Len(t, what.UpcomingFixtures, len(activeLeague.UpcomingFixtures)-1)
+	for _, s := range what.Standings {
+		assert.Equal(t, 1, s.Points, s.Team.Name)
+		assert.NotZero(t, s.Team.Rating, s.Team.Name)
+	}
+}
+
+func TestScenario_RejectsUnknownAndRepeatedMatches(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	match := activeLeague.UpcomingFixtures[0].Matches[0]
+	result := models.HypotheticalResult{Home: match.Home.Name, Away: match.Away.Name, HomeScore: 1}
+
+	// Execute
+	_, repeated := NewScenario(activeLeague, models.DefaultLeagueRules()).
+		ApplyAll([]models.HypotheticalResult{result, result})
+	_, unknown := NewScenario(activeLeague, models.DefaultLeagueRules()).
+		Apply(models.HypotheticalResult{Home: "Nobody", Away: match.Away.Name})
+
+	// Assert
+	require.Error(t, repeated)
+	assert.True(t, apperrors.Is(repeated, apperrors.KindValidation))
+	var appErr *apperrors.Error
+	require.ErrorAs(t, repeated, &appErr)
+	assert.Equal(t, "results[1]", appErr.Fields[0].Field)
+	assert.True(t, apperrors.Is(unknown, apperrors.KindValidation))
+}
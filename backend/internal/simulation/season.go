@@ -0,0 +1,147 @@
+package simulation
+
+import (
+	"league-sim/internal/models"
+	"league-sim/internal/rating"
+)
+
+// Season plays the upcoming weeks of a league in memory. The league's
+// standings, fixtures and team ratings are updated in place; teams carry their
+// stamina and morale from one week to the next for as long as the Season is
+// used.
+type Season struct {
+	league    *models.League
+	rules     models.LeagueRules
+	elo       models.EloRules
+	teams     map[string]*models.Team
+	standings map[string]*models.Standings
+	changes   []models.RatingChange
+}
+
+// NewSeason prepares activeLeague to be played under rules. Teams that have
+// not been rated yet get their starting rating.
+func NewSeason(activeLeague *models.League, rules models.LeagueRules) *Season {
+	rating.Seed(activeLeague.Teams, rules.StrengthWeights)
+
+	teams := make(map[string]*models.Team, len(activeLeague.Teams))
+	for _, t := range activeLeague.Teams {
+		teams[t.Name] = &t
+	}
+
+	standings := make(map[string]*models.Standings, len(activeLeague.Standings))
+	for i := range activeLeague.Standings {
+		standings[activeLeague.Standings[i].Team.Name] = &activeLeague.Standings[i]
+	}
+
+	return &Season{
+		league:    activeLeague,
+		rules:     rules,
+		elo:       rating.Parameters(rules),
+		teams:     teams,
+		standings: standings,
+	}
+}
+
+// RatingChanges returns the rating changes of every match played so far.
+func (s *Season) RatingChanges() []models.RatingChange {
+	return s.changes
+}
+
+// Finished reports whether no weeks are left to play.
+func (s *Season) Finished() bool {
+	return len(s.league.UpcomingFixtures) == 0
+}
+
+// PlayNextWeek plays the first upcoming week, moves it to the played fixtures
+// and returns its results. It returns nil once the season is finished.
+func (s *Season) PlayNextWeek() []models.MatchResult {
+	if s.Finished() {
+		return nil
+	}
+
+	week := s.league.UpcomingFixtures[0]
+	matches := make([]models.MatchResult, 0, len(week.Matches))
+	for _, match := range week.Matches {
+		matches = append(matches, s.play(week.Number, match))
+	}
+
+	s.league.PlayedFixtures = append(s.league.PlayedFixtures, week)
+	s.league.UpcomingFixtures = s.league.UpcomingFixtures[1:]
+	s.league.CurrentWeek = week.Number
+
+	return matches
+}
+
+func (s *Season) play(weekNumber int, match models.Match) models.MatchResult {
+	rules := s.rules
+	home := withCurrentTactics(*match.Home, s.teams)
+	away := withCurrentTactics(*match.Away, s.teams)
+	home.Rating, away.Rating = s.rating(home.Name), s.rating(away.Name)
+	matchResult := GenerateMatchResult(home, away, rules)
+	homeStanding := s.standings[match.Home.Name]
+	awayStanding := s.standings[match.Away.Name]
+
+	winnerTeam := s.teams[matchResult.Winner.Name]
+	loserTeam := s.teams[matchResult.Loser.Name]
+
+	if matchResult.IsDraw {
+		DrawTeamAttributeChanging(s.standings[matchResult.Winner.Name], winnerTeam, matchResult, rules)
+		DrawTeamAttributeChanging(s.standings[matchResult.Loser.Name], loserTeam, matchResult, rules)
+	} else {
+		WinnerTeamAttributeChanging(s.standings[matchResult.Winner.Name], winnerTeam, matchResult, rules)
+		LoserTeamAttributeChanging(s.standings[matchResult.Loser.Name], loserTeam, matchResult, rules)
+	}
+	var homeScore, awayScore int
+	matchWinner := matchResult.Winner.Name
+	if matchResult.IsDraw {
+		homeScore = matchResult.WinnerGoals
+		awayScore = matchResult.LoserGoals
+	} else if match.Home.Name == matchResult.Winner.Name {
+		homeScore = matchResult.WinnerGoals
+		awayScore = matchResult.LoserGoals
+	} else {
+		homeScore = matchResult.LoserGoals
+		awayScore = matchResult.WinnerGoals
+	}
+
+	result := models.MatchResult{
+		MatchWeek: weekNumber,
+		Home:      match.Home.Name,
+		HomeScore: homeScore,
+		Away:      match.Away.Name,
+		AwayScore: awayScore,
+		Winner:    matchWinner,
+	}
+
+	changes := rating.Update(home.Rating, away.Rating, result, s.elo)
+	for _, change := range changes {
+		s.setRating(change.Team, change.After)
+	}
+	s.changes = append(s.changes, changes...)
+
+	homeStanding.Team = *s.teams[match.Home.Name]
+	awayStanding.Team = *s.teams[match.Away.Name]
+
+	return result
+}
+
+func (s *Season) rating(teamName string) float64 {
+	if team, ok := s.teams[teamName]; ok {
+		return team.Rating
+	}
+
+	return rating.Initial
+}
+
+// setRating stores a team's new rating both for the rest of the season and on
+// the league, which keeps ratings between seasons.
+func (s *Season) setRating(teamName string, value float64) {
+	if team, ok := s.teams[teamName]; ok {
+		team.Rating = value
+	}
+	for i := range s.league.Teams {
+		if s.league.Teams[i].Name == teamName {
+			s.league.Teams[i].Rating = value
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package simulation
+
+import (
+	"testing"
+
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeason_PlaysEveryWeek(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	season := NewSeason(&activeLeague, models.DefaultLeagueRules())
+
+	// Execute
+	var matches []models.MatchResult
+	for !season.Finished() {
+		matches = append(matches, season.PlayNextWeek()...)
+	}
+
+	// Assert
+	require.Len(t, matches, 6)
+	assert.Equal(t, 1, matches[0].MatchWeek)
+	assert.Equal(t, 3, matches[5].MatchWeek)
+	assert.Empty(t, activeLeague.UpcomingFixtures)
+	assert.Len(t, activeLeague.PlayedFixtures, 3)
+	assert.Equal(t, 3, activeLeague.CurrentWeek)
+	for _, s := range activeLeague.Standings {
+		assert.Equal(t, 3, s.Played, s.Team.Name)
+	}
+	assert.Nil(t, season.PlayNextWeek())
+}
+
+func TestSeason_TracksRatings(t *testing.T) {
+	// Setup
+	teams := league.TeamGenerate(4)
+	activeLeague := models.League{
+		Teams:            teams,
+		Standings:        league.CreateStandingsTable(teams),
+		UpcomingFixtures: league.GenerateFixtures(teams),
+	}
+	season := NewSeason(&activeLeague, models.DefaultLeagueRules())
+
+	// Execute
+	matches := season.PlayNextWeek()
+
+	// Assert
+	changes := season.RatingChanges()
+	require.Len(t, changes, 2*len(matches))
+	var total float64
+	for _, change := range changes {
+		assert.Equal(t, 1, change.MatchWeek)
+		total += change.After - change.Before
+	}
+	assert.InDelta(t, 0, total, 1e-9)
+
+	ratings := map[string]float64{}
+	for _, team := range activeLeague.Teams {
+		assert.NotZero(t, team.Rating, team.Name)
+		ratings[team.Name] = team.Rating
+	}
+	for _, change := range changes {
+		assert.Equal(t, change.After, ratings[change.Team], change.Team)
+	}
+	for _, s := range activeLeague.Standings {
+		assert.Equal(t, ratings[s.Team.Name], s.Team.Rating, s.Team.Name)
+	}
+}
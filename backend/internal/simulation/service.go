@@ -1,9 +1,16 @@
 package simulation
 
 import (
+	"context"
+	"fmt"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/audit"
 	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/forecast"
 	"league-sim/internal/league"
 	"league-sim/internal/models"
+	"league-sim/internal/rating"
+	"math"
 	"math/rand"
 )
 
@@ -17,9 +24,9 @@ func NewSimulationService(ctx appContext.AppContext) *SimulationService {
 	}
 }
 
-func (ss *SimulationService) Simulation(leagueId string, playAllFixture bool) (models.SimulationResponse, error) {
+func (ss *SimulationService) Simulation(ctx context.Context, leagueId string, playAllFixture bool) (models.SimulationResponse, error) {
 	var matches []models.MatchResult
-	activeLeague, err := ss.appCtx.ActiveLeagueRepository().GetActiveLeague(leagueId)
+	activeLeague, err := ss.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
 	if err != nil {
 		return models.SimulationResponse{}, err
 	}
@@ -28,107 +35,72 @@ func (ss *SimulationService) Simulation(leagueId string, playAllFixture bool) (m
 		return models.SimulationResponse{}, nil
 	}
 
-	activeLeague.TotalWeeks = len(activeLeague.UpcomingFixtures) + len(activeLeague.PlayedFixtures)
-	teamMap := make(map[string]*models.Team)
-	for _, t := range activeLeague.Teams {
-		teamMap[t.Name] = &t
-	}
-
-	standingsMap := make(map[string]*models.Standings)
-	for i := range activeLeague.Standings {
-		t := activeLeague.Standings[i].Team.Name
-		standingsMap[t] = &activeLeague.Standings[i]
+	rules, err := ss.appCtx.LeagueRepository().GetLeagueRules(ctx, leagueId)
+	if err != nil {
+		return models.SimulationResponse{}, err
 	}
 
+	before := audit.Snapshot(activeLeague)
+	activeLeague.Rules = rules
+	activeLeague.TotalWeeks = len(activeLeague.UpcomingFixtures) + len(activeLeague.PlayedFixtures)
 	playingWeekCount := 1
 
 	if playAllFixture {
 		playingWeekCount = len(activeLeague.UpcomingFixtures)
 	}
 
+	// The title odds before each week are kept to score the predictor once
+	// the season is over.
+	var snapshots []models.PredictionSnapshot
+	season := NewSeason(&activeLeague, rules)
 	for i := 0; i < playingWeekCount; i++ {
+		snapshots = append(
+			snapshots, models.PredictionSnapshot{
+				Week: activeLeague.UpcomingFixtures[0].Number,
+				Predictions: forecast.Heuristic(
+					activeLeague.Standings, activeLeague.UpcomingFixtures, rules, ss.appCtx.Config().Predict),
+			})
+		matches = append(matches, season.PlayNextWeek()...)
+	}
 
-		var currentFixtureWeek models.Week
-
-		if playAllFixture {
-			currentFixtureWeek = activeLeague.UpcomingFixtures[0]
-		} else {
-			currentFixtureWeek = activeLeague.UpcomingFixtures[i]
-		}
+	// The season only moves on together with its results, ratings, audit entry
+	// and the snapshots of the weeks played, so a failed write loses none of
+	// them and the series of snapshots has no gaps.
+	err = ss.appCtx.Transactor().WithinTx(
+		ctx, func(ctx context.Context) error {
+			err := ss.appCtx.ActiveLeagueRepository().SetActiveLeague(ctx, activeLeague)
 
-		for _, match := range currentFixtureWeek.Matches {
-			matchResult := GenerateMatchResult(*match.Home, *match.Away)
-			homeStanding := standingsMap[match.Home.Name]
-			awayStanding := standingsMap[match.Away.Name]
-
-			winnerTeam := teamMap[matchResult.Winner.Name]
-			loserTeam := teamMap[matchResult.Loser.Name]
-
-			if matchResult.IsDraw {
-				DrawTeamAttributeChanging(
-					standingsMap[matchResult.Winner.Name],
-					winnerTeam,
-					matchResult)
-				DrawTeamAttributeChanging(
-					standingsMap[matchResult.Loser.Name],
-					loserTeam,
-					matchResult)
-			} else {
-				WinnerTeamAttributeChanging(
-					standingsMap[matchResult.Winner.Name],
-					winnerTeam,
-					matchResult)
-				LoserTeamAttributeChanging(
-					standingsMap[matchResult.Loser.Name],
-					loserTeam,
-					matchResult)
-			}
-			homeStanding.Team = *teamMap[match.Home.Name]
-			awayStanding.Team = *teamMap[match.Away.Name]
-
-			var homeScore, awayScore int
-			matchWinner := matchResult.Winner.Name
-			if matchResult.IsDraw {
-				homeScore = matchResult.WinnerGoals
-				awayScore = matchResult.LoserGoals
-			} else if match.Home.Name == matchResult.Winner.Name {
-				homeScore = matchResult.WinnerGoals
-				awayScore = matchResult.LoserGoals
-
-			} else if matchResult.WinnerGoals == matchResult.LoserGoals {
-				matchWinner = "draw"
-			} else {
-				homeScore = matchResult.LoserGoals
-				awayScore = matchResult.WinnerGoals
+			if err != nil {
+				return err
 			}
 
-			matches = append(
-				matches, models.MatchResult{
-					MatchWeek: currentFixtureWeek.Number,
-					Home:      match.Home.Name,
-					HomeScore: homeScore,
-					Away:      match.Away.Name,
-					AwayScore: awayScore,
-					Winner:    matchWinner,
-				})
-		}
+			err = ss.appCtx.MatchResultRepository().SetMatchResults(ctx, activeLeague.LeagueID, matches)
 
-		activeLeague.PlayedFixtures = append(activeLeague.PlayedFixtures, currentFixtureWeek)
-		activeLeague.UpcomingFixtures = activeLeague.UpcomingFixtures[1:]
-		activeLeague.CurrentWeek = currentFixtureWeek.Number
-	}
+			if err != nil {
+				return err
+			}
 
-	err = ss.appCtx.ActiveLeagueRepository().SetActiveLeague(activeLeague)
+			err = ss.appCtx.RatingRepository().AppendRatingChanges(ctx, activeLeague.LeagueID, season.RatingChanges())
 
-	if err != nil {
-		panic(err)
-		return models.SimulationResponse{}, err
-	}
+			if err != nil {
+				return err
+			}
+
+			for _, snapshot := range snapshots {
+				err = ss.appCtx.PredictionRepository().SavePredictionSnapshot(ctx, activeLeague.LeagueID, snapshot)
+				if err != nil {
+					return err
+				}
+			}
 
-	err = ss.appCtx.MatchResultRepository().SetMatchResults(activeLeague.LeagueID, matches)
+			return audit.Record(
+				ctx, ss.appCtx.AuditRepository(), leagueId, models.AuditActionSimulate, before, models.SimulationAudit{
+					LeagueSnapshot: audit.Snapshot(activeLeague),
+					Matches:        matches,
+				})
+		})
 
 	if err != nil {
-		panic(err)
 		return models.SimulationResponse{}, err
 	}
 
@@ -139,15 +111,17 @@ func (ss *SimulationService) Simulation(leagueId string, playAllFixture bool) (m
 	}, nil
 }
 
-func GenerateMatchResult(home models.Team, away models.Team) models.MatchOutcome {
-	homeScore := league.CalculateStrength(home) * 1.05
-	awayScore := league.CalculateStrength(away)
+func GenerateMatchResult(home models.Team, away models.Team, rules models.LeagueRules) models.MatchOutcome {
+	homeModifier, awayModifier := MatchupModifiers(home.Tactics, away.Tactics)
+	homeScore := matchStrength(home, homeModifier, rules) * rules.HomeAdvantage
+	awayScore := matchStrength(away, awayModifier, rules)
 
 	total := homeScore + awayScore
+	// The goal model draws the score, which tactics then scale for each side.
+	homeFactor, awayFactor := goalFactors(homeModifier, awayModifier)
 
-	drawChance := 0.2
-	if rand.Float64() < drawChance {
-		goals := rand.Intn(3)
+	if rand.Float64() < rules.Draw.Chance {
+		goals := scaleGoals(drawGoals(rules), (homeFactor+awayFactor)/2)
 		return models.MatchOutcome{
 			Winner:      home,
 			Loser:       away,
@@ -160,10 +134,10 @@ func GenerateMatchResult(home models.Team, away models.Team) models.MatchOutcome
 	homeChance := homeScore / total
 	homeWins := rand.Float64() < homeChance
 
-	winnerGoals := rand.Intn(5) + 1
-	loserGoals := rand.Intn(winnerGoals)
+	winnerGoals, loserGoals := decidedGoals(rules)
 
 	if homeWins {
+		winnerGoals, loserGoals = keepDecided(scaleGoals(winnerGoals, homeFactor), scaleGoals(loserGoals, awayFactor))
 		return models.MatchOutcome{
 			Winner:      home,
 			Loser:       away,
@@ -172,6 +146,7 @@ func GenerateMatchResult(home models.Team, away models.Team) models.MatchOutcome
 			LoserGoals:  loserGoals,
 		}
 	} else {
+		winnerGoals, loserGoals = keepDecided(scaleGoals(winnerGoals, awayFactor), scaleGoals(loserGoals, homeFactor))
 		return models.MatchOutcome{
 			Winner:      away,
 			Loser:       home,
@@ -182,12 +157,196 @@ func GenerateMatchResult(home models.Team, away models.Team) models.MatchOutcome
 	}
 }
 
-func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
-	matching, err := ss.appCtx.MatchResultRepository().GetMatchResultByWeekAndTeam(data)
+// matchStrength is the strength team plays with. When the rules let Elo
+// ratings into the engine, a rated team's static strength is replaced by its
+// rating, scaled by how much its tactics change the static strength.
+func matchStrength(team models.Team, modifier TacticalModifier, rules models.LeagueRules) float64 {
+	strength := league.CalculateStrength(ApplyTactics(team, modifier), rules.StrengthWeights)
+	if rules.Elo == nil || !rules.Elo.InEngine || team.Rating == 0 {
+		return strength
+	}
+
+	if static := league.CalculateStrength(team, rules.StrengthWeights); static > 0 {
+		return rating.Strength(team.Rating) * strength / static
+	}
+
+	return rating.Strength(team.Rating)
+}
+
+// drawGoals is what each side scores in a draw: up to Draw.MaxGoals, evenly
+// spread, unless the rules carry goal rates.
+func drawGoals(rules models.LeagueRules) int {
+	if rules.GoalRates != nil {
+		return poisson(rules.GoalRates.Draw)
+	}
+
+	return rand.Intn(rules.Draw.MaxGoals + 1)
+}
+
+// decidedGoals is the score of a match with a winner. Without goal rates the
+// winner scores 1 to 5 goals and the loser fewer, evenly spread.
+func decidedGoals(rules models.LeagueRules) (winnerGoals int, loserGoals int) {
+	if rates := rules.GoalRates; rates != nil {
+		winnerGoals = 1 + poisson(rates.Winner)
+		for range winnerGoals - 1 {
+			if rand.Float64() < rates.Loser {
+				loserGoals++
+			}
+		}
+		return winnerGoals, loserGoals
+	}
+
+	winnerGoals = rand.Intn(5) + 1
+	return winnerGoals, rand.Intn(winnerGoals)
+}
+
+// poisson draws from a Poisson distribution with mean lambda by Knuth's
+// method, which is fast enough for football scores.
+func poisson(lambda float64) int {
+	limit := math.Exp(-lambda)
+	k := 0
+	for p := rand.Float64(); p > limit; p *= rand.Float64() {
+		k++
+	}
+
+	return k
+}
+
+// replayRatings rates every played match again with the edited score, as
+// every rating after the edited match depends on it, and stores the new
+// ratings on the league's teams. Teams start from the ratings they had before
+// their first match.
+func (ss *SimulationService) replayRatings(
+	ctx context.Context, activeLeague *models.League, rules models.LeagueRules, edited models.EditMatchResult,
+) ([]models.RatingChange, error) {
+	results, err := ss.appCtx.MatchResultRepository().GetMatchResults(ctx, edited.LeagueId)
+	if err != nil {
+		return nil, err
+	}
+	history, err := ss.appCtx.RatingRepository().GetRatingChanges(ctx, edited.LeagueId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		if result.MatchWeek == edited.MatchWeek && result.Home == edited.Home && result.Away == edited.Away {
+			results[i].HomeScore, results[i].AwayScore, results[i].Winner = edited.HomeScore, edited.AwayScore, edited.Winner
+		}
+	}
+
+	rating.Seed(activeLeague.Teams, rules.StrengthWeights)
+	initial := rating.Starting(history)
+	for _, team := range activeLeague.Teams {
+		if _, ok := initial[team.Name]; !ok {
+			initial[team.Name] = team.Rating
+		}
+	}
+
+	changes, ratings := rating.Replay(initial, results, rating.Parameters(rules))
+	for i := range activeLeague.Teams {
+		activeLeague.Teams[i].Rating = ratings[activeLeague.Teams[i].Name]
+	}
+	for i := range activeLeague.Standings {
+		activeLeague.Standings[i].Team.Rating = ratings[activeLeague.Standings[i].Team.Name]
+	}
+
+	return changes, nil
+}
+
+func withCurrentTactics(team models.Team, teamMap map[string]*models.Team) models.Team {
+	if current, ok := teamMap[team.Name]; ok {
+		team.Tactics = current.Tactics
+	}
+
+	return team
+}
+
+func (ss *SimulationService) SetTactics(ctx context.Context, leagueId string, teamName string, tactics models.Tactics) error {
+	if err := ValidateTactics(tactics); err != nil {
+		return apperrors.Validation("%v", err)
+	}
+
+	activeLeague, err := ss.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, leagueId)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	var previous models.Tactics
+	for i := range activeLeague.Teams {
+		if activeLeague.Teams[i].Name == teamName {
+			previous = activeLeague.Teams[i].Tactics
+			activeLeague.Teams[i].Tactics = tactics
+			found = true
+		}
+	}
+	if !found {
+		return apperrors.NotFound("team %s not found in league %s", teamName, leagueId)
+	}
+
+	for i := range activeLeague.Standings {
+		if activeLeague.Standings[i].Team.Name == teamName {
+			activeLeague.Standings[i].Team.Tactics = tactics
+		}
+	}
+
+	err = ss.appCtx.ActiveLeagueRepository().SetActiveLeague(ctx, activeLeague)
+	if err != nil {
+		return err
+	}
+
+	return audit.Record(
+		ctx, ss.appCtx.AuditRepository(), leagueId, models.AuditActionTactics,
+		models.SetTacticsRequest{Team: teamName, Tactics: previous},
+		models.SetTacticsRequest{Team: teamName, Tactics: tactics})
+}
+
+// validateEdit checks an edit against the league it targets: both teams must
+// be in the league and the week must already have been played.
+func validateEdit(activeLeague models.League, data models.EditMatchResult) error {
+	teams := make(map[string]bool, len(activeLeague.Teams))
+	for _, t := range activeLeague.Teams {
+		teams[t.Name] = true
+	}
+
+	var fields []apperrors.FieldError
+	if !teams[data.Home] {
+		fields = append(fields, apperrors.FieldError{Field: "home", Message: "is not a team in this league"})
+	}
+	if !teams[data.Away] {
+		fields = append(fields, apperrors.FieldError{Field: "away", Message: "is not a team in this league"})
+	}
+	if data.MatchWeek < 1 || data.MatchWeek > activeLeague.CurrentWeek {
+		fields = append(
+			fields, apperrors.FieldError{
+				Field:   "matchWeek",
+				Message: fmt.Sprintf("must be a week that has been played (1 to %d)", activeLeague.CurrentWeek),
+			})
+	}
+
+	if len(fields) > 0 {
+		return apperrors.InvalidFields(fields...)
+	}
+
+	return nil
+}
+
+func (ss *SimulationService) EditMatch(ctx context.Context, data models.EditMatchResult) error {
+	activeLeague, err := ss.appCtx.ActiveLeagueRepository().GetActiveLeague(ctx, data.LeagueId)
+	if err != nil {
+		return err
+	}
+
+	if err := validateEdit(activeLeague, data); err != nil {
+		return err
+	}
+
+	matching, err := ss.appCtx.MatchResultRepository().GetMatchResultByWeekAndTeam(ctx, data)
 	if err != nil {
 		return err
 	}
-	activeLeague, err := ss.appCtx.ActiveLeagueRepository().GetActiveLeague(data.LeagueId)
+
+	rules, err := ss.appCtx.LeagueRepository().GetLeagueRules(ctx, data.LeagueId)
 	if err != nil {
 		return err
 	}
@@ -209,16 +368,18 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 	homeTeam := teamMap[matching.Home]
 	awayTeam := teamMap[matching.Away]
 
-	if matching.HomeScore == matching.AwayScore {
-		awayStanding.Points -= 1
-		homeStanding.Points -= 1
+	if homeStanding == nil || awayStanding == nil || homeTeam == nil || awayTeam == nil {
+		return apperrors.Conflict(
+			"match %s vs %s is not part of the current standings of league %s", matching.Home, matching.Away, data.LeagueId)
+	}
+
+	homeStanding.Points -= ResultPoints(rules, matching.HomeScore, matching.AwayScore)
+	awayStanding.Points -= ResultPoints(rules, matching.AwayScore, matching.HomeScore)
 
-	} else if matching.HomeScore > matching.AwayScore {
-		homeStanding.Points -= 3
+	if matching.HomeScore > matching.AwayScore {
 		homeStanding.Wins -= 1
 
 	} else if matching.HomeScore < matching.AwayScore {
-		awayStanding.Points -= 3
 		awayStanding.Wins -= 1
 	}
 	awayStanding.Goals -= matching.AwayScore
@@ -238,7 +399,7 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 				IsDraw:      true,
 				WinnerGoals: data.HomeScore,
 				LoserGoals:  data.AwayScore,
-			})
+			}, rules)
 		DrawTeamAttributeChanging(
 			homeStanding, homeTeam, models.MatchOutcome{
 				Winner:      *awayTeam,
@@ -246,7 +407,7 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 				IsDraw:      true,
 				WinnerGoals: data.HomeScore,
 				LoserGoals:  data.AwayScore,
-			})
+			}, rules)
 		data.Winner = "draw"
 	}
 	if data.HomeScore > data.AwayScore {
@@ -257,7 +418,7 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 				IsDraw:      false,
 				WinnerGoals: data.HomeScore,
 				LoserGoals:  data.AwayScore,
-			})
+			}, rules)
 		LoserTeamAttributeChanging(
 			awayStanding, awayTeam, models.MatchOutcome{
 				Winner:      *homeTeam,
@@ -265,7 +426,7 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 				IsDraw:      false,
 				WinnerGoals: data.HomeScore,
 				LoserGoals:  data.AwayScore,
-			})
+			}, rules)
 		data.Winner = data.Home
 	}
 	if data.HomeScore < data.AwayScore {
@@ -276,7 +437,7 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 				IsDraw:      false,
 				WinnerGoals: data.AwayScore,
 				LoserGoals:  data.HomeScore,
-			})
+			}, rules)
 		LoserTeamAttributeChanging(
 			homeStanding, homeTeam, models.MatchOutcome{
 				Winner:      *awayTeam,
@@ -284,17 +445,44 @@ func (ss *SimulationService) EditMatch(data models.EditMatchResult) error {
 				IsDraw:      false,
 				WinnerGoals: data.AwayScore,
 				LoserGoals:  data.HomeScore,
-			})
+			}, rules)
 		data.Winner = data.Away
 	}
 
-	err = ss.appCtx.ActiveLeagueRepository().SetActiveLeague(activeLeague)
-	if err != nil {
-		return err
-	}
-	err = ss.appCtx.MatchResultRepository().EditMatchScore(data)
+	changes, err := ss.replayRatings(ctx, &activeLeague, rules, data)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	// The edited result and the rating history replayed from it are stored
+	// together, so a failed write cannot leave the league without a history.
+	return ss.appCtx.Transactor().WithinTx(
+		ctx, func(ctx context.Context) error {
+			err := ss.appCtx.ActiveLeagueRepository().SetActiveLeague(ctx, activeLeague)
+			if err != nil {
+				return err
+			}
+			err = ss.appCtx.MatchResultRepository().EditMatchScore(ctx, data)
+			if err != nil {
+				return err
+			}
+			err = ss.appCtx.RatingRepository().DeleteRatingChanges(ctx, data.LeagueId)
+			if err != nil {
+				return err
+			}
+			err = ss.appCtx.RatingRepository().AppendRatingChanges(ctx, data.LeagueId, changes)
+			if err != nil {
+				return err
+			}
+
+			return audit.Record(
+				ctx, ss.appCtx.AuditRepository(), data.LeagueId, models.AuditActionEdit, matching, models.MatchResult{
+					MatchWeek: data.MatchWeek,
+					Home:      data.Home,
+					HomeScore: data.HomeScore,
+					Away:      data.Away,
+					AwayScore: data.AwayScore,
+					Winner:    data.Winner,
+				})
+		})
 }
@@ -1,11 +1,16 @@
 package simulation
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
+	"league-sim/config"
+	"league-sim/internal/apperrors"
 	appContext "league-sim/internal/contexts/appContexts"
 	"league-sim/internal/models"
+	"league-sim/internal/rating"
 	"league-sim/internal/repositories/interfaces"
 
 	"github.com/stretchr/testify/assert"
@@ -32,16 +37,89 @@ func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepositor
 	return args.Get(0).(interfaces.MatchResultRepository)
 }
 
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
 func (m *MockAppContext) DB() *appContext.DB {
 	args := m.Called()
 	return args.Get(0).(*appContext.DB)
 }
 
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
 // Helper function to create a test AppContext with mock repositories
 func createTestAppContext(activeLeagueRepo interfaces.ActiveLeagueRepository, matchResultRepo interfaces.MatchResultRepository) *MockAppContext {
 	mockAppCtx := &MockAppContext{}
 	mockAppCtx.On("ActiveLeagueRepository").Return(activeLeagueRepo)
 	mockAppCtx.On("MatchResultRepository").Return(matchResultRepo)
+	return withTransactions(withPredictions(withRatings(withAudit(withDefaultRules(mockAppCtx)))))
+}
+
+// Helper function to run transactions directly on a mock AppContext
+func withTransactions(mockAppCtx *MockAppContext) *MockAppContext {
+	mockTx := &interfaces.MockTransactor{}
+	mockTx.On("WithinTx", mock.Anything).Return(nil).Maybe()
+	mockAppCtx.On("Transactor").Return(mockTx).Maybe()
+	return mockAppCtx
+}
+
+// Helper function to register the default league rules on a mock AppContext
+func withDefaultRules(mockAppCtx *MockAppContext) *MockAppContext {
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, mock.AnythingOfType("string")).Return(models.DefaultLeagueRules(), nil).Maybe()
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo).Maybe()
+	return mockAppCtx
+}
+
+// Helper function to accept audit entries on a mock AppContext
+func withAudit(mockAppCtx *MockAppContext) *MockAppContext {
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAuditRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).Return(nil).Maybe()
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo).Maybe()
+	return mockAppCtx
+}
+
+// Helper function to accept rating history on a mock AppContext
+func withRatings(mockAppCtx *MockAppContext) *MockAppContext {
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	mockRatingRepo.On("AppendRatingChanges", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRatingRepo.On("GetRatingChanges", mock.Anything, mock.Anything, "").Return([]models.RatingChange{}, nil).Maybe()
+	mockRatingRepo.On("DeleteRatingChanges", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo).Maybe()
+	return mockAppCtx
+}
+
+// Helper function to accept prediction snapshots on a mock AppContext
+func withPredictions(mockAppCtx *MockAppContext) *MockAppContext {
+	mockPredictionRepo := &interfaces.MockPredictionRepository{}
+	mockPredictionRepo.On("SavePredictionSnapshot", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockAppCtx.On("PredictionRepository").Return(mockPredictionRepo).Maybe()
+	mockAppCtx.On("Config").Return(config.Default()).Maybe()
 	return mockAppCtx
 }
 
@@ -107,9 +185,9 @@ func TestSimulationService_Simulation_SingleWeek_Success(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("SetMatchResults", leagueId, mock.AnythingOfType("[]models.MatchResult")).Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, leagueId, mock.AnythingOfType("[]models.MatchResult")).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -118,7 +196,7 @@ func TestSimulationService_Simulation_SingleWeek_Success(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute - simulate single week
-	result, err := service.Simulation(leagueId, false)
+	result, err := service.Simulation(context.Background(), leagueId, false)
 
 	// Assert
 	assert.NoError(t, err)
@@ -186,9 +264,9 @@ func TestSimulationService_Simulation_AllWeeks_Success(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("SetMatchResults", leagueId, mock.AnythingOfType("[]models.MatchResult")).Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, leagueId, mock.AnythingOfType("[]models.MatchResult")).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -197,7 +275,7 @@ func TestSimulationService_Simulation_AllWeeks_Success(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute - simulate all weeks
-	result, err := service.Simulation(leagueId, true)
+	result, err := service.Simulation(context.Background(), leagueId, true)
 
 	// Assert
 	assert.NoError(t, err)
@@ -222,13 +300,14 @@ func TestSimulationService_Simulation_GetActiveLeagueError(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(models.League{}, expectedError)
+	withTransactions(withPredictions(withRatings(withAudit(withDefaultRules(mockAppCtx)))))
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(models.League{}, expectedError)
 
 	// Create service
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute - should return error instead of panic
-	result, err := service.Simulation(leagueId, false)
+	result, err := service.Simulation(context.Background(), leagueId, false)
 
 	// Assert
 	assert.Error(t, err)
@@ -263,13 +342,14 @@ func TestSimulationService_Simulation_EmptyUpcomingFixtures(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(activeLeague, nil)
+	withTransactions(withPredictions(withRatings(withAudit(withDefaultRules(mockAppCtx)))))
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
 
 	// Create service
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	result, err := service.Simulation(leagueId, false)
+	result, err := service.Simulation(context.Background(), leagueId, false)
 
 	// Assert - should return empty response without error
 	assert.NoError(t, err)
@@ -316,16 +396,18 @@ func TestSimulationService_Simulation_SetActiveLeagueError(t *testing.T) {
 
 	// Configure mock expectations
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeague", leagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(expectedError)
+	withTransactions(withPredictions(withRatings(withAudit(withDefaultRules(mockAppCtx)))))
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(expectedError)
 
 	// Create service
 	service := NewSimulationService(mockAppCtx)
 
-	// Execute and expect panic
-	assert.Panics(t, func() {
-		service.Simulation(leagueId, false)
-	}, "Should panic when SetActiveLeague fails")
+	// Execute
+	_, err := service.Simulation(context.Background(), leagueId, false)
+
+	// Assert
+	assert.ErrorIs(t, err, expectedError)
 
 	// Verify mock expectations
 	mockAppCtx.AssertExpectations(t)
@@ -359,7 +441,8 @@ func TestSimulationService_EditMatch_Success_Win(t *testing.T) {
 	}
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 75},
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 70},
@@ -385,10 +468,11 @@ func TestSimulationService_EditMatch_Success_Win(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("EditMatchScore", editData).Return(nil)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, editData).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -397,7 +481,7 @@ func TestSimulationService_EditMatch_Success_Win(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -435,7 +519,8 @@ func TestSimulationService_EditMatch_Success_Draw(t *testing.T) {
 	}
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 75},
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 70},
@@ -461,10 +546,11 @@ func TestSimulationService_EditMatch_Success_Draw(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("EditMatchScore", editData).Return(nil)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, editData).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -473,7 +559,7 @@ func TestSimulationService_EditMatch_Success_Draw(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -511,7 +597,8 @@ func TestSimulationService_EditMatch_MoraleCapLimits(t *testing.T) {
 	}
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 98}, // High morale to test cap
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 3},  // Low morale to test floor
@@ -537,10 +624,11 @@ func TestSimulationService_EditMatch_MoraleCapLimits(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("EditMatchScore", editData).Return(nil)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, editData).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -549,7 +637,7 @@ func TestSimulationService_EditMatch_MoraleCapLimits(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -590,7 +678,8 @@ func TestSimulationService_EditMatch_DetailedWinScenario(t *testing.T) {
 	initialTeamBMorale := float64(70)
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: initialTeamAMorale},
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: initialTeamBMorale},
@@ -616,10 +705,11 @@ func TestSimulationService_EditMatch_DetailedWinScenario(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("EditMatchScore", editData).Return(nil)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, editData).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -628,7 +718,7 @@ func TestSimulationService_EditMatch_DetailedWinScenario(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -669,7 +759,8 @@ func TestSimulationService_EditMatch_DetailedDrawScenario(t *testing.T) {
 	initialTeamBMorale := float64(80)
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: initialTeamAMorale},
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: initialTeamBMorale},
@@ -695,10 +786,11 @@ func TestSimulationService_EditMatch_DetailedDrawScenario(t *testing.T) {
 	}
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("EditMatchScore", editData).Return(nil)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, editData).Return(nil)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -707,7 +799,7 @@ func TestSimulationService_EditMatch_DetailedDrawScenario(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.NoError(t, err)
@@ -740,7 +832,7 @@ func TestGenerateMatchResult_HomeWins(t *testing.T) {
 	totalTests := 100
 
 	for i := 0; i < totalTests; i++ {
-		result := GenerateMatchResult(homeTeam, awayTeam)
+		result := GenerateMatchResult(homeTeam, awayTeam, models.DefaultLeagueRules())
 
 		// Verify basic structure
 		assert.GreaterOrEqual(t, result.WinnerGoals, 0, "Winner goals should be non-negative")
@@ -787,7 +879,7 @@ func TestGenerateMatchResult_ZeroStrengthTeams(t *testing.T) {
 	totalTests := 100
 
 	for i := 0; i < totalTests; i++ {
-		result := GenerateMatchResult(homeTeam, awayTeam)
+		result := GenerateMatchResult(homeTeam, awayTeam, models.DefaultLeagueRules())
 
 		// Verify basic structure
 		assert.GreaterOrEqual(t, result.WinnerGoals, 0, "Winner goals should be non-negative")
@@ -836,7 +928,7 @@ func TestGenerateMatchResult_EqualStrengthTeams(t *testing.T) {
 	totalTests := 100
 
 	for i := 0; i < totalTests; i++ {
-		result := GenerateMatchResult(homeTeam, awayTeam)
+		result := GenerateMatchResult(homeTeam, awayTeam, models.DefaultLeagueRules())
 
 		if result.IsDraw {
 			draws++
@@ -859,6 +951,40 @@ func TestGenerateMatchResult_EqualStrengthTeams(t *testing.T) {
 	assert.Less(t, homeWinPercentage, 80.0, "Home advantage shouldn't be overwhelming")
 }
 
+func TestGenerateMatchResult_TacticsChangeGoals(t *testing.T) {
+	// Setup
+	team := models.Team{AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	home, away := team, team
+	home.Name, away.Name = "Home", "Away"
+	attacking := home
+	attacking.Tactics = models.Tactics{Formation: "4-3-3", Pressing: PressingHigh, DefensiveLine: LineHigh}
+
+	// Execute
+	neutralScored, neutralConceded := meanGoals(home, away, 20000)
+	attackingScored, attackingConceded := meanGoals(attacking, away, 20000)
+
+	// Assert
+	assert.Greater(t, attackingScored, neutralScored+0.1, "An attacking side should score more")
+	assert.Greater(t, attackingConceded, neutralConceded+0.05, "An attacking side should concede more")
+}
+
+// meanGoals is the average number of goals home scores and concedes against
+// away over a number of matches.
+func meanGoals(home models.Team, away models.Team, matches int) (scored float64, conceded float64) {
+	for i := 0; i < matches; i++ {
+		result := GenerateMatchResult(home, away, models.DefaultLeagueRules())
+		if result.Winner.Name == home.Name {
+			scored += float64(result.WinnerGoals)
+			conceded += float64(result.LoserGoals)
+		} else {
+			scored += float64(result.LoserGoals)
+			conceded += float64(result.WinnerGoals)
+		}
+	}
+
+	return scored / float64(matches), conceded / float64(matches)
+}
+
 // Benchmark tests
 func BenchmarkSimulationService_Simulation(b *testing.B) {
 	// Setup mocks
@@ -891,16 +1017,17 @@ func BenchmarkSimulationService_Simulation(b *testing.B) {
 	}
 
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	withTransactions(withPredictions(withRatings(withAudit(withDefaultRules(mockAppCtx)))))
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockActiveLeagueRepo.On("GetActiveLeague", "test-league").Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("SetMatchResults", "test-league", mock.AnythingOfType("[]models.MatchResult")).Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league").Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, "test-league", mock.AnythingOfType("[]models.MatchResult")).Return(nil)
 
 	service := NewSimulationService(mockAppCtx)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		service.Simulation("test-league", false)
+		service.Simulation(context.Background(), "test-league", false)
 	}
 }
 
@@ -922,7 +1049,7 @@ func BenchmarkGenerateMatchResult(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		GenerateMatchResult(homeTeam, awayTeam)
+		GenerateMatchResult(homeTeam, awayTeam, models.DefaultLeagueRules())
 	}
 }
 
@@ -942,29 +1069,17 @@ func TestSimulationService_EditMatch_GetActiveLeagueError(t *testing.T) {
 		MatchWeek: 1,
 		Winner:    "Team A",
 	}
-
-	// Existing match result that will be returned by GetMatchResultByWeekAndTeam
-	existingMatch := models.MatchResult{
-		MatchWeek: 1,
-		Home:      "Team A",
-		Away:      "Team B",
-		HomeScore: 1,
-		AwayScore: 2,
-		Winner:    "Team B",
-	}
 	expectedError := errors.New("league not found")
 
 	// Configure mock expectations
-	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
 	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(models.League{}, expectedError)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(models.League{}, expectedError)
 
 	// Create service
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.Error(t, err)
@@ -973,7 +1088,7 @@ func TestSimulationService_EditMatch_GetActiveLeagueError(t *testing.T) {
 	// Verify mock expectations
 	mockAppCtx.AssertExpectations(t)
 	mockActiveLeagueRepo.AssertExpectations(t)
-	mockMatchResultRepo.AssertExpectations(t)
+	mockMatchResultRepo.AssertNotCalled(t, "GetMatchResultByWeekAndTeam", mock.Anything, mock.Anything)
 }
 
 func TestSimulationService_EditMatch_SetActiveLeagueError(t *testing.T) {
@@ -1003,7 +1118,8 @@ func TestSimulationService_EditMatch_SetActiveLeagueError(t *testing.T) {
 	}
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 75},
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 70},
@@ -1030,9 +1146,10 @@ func TestSimulationService_EditMatch_SetActiveLeagueError(t *testing.T) {
 	expectedError := errors.New("failed to set active league")
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(expectedError)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(expectedError)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -1041,7 +1158,7 @@ func TestSimulationService_EditMatch_SetActiveLeagueError(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert - The current implementation returns the error from SetActiveLeague
 	assert.Error(t, err)
@@ -1080,7 +1197,8 @@ func TestSimulationService_EditMatch_EditMatchScoreError(t *testing.T) {
 	}
 
 	activeLeague := models.League{
-		LeagueID: editData.LeagueId,
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
 		Teams: []models.Team{
 			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 75},
 			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 70},
@@ -1107,10 +1225,11 @@ func TestSimulationService_EditMatch_EditMatchScoreError(t *testing.T) {
 	expectedError := errors.New("failed to edit match score")
 
 	// Configure mock expectations
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(existingMatch, nil)
-	mockActiveLeagueRepo.On("GetActiveLeague", editData.LeagueId).Return(activeLeague, nil)
-	mockActiveLeagueRepo.On("SetActiveLeague", mock.AnythingOfType("models.League")).Return(nil)
-	mockMatchResultRepo.On("EditMatchScore", editData).Return(expectedError)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(existingMatch, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{existingMatch}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, editData).Return(expectedError)
 
 	// Create test AppContext
 	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
@@ -1119,7 +1238,7 @@ func TestSimulationService_EditMatch_EditMatchScoreError(t *testing.T) {
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.Error(t, err)
@@ -1133,6 +1252,7 @@ func TestSimulationService_EditMatch_EditMatchScoreError(t *testing.T) {
 
 func TestSimulationService_EditMatch_GetMatchResultError(t *testing.T) {
 	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
 	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
 	mockAppCtx := &MockAppContext{}
 
@@ -1146,17 +1266,24 @@ func TestSimulationService_EditMatch_GetMatchResultError(t *testing.T) {
 		MatchWeek: 1,
 		Winner:    "Team A",
 	}
+	activeLeague := models.League{
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
+		Teams:       []models.Team{{Name: "Team A"}, {Name: "Team B"}},
+	}
 	expectedError := errors.New("match not found")
 
 	// Configure mock expectations
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
 	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
-	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", editData).Return(models.MatchResult{}, expectedError)
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(models.MatchResult{}, expectedError)
 
 	// Create service
 	service := NewSimulationService(mockAppCtx)
 
 	// Execute
-	err := service.EditMatch(editData)
+	err := service.EditMatch(context.Background(), editData)
 
 	// Assert
 	assert.Error(t, err)
@@ -1166,3 +1293,503 @@ func TestSimulationService_EditMatch_GetMatchResultError(t *testing.T) {
 	mockAppCtx.AssertExpectations(t)
 	mockMatchResultRepo.AssertExpectations(t)
 }
+
+func TestSimulationService_EditMatch_RejectsUnknownTeamsAndUnplayedWeeks(t *testing.T) {
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+
+	activeLeague := models.League{
+		LeagueID:    "test-league-id",
+		CurrentWeek: 2,
+		Teams:       []models.Team{{Name: "Team A"}, {Name: "Team B"}},
+	}
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, "test-league-id").Return(activeLeague, nil)
+
+	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
+	service := NewSimulationService(mockAppCtx)
+
+	// Execute
+	err := service.EditMatch(
+		context.Background(), models.EditMatchResult{
+			LeagueId:  "test-league-id",
+			Home:      "Team A",
+			Away:      "Team Q",
+			MatchWeek: 3,
+		})
+
+	// Assert
+	var appErr *apperrors.Error
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperrors.KindValidation, appErr.Kind)
+	assert.Equal(t, []string{"away", "matchWeek"}, []string{appErr.Fields[0].Field, appErr.Fields[1].Field})
+	mockMatchResultRepo.AssertNotCalled(t, "GetMatchResultByWeekAndTeam", mock.Anything, mock.Anything)
+}
+
+func TestSimulationService_EditMatch_TeamMissingFromStandings(t *testing.T) {
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+
+	editData := models.EditMatchResult{
+		LeagueId:  "test-league-id",
+		Home:      "Team A",
+		Away:      "Team Z",
+		HomeScore: 1,
+		AwayScore: 0,
+		MatchWeek: 1,
+	}
+	activeLeague := models.League{
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
+		Teams:       []models.Team{{Name: "Team A"}, {Name: "Team Z"}},
+		Standings:   []models.Standings{{Team: models.Team{Name: "Team A"}}},
+	}
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(
+		models.MatchResult{Home: "Team A", Away: "Team Z", MatchWeek: 1}, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+
+	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
+	service := NewSimulationService(mockAppCtx)
+
+	// Execute
+	err := service.EditMatch(context.Background(), editData)
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	mockActiveLeagueRepo.AssertNotCalled(t, "SetActiveLeague", mock.Anything, mock.Anything)
+	mockMatchResultRepo.AssertNotCalled(t, "EditMatchScore", mock.Anything, mock.Anything)
+}
+
+func TestSimulationService_SetTactics_Success(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+
+	// Test data
+	leagueId := "test-league-id"
+	tactics := models.Tactics{Formation: "5-3-2", Pressing: "low", DefensiveLine: "deep"}
+	activeLeague := models.League{
+		LeagueID: leagueId,
+		Teams: []models.Team{
+			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80},
+			{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 75},
+		},
+		Standings: []models.Standings{
+			{Team: models.Team{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}},
+			{Team: models.Team{Name: "Team B", AttackPower: 85, DefensePower: 75, Stamina: 85, Morale: 75}},
+		},
+	}
+
+	// Configure mocks
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On(
+		"SetActiveLeague", mock.Anything, mock.MatchedBy(
+			func(league models.League) bool {
+				return league.Teams[1].Tactics == tactics &&
+					league.Standings[1].Team.Tactics == tactics &&
+					league.Teams[0].Tactics == models.Tactics{}
+			})).Return(nil)
+
+	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
+	service := NewSimulationService(mockAppCtx)
+
+	// Execute
+	err := service.SetTactics(context.Background(), leagueId, "Team B", tactics)
+
+	// Assert
+	assert.NoError(t, err)
+	mockActiveLeagueRepo.AssertExpectations(t)
+}
+
+func TestSimulationService_SetTactics_RecordsAudit(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+
+	// Test data
+	leagueId := "test-league-id"
+	previous := models.Tactics{Formation: "4-4-2", Pressing: "medium", DefensiveLine: "normal"}
+	tactics := models.Tactics{Formation: "5-3-2", Pressing: "low", DefensiveLine: "deep"}
+	activeLeague := models.League{
+		LeagueID: leagueId,
+		Teams:    []models.Team{{Name: "Team A", Tactics: previous}},
+	}
+
+	// Configure mocks
+	var entry models.AuditEntry
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", mock.Anything, mock.Anything).Return(nil)
+	mockAuditRepo.On("AppendAuditEntry", mock.Anything, mock.AnythingOfType("models.AuditEntry")).
+		Run(
+			func(args mock.Arguments) {
+				entry = args.Get(1).(models.AuditEntry)
+			}).
+		Return(nil)
+
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	service := NewSimulationService(mockAppCtx)
+
+	// Execute
+	err := service.SetTactics(context.Background(), leagueId, "Team A", tactics)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuditActionTactics, entry.Action)
+	assert.Equal(t, leagueId, entry.LeagueId)
+
+	var before, after models.SetTacticsRequest
+	assert.NoError(t, json.Unmarshal(entry.Before, &before))
+	assert.NoError(t, json.Unmarshal(entry.After, &after))
+	assert.Equal(t, previous, before.Tactics)
+	assert.Equal(t, tactics, after.Tactics)
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestSimulationService_SetTactics_InvalidTactics(t *testing.T) {
+	mockAppCtx := &MockAppContext{}
+	service := NewSimulationService(mockAppCtx)
+
+	err := service.SetTactics(context.Background(), "test-league-id", "Team A", models.Tactics{Formation: "1-1-8"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown formation")
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	mockAppCtx.AssertNotCalled(t, "ActiveLeagueRepository")
+}
+
+func TestSimulationService_SetTactics_UnknownTeam(t *testing.T) {
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+
+	leagueId := "test-league-id"
+	activeLeague := models.League{
+		LeagueID: leagueId,
+		Teams:    []models.Team{{Name: "Team A"}},
+	}
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+
+	mockAppCtx := createTestAppContext(mockActiveLeagueRepo, mockMatchResultRepo)
+	service := NewSimulationService(mockAppCtx)
+
+	err := service.SetTactics(context.Background(), leagueId, "Team Z", models.Tactics{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Team Z")
+	assert.True(t, apperrors.Is(err, apperrors.KindNotFound))
+	mockActiveLeagueRepo.AssertNotCalled(t, "SetActiveLeague", mock.Anything, mock.Anything)
+}
+
+func TestGenerateMatchResult_TacticalEdge(t *testing.T) {
+	// Equal teams, but the home side counters a high-pressing opponent
+	homeTeam := models.Team{
+		Name: "Counter Home", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80,
+		Tactics: models.Tactics{Formation: "4-4-2", Pressing: "medium", DefensiveLine: "deep"},
+	}
+	awayTeam := models.Team{
+		Name: "Pressing Away", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80,
+		Tactics: models.Tactics{Formation: "4-4-2", Pressing: "high", DefensiveLine: "normal"},
+	}
+
+	homeModifier, _ := MatchupModifiers(homeTeam.Tactics, awayTeam.Tactics)
+	assert.Greater(t, homeModifier.Attack, TacticsModifier(homeTeam.Tactics).Attack)
+
+	for i := 0; i < 100; i++ {
+		result := GenerateMatchResult(homeTeam, awayTeam, models.DefaultLeagueRules())
+		assert.GreaterOrEqual(t, result.WinnerGoals, result.LoserGoals)
+	}
+}
+
+func TestGenerateMatchResult_RulesDrawModel(t *testing.T) {
+	homeTeam := models.Team{Name: "Home", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	awayTeam := models.Team{Name: "Away", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+
+	noDraws := models.DefaultLeagueRules()
+	noDraws.Draw.Chance = 0
+	for i := 0; i < 100; i++ {
+		assert.False(t, GenerateMatchResult(homeTeam, awayTeam, noDraws).IsDraw)
+	}
+
+	goallessDraws := models.DefaultLeagueRules()
+	goallessDraws.Draw = models.DrawModel{Chance: 0.99, MaxGoals: 0}
+	for i := 0; i < 100; i++ {
+		result := GenerateMatchResult(homeTeam, awayTeam, goallessDraws)
+		if result.IsDraw {
+			assert.Equal(t, 0, result.WinnerGoals)
+		}
+	}
+}
+
+func TestGenerateMatchResult_GoalRates(t *testing.T) {
+	homeTeam := models.Team{Name: "Home", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	awayTeam := models.Team{Name: "Away", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+
+	oneNilWins := models.DefaultLeagueRules()
+	oneNilWins.Draw.Chance = 0
+	oneNilWins.GoalRates = &models.GoalRates{Winner: 0, Loser: 0, Draw: 3}
+	for i := 0; i < 100; i++ {
+		result := GenerateMatchResult(homeTeam, awayTeam, oneNilWins)
+		assert.Equal(t, 1, result.WinnerGoals)
+		assert.Equal(t, 0, result.LoserGoals)
+	}
+
+	rules := models.DefaultLeagueRules()
+	rules.Draw.Chance = 0.5
+	rules.GoalRates = &models.GoalRates{Winner: 1.5, Loser: 0.4, Draw: 1}
+	drawGoals, draws := 0, 0
+	for i := 0; i < 5000; i++ {
+		result := GenerateMatchResult(homeTeam, awayTeam, rules)
+		if result.IsDraw {
+			assert.Equal(t, result.WinnerGoals, result.LoserGoals)
+			drawGoals += result.WinnerGoals
+			draws++
+		} else {
+			assert.Greater(t, result.WinnerGoals, result.LoserGoals)
+		}
+	}
+	assert.InDelta(t, 1.0, float64(drawGoals)/float64(draws), 0.15)
+}
+
+func TestGenerateMatchResult_EloInEngine(t *testing.T) {
+	// Equal attributes, but the home team is rated far above the away team.
+	homeTeam := models.Team{Name: "Home", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80, Rating: 2300}
+	awayTeam := models.Team{Name: "Away", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80, Rating: 1500}
+
+	rules := models.DefaultLeagueRules()
+	rules.Draw.Chance = 0
+	rules.Elo = &models.EloRules{InEngine: true}
+	homeWins := 0
+	for i := 0; i < 1000; i++ {
+		if GenerateMatchResult(homeTeam, awayTeam, rules).Winner.Name == "Home" {
+			homeWins++
+		}
+	}
+
+	// Odds of 100 to 1 before home advantage.
+	assert.Greater(t, homeWins, 970)
+
+	rules.Elo.InEngine = false
+	homeWins = 0
+	for i := 0; i < 1000; i++ {
+		if GenerateMatchResult(homeTeam, awayTeam, rules).Winner.Name == "Home" {
+			homeWins++
+		}
+	}
+	assert.Less(t, homeWins, 700)
+}
+
+func TestSimulationService_EditMatch_ReplaysRatings(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+
+	// Team A lost week 1 and the edit turns it into a 1-0 win.
+	editData := models.EditMatchResult{
+		LeagueId: "test-league-id", Home: "Team A", Away: "Team B", HomeScore: 1, AwayScore: 0, MatchWeek: 1,
+	}
+	played := []models.MatchResult{
+		{MatchWeek: 1, Home: "Team A", Away: "Team B", HomeScore: 0, AwayScore: 1, Winner: "Team B"},
+	}
+	history := []models.RatingChange{
+		{MatchWeek: 1, Team: "Team A", Opponent: "Team B", Before: 1500, After: 1490},
+		{MatchWeek: 1, Team: "Team B", Opponent: "Team A", Before: 1500, After: 1510},
+	}
+	activeLeague := models.League{
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
+		Teams: []models.Team{
+			{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 75, Rating: 1490},
+			{Name: "Team B", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 75, Rating: 1510},
+		},
+		Standings: []models.Standings{
+			{Team: models.Team{Name: "Team A", Rating: 1490}, Played: 1, Losses: 1, Against: 1},
+			{Team: models.Team{Name: "Team B", Rating: 1510}, Played: 1, Wins: 1, Points: 3, Goals: 1},
+		},
+	}
+
+	rules := models.DefaultLeagueRules()
+	edited := models.MatchResult{MatchWeek: 1, Home: "Team A", Away: "Team B", HomeScore: 1, Winner: "Team A"}
+	expected := rating.Update(1500, 1500, edited, rating.Parameters(rules))
+
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(played[0], nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return(played, nil)
+	mockMatchResultRepo.On("EditMatchScore", mock.Anything, mock.AnythingOfType("models.EditMatchResult")).Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On(
+		"SetActiveLeague", mock.Anything, mock.MatchedBy(
+			func(l models.League) bool {
+				return l.Teams[0].Rating == expected[0].After && l.Standings[1].Team.Rating == expected[1].After
+			})).Return(nil)
+	mockRatingRepo.On("GetRatingChanges", mock.Anything, editData.LeagueId, "").Return(history, nil)
+	mockRatingRepo.On("DeleteRatingChanges", mock.Anything, editData.LeagueId).Return(nil)
+	mockRatingRepo.On("AppendRatingChanges", mock.Anything, editData.LeagueId, expected).Return(nil)
+
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, editData.LeagueId).Return(rules, nil)
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo)
+	withTransactions(withAudit(mockAppCtx))
+
+	// Execute
+	err := NewSimulationService(mockAppCtx).EditMatch(context.Background(), editData)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Greater(t, expected[0].After, 1500.0)
+	mockActiveLeagueRepo.AssertExpectations(t)
+	mockRatingRepo.AssertExpectations(t)
+}
+
+func TestSimulationService_EditMatch_WritesInOneTransaction(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	inTx := mock.MatchedBy(interfaces.InMockTx)
+
+	editData := models.EditMatchResult{
+		LeagueId: "test-league-id", Home: "Team A", Away: "Team B", HomeScore: 1, AwayScore: 0, MatchWeek: 1,
+	}
+	played := models.MatchResult{MatchWeek: 1, Home: "Team A", Away: "Team B", AwayScore: 1, Winner: "Team B"}
+	activeLeague := models.League{
+		LeagueID:    editData.LeagueId,
+		CurrentWeek: 1,
+		Teams:       []models.Team{{Name: "Team A"}, {Name: "Team B"}},
+		Standings: []models.Standings{
+			{Team: models.Team{Name: "Team A"}, Played: 1, Losses: 1, Against: 1},
+			{Team: models.Team{Name: "Team B"}, Played: 1, Wins: 1, Points: 3, Goals: 1},
+		},
+	}
+	appendErr := apperrors.Internal(errors.New("connection lost"), "failed to write rating history")
+
+	mockMatchResultRepo.On("GetMatchResultByWeekAndTeam", mock.Anything, editData).Return(played, nil)
+	mockMatchResultRepo.On("GetMatchResults", mock.Anything, editData.LeagueId).Return([]models.MatchResult{played}, nil)
+	mockMatchResultRepo.On("EditMatchScore", inTx, mock.AnythingOfType("models.EditMatchResult")).Return(nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, editData.LeagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", inTx, mock.AnythingOfType("models.League")).Return(nil)
+	mockRatingRepo.On("GetRatingChanges", mock.Anything, editData.LeagueId, "").Return([]models.RatingChange{}, nil)
+	mockRatingRepo.On("DeleteRatingChanges", inTx, editData.LeagueId).Return(nil)
+	mockRatingRepo.On("AppendRatingChanges", inTx, editData.LeagueId, mock.Anything).Return(appendErr)
+
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	withTransactions(withDefaultRules(mockAppCtx))
+
+	// Execute
+	err := NewSimulationService(mockAppCtx).EditMatch(context.Background(), editData)
+
+	// Assert
+	assert.ErrorIs(t, err, appendErr)
+	mockActiveLeagueRepo.AssertExpectations(t)
+	mockMatchResultRepo.AssertExpectations(t)
+	mockRatingRepo.AssertExpectations(t)
+	mockAuditRepo.AssertNotCalled(t, "AppendAuditEntry", mock.Anything, mock.Anything)
+}
+
+func TestSimulationService_Simulation_UsesLeagueRules(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockLeagueRepo := &interfaces.MockLeagueRepository{}
+
+	// Test data: draws are impossible and a win is worth 2 points
+	leagueId := "rules-league-id"
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 2
+	rules.Draw.Chance = 0
+	teamA := models.Team{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	teamB := models.Team{Name: "Team B", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	activeLeague := models.League{
+		LeagueID:  leagueId,
+		Teams:     []models.Team{teamA, teamB},
+		Standings: []models.Standings{{Team: teamA}, {Team: teamB}},
+		UpcomingFixtures: []models.Week{
+			{Number: 1, Matches: []models.Match{{Home: &teamA, Away: &teamB}}},
+		},
+		PlayedFixtures: []models.Week{},
+	}
+
+	// Configure mocks
+	mockAppCtx := &MockAppContext{}
+	withTransactions(withPredictions(withRatings(withAudit(mockAppCtx))))
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockAppCtx.On("LeagueRepository").Return(mockLeagueRepo)
+	mockLeagueRepo.On("GetLeagueRules", mock.Anything, leagueId).Return(rules, nil)
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On(
+		"SetActiveLeague", mock.Anything, mock.MatchedBy(
+			func(league models.League) bool {
+				return league.Standings[0].Points+league.Standings[1].Points == 2
+			})).Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", mock.Anything, leagueId, mock.AnythingOfType("[]models.MatchResult")).Return(nil)
+
+	service := NewSimulationService(mockAppCtx)
+
+	// Execute
+	_, err := service.Simulation(context.Background(), leagueId, false)
+
+	// Assert
+	assert.NoError(t, err)
+	mockLeagueRepo.AssertExpectations(t)
+	mockActiveLeagueRepo.AssertExpectations(t)
+}
+
+func TestSimulationService_Simulation_WritesInOneTransaction(t *testing.T) {
+	// Setup mocks
+	mockActiveLeagueRepo := &interfaces.MockActiveLeagueRepository{}
+	mockMatchResultRepo := &interfaces.MockMatchResultRepository{}
+	mockRatingRepo := &interfaces.MockRatingRepository{}
+	mockPredictionRepo := &interfaces.MockPredictionRepository{}
+	mockAuditRepo := &interfaces.MockAuditRepository{}
+	inTx := mock.MatchedBy(interfaces.InMockTx)
+
+	leagueId := "test-league-id"
+	teamA := models.Team{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	teamB := models.Team{Name: "Team B", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+	activeLeague := models.League{
+		LeagueID:  leagueId,
+		Teams:     []models.Team{teamA, teamB},
+		Standings: []models.Standings{{Team: teamA}, {Team: teamB}},
+		UpcomingFixtures: []models.Week{
+			{Number: 1, Matches: []models.Match{{Home: &teamA, Away: &teamB}}},
+		},
+		PlayedFixtures: []models.Week{},
+	}
+	snapshotErr := apperrors.Internal(errors.New("connection lost"), "failed to write prediction snapshot")
+
+	mockActiveLeagueRepo.On("GetActiveLeague", mock.Anything, leagueId).Return(activeLeague, nil)
+	mockActiveLeagueRepo.On("SetActiveLeague", inTx, mock.AnythingOfType("models.League")).Return(nil)
+	mockMatchResultRepo.On("SetMatchResults", inTx, leagueId, mock.AnythingOfType("[]models.MatchResult")).Return(nil)
+	mockRatingRepo.On("AppendRatingChanges", inTx, leagueId, mock.Anything).Return(nil)
+	mockPredictionRepo.On("SavePredictionSnapshot", inTx, leagueId, mock.Anything).Return(snapshotErr)
+
+	mockAppCtx := &MockAppContext{}
+	mockAppCtx.On("ActiveLeagueRepository").Return(mockActiveLeagueRepo)
+	mockAppCtx.On("MatchResultRepository").Return(mockMatchResultRepo)
+	mockAppCtx.On("RatingRepository").Return(mockRatingRepo)
+	mockAppCtx.On("PredictionRepository").Return(mockPredictionRepo)
+	mockAppCtx.On("AuditRepository").Return(mockAuditRepo)
+	mockAppCtx.On("Config").Return(config.Default())
+	withTransactions(withDefaultRules(mockAppCtx))
+
+	// Execute
+	response, err := NewSimulationService(mockAppCtx).Simulation(context.Background(), leagueId, false)
+
+	// Assert
+	assert.ErrorIs(t, err, snapshotErr)
+	assert.Empty(t, response.Matches)
+	mockActiveLeagueRepo.AssertExpectations(t)
+	mockMatchResultRepo.AssertExpectations(t)
+	mockRatingRepo.AssertExpectations(t)
+	mockPredictionRepo.AssertExpectations(t)
+	mockAuditRepo.AssertNotCalled(t, "AppendAuditEntry", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,206 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"league-sim/internal/models"
+)
+
+const (
+	PressingLow    = "low"
+	PressingMedium = "medium"
+	PressingHigh   = "high"
+
+	LineDeep   = "deep"
+	LineNormal = "normal"
+	LineHigh   = "high"
+
+	StylePossession = "possession"
+	StylePressing   = "pressing"
+	StyleCounter    = "counter"
+
+	baseStaminaDrain = 5.0
+	styleEdgeBonus   = 1.06
+)
+
+type TacticalModifier struct {
+	Attack       float64
+	Defense      float64
+	StaminaDrain float64
+}
+
+var formationModifiers = map[string]TacticalModifier{
+	"4-4-2": {Attack: 1.00, Defense: 1.00, StaminaDrain: 1.0},
+	"4-3-3": {Attack: 1.08, Defense: 0.95, StaminaDrain: 1.1},
+	"3-5-2": {Attack: 1.04, Defense: 0.98, StaminaDrain: 1.1},
+	"4-5-1": {Attack: 0.95, Defense: 1.05, StaminaDrain: 1.0},
+	"5-3-2": {Attack: 0.92, Defense: 1.08, StaminaDrain: 0.9},
+}
+
+var pressingModifiers = map[string]TacticalModifier{
+	PressingLow:    {Attack: 0.97, Defense: 1.00, StaminaDrain: 0.8},
+	PressingMedium: {Attack: 1.00, Defense: 1.00, StaminaDrain: 1.0},
+	PressingHigh:   {Attack: 1.05, Defense: 1.02, StaminaDrain: 1.5},
+}
+
+var lineModifiers = map[string]TacticalModifier{
+	LineDeep:   {Attack: 0.97, Defense: 1.05, StaminaDrain: 0.9},
+	LineNormal: {Attack: 1.00, Defense: 1.00, StaminaDrain: 1.0},
+	LineHigh:   {Attack: 1.04, Defense: 0.96, StaminaDrain: 1.1},
+}
+
+// styleBeats maps each style to the style it has the upper hand against:
+// pressing disrupts possession, possession starves the counter, and the
+// counter exploits the space a pressing side leaves behind.
+var styleBeats = map[string]string{
+	StylePressing:   StylePossession,
+	StylePossession: StyleCounter,
+	StyleCounter:    StylePressing,
+}
+
+func DefaultTactics() models.Tactics {
+	return models.Tactics{
+		Formation:     "4-4-2",
+		Pressing:      PressingMedium,
+		DefensiveLine: LineNormal,
+	}
+}
+
+func normalizeTactics(tactics models.Tactics) models.Tactics {
+	defaults := DefaultTactics()
+	if tactics.Formation == "" {
+		tactics.Formation = defaults.Formation
+	}
+	if tactics.Pressing == "" {
+		tactics.Pressing = defaults.Pressing
+	}
+	if tactics.DefensiveLine == "" {
+		tactics.DefensiveLine = defaults.DefensiveLine
+	}
+
+	return tactics
+}
+
+func ValidateTactics(tactics models.Tactics) error {
+	tactics = normalizeTactics(tactics)
+
+	if _, ok := formationModifiers[tactics.Formation]; !ok {
+		return fmt.Errorf("unknown formation %q", tactics.Formation)
+	}
+	if _, ok := pressingModifiers[tactics.Pressing]; !ok {
+		return fmt.Errorf("unknown pressing intensity %q", tactics.Pressing)
+	}
+	if _, ok := lineModifiers[tactics.DefensiveLine]; !ok {
+		return fmt.Errorf("unknown defensive line %q", tactics.DefensiveLine)
+	}
+
+	return nil
+}
+
+func TacticalStyle(tactics models.Tactics) string {
+	tactics = normalizeTactics(tactics)
+
+	if tactics.Pressing == PressingHigh {
+		return StylePressing
+	}
+	if tactics.DefensiveLine == LineDeep {
+		return StyleCounter
+	}
+
+	return StylePossession
+}
+
+func TacticsModifier(tactics models.Tactics) TacticalModifier {
+	tactics = normalizeTactics(tactics)
+	modifier := TacticalModifier{Attack: 1, Defense: 1, StaminaDrain: 1}
+
+	for _, m := range []TacticalModifier{
+		formationModifiers[tactics.Formation],
+		pressingModifiers[tactics.Pressing],
+		lineModifiers[tactics.DefensiveLine],
+	} {
+		if m == (TacticalModifier{}) {
+			continue
+		}
+		modifier.Attack *= m.Attack
+		modifier.Defense *= m.Defense
+		modifier.StaminaDrain *= m.StaminaDrain
+	}
+
+	return modifier
+}
+
+// MatchupModifiers returns the modifiers of both sides once the style
+// interaction between them has been applied.
+func MatchupModifiers(home models.Tactics, away models.Tactics) (TacticalModifier, TacticalModifier) {
+	homeModifier := TacticsModifier(home)
+	awayModifier := TacticsModifier(away)
+
+	homeStyle := TacticalStyle(home)
+	awayStyle := TacticalStyle(away)
+
+	if styleBeats[homeStyle] == awayStyle {
+		homeModifier.Attack *= styleEdgeBonus
+	} else if styleBeats[awayStyle] == homeStyle {
+		awayModifier.Attack *= styleEdgeBonus
+	}
+
+	return homeModifier, awayModifier
+}
+
+func ApplyTactics(team models.Team, modifier TacticalModifier) models.Team {
+	team.AttackPower *= modifier.Attack
+	team.DefensePower *= modifier.Defense
+
+	return team
+}
+
+// goalFactors is how much tactics change the goals each side scores: the
+// tactic-adjusted attack of a side against the tactic-adjusted defence it
+// faces, relative to the same teams without tactics. When both factors are 1
+// the goal model's score stands as drawn.
+func goalFactors(home TacticalModifier, away TacticalModifier) (homeFactor float64, awayFactor float64) {
+	return home.Attack / away.Defense, away.Attack / home.Defense
+}
+
+// scaledGoal is one outcome of scaling a number of goals, with its chance.
+type scaledGoal struct {
+	goals int
+	p     float64
+}
+
+// scaledGoals spreads goals times factor over the whole numbers on either
+// side of it, so that on average a side scores exactly goals times factor.
+func scaledGoals(goals int, factor float64) []scaledGoal {
+	scaled := float64(goals) * factor
+	whole := math.Floor(scaled)
+	if fraction := scaled - whole; fraction > 0 {
+		return []scaledGoal{{int(whole), 1 - fraction}, {int(whole) + 1, fraction}}
+	}
+
+	return []scaledGoal{{int(whole), 1}}
+}
+
+// scaleGoals draws one outcome of scaledGoals.
+func scaleGoals(goals int, factor float64) int {
+	outcomes := scaledGoals(goals, factor)
+	if len(outcomes) == 2 && rand.Float64() < outcomes[1].p {
+		return outcomes[1].goals
+	}
+
+	return outcomes[0].goals
+}
+
+// keepDecided keeps a scaled score a win for the side that won the match: the
+// winner scores at least once and more than the loser.
+func keepDecided(winnerGoals int, loserGoals int) (int, int) {
+	winnerGoals = max(winnerGoals, 1)
+
+	return winnerGoals, min(loserGoals, winnerGoals-1)
+}
+
+func StaminaDrain(tactics models.Tactics) float64 {
+	return baseStaminaDrain * TacticsModifier(tactics).StaminaDrain
+}
@@ -0,0 +1,154 @@
+package simulation
+
+import (
+	"testing"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTactics(t *testing.T) {
+	tests := []struct {
+		name    string
+		tactics models.Tactics
+		wantErr bool
+	}{
+		{name: "Empty tactics use defaults", tactics: models.Tactics{}, wantErr: false},
+		{name: "Valid tactics", tactics: models.Tactics{Formation: "4-3-3", Pressing: PressingHigh, DefensiveLine: LineHigh}, wantErr: false},
+		{name: "Unknown formation", tactics: models.Tactics{Formation: "2-2-6"}, wantErr: true},
+		{name: "Unknown pressing", tactics: models.Tactics{Pressing: "extreme"}, wantErr: true},
+		{name: "Unknown defensive line", tactics: models.Tactics{DefensiveLine: "offside"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := ValidateTactics(tt.tactics)
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+	}
+}
+
+func TestTacticalStyle(t *testing.T) {
+	assert.Equal(t, StylePossession, TacticalStyle(models.Tactics{}))
+	assert.Equal(t, StylePressing, TacticalStyle(models.Tactics{Pressing: PressingHigh}))
+	assert.Equal(t, StyleCounter, TacticalStyle(models.Tactics{DefensiveLine: LineDeep}))
+	assert.Equal(t, StylePressing, TacticalStyle(models.Tactics{Pressing: PressingHigh, DefensiveLine: LineDeep}))
+}
+
+func TestTacticsModifier_DefaultIsNeutral(t *testing.T) {
+	modifier := TacticsModifier(models.Tactics{})
+
+	assert.Equal(t, TacticalModifier{Attack: 1, Defense: 1, StaminaDrain: 1}, modifier)
+	assert.Equal(t, 5.0, StaminaDrain(models.Tactics{}))
+}
+
+func TestTacticsModifier_Combines(t *testing.T) {
+	modifier := TacticsModifier(models.Tactics{Formation: "5-3-2", Pressing: PressingLow, DefensiveLine: LineDeep})
+
+	assert.Less(t, modifier.Attack, 1.0)
+	assert.Greater(t, modifier.Defense, 1.0)
+	assert.Less(t, modifier.StaminaDrain, 1.0)
+	assert.Greater(t, StaminaDrain(models.Tactics{Pressing: PressingHigh}), 5.0)
+}
+
+func TestMatchupModifiers_RockPaperScissors(t *testing.T) {
+	pressing := models.Tactics{Pressing: PressingHigh}
+	possession := models.Tactics{}
+	counter := models.Tactics{DefensiveLine: LineDeep}
+
+	tests := []struct {
+		name   string
+		winner models.Tactics
+		loser  models.Tactics
+	}{
+		{name: "Pressing beats possession", winner: pressing, loser: possession},
+		{name: "Possession beats counter", winner: possession, loser: counter},
+		{name: "Counter beats pressing", winner: counter, loser: pressing},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				winnerModifier, loserModifier := MatchupModifiers(tt.winner, tt.loser)
+				assert.InDelta(t, TacticsModifier(tt.winner).Attack*styleEdgeBonus, winnerModifier.Attack, 1e-9)
+				assert.Equal(t, TacticsModifier(tt.loser), loserModifier)
+
+				loserModifier, winnerModifier = MatchupModifiers(tt.loser, tt.winner)
+				assert.InDelta(t, TacticsModifier(tt.winner).Attack*styleEdgeBonus, winnerModifier.Attack, 1e-9)
+				assert.Equal(t, TacticsModifier(tt.loser), loserModifier)
+			})
+	}
+}
+
+func TestMatchupModifiers_SameStyleIsNeutral(t *testing.T) {
+	homeModifier, awayModifier := MatchupModifiers(models.Tactics{}, models.Tactics{})
+
+	assert.Equal(t, TacticsModifier(models.Tactics{}), homeModifier)
+	assert.Equal(t, TacticsModifier(models.Tactics{}), awayModifier)
+}
+
+func TestApplyTactics(t *testing.T) {
+	team := models.Team{Name: "Team A", AttackPower: 80, DefensePower: 80, Stamina: 80, Morale: 80}
+
+	result := ApplyTactics(team, TacticalModifier{Attack: 1.1, Defense: 0.9, StaminaDrain: 1})
+
+	assert.InDelta(t, 88.0, result.AttackPower, 1e-9)
+	assert.InDelta(t, 72.0, result.DefensePower, 1e-9)
+	assert.Equal(t, team.Stamina, result.Stamina)
+	assert.Equal(t, team.Morale, result.Morale)
+}
+
+func TestWinnerTeamAttributeChanging_HighPressingDrainsMore(t *testing.T) {
+	team := models.Team{Name: "Pressers", Stamina: 80, Morale: 80, Tactics: models.Tactics{Pressing: PressingHigh}}
+	standings := models.Standings{Team: team}
+
+	WinnerTeamAttributeChanging(&standings, &team, models.MatchOutcome{WinnerGoals: 2, LoserGoals: 0}, models.DefaultLeagueRules())
+
+	assert.InDelta(t, 80-StaminaDrain(team.Tactics), team.Stamina, 1e-9)
+	assert.Less(t, team.Stamina, 75.0)
+}
+
+func TestGoalFactors(t *testing.T) {
+	neutral := TacticsModifier(models.Tactics{})
+	homeFactor, awayFactor := goalFactors(neutral, neutral)
+	assert.Equal(t, 1.0, homeFactor)
+	assert.Equal(t, 1.0, awayFactor)
+
+	homeFactor, awayFactor = goalFactors(TacticalModifier{Attack: 1.2, Defense: 0.8}, TacticalModifier{Attack: 0.9, Defense: 1.5})
+	assert.InDelta(t, 0.8, homeFactor, 1e-9)
+	assert.InDelta(t, 1.125, awayFactor, 1e-9)
+}
+
+func TestScaledGoals(t *testing.T) {
+	assert.Equal(t, []scaledGoal{{goals: 3, p: 1}}, scaledGoals(3, 1))
+	assert.Equal(t, []scaledGoal{{goals: 0, p: 1}}, scaledGoals(0, 1.3))
+
+	outcomes := scaledGoals(3, 1.25)
+	assert.Len(t, outcomes, 2)
+	assert.Equal(t, 3, outcomes[0].goals)
+	assert.InDelta(t, 0.25, outcomes[0].p, 1e-9)
+	assert.Equal(t, 4, outcomes[1].goals)
+	assert.InDelta(t, 0.75, outcomes[1].p, 1e-9)
+	assert.InDelta(t, 3.75, float64(outcomes[0].goals)*outcomes[0].p+float64(outcomes[1].goals)*outcomes[1].p, 1e-9)
+	assert.Equal(t, 2, scaleGoals(2, 1))
+}
+
+func TestKeepDecided(t *testing.T) {
+	winnerGoals, loserGoals := keepDecided(0, 0)
+	assert.Equal(t, 1, winnerGoals)
+	assert.Equal(t, 0, loserGoals)
+
+	winnerGoals, loserGoals = keepDecided(2, 3)
+	assert.Equal(t, 2, winnerGoals)
+	assert.Equal(t, 1, loserGoals)
+
+	winnerGoals, loserGoals = keepDecided(4, 1)
+	assert.Equal(t, 4, winnerGoals)
+	assert.Equal(t, 1, loserGoals)
+}
@@ -4,12 +4,34 @@ import (
 	"league-sim/internal/models"
 )
 
-func LoserTeamAttributeChanging(standings *models.Standings, team *models.Team, matchOutCome models.MatchOutcome) {
+func ResultPoints(rules models.LeagueRules, goalsFor int, goalsAgainst int) int {
+	points := rules.PointsForLoss
+	if goalsFor > goalsAgainst {
+		points = rules.PointsForWin
+	} else if goalsFor == goalsAgainst {
+		points = rules.PointsForDraw
+	}
+
+	bonus := rules.BonusPoints
+	if bonus.Points > 0 && bonus.GoalThreshold > 0 && goalsFor >= bonus.GoalThreshold {
+		points += bonus.Points
+	}
+
+	return points
+}
+
+func LoserTeamAttributeChanging(
+	standings *models.Standings,
+	team *models.Team,
+	matchOutCome models.MatchOutcome,
+	rules models.LeagueRules,
+) {
 	standings.Goals += matchOutCome.LoserGoals
 	standings.Against += matchOutCome.WinnerGoals
 	standings.Played += 1
 	standings.Losses += 1
-	team.Stamina -= 5
+	standings.Points += ResultPoints(rules, matchOutCome.LoserGoals, matchOutCome.WinnerGoals)
+	team.Stamina -= StaminaDrain(team.Tactics)
 	if team.Stamina < 0 {
 		team.Stamina = 0
 	}
@@ -19,13 +41,18 @@ func LoserTeamAttributeChanging(standings *models.Standings, team *models.Team,
 	}
 }
 
-func WinnerTeamAttributeChanging(standings *models.Standings, team *models.Team, matchOutCome models.MatchOutcome) {
+func WinnerTeamAttributeChanging(
+	standings *models.Standings,
+	team *models.Team,
+	matchOutCome models.MatchOutcome,
+	rules models.LeagueRules,
+) {
 	standings.Goals += matchOutCome.WinnerGoals
 	standings.Against += matchOutCome.LoserGoals
 	standings.Played += 1
 	standings.Wins += 1
-	standings.Points += 3
-	team.Stamina -= 5
+	standings.Points += ResultPoints(rules, matchOutCome.WinnerGoals, matchOutCome.LoserGoals)
+	team.Stamina -= StaminaDrain(team.Tactics)
 	if team.Stamina < 0 {
 		team.Stamina = 0
 	}
@@ -35,13 +62,18 @@ func WinnerTeamAttributeChanging(standings *models.Standings, team *models.Team,
 	}
 }
 
-func DrawTeamAttributeChanging(standings *models.Standings, team *models.Team, matchOutCome models.MatchOutcome) {
+func DrawTeamAttributeChanging(
+	standings *models.Standings,
+	team *models.Team,
+	matchOutCome models.MatchOutcome,
+	rules models.LeagueRules,
+) {
 	standings.Goals += matchOutCome.LoserGoals
 	standings.Against += matchOutCome.WinnerGoals
 	standings.Played += 1
-	standings.Points += 1
+	standings.Points += ResultPoints(rules, matchOutCome.LoserGoals, matchOutCome.WinnerGoals)
 
-	team.Stamina -= 5
+	team.Stamina -= StaminaDrain(team.Tactics)
 	if team.Stamina < 0 {
 		team.Stamina = 0
 	}
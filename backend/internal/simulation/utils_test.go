@@ -108,7 +108,7 @@ func TestLoserTeamAttributeChanging(t *testing.T) {
 			standings := tt.standings
 
 			// Execute
-			LoserTeamAttributeChanging(&standings, &team, tt.matchOutcome)
+			LoserTeamAttributeChanging(&standings, &team, tt.matchOutcome, models.DefaultLeagueRules())
 
 			// Assert team attributes
 			assert.Equal(t, tt.expectedTeam.Stamina, team.Stamina, "Stamina should be correctly updated")
@@ -227,7 +227,7 @@ func TestWinnerTeamAttributeChanging(t *testing.T) {
 			standings := tt.standings
 
 			// Execute
-			WinnerTeamAttributeChanging(&standings, &team, tt.matchOutcome)
+			WinnerTeamAttributeChanging(&standings, &team, tt.matchOutcome, models.DefaultLeagueRules())
 
 			// Assert team attributes
 			assert.Equal(t, tt.expectedTeam.Stamina, team.Stamina, "Stamina should be correctly updated")
@@ -348,7 +348,7 @@ func TestDrawTeamAttributeChanging(t *testing.T) {
 			initialPoints := standings.Points
 
 			// Execute
-			DrawTeamAttributeChanging(&standings, &team, tt.matchOutcome)
+			DrawTeamAttributeChanging(&standings, &team, tt.matchOutcome, models.DefaultLeagueRules())
 
 			// Assert team attributes
 			assert.Equal(t, tt.expectedTeam.Stamina, team.Stamina, "Stamina should be correctly updated")
@@ -384,7 +384,7 @@ func TestAttributeChanging_EdgeCases(t *testing.T) {
 			LoserGoals:  0,
 		}
 
-		LoserTeamAttributeChanging(&standings, &team, matchOutcome)
+		LoserTeamAttributeChanging(&standings, &team, matchOutcome, models.DefaultLeagueRules())
 
 		assert.Equal(t, 0.0, team.Stamina, "Stamina should remain 0")
 		assert.Equal(t, 0.0, team.Morale, "Morale should remain 0")
@@ -407,7 +407,7 @@ func TestAttributeChanging_EdgeCases(t *testing.T) {
 			LoserGoals:  0,
 		}
 
-		WinnerTeamAttributeChanging(&standings, &team, matchOutcome)
+		WinnerTeamAttributeChanging(&standings, &team, matchOutcome, models.DefaultLeagueRules())
 
 		assert.Equal(t, 95.0, team.Stamina, "Stamina should decrease by 5")
 		assert.Equal(t, 100.0, team.Morale, "Morale should be capped at 100")
@@ -437,7 +437,7 @@ func BenchmarkLoserTeamAttributeChanging(b *testing.B) {
 		// Reset values for each iteration
 		teamCopy := team
 		standingsCopy := standings
-		LoserTeamAttributeChanging(&standingsCopy, &teamCopy, matchOutcome)
+		LoserTeamAttributeChanging(&standingsCopy, &teamCopy, matchOutcome, models.DefaultLeagueRules())
 	}
 }
 
@@ -463,7 +463,7 @@ func BenchmarkWinnerTeamAttributeChanging(b *testing.B) {
 		// Reset values for each iteration
 		teamCopy := team
 		standingsCopy := standings
-		WinnerTeamAttributeChanging(&standingsCopy, &teamCopy, matchOutcome)
+		WinnerTeamAttributeChanging(&standingsCopy, &teamCopy, matchOutcome, models.DefaultLeagueRules())
 	}
 }
 
@@ -490,6 +490,38 @@ func BenchmarkDrawTeamAttributeChanging(b *testing.B) {
 		// Reset values for each iteration
 		teamCopy := team
 		standingsCopy := standings
-		DrawTeamAttributeChanging(&standingsCopy, &teamCopy, matchOutcome)
+		DrawTeamAttributeChanging(&standingsCopy, &teamCopy, matchOutcome, models.DefaultLeagueRules())
 	}
 }
+
+func TestResultPoints(t *testing.T) {
+	rules := models.DefaultLeagueRules()
+	assert.Equal(t, 3, ResultPoints(rules, 2, 1))
+	assert.Equal(t, 1, ResultPoints(rules, 1, 1))
+	assert.Equal(t, 0, ResultPoints(rules, 0, 1))
+
+	rules.PointsForWin = 2
+	rules.PointsForLoss = 0
+	rules.BonusPoints = models.BonusPoints{GoalThreshold: 4, Points: 1}
+	assert.Equal(t, 2, ResultPoints(rules, 3, 0))
+	assert.Equal(t, 3, ResultPoints(rules, 4, 0))
+	assert.Equal(t, 1, ResultPoints(rules, 4, 5))
+}
+
+func TestAttributeChanging_CustomRules(t *testing.T) {
+	rules := models.DefaultLeagueRules()
+	rules.PointsForWin = 2
+	rules.PointsForDraw = 1
+	rules.PointsForLoss = 1
+	outcome := models.MatchOutcome{WinnerGoals: 2, LoserGoals: 1}
+
+	winnerStanding := models.Standings{}
+	winner := models.Team{Stamina: 80, Morale: 80}
+	WinnerTeamAttributeChanging(&winnerStanding, &winner, outcome, rules)
+	assert.Equal(t, 2, winnerStanding.Points)
+
+	loserStanding := models.Standings{}
+	loser := models.Team{Stamina: 80, Morale: 80}
+	LoserTeamAttributeChanging(&loserStanding, &loser, outcome, rules)
+	assert.Equal(t, 1, loserStanding.Points)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserServiceInterface is a mock implementation of UserServiceInterface
+type MockUserServiceInterface struct {
+	mock.Mock
+}
+
+func (m *MockUserServiceInterface) Register(ctx context.Context, username string) (models.RegisterUserResponse, error) {
+	args := m.Called(ctx, username)
+	return args.Get(0).(models.RegisterUserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceInterface) Authenticate(ctx context.Context, apiKey string) (models.User, error) {
+	args := m.Called(ctx, apiKey)
+	return args.Get(0).(models.User), args.Error(1)
+}
@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"testing"
+
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMockUserServiceInterface_ImplementsInterface(t *testing.T) {
+	// Test that MockUserServiceInterface implements UserServiceInterface
+	var _ UserServiceInterface = (*MockUserServiceInterface)(nil)
+	assert.True(t, true, "MockUserServiceInterface implements UserServiceInterface interface")
+}
+
+func TestMockUserServiceInterface_Authenticate(t *testing.T) {
+	// Create mock
+	mockService := &MockUserServiceInterface{}
+
+	// Setup expectations
+	expectedUser := models.User{UserId: "user-id", Username: "alice"}
+	mockService.On("Authenticate", mock.Anything, "lsk_key").Return(expectedUser, nil)
+
+	// Call method
+	result, err := mockService.Authenticate(context.Background(), "lsk_key")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedUser, result)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+
+	"league-sim/internal/models"
+)
+
+type UserServiceInterface interface {
+	Register(ctx context.Context, username string) (models.RegisterUserResponse, error)
+	Authenticate(ctx context.Context, apiKey string) (models.User, error)
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type UserService struct {
+	appCtx appContext.AppContext
+}
+
+func NewUserService(ctx appContext.AppContext) *UserService {
+	return &UserService{
+		appCtx: ctx,
+	}
+}
+
+// Register creates a user and issues its API key. The key is returned once
+// and cannot be recovered later.
+func (us *UserService) Register(ctx context.Context, username string) (models.RegisterUserResponse, error) {
+	apiKey, apiKeyHash, err := auth.GenerateAPIKey()
+
+	if err != nil {
+		return models.RegisterUserResponse{}, apperrors.Internal(err, "failed to generate api key")
+	}
+
+	user := models.User{
+		UserId:   uuid.New().String(),
+		Username: username,
+	}
+
+	err = us.appCtx.UserRepository().CreateUser(ctx, user, apiKeyHash)
+
+	if err != nil {
+
+		return models.RegisterUserResponse{}, err
+	}
+
+	return models.RegisterUserResponse{User: user, APIKey: apiKey}, nil
+}
+
+// Authenticate resolves an API key to its user. Unknown keys are Unauthorized
+// rather than NotFound so callers cannot probe for users.
+func (us *UserService) Authenticate(ctx context.Context, apiKey string) (models.User, error) {
+	user, err := us.appCtx.UserRepository().GetUserByAPIKeyHash(ctx, auth.HashAPIKey(apiKey))
+
+	if apperrors.Is(err, apperrors.KindNotFound) {
+
+		return models.User{}, apperrors.Unauthorized("invalid api key")
+	}
+
+	if err != nil {
+
+		return models.User{}, err
+	}
+
+	return user, nil
+}
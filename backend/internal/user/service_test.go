@@ -0,0 +1,161 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/auth"
+	appContext "league-sim/internal/contexts/appContexts"
+	"league-sim/internal/models"
+	"league-sim/internal/repositories/interfaces"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAppContext is a mock implementation of AppContext for testing
+type MockAppContext struct {
+	mock.Mock
+}
+
+func (m *MockAppContext) LeagueRepository() interfaces.LeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.LeagueRepository)
+}
+
+func (m *MockAppContext) ActiveLeagueRepository() interfaces.ActiveLeagueRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.ActiveLeagueRepository)
+}
+
+func (m *MockAppContext) MatchResultRepository() interfaces.MatchResultRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.MatchResultRepository)
+}
+
+func (m *MockAppContext) UserRepository() interfaces.UserRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.UserRepository)
+}
+
+func (m *MockAppContext) AuditRepository() interfaces.AuditRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.AuditRepository)
+}
+
+func (m *MockAppContext) RatingRepository() interfaces.RatingRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.RatingRepository)
+}
+
+func (m *MockAppContext) PredictionRepository() interfaces.PredictionRepository {
+	args := m.Called()
+	return args.Get(0).(interfaces.PredictionRepository)
+}
+
+func (m *MockAppContext) Transactor() interfaces.Transactor {
+	args := m.Called()
+	return args.Get(0).(interfaces.Transactor)
+}
+
+func (m *MockAppContext) DB() *appContext.DB {
+	args := m.Called()
+	return args.Get(0).(*appContext.DB)
+}
+
+func (m *MockAppContext) Config() *config.Config {
+	args := m.Called()
+	return args.Get(0).(*config.Config)
+}
+
+func TestUserService_Register_Success(t *testing.T) {
+	// Setup mocks
+	mockUserRepo := &interfaces.MockUserRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	var storedHash string
+	mockAppCtx.On("UserRepository").Return(mockUserRepo)
+	mockUserRepo.On("CreateUser", mock.Anything, mock.AnythingOfType("models.User"), mock.AnythingOfType("string")).
+		Run(
+			func(args mock.Arguments) {
+				storedHash = args.String(2)
+			}).
+		Return(nil)
+
+	service := NewUserService(mockAppCtx)
+
+	// Execute
+	result, err := service.Register(context.Background(), "alice")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", result.Username)
+	assert.NotEmpty(t, result.UserId)
+	assert.True(t, strings.HasPrefix(result.APIKey, "lsk_"))
+	assert.Equal(t, auth.HashAPIKey(result.APIKey), storedHash, "only the hash of the key is stored")
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestUserService_Register_UsernameTaken(t *testing.T) {
+	// Setup mocks
+	mockUserRepo := &interfaces.MockUserRepository{}
+	mockAppCtx := &MockAppContext{}
+
+	mockAppCtx.On("UserRepository").Return(mockUserRepo)
+	mockUserRepo.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).
+		Return(apperrors.Conflict("username %q is already taken", "alice"))
+
+	service := NewUserService(mockAppCtx)
+
+	// Execute
+	result, err := service.Register(context.Background(), "alice")
+
+	// Assert
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+	assert.Empty(t, result.APIKey)
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	expectedUser := models.User{UserId: "user-id", Username: "alice"}
+
+	tests := []struct {
+		name         string
+		repoUser     models.User
+		repoErr      error
+		expectedKind apperrors.Kind
+	}{
+		{name: "known key", repoUser: expectedUser},
+		{name: "unknown key", repoErr: apperrors.NotFound("user not found"), expectedKind: apperrors.KindUnauthorized},
+		{name: "database failure", repoErr: apperrors.Internal(errors.New("boom"), "failed to read user"), expectedKind: apperrors.KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Setup mocks
+				mockUserRepo := &interfaces.MockUserRepository{}
+				mockAppCtx := &MockAppContext{}
+
+				mockAppCtx.On("UserRepository").Return(mockUserRepo)
+				mockUserRepo.On("GetUserByAPIKeyHash", mock.Anything, auth.HashAPIKey("lsk_key")).Return(tt.repoUser, tt.repoErr)
+
+				service := NewUserService(mockAppCtx)
+
+				// Execute
+				user, err := service.Authenticate(context.Background(), "lsk_key")
+
+				// Assert
+				if tt.expectedKind == "" {
+					assert.NoError(t, err)
+					assert.Equal(t, expectedUser, user)
+				} else {
+					assert.Equal(t, tt.expectedKind, apperrors.KindOf(err))
+				}
+				mockUserRepo.AssertExpectations(t)
+			})
+	}
+}
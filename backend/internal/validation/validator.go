@@ -0,0 +1,216 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/league"
+	"league-sim/internal/models"
+	"league-sim/internal/simulation"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validator checks request DTOs against their `validate` struct tags. Besides
+// the stock go-playground rules it understands:
+//
+//	teamcount   a number, or numeric string, within the configured team limits
+//	leaguename  a name no longer than the configured maximum
+//	goals       a score between zero and the configured maximum
+//	experimentleagues
+//	            a league count between one and the configured maximum
+//	rules       league rules accepted by league.ValidateRules
+//	tactics     tactics accepted by simulation.ValidateTactics
+//	auditaction one of models.AuditActions
+//
+// It satisfies echo.Validator.
+type Validator struct {
+	validate *validator.Validate
+	limits   config.LimitsConfig
+}
+
+func New(limits config.LimitsConfig) *Validator {
+	v := &Validator{validate: validator.New(validator.WithRequiredStructEnabled()), limits: limits}
+
+	v.validate.RegisterTagNameFunc(jsonFieldName)
+	v.register("teamcount", v.teamCount)
+	v.register("leaguename", v.leagueName)
+	v.register("goals", v.goals)
+	v.register("experimentleagues", v.experimentLeagues)
+	v.register(
+		"rules", func(fl validator.FieldLevel) bool {
+			rules, ok := fl.Field().Interface().(models.LeagueRules)
+			return ok && league.ValidateRules(rules) == nil
+		})
+	v.register(
+		"tactics", func(fl validator.FieldLevel) bool {
+			tactics, ok := fl.Field().Interface().(models.Tactics)
+			return ok && simulation.ValidateTactics(tactics) == nil
+		})
+	v.register(
+		"auditaction", func(fl validator.FieldLevel) bool {
+			return slices.Contains(models.AuditActions, fl.Field().String())
+		})
+
+	return v
+}
+
+// Validate returns nil for a valid value and otherwise an apperrors validation
+// error carrying one FieldError per failed rule.
+func (v *Validator) Validate(i any) error {
+	err := v.validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return apperrors.Validation("%v", err)
+	}
+
+	fields := make([]apperrors.FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, apperrors.FieldError{Field: fieldPath(fe), Message: v.message(i, fe)})
+	}
+
+	return apperrors.InvalidFields(fields...)
+}
+
+func (v *Validator) register(tag string, fn validator.Func) {
+	if err := v.validate.RegisterValidation(tag, fn); err != nil {
+		panic(fmt.Sprintf("registering %s validation: %v", tag, err))
+	}
+}
+
+func (v *Validator) teamCount(fl validator.FieldLevel) bool {
+	var n int
+	switch fl.Field().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = int(fl.Field().Int())
+	default:
+		var err error
+		if n, err = strconv.Atoi(strings.TrimSpace(fl.Field().String())); err != nil {
+			return false
+		}
+	}
+
+	return n >= v.limits.MinTeams && n <= v.limits.MaxTeams
+}
+
+func (v *Validator) leagueName(fl validator.FieldLevel) bool {
+	name := strings.TrimSpace(fl.Field().String())
+	return name != "" && len([]rune(name)) <= v.limits.MaxLeagueNameLength
+}
+
+func (v *Validator) goals(fl validator.FieldLevel) bool {
+	goals := fl.Field().Int()
+	return goals >= 0 && goals <= int64(v.limits.MaxGoals)
+}
+
+func (v *Validator) experimentLeagues(fl validator.FieldLevel) bool {
+	leagues := fl.Field().Int()
+	return leagues >= 1 && leagues <= int64(v.limits.MaxExperimentLeagues)
+}
+
+func (v *Validator) message(i any, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "teamcount":
+		return fmt.Sprintf("must be a whole number between %d and %d", v.limits.MinTeams, v.limits.MaxTeams)
+	case "leaguename":
+		return fmt.Sprintf("must not be blank or longer than %d characters", v.limits.MaxLeagueNameLength)
+	case "goals":
+		return fmt.Sprintf("must be between 0 and %d", v.limits.MaxGoals)
+	case "experimentleagues":
+		return fmt.Sprintf("must be between 1 and %d", v.limits.MaxExperimentLeagues)
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "min":
+		if fe.Kind() != reflect.String {
+			return "must be at least " + fe.Param()
+		}
+		return "must be at least " + fe.Param() + " characters long"
+	case "max":
+		if fe.Kind() != reflect.String {
+			return "must be at most " + fe.Param()
+		}
+		return "must be at most " + fe.Param() + " characters long"
+	case "alphanum":
+		return "must contain only letters and digits"
+	case "uuid":
+		return "must be a UUID"
+	case "auditaction":
+		return "must be one of: " + strings.Join(models.AuditActions, ", ")
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "nefield":
+		return "must differ from " + jsonName(reflect.TypeOf(i), fe.Param())
+	case "rules":
+		return ruleMessage(league.ValidateRules(fe.Value().(models.LeagueRules)))
+	case "tactics":
+		return ruleMessage(simulation.ValidateTactics(fe.Value().(models.Tactics)))
+	}
+
+	return "failed the " + fe.Tag() + " rule"
+}
+
+func ruleMessage(err error) string {
+	if err == nil {
+		return "is invalid"
+	}
+
+	return err.Error()
+}
+
+// fieldPath drops the struct name from the namespace, so
+// CreateLeagueRequest.teamCount is reported as teamCount. Embedded structs keep
+// their Go name in the namespace and are dropped too, so
+// ListMatchesRequest.PageRequest.limit is reported as limit.
+func fieldPath(fe validator.FieldError) string {
+	segments := strings.Split(fe.Namespace(), ".")[1:]
+	var path []string
+	for i, segment := range segments {
+		if i < len(segments)-1 && segment != "" && unicode.IsUpper([]rune(segment)[0]) {
+			continue
+		}
+		path = append(path, segment)
+	}
+
+	return strings.Join(path, ".")
+}
+
+// jsonFieldName names a field the way clients send it: by its json tag, or by
+// its query tag for fields bound from the query string.
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		name = field.Tag.Get("query")
+	}
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func jsonName(t reflect.Type, fieldName string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if field, ok := t.FieldByName(fieldName); ok {
+		return jsonFieldName(field)
+	}
+
+	return fieldName
+}
@@ -0,0 +1,190 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"league-sim/config"
+	"league-sim/internal/apperrors"
+	"league-sim/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldsOf(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var appErr *apperrors.Error
+	if !assert.ErrorAs(t, err, &appErr) {
+		return nil
+	}
+	assert.Equal(t, apperrors.KindValidation, appErr.Kind)
+
+	fields := map[string]string{}
+	for _, f := range appErr.Fields {
+		fields[f.Field] = f.Message
+	}
+
+	return fields
+}
+
+func TestValidate_CreateLeagueRequest(t *testing.T) {
+	v := New(config.Default().Limits)
+	badRules := models.DefaultLeagueRules()
+	badRules.PointsForWin = 0
+
+	tests := []struct {
+		name    string
+		request models.CreateLeagueRequest
+		field   string
+		message string
+	}{
+		{name: "Valid", request: models.CreateLeagueRequest{LeagueName: "Premier", TeamCount: "4"}},
+		{
+			name:    "Missing team count",
+			request: models.CreateLeagueRequest{LeagueName: "Premier"},
+			field:   "teamCount", message: "is required",
+		},
+		{
+			name:    "Non numeric team count",
+			request: models.CreateLeagueRequest{LeagueName: "Premier", TeamCount: "four"},
+			field:   "teamCount", message: "between 2 and 26",
+		},
+		{
+			name:    "Zero teams",
+			request: models.CreateLeagueRequest{LeagueName: "Premier", TeamCount: "0"},
+			field:   "teamCount", message: "between 2 and 26",
+		},
+		{
+			name:    "Negative teams",
+			request: models.CreateLeagueRequest{LeagueName: "Premier", TeamCount: "-4"},
+			field:   "teamCount", message: "between 2 and 26",
+		},
+		{
+			name:    "Too many teams",
+			request: models.CreateLeagueRequest{LeagueName: "Premier", TeamCount: "1000000"},
+			field:   "teamCount", message: "between 2 and 26",
+		},
+		{
+			name:    "Blank name",
+			request: models.CreateLeagueRequest{LeagueName: "   ", TeamCount: "4"},
+			field:   "leagueName", message: "must not be blank",
+		},
+		{
+			name:    "Long name",
+			request: models.CreateLeagueRequest{LeagueName: strings.Repeat("x", 65), TeamCount: "4"},
+			field:   "leagueName", message: "64 characters",
+		},
+		{
+			name:    "Invalid rules",
+			request: models.CreateLeagueRequest{LeagueName: "Premier", TeamCount: "4", Rules: &badRules},
+			field:   "rules", message: "points for a win",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := v.Validate(tt.request)
+
+				if tt.field == "" {
+					assert.NoError(t, err)
+					return
+				}
+				assert.Contains(t, fieldsOf(t, err)[tt.field], tt.message)
+			})
+	}
+}
+
+func TestValidate_EditMatchResult(t *testing.T) {
+	v := New(config.Default().Limits)
+
+	valid := models.EditMatchResult{Home: "Team A", Away: "Team B", HomeScore: 2, AwayScore: 1, MatchWeek: 1}
+	assert.NoError(t, v.Validate(valid))
+
+	err := v.Validate(models.EditMatchResult{Home: "Team A", Away: "Team A", HomeScore: -1, AwayScore: 99})
+	fields := fieldsOf(t, err)
+
+	assert.Equal(t, "must differ from home", fields["away"])
+	assert.Equal(t, "must be between 0 and 20", fields["homeScore"])
+	assert.Equal(t, "must be between 0 and 20", fields["awayScore"])
+	assert.Equal(t, "must be at least 1", fields["matchWeek"])
+}
+
+func TestValidate_SetTacticsRequest(t *testing.T) {
+	v := New(config.Default().Limits)
+
+	assert.NoError(t, v.Validate(models.SetTacticsRequest{Team: "Team A"}), "empty tactics fall back to defaults")
+
+	err := v.Validate(models.SetTacticsRequest{Tactics: models.Tactics{Pressing: "frantic"}})
+	fields := fieldsOf(t, err)
+
+	assert.Equal(t, "is required", fields["team"])
+	assert.Contains(t, fields["tactics"], "unknown pressing intensity")
+}
+
+func TestValidate_SimulateLeagueRequest(t *testing.T) {
+	v := New(config.Default().Limits)
+
+	assert.NoError(t, v.Validate(models.SimulateLeagueRequest{PlayAllFixture: true}))
+	assert.NoError(t, v.Validate(&models.SimulateLeagueRequest{}))
+}
+
+func TestValidate_LimitsFromConfig(t *testing.T) {
+	limits := config.Default().Limits
+	limits.MaxTeams = 6
+	limits.MaxGoals = 5
+	v := New(limits)
+
+	assert.NoError(t, v.Validate(models.CreateLeagueRequest{LeagueName: "Small", TeamCount: "6"}))
+	assert.Contains(
+		t, fieldsOf(t, v.Validate(models.CreateLeagueRequest{LeagueName: "Small", TeamCount: "8"}))["teamCount"],
+		"between 2 and 6")
+	assert.Equal(
+		t, "must be between 0 and 5",
+		fieldsOf(
+			t, v.Validate(
+				models.EditMatchResult{
+					Home: "A", Away: "B", HomeScore: 6, MatchWeek: 1,
+				}))["homeScore"])
+}
+
+func TestValidate_CreateLeagueV2Request(t *testing.T) {
+	v := New(config.Default().Limits)
+
+	assert.NoError(t, v.Validate(models.CreateLeagueV2Request{Name: "Premier", TeamCount: 4}))
+
+	fields := fieldsOf(t, v.Validate(models.CreateLeagueV2Request{Name: "Premier", TeamCount: 40}))
+	assert.Equal(t, "must be a whole number between 2 and 26", fields["teamCount"])
+}
+
+func TestValidate_EmbeddedQueryFields(t *testing.T) {
+	v := New(config.Default().Limits)
+
+	assert.NoError(t, v.Validate(models.ListMatchesRequest{Week: 2, Status: models.MatchPlayed}))
+
+	err := v.Validate(
+		models.ListMatchesRequest{
+			PageRequest: models.PageRequest{Limit: 500},
+			Status:      "postponed",
+		})
+	fields := fieldsOf(t, err)
+
+	assert.Equal(t, "must be at most 100", fields["limit"])
+	assert.Equal(t, "must be one of: played, upcoming", fields["status"])
+}
+
+func TestValidate_ExperimentRequest(t *testing.T) {
+	limits := config.Default().Limits
+	limits.MaxExperimentLeagues = 50
+	v := New(limits)
+
+	assert.NoError(t, v.Validate(models.ExperimentRequest{Leagues: 50, TeamCount: 4}))
+
+	fields := fieldsOf(t, v.Validate(models.ExperimentRequest{Leagues: 51, TeamCount: 1}))
+	assert.Equal(t, "must be between 1 and 50", fields["leagues"])
+	assert.Equal(t, "must be a whole number between 2 and 26", fields["teamCount"])
+	assert.Equal(
+		t, "must be between 1 and 50", fieldsOf(t, v.Validate(models.ExperimentRequest{TeamCount: 4}))["leagues"])
+	assert.Contains(t, fieldsOf(t, v.Validate(models.ExperimentFormatRequest{Format: "xml"})), "format")
+}
@@ -1,12 +1,49 @@
 create schema league_sim;
 USE league_sim;
 
+CREATE TABLE IF NOT EXISTS users
+(
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    userId     CHAR(36)    NOT NULL UNIQUE,
+    username   VARCHAR(64) NOT NULL UNIQUE,
+    apiKeyHash CHAR(64)    NOT NULL UNIQUE,
+    createdAt  DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE IF NOT EXISTS league
 (
     id        INT AUTO_INCREMENT PRIMARY KEY,
     name      VARCHAR(255) NOT NULL,
     leagueId  CHAR(36)     NOT NULL UNIQUE,
-    createdAt DATETIME DEFAULT CURRENT_TIMESTAMP
+    -- NULL only for leagues created before user accounts, until adopted.
+    ownerId   CHAR(36),
+    rules     JSON,
+    -- The league this one was forked from; a fork outlives its parent.
+    parentId  CHAR(36),
+    createdAt DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (ownerId) REFERENCES users (userId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    FOREIGN KEY (parentId) REFERENCES league (leagueId)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS league_members
+(
+    leagueId  CHAR(36) NOT NULL,
+    userId    CHAR(36)    NOT NULL,
+    role      VARCHAR(16) NOT NULL DEFAULT 'viewer',
+    createdAt DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (leagueId, userId),
+    FOREIGN KEY (leagueId) REFERENCES league (leagueId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    FOREIGN KEY (userId) REFERENCES users (userId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
 );
 
 CREATE TABLE IF NOT EXISTS active_league
@@ -47,3 +84,60 @@ CREATE TABLE IF NOT EXISTS match_results
 
 
 
+
+-- One row per team per match; a result edit replaces the whole history of its
+-- league, as every later rating depends on it.
+CREATE TABLE IF NOT EXISTS rating_history
+(
+    id        BIGINT AUTO_INCREMENT PRIMARY KEY,
+    leagueId  CHAR(36)    NOT NULL,
+    matchWeek INT         NOT NULL,
+    team      VARCHAR(36) NOT NULL,
+    opponent  VARCHAR(36) NOT NULL,
+    ratingBefore DOUBLE   NOT NULL,
+    ratingAfter  DOUBLE   NOT NULL,
+
+    INDEX idx_rating_league_team (leagueId, team),
+    FOREIGN KEY (leagueId) REFERENCES league (leagueId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- One row per week: the title odds the predictor gave before the week was
+-- played, scored once the season is over.
+CREATE TABLE IF NOT EXISTS prediction_snapshots
+(
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    leagueId    CHAR(36) NOT NULL,
+    matchWeek   INT      NOT NULL,
+    predictions JSON     NOT NULL,
+
+    UNIQUE KEY uq_prediction_league_week (leagueId, matchWeek),
+    FOREIGN KEY (leagueId) REFERENCES league (leagueId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- Append-only: rows are never updated or deleted, and there is deliberately no
+-- foreign key so that the history of a deleted league survives it.
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    leagueId    CHAR(36)    NOT NULL,
+    action      VARCHAR(32) NOT NULL,
+    actorId     CHAR(36),
+    requestId   VARCHAR(64),
+    beforeValue JSON,
+    afterValue  JSON,
+    createdAt   DATETIME(3) NOT NULL,
+
+    INDEX idx_audit_league_action (leagueId, action)
+);
+
+-- Token buckets of the shared rate limiter (rateLimit.store: mysql).
+CREATE TABLE IF NOT EXISTS rate_limit_buckets
+(
+    bucketKey VARCHAR(128) PRIMARY KEY,
+    tokens    DOUBLE       NOT NULL,
+    updatedAt DATETIME(6)  NOT NULL
+);
@@ -0,0 +1,105 @@
+-- Upgrades a database created from the original schema, before league rules,
+-- user accounts and everything built on them; init.sql only runs on an empty
+-- one. Run it once. The leagues already in it keep the default rules and get
+-- no owner, so nobody can reach them until a registered user adopts them with
+-- `leaguesim adopt`.
+USE league_sim;
+
+-- League rules; NULL reads as the default rules.
+ALTER TABLE league
+    ADD COLUMN rules JSON AFTER leagueId;
+
+-- User accounts and league ownership.
+CREATE TABLE IF NOT EXISTS users
+(
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    userId     CHAR(36)    NOT NULL UNIQUE,
+    username   VARCHAR(64) NOT NULL UNIQUE,
+    apiKeyHash CHAR(64)    NOT NULL UNIQUE,
+    createdAt  DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE league
+    ADD COLUMN ownerId CHAR(36) AFTER leagueId,
+    ADD FOREIGN KEY (ownerId) REFERENCES users (userId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE;
+
+-- Editors and viewers invited to a league.
+CREATE TABLE IF NOT EXISTS league_members
+(
+    leagueId  CHAR(36) NOT NULL,
+    userId    CHAR(36)    NOT NULL,
+    role      VARCHAR(16) NOT NULL DEFAULT 'viewer',
+    createdAt DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (leagueId, userId),
+    FOREIGN KEY (leagueId) REFERENCES league (leagueId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    FOREIGN KEY (userId) REFERENCES users (userId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- The audit log of league mutations.
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    leagueId    CHAR(36)    NOT NULL,
+    action      VARCHAR(32) NOT NULL,
+    actorId     CHAR(36),
+    requestId   VARCHAR(64),
+    beforeValue JSON,
+    afterValue  JSON,
+    createdAt   DATETIME(3) NOT NULL,
+
+    INDEX idx_audit_league_action (leagueId, action)
+);
+
+-- Token buckets of the shared rate limiter.
+CREATE TABLE IF NOT EXISTS rate_limit_buckets
+(
+    bucketKey VARCHAR(128) PRIMARY KEY,
+    tokens    DOUBLE       NOT NULL,
+    updatedAt DATETIME(6)  NOT NULL
+);
+
+-- Elo rating history. Leagues already played start theirs with the next
+-- simulated week, or with the next result edit, which replays the season.
+CREATE TABLE IF NOT EXISTS rating_history
+(
+    id        BIGINT AUTO_INCREMENT PRIMARY KEY,
+    leagueId  CHAR(36)    NOT NULL,
+    matchWeek INT         NOT NULL,
+    team      VARCHAR(36) NOT NULL,
+    opponent  VARCHAR(36) NOT NULL,
+    ratingBefore DOUBLE   NOT NULL,
+    ratingAfter  DOUBLE   NOT NULL,
+
+    INDEX idx_rating_league_team (leagueId, team),
+    FOREIGN KEY (leagueId) REFERENCES league (leagueId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- Title odds recorded before each simulated week.
+CREATE TABLE IF NOT EXISTS prediction_snapshots
+(
+    id          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    leagueId    CHAR(36) NOT NULL,
+    matchWeek   INT      NOT NULL,
+    predictions JSON     NOT NULL,
+
+    UNIQUE KEY uq_prediction_league_week (leagueId, matchWeek),
+    FOREIGN KEY (leagueId) REFERENCES league (leagueId)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- Forks: the league each one was copied from.
+ALTER TABLE league
+    ADD COLUMN parentId CHAR(36) AFTER rules,
+    ADD FOREIGN KEY (parentId) REFERENCES league (leagueId)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE;
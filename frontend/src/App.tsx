@@ -1,10 +1,13 @@
 import './App.css'
 import Router from './router'
+import AuthGate from './components/auth/AuthGate'
 
 function App() {
     return (
         <div className="container mx-auto">
-            <Router />
+            <AuthGate>
+                <Router />
+            </AuthGate>
         </div>
     )
 }
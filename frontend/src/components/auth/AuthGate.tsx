@@ -0,0 +1,151 @@
+import {useEffect, useState, type ReactNode} from 'react'
+import axios from 'axios'
+import {API_KEY_CLEARED, clearApiKey, getApiKey, registerUser, signIn} from '@/services/api'
+import {Button} from '@/components/ui/button'
+import {Input} from '@/components/ui/input'
+import {Label} from '@/components/ui/label'
+
+// errorMessage prefers the message the API sent back over axios' own.
+const errorMessage = (error: unknown) => {
+    if (axios.isAxiosError(error) && error.response?.data?.error?.message) {
+        return String(error.response.data.error.message)
+    }
+    return 'Something went wrong, please try again.'
+}
+
+// The key is shown once, right after registering, as the server never returns
+// it again.
+const NewKey = ({apiKey, onContinue}: { apiKey: string, onContinue: () => void }) => (
+    <div className="space-y-4">
+        <h2 className="text-2xl text-gray-700">Your API key</h2>
+        <p className="text-gray-600">
+            Keep a copy of this key: it is the only way to sign in again, and it will not be shown again.
+        </p>
+        <Input readOnly value={apiKey} onFocus={(e) => e.target.select()}/>
+        <Button className="bg-blue-600 hover:bg-blue-700 text-black w-full" onClick={onContinue}>
+            Continue
+        </Button>
+    </div>
+)
+
+const SignInForms = ({onRegistered, onSignedIn}: {
+    onRegistered: (apiKey: string) => void
+    onSignedIn: () => void
+}) => {
+    const [username, setUsername] = useState("")
+    const [apiKey, setApiKeyInput] = useState("")
+    const [error, setError] = useState("")
+    const [busy, setBusy] = useState(false)
+
+    const run = async (action: () => Promise<void>) => {
+        try {
+            setBusy(true)
+            setError("")
+            await action()
+        } catch (e) {
+            setError(errorMessage(e))
+        } finally {
+            setBusy(false)
+        }
+    }
+
+    const handleRegister = () => run(async () => {
+        const registered = await registerUser(username.trim())
+        onRegistered(registered.apiKey)
+    })
+
+    const handleSignIn = () => run(async () => {
+        await signIn(apiKey.trim())
+        onSignedIn()
+    })
+
+    return (
+        <div className="space-y-6">
+            <div className="space-y-2">
+                <Label htmlFor="username">New user</Label>
+                <Input
+                    id="username"
+                    value={username}
+                    onChange={(e) => setUsername(e.target.value)}
+                    placeholder="Choose a username"
+                    autoFocus
+                />
+                <Button
+                    className="bg-blue-600 hover:bg-blue-700 text-black w-full"
+                    onClick={handleRegister}
+                    disabled={busy || !username.trim()}
+                >
+                    Register
+                </Button>
+            </div>
+
+            <div className="space-y-2">
+                <Label htmlFor="apiKey">Existing API key</Label>
+                <Input
+                    id="apiKey"
+                    value={apiKey}
+                    onChange={(e) => setApiKeyInput(e.target.value)}
+                    placeholder="Paste your API key"
+                />
+                <Button
+                    variant="outline"
+                    className="w-full"
+                    onClick={handleSignIn}
+                    disabled={busy || !apiKey.trim()}
+                >
+                    Sign in
+                </Button>
+            </div>
+
+            {error && <p className="text-sm text-red-600">{error}</p>}
+        </div>
+    )
+}
+
+// AuthGate only renders the app once an API key is stored, and asks the user
+// to register or enter their key otherwise. The API client drops a key the
+// server rejects, which brings the user back here.
+function AuthGate({children}: { children: ReactNode }) {
+    const [signedIn, setSignedIn] = useState(() => getApiKey() !== null)
+    const [newKey, setNewKey] = useState("")
+
+    useEffect(() => {
+        const handleCleared = () => setSignedIn(false)
+        window.addEventListener(API_KEY_CLEARED, handleCleared)
+        return () => window.removeEventListener(API_KEY_CLEARED, handleCleared)
+    }, [])
+
+    if (signedIn && !newKey) {
+        return (
+            <>
+                <div className="flex justify-end p-2">
+                    <Button variant="outline" size="sm" onClick={clearApiKey}>
+                        Sign out
+                    </Button>
+                </div>
+                {children}
+            </>
+        )
+    }
+
+    return (
+        <div className="flex justify-center items-center min-h-screen">
+            <div className="flex flex-col rounded-2xl gap-4 p-6 bg-gray-50 w-full max-w-md">
+                <h1 className="text-4xl font-bold text-gray-900 text-center">League Sim</h1>
+                {newKey ? (
+                    <NewKey apiKey={newKey} onContinue={() => setNewKey("")}/>
+                ) : (
+                    <SignInForms
+                        onRegistered={(apiKey) => {
+                            setNewKey(apiKey)
+                            setSignedIn(true)
+                        }}
+                        onSignedIn={() => setSignedIn(true)}
+                    />
+                )}
+            </div>
+        </div>
+    )
+}
+
+export default AuthGate
@@ -37,4 +37,14 @@ export interface SimulationResponse {
 export interface LeagueIdWithName {
     leagueId: string;
     leagueName: string;
-}
\ No newline at end of file
+}
+
+export interface User {
+    userId: string;
+    username: string;
+}
+
+// The API key is only ever returned here; the server keeps just its hash.
+export interface RegisterUserResponse extends User {
+    apiKey: string;
+}
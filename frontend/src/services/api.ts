@@ -1,6 +1,8 @@
 import axios from 'axios';
 import type {
     LeagueIdWithName,
+    RegisterUserResponse,
+    User,
     SimulationResponse,
     GetActiveLeagueStandingsResponse,
     GetActiveLeagueFixturesResponse,
@@ -17,7 +19,70 @@ const api = axios.create({
 
 });
 
+const API_KEY_STORAGE = 'apiKey';
+
+// Fired when the stored API key is dropped, so the app asks for another one.
+export const API_KEY_CLEARED = 'apiKeyCleared';
+
+export function getApiKey(): string | null {
+    return localStorage.getItem(API_KEY_STORAGE);
+}
+
+export function setApiKey(apiKey: string) {
+    localStorage.setItem(API_KEY_STORAGE, apiKey);
+}
+
+export function clearApiKey() {
+    localStorage.removeItem(API_KEY_STORAGE);
+    window.dispatchEvent(new Event(API_KEY_CLEARED));
+}
+
+// Leagues belong to the user whose API key is sent with each request.
+api.interceptors.request.use((config) => {
+    const apiKey = getApiKey();
+    if (apiKey && !config.headers.Authorization) {
+        config.headers.Authorization = `Bearer ${apiKey}`;
+    }
+    return config;
+});
+
+// A key the server no longer accepts is forgotten.
+api.interceptors.response.use(
+    (response) => response,
+    (error) => {
+        if (axios.isAxiosError(error) && error.response?.status === 401 && getApiKey()) {
+            clearApiKey();
+        }
+        return Promise.reject(error);
+    },
+);
+
 // API functions
+export async function registerUser(username: string): Promise<RegisterUserResponse> {
+    try {
+        const response = await api.post<RegisterUserResponse>('/users', {username});
+        setApiKey(response.data.apiKey);
+        return response.data;
+    } catch (error) {
+        console.error('Error registering user:', error);
+        throw error;
+    }
+}
+
+// signIn checks an API key the user already has and keeps it if it is valid.
+export async function signIn(apiKey: string): Promise<User> {
+    try {
+        const response = await api.get<User>('/me', {
+            headers: {Authorization: `Bearer ${apiKey}`},
+        });
+        setApiKey(apiKey);
+        return response.data;
+    } catch (error) {
+        console.error('Error signing in:', error);
+        throw error;
+    }
+}
+
 export async function getLeagues(): Promise<LeagueIdWithName[]> {
     try {
         const response = await api.get('/league');